@@ -0,0 +1,45 @@
+// Command eval scores retrievers against a golden question set.
+package main
+
+import (
+	"github.com/lex00/wetwire-neo4j-go/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func newEvalCmd() *cobra.Command {
+	var path string
+	var opts cli.EvalOptions
+
+	cmd := &cobra.Command{
+		Use:   "eval <retriever> [candidate]",
+		Short: "Evaluate retrievers against a golden question set",
+		Long: `Eval runs retrievers against an in-memory fixture corpus with precomputed
+embeddings and reports recall@k, MRR and nDCG for a golden question set.
+No database is needed, so it can run in CI.
+
+With two retrievers, the candidate is compared against the first.
+
+The golden set lists each question with its expected node IDs or answer
+snippets. The corpus lists documents with their text and embedding, and the
+embedding of each question.
+
+Examples:
+  # Score a single retriever
+  wetwire-neo4j eval ChunkSearch --golden golden.json --corpus corpus.json
+
+  # Compare two configurations at k=10
+  wetwire-neo4j eval ChunkSearch HybridSearch --golden golden.json --corpus corpus.json --k 10`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cli.NewEvaluator().Evaluate(cmd.Context(), cmd.OutOrStdout(), path, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&path, "path", "p", ".", "Path to scan for retrievers")
+	cmd.Flags().StringVar(&opts.Golden, "golden", "", "Golden question set (JSON)")
+	cmd.Flags().StringVar(&opts.Corpus, "corpus", "", "Fixture corpus with precomputed embeddings (JSON)")
+	cmd.Flags().IntVar(&opts.K, "k", 0, "Metric cutoff (default: each retriever's TopK)")
+	_ = cmd.MarkFlagRequired("golden")
+	_ = cmd.MarkFlagRequired("corpus")
+	return cmd
+}
@@ -0,0 +1,38 @@
+// Command explain prints the Cypher that retrievers run against Neo4j.
+package main
+
+import (
+	"github.com/lex00/wetwire-neo4j-go/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func newExplainCmd() *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "explain [retriever]",
+		Short: "Show the Cypher a retriever runs",
+		Long: `Explain prints the parameterized Cypher each retriever runs against Neo4j,
+preceded by a :params command so the output can be pasted into Neo4j Browser.
+
+$embedding and $queryText are supplied per search and must be set by hand.
+
+Examples:
+  # Explain every retriever in the current directory
+  wetwire-neo4j explain
+
+  # Explain a single retriever
+  wetwire-neo4j explain ChunkSearch --path ./retrievers`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return cli.NewExplainer().Explain(cmd.OutOrStdout(), path, name)
+		},
+	}
+
+	cmd.Flags().StringVarP(&path, "path", "p", ".", "Path to scan for retrievers")
+	return cmd
+}
@@ -12,6 +12,9 @@
 //	wetwire-neo4j design       - AI-assisted schema and algorithm design
 //	wetwire-neo4j test         - Run persona-based testing
 //	wetwire-neo4j diff         - Compare two Neo4j configurations
+//	wetwire-neo4j explain      - Show the Cypher a retriever runs
+//	wetwire-neo4j eval         - Evaluate retrievers against a golden set
+//	wetwire-neo4j session      - Manage Aura Graph Analytics sessions
 //	wetwire-neo4j watch        - Watch for file changes and auto-rebuild
 //	wetwire-neo4j version      - Show version information
 package main
@@ -47,6 +50,9 @@ func main() {
 	rootCmd.AddCommand(newDesignCmd())
 	rootCmd.AddCommand(newTestCmd())
 	rootCmd.AddCommand(newDiffCmd())
+	rootCmd.AddCommand(newExplainCmd())
+	rootCmd.AddCommand(newEvalCmd())
+	rootCmd.AddCommand(newSessionCmd())
 	rootCmd.AddCommand(newWatchCmd())
 	rootCmd.AddCommand(newMCPCommand())
 	rootCmd.AddCommand(newVersionCommand())
@@ -64,6 +70,7 @@ func newValidateCommand() *cobra.Command {
 	var password string
 	var database string
 	var dryRun bool
+	var offline bool
 
 	cmd := &cobra.Command{
 		Use:   "validate",
@@ -73,13 +80,20 @@ func newValidateCommand() *cobra.Command {
 This checks that:
 - Node labels and relationship types exist in the database
 - GDS algorithms are available
-- Graph projections reference valid labels and types`,
+- Graph projections reference valid labels and types
+
+With --offline, Aura sessions are checked against their CSV files instead:
+ID, endpoint and property columns exist, sampled property values are
+numeric, and relationship endpoints reference known nodes.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			validator := cli.NewValidatorCLI()
 
 			if dryRun {
 				return validator.ValidateDryRun(path, cmd.OutOrStdout())
 			}
+			if offline {
+				return validator.ValidateOffline(path, cmd.OutOrStdout())
+			}
 
 			config := validator.ParseConfig(uri, username, password, database)
 			return validator.ValidateWithConfig(path, config, cmd.OutOrStdout())
@@ -92,6 +106,7 @@ This checks that:
 	cmd.Flags().StringVar(&password, "password", "", "Neo4j password (or $NEO4J_PASSWORD)")
 	cmd.Flags().StringVar(&database, "database", "neo4j", "Database name")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List discovered resources without validating")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Check Aura sessions against their CSV files without connecting")
 
 	return cmd
 }
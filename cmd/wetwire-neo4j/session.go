@@ -0,0 +1,101 @@
+// Command session manages Aura Graph Analytics sessions.
+package main
+
+import (
+	"os"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/aura"
+	"github.com/lex00/wetwire-neo4j-go/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func newSessionCmd() *cobra.Command {
+	var apiURL string
+	var creds aura.Credentials
+
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Manage Aura Graph Analytics sessions",
+		Long: `Session creates, deletes and lists Aura Graph Analytics sessions defined
+as aura.Session literals.
+
+Credentials are Aura API client credentials, read from $AURA_CLIENT_ID,
+$AURA_CLIENT_SECRET and $AURA_TENANT_ID unless given as flags.
+
+Examples:
+  # Create every session defined in the current directory
+  wetwire-neo4j session up
+
+  # Delete one session
+  wetwire-neo4j session down fraud-analytics --path ./analytics
+
+  # List running sessions
+  wetwire-neo4j session ls`,
+	}
+
+	cmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "Aura API URL (or $AURA_API_URL)")
+	cmd.PersistentFlags().StringVar(&creds.ClientID, "client-id", "", "Aura API client ID (or $AURA_CLIENT_ID)")
+	cmd.PersistentFlags().StringVar(&creds.ClientSecret, "client-secret", "", "Aura API client secret (or $AURA_CLIENT_SECRET)")
+	cmd.PersistentFlags().StringVar(&creds.TenantID, "tenant-id", "", "Aura tenant (project) ID (or $AURA_TENANT_ID)")
+
+	// Environment values are read after flag parsing rather than used as
+	// flag defaults, so --help never prints the client secret.
+	sessions := func() *cli.SessionCLI {
+		env := aura.CredentialsFromEnv()
+		c := creds
+		if c.ClientID == "" {
+			c.ClientID = env.ClientID
+		}
+		if c.ClientSecret == "" {
+			c.ClientSecret = env.ClientSecret
+		}
+		if c.TenantID == "" {
+			c.TenantID = env.TenantID
+		}
+		url := apiURL
+		if url == "" {
+			url = os.Getenv("AURA_API_URL")
+		}
+		return cli.NewSessionCLI(aura.NewClient(url, c))
+	}
+
+	var path string
+	up := &cobra.Command{
+		Use:   "up [session]",
+		Short: "Create the defined sessions that are not running",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sessions().Up(cmd.Context(), cmd.OutOrStdout(), path, firstArg(args))
+		},
+	}
+	up.Flags().StringVarP(&path, "path", "p", ".", "Path to scan for sessions")
+
+	down := &cobra.Command{
+		Use:   "down [session]",
+		Short: "Delete the defined sessions that are running",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sessions().Down(cmd.Context(), cmd.OutOrStdout(), path, firstArg(args))
+		},
+	}
+	down.Flags().StringVarP(&path, "path", "p", ".", "Path to scan for sessions")
+
+	ls := &cobra.Command{
+		Use:   "ls",
+		Short: "List running sessions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return sessions().List(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.AddCommand(up, down, ls)
+	return cmd
+}
+
+func firstArg(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return ""
+}
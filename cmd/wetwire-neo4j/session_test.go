@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSessionCmd_HelpHidesCredentials(t *testing.T) {
+	t.Setenv("AURA_CLIENT_ID", "env-client-id")
+	t.Setenv("AURA_CLIENT_SECRET", "env-client-secret")
+
+	cmd := newSessionCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--help"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("session --help failed: %v", err)
+	}
+	for _, secret := range []string{"env-client-id", "env-client-secret"} {
+		if strings.Contains(out.String(), secret) {
+			t.Errorf("help output contains %q:\n%s", secret, out.String())
+		}
+	}
+}
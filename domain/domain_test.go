@@ -3,6 +3,7 @@ package domain
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	coredomain "github.com/lex00/wetwire-core-go/domain"
@@ -132,6 +133,155 @@ var person = schema.NodeType{
 	}
 }
 
+// TestNeo4jLinter_Lint_Target tests that $NEO4J_VERSION enables WN4080
+func TestNeo4jLinter_Lint_Target(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	code := `package schema
+
+import "github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+
+var Chunk = schema.NodeType{
+	Label: "Chunk",
+	Indexes: []schema.Index{
+		{Name: "chunk_embedding", Type: schema.VECTOR, Properties: []string{"embedding"}},
+	},
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "schema.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	linter := &neo4jLinter{}
+	t.Setenv("NEO4J_VERSION", "4.4")
+	result, err := linter.Lint(&Context{}, tmpDir, LintOpts{})
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	found := false
+	for _, e := range result.Errors {
+		if e.Code == "WN4080" && strings.Contains(e.Message, "VECTOR indexes require Neo4j 5.13") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected WN4080 for the vector index, got %+v", result.Errors)
+	}
+
+	t.Setenv("NEO4J_VERSION", "3.5")
+	if _, err := linter.Lint(&Context{}, tmpDir, LintOpts{}); err == nil || !strings.Contains(err.Error(), "unsupported Neo4j version") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
+
+// TestNeo4jLinter_Lint_MemoryBudget tests that $GDS_MEMORY_BUDGET and $GDS_GRAPH_SIZE are read
+func TestNeo4jLinter_Lint_MemoryBudget(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	code := `package schema
+
+import "github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+
+var Person = schema.NodeType{Label: "Person"}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "schema.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	sizeFile := filepath.Join(t.TempDir(), "size.json")
+	if err := os.WriteFile(sizeFile, []byte(`{"nodeCounts": {"Person": 1000}}`), 0644); err != nil {
+		t.Fatalf("failed to write size file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		budget  string
+		size    string
+		wantErr string
+	}{
+		{name: "unset"},
+		{name: "budget and size", budget: "8GB", size: sizeFile},
+		{name: "invalid budget", budget: "eight", size: sizeFile, wantErr: "memory budget: GDS_MEMORY_BUDGET"},
+		{name: "missing size", budget: "8GB", wantErr: "requires GDS_GRAPH_SIZE"},
+		{name: "missing size file", budget: "8GB", size: filepath.Join(tmpDir, "none.json"), wantErr: "GDS_GRAPH_SIZE"},
+	}
+
+	linter := &neo4jLinter{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GDS_MEMORY_BUDGET", tt.budget)
+			t.Setenv("GDS_GRAPH_SIZE", tt.size)
+
+			_, err := linter.Lint(&Context{}, tmpDir, LintOpts{})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Lint failed: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Lint() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNeo4jBuilder_Build_Target tests that $NEO4J_VERSION selects the DDL dialect
+func TestNeo4jBuilder_Build_Target(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	code := `package schema
+
+import "github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+
+var Person = schema.NodeType{
+	Label: "Person",
+	Constraints: []schema.Constraint{
+		{Type: schema.UNIQUE, Properties: []string{"email"}},
+	},
+	Indexes: []schema.Index{
+		{Name: "person_name", Type: schema.BTREE, Properties: []string{"name"}},
+	},
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "schema.go"), []byte(code), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		neo4j string
+		want  []string
+	}{
+		{"", []string{
+			"CREATE CONSTRAINT person_email_unique IF NOT EXISTS FOR (n:Person) REQUIRE (n.email) IS UNIQUE",
+			"CREATE INDEX person_name IF NOT EXISTS FOR (n:Person) ON (n.name)",
+		}},
+		{"4.3", []string{
+			"CREATE CONSTRAINT person_email_unique IF NOT EXISTS ON (n:Person) ASSERT (n.email) IS UNIQUE",
+			"CREATE BTREE INDEX person_name IF NOT EXISTS FOR (n:Person) ON (n.name)",
+		}},
+	}
+
+	builder := &neo4jBuilder{}
+	for _, tt := range tests {
+		t.Setenv("NEO4J_VERSION", tt.neo4j)
+		result, err := builder.Build(&Context{}, tmpDir, BuildOpts{Format: "cypher"})
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		output, _ := result.Data.(string)
+		for _, want := range tt.want {
+			if !strings.Contains(output, want) {
+				t.Errorf("NEO4J_VERSION=%q: expected %q in output:\n%s", tt.neo4j, want, output)
+			}
+		}
+	}
+
+	t.Setenv("GDS_VERSION", "1.8")
+	if _, err := builder.Build(&Context{}, tmpDir, BuildOpts{Format: "cypher"}); err == nil || !strings.Contains(err.Error(), "unsupported GDS version") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
+
 // TestNeo4jLinter_Lint_Fix tests that Fix mode is properly handled
 func TestNeo4jLinter_Lint_Fix(t *testing.T) {
 	// Create a temp directory with a Go file that has lint issues
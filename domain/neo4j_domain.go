@@ -7,11 +7,17 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	coredomain "github.com/lex00/wetwire-core-go/domain"
+	"github.com/lex00/wetwire-neo4j-go/internal/cli"
 	"github.com/lex00/wetwire-neo4j-go/internal/differ"
 	"github.com/lex00/wetwire-neo4j-go/internal/discover"
+	"github.com/lex00/wetwire-neo4j-go/internal/estimation"
 	"github.com/lex00/wetwire-neo4j-go/internal/lint"
+	"github.com/lex00/wetwire-neo4j-go/internal/retrievers"
+	"github.com/lex00/wetwire-neo4j-go/internal/serializer"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
 	"github.com/spf13/cobra"
 )
 
@@ -129,6 +135,12 @@ func (b *neo4jBuilder) Build(ctx *Context, path string, opts BuildOpts) (*Result
 		return nil, fmt.Errorf("failed to resolve dependencies: %w", err)
 	}
 
+	// DDL and procedures are generated for $NEO4J_VERSION and $GDS_VERSION
+	t, err := target.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("target: %w", err)
+	}
+
 	// Determine output format
 	format := opts.Format
 	if format == "" {
@@ -141,7 +153,7 @@ func (b *neo4jBuilder) Build(ctx *Context, path string, opts BuildOpts) (*Result
 	case "json", "pretty":
 		output, err = b.buildJSON(sortedResources, format == "pretty")
 	case "cypher":
-		output, err = b.buildCypher(sortedResources)
+		output, err = b.buildCypher(sortedResources, t)
 	default:
 		output, err = b.buildJSON(sortedResources, true)
 	}
@@ -170,6 +182,10 @@ func (b *neo4jBuilder) buildJSON(resources []discover.DiscoveredResource, pretty
 	algorithms := []map[string]any{}
 	pipelines := []map[string]any{}
 	retrievers := []map[string]any{}
+	projections := []map[string]any{}
+	catalogOps := []map[string]any{}
+	workflows := []map[string]any{}
+	sessions := []map[string]any{}
 
 	for _, r := range resources {
 		switch r.Kind {
@@ -183,6 +199,14 @@ func (b *neo4jBuilder) buildJSON(resources []discover.DiscoveredResource, pretty
 			pipelines = append(pipelines, resourceToMap(r))
 		case discover.KindRetriever:
 			retrievers = append(retrievers, resourceToMap(r))
+		case discover.KindProjection:
+			projections = append(projections, resourceToMap(r))
+		case discover.KindCatalogOperation:
+			catalogOps = append(catalogOps, resourceToMap(r))
+		case discover.KindWorkflow:
+			workflows = append(workflows, resourceToMap(r))
+		case discover.KindSession:
+			sessions = append(sessions, resourceToMap(r))
 		}
 	}
 
@@ -201,6 +225,18 @@ func (b *neo4jBuilder) buildJSON(resources []discover.DiscoveredResource, pretty
 	if len(retrievers) > 0 {
 		output["retrievers"] = retrievers
 	}
+	if len(projections) > 0 {
+		output["projections"] = projections
+	}
+	if len(catalogOps) > 0 {
+		output["catalogOperations"] = catalogOps
+	}
+	if len(workflows) > 0 {
+		output["workflows"] = workflows
+	}
+	if len(sessions) > 0 {
+		output["sessions"] = sessions
+	}
 
 	var data []byte
 	var err error
@@ -217,10 +253,30 @@ func (b *neo4jBuilder) buildJSON(resources []discover.DiscoveredResource, pretty
 	return string(data), nil
 }
 
-func (b *neo4jBuilder) buildCypher(resources []discover.DiscoveredResource) (string, error) {
-	// TODO: Implement Cypher generation
-	// For now, return a placeholder
-	return "-- Cypher output not yet implemented\n", nil
+// buildCypher renders retrievers as runnable Cypher with a :params header,
+// and node and relationship types as DDL for the target Neo4j version.
+// Other resources are listed as comments.
+func (b *neo4jBuilder) buildCypher(resources []discover.DiscoveredResource, t target.Target) (string, error) {
+	retrieverSerializer := retrievers.NewRetrieverSerializer()
+	schemaSerializer := serializer.NewCypherSerializer().WithTarget(t)
+	sections := make([]string, 0, len(resources))
+	for _, r := range resources {
+		var section string
+		var err error
+		switch r.Kind {
+		case discover.KindRetriever:
+			section, err = cli.RetrieverCypher(retrieverSerializer, r)
+		case discover.KindNodeType, discover.KindRelationshipType:
+			section, err = cli.SchemaCypher(schemaSerializer, r)
+		default:
+			section = fmt.Sprintf("// %s: %s (from %s:%d)", r.Kind, r.Name, r.File, r.Line)
+		}
+		if err != nil {
+			section = fmt.Sprintf("// %s: %s (from %s:%d)\n// %v", r.Kind, r.Name, r.File, r.Line, err)
+		}
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n\n") + "\n", nil
 }
 
 func detectFormatFromOutput(output string) string {
@@ -264,6 +320,9 @@ func resourceToMap(r discover.DiscoveredResource) map[string]any {
 	if r.AgentContext != "" {
 		m["agentContext"] = r.AgentContext
 	}
+	if len(r.Steps) > 0 {
+		m["steps"] = r.Steps
+	}
 
 	return m
 }
@@ -290,8 +349,20 @@ func (l *neo4jLinter) Lint(ctx *Context, path string, opts LintOpts) (*Result, e
 		Fix:           opts.Fix,
 	}
 
+	// Features unavailable in $NEO4J_VERSION and $GDS_VERSION are reported
+	t, err := target.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("target: %w", err)
+	}
+
+	// Workflows over $GDS_MEMORY_BUDGET for the $GDS_GRAPH_SIZE graph are reported
+	budget, size, err := estimation.BudgetFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("memory budget: %w", err)
+	}
+
 	// Run lint on all resources
-	linter := lint.NewLinter()
+	linter := lint.NewLinter().WithTarget(t).WithMemoryBudget(budget, size)
 	var allResults []lint.LintResult
 
 	// Convert discovered resources to lintable objects
@@ -301,8 +372,10 @@ func (l *neo4jLinter) Lint(ctx *Context, path string, opts LintOpts) (*Result, e
 		switch r.Kind {
 		case discover.KindNodeType:
 			node := &discover.LintableNodeType{
-				Label:      r.Name,
-				Properties: r.Properties,
+				Label:       r.Name,
+				Properties:  r.Properties,
+				Constraints: r.Constraints,
+				Indexes:     r.Indexes,
 			}
 			// Lint using the discovered node type
 			nodeResults := linter.LintNodeType(node.ToSchemaNodeType())
@@ -600,6 +673,10 @@ func matchesTypeAlias(kind, requestedType string) bool {
 		"Pipeline":         "pipelines",
 		"Retriever":        "retrievers",
 		"Schema":           "schemas",
+		"Projection":       "projections",
+		"CatalogOperation": "catalogoperations",
+		"Workflow":         "workflows",
+		"Session":          "sessions",
 	}
 
 	if plural, ok := plurals[kindLower]; ok && plural == typeLower {
@@ -668,6 +745,18 @@ func generateDOT(resources []discover.DiscoveredResource, graph *discover.Depend
 		case discover.KindRetriever:
 			shape = "component"
 			color = "lightgray"
+		case discover.KindProjection:
+			shape = "cylinder"
+			color = "lightcyan"
+		case discover.KindCatalogOperation:
+			shape = "cds"
+			color = "wheat"
+		case discover.KindWorkflow:
+			shape = "note"
+			color = "thistle"
+		case discover.KindSession:
+			shape = "box3d"
+			color = "lightsalmon"
 		}
 
 		attrs := fmt.Sprintf("shape=%s", shape)
@@ -685,6 +774,10 @@ func generateDOT(resources []discover.DiscoveredResource, graph *discover.Depend
 		for _, dep := range r.Dependencies {
 			output += fmt.Sprintf("  \"%s\" -> \"%s\";\n", r.Name, dep)
 		}
+		// Workflow steps run in order
+		for i := 1; i < len(r.Steps); i++ {
+			output += fmt.Sprintf("  \"%s\" -> \"%s\" [style=dashed, label=\"then\"];\n", r.Steps[i-1], r.Steps[i])
+		}
 	}
 
 	output += "}\n"
@@ -709,6 +802,14 @@ func generateMermaid(resources []discover.DiscoveredResource, graph *discover.De
 			nodeType = "[/%s/]"
 		case discover.KindRetriever:
 			nodeType = "{{%s}}"
+		case discover.KindProjection:
+			nodeType = "[(%s)]"
+		case discover.KindCatalogOperation:
+			nodeType = "[\\%s/]"
+		case discover.KindWorkflow:
+			nodeType = "[/%s\\]"
+		case discover.KindSession:
+			nodeType = "((%s))"
 		default:
 			nodeType = "[%s]"
 		}
@@ -723,6 +824,10 @@ func generateMermaid(resources []discover.DiscoveredResource, graph *discover.De
 		for _, dep := range r.Dependencies {
 			output += fmt.Sprintf("  %s --> %s\n", r.Name, dep)
 		}
+		// Workflow steps run in order
+		for i := 1; i < len(r.Steps); i++ {
+			output += fmt.Sprintf("  %s -. then .-> %s\n", r.Steps[i-1], r.Steps[i])
+		}
 	}
 
 	return output
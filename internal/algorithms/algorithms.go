@@ -56,6 +56,8 @@ type Algorithm interface {
 	GetGraphName() string
 	// GetMode returns the execution mode.
 	GetMode() Mode
+	// GetConcurrency returns the configured concurrency (0 means the GDS default).
+	GetConcurrency() int
 }
 
 // BaseAlgorithm contains common algorithm configuration fields.
@@ -92,6 +94,11 @@ func (b *BaseAlgorithm) GetMode() Mode {
 	return b.Mode
 }
 
+// GetConcurrency returns the configured concurrency.
+func (b *BaseAlgorithm) GetConcurrency() int {
+	return b.Concurrency
+}
+
 // PageRank computes the PageRank centrality score.
 type PageRank struct {
 	BaseAlgorithm
@@ -165,6 +172,71 @@ type Closeness struct {
 func (c *Closeness) AlgorithmType() string       { return "gds.closeness" }
 func (c *Closeness) AlgorithmCategory() Category { return Centrality }
 
+// Eigenvector computes eigenvector centrality, where a node is important if its
+// neighbors are important.
+type Eigenvector struct {
+	BaseAlgorithm
+	// MaxIterations is the maximum number of iterations (default: 20).
+	MaxIterations int
+	// Tolerance is the minimum change in scores between iterations (default: 0.0000001).
+	Tolerance float64
+	// SourceNodes bias the computation towards these nodes (personalized eigenvector).
+	SourceNodes []any
+	// Scaler normalizes the scores: None (default), MinMax, Max, Mean, Log, L1Norm, L2Norm, StdScore.
+	Scaler                     string
+	RelationshipWeightProperty string
+	WriteProperty              string
+	MutateProperty             string
+}
+
+func (e *Eigenvector) AlgorithmType() string       { return "gds.eigenvector" }
+func (e *Eigenvector) AlgorithmCategory() Category { return Centrality }
+
+// HarmonicCentrality computes closeness centrality as the mean of inverse
+// distances, which also handles disconnected graphs.
+type HarmonicCentrality struct {
+	BaseAlgorithm
+	WriteProperty  string
+	MutateProperty string
+}
+
+func (h *HarmonicCentrality) AlgorithmType() string       { return "gds.closeness.harmonic" }
+func (h *HarmonicCentrality) AlgorithmCategory() Category { return Centrality }
+
+// HITS computes hub and authority scores with the Hyperlink-Induced Topic Search algorithm.
+type HITS struct {
+	BaseAlgorithm
+	// HitsIterations is the number of hub/authority iterations (default: 20).
+	HitsIterations int
+	// AuthProperty is the name of the authority score property (default: "auth").
+	AuthProperty string
+	// HubProperty is the name of the hub score property (default: "hub").
+	HubProperty    string
+	WriteProperty  string
+	MutateProperty string
+}
+
+func (h *HITS) AlgorithmType() string       { return "gds.hits" }
+func (h *HITS) AlgorithmCategory() Category { return Centrality }
+
+// CELF selects the seed nodes that maximize influence spread under the
+// Independent Cascade model.
+type CELF struct {
+	BaseAlgorithm
+	// SeedSetSize is the number of seed nodes to select (required).
+	SeedSetSize int
+	// MonteCarloSimulations is the number of spread simulations (default: 100).
+	MonteCarloSimulations int
+	// PropagationProbability is the probability that a node activates a neighbor (default: 0.1).
+	PropagationProbability float64
+	RandomSeed             int64
+	WriteProperty          string
+	MutateProperty         string
+}
+
+func (c *CELF) AlgorithmType() string       { return "gds.influenceMaximization.celf" }
+func (c *CELF) AlgorithmCategory() Category { return Centrality }
+
 // Louvain detects communities using the Louvain algorithm.
 type Louvain struct {
 	BaseAlgorithm
@@ -255,6 +327,131 @@ type KCore struct {
 func (k *KCore) AlgorithmType() string       { return "gds.kcore" }
 func (k *KCore) AlgorithmCategory() Category { return Community }
 
+// SCC finds strongly connected components in a directed graph.
+type SCC struct {
+	BaseAlgorithm
+	// ConsecutiveIds assigns component IDs from 0 without gaps.
+	ConsecutiveIds bool
+	WriteProperty  string
+	MutateProperty string
+}
+
+func (s *SCC) AlgorithmType() string       { return "gds.scc" }
+func (s *SCC) AlgorithmCategory() Category { return Community }
+
+// ModularityOptimization detects communities by greedily moving nodes to the
+// community that increases modularity most.
+type ModularityOptimization struct {
+	BaseAlgorithm
+	// MaxIterations is the maximum number of iterations (default: 10).
+	MaxIterations int
+	// Tolerance is the minimum modularity change for convergence (default: 0.0001).
+	Tolerance float64
+	// SeedProperty is the property for initial community assignments.
+	SeedProperty string
+	// ConsecutiveIds assigns community IDs from 0 without gaps.
+	ConsecutiveIds bool
+	// MinCommunitySize only keeps communities with at least this many nodes.
+	MinCommunitySize           int
+	RelationshipWeightProperty string
+	WriteProperty              string
+	MutateProperty             string
+}
+
+func (m *ModularityOptimization) AlgorithmType() string       { return "gds.modularityOptimization" }
+func (m *ModularityOptimization) AlgorithmCategory() Category { return Community }
+
+// SLPA detects overlapping communities with Speaker-Listener Label Propagation.
+type SLPA struct {
+	BaseAlgorithm
+	// MaxIterations is the maximum number of iterations.
+	MaxIterations int
+	// MinAssociationStrength is the minimum label frequency to keep a community (default: 0.2).
+	MinAssociationStrength float64
+	WriteProperty          string
+	MutateProperty         string
+}
+
+func (s *SLPA) AlgorithmType() string       { return "gds.sllpa" }
+func (s *SLPA) AlgorithmCategory() Category { return Community }
+
+// KMeans clusters nodes by a numeric array property.
+type KMeans struct {
+	BaseAlgorithm
+	// NodeProperty is the node array property to cluster on (required).
+	NodeProperty string
+	// K is the number of clusters (default: 10).
+	K int
+	// MaxIterations is the maximum number of iterations (default: 10).
+	MaxIterations int
+	// DeltaThreshold is the fraction of moved nodes below which iteration stops (default: 0.05).
+	DeltaThreshold float64
+	// NumberOfRestarts runs K-Means several times and keeps the best result (default: 1).
+	NumberOfRestarts int
+	// InitialSampler is "uniform" (default) or "kmeans++".
+	InitialSampler string
+	// SeedCentroids are initial centroid coordinates.
+	SeedCentroids [][]float64
+	// ComputeSilhouette computes a silhouette score per node.
+	ComputeSilhouette bool
+	RandomSeed        int64
+	WriteProperty     string
+	MutateProperty    string
+}
+
+func (k *KMeans) AlgorithmType() string       { return "gds.kmeans" }
+func (k *KMeans) AlgorithmCategory() Category { return Community }
+
+// K1Coloring assigns colors to nodes so that neighbors have different colors.
+type K1Coloring struct {
+	BaseAlgorithm
+	// MaxIterations is the maximum number of iterations (default: 10).
+	MaxIterations int
+	// MinCommunitySize only keeps colors with at least this many nodes.
+	MinCommunitySize int
+	WriteProperty    string
+	MutateProperty   string
+}
+
+func (k *K1Coloring) AlgorithmType() string       { return "gds.k1coloring" }
+func (k *K1Coloring) AlgorithmCategory() Category { return Community }
+
+// LocalClusteringCoefficient computes how close each node's neighborhood is to a clique.
+type LocalClusteringCoefficient struct {
+	BaseAlgorithm
+	// TriangleCountProperty reuses precomputed triangle counts.
+	TriangleCountProperty string
+	WriteProperty         string
+	MutateProperty        string
+}
+
+func (l *LocalClusteringCoefficient) AlgorithmType() string       { return "gds.localClusteringCoefficient" }
+func (l *LocalClusteringCoefficient) AlgorithmCategory() Category { return Community }
+
+// Conductance evaluates a community assignment by the ratio of relationships
+// leaving each community. It only supports stream mode.
+type Conductance struct {
+	BaseAlgorithm
+	// CommunityProperty is the node property holding community IDs (required).
+	CommunityProperty          string
+	RelationshipWeightProperty string
+}
+
+func (c *Conductance) AlgorithmType() string       { return "gds.conductance" }
+func (c *Conductance) AlgorithmCategory() Category { return Community }
+
+// Modularity evaluates a community assignment by its modularity score.
+// It supports stream and stats modes.
+type Modularity struct {
+	BaseAlgorithm
+	// CommunityProperty is the node property holding community IDs (required).
+	CommunityProperty          string
+	RelationshipWeightProperty string
+}
+
+func (m *Modularity) AlgorithmType() string       { return "gds.modularity" }
+func (m *Modularity) AlgorithmCategory() Category { return Community }
+
 // NodeSimilarity computes similarity between nodes based on neighbors.
 type NodeSimilarity struct {
 	BaseAlgorithm
@@ -272,6 +469,10 @@ type NodeSimilarity struct {
 	WriteRelationshipType string
 	// WriteProperty is the property to write similarity to.
 	WriteProperty string
+	// MutateRelationshipType is the relationship type to add to the projection.
+	MutateRelationshipType string
+	// MutateProperty is the relationship property to store similarity in.
+	MutateProperty string
 }
 
 func (n *NodeSimilarity) AlgorithmType() string       { return "gds.nodeSimilarity" }
@@ -297,6 +498,9 @@ type KNN struct {
 	// WriteRelationshipType is the relationship type to write.
 	WriteRelationshipType string
 	WriteProperty         string
+	// MutateRelationshipType is the relationship type to add to the projection.
+	MutateRelationshipType string
+	MutateProperty         string
 }
 
 func (k *KNN) AlgorithmType() string       { return "gds.knn" }
@@ -456,3 +660,135 @@ type DFS struct {
 
 func (d *DFS) AlgorithmType() string       { return "gds.dfs" }
 func (d *DFS) AlgorithmCategory() Category { return PathFinding }
+
+// BellmanFord finds shortest paths from a source node and supports negative weights.
+// Negative cycles reachable from the source are reported instead of paths.
+type BellmanFord struct {
+	BaseAlgorithm
+	SourceNode                 any
+	RelationshipWeightProperty string
+	// WriteRelationshipType is the relationship type for written paths.
+	WriteRelationshipType string
+	// WriteNodeIds stores the node IDs of each path on the written relationship.
+	WriteNodeIds bool
+	// WriteCosts stores the accumulated costs on the written relationship.
+	WriteCosts bool
+	// MutateRelationshipType is the relationship type to add to the projection.
+	MutateRelationshipType string
+}
+
+func (b *BellmanFord) AlgorithmType() string       { return "gds.bellmanFord" }
+func (b *BellmanFord) AlgorithmCategory() Category { return PathFinding }
+
+// Yens finds the K shortest paths between a source and a target node.
+type Yens struct {
+	BaseAlgorithm
+	SourceNode any
+	TargetNode any
+	// K is the number of shortest paths to compute (default: 1).
+	K                          int
+	RelationshipWeightProperty string
+	WriteRelationshipType      string
+	WriteNodeIds               bool
+	WriteCosts                 bool
+	MutateRelationshipType     string
+}
+
+func (y *Yens) AlgorithmType() string       { return "gds.shortestPath.yens" }
+func (y *Yens) AlgorithmCategory() Category { return PathFinding }
+
+// DeltaStepping finds shortest paths from a source node to all other nodes in parallel.
+type DeltaStepping struct {
+	BaseAlgorithm
+	SourceNode any
+	// Delta is the bucket width used to group nodes by tentative distance (default: 2.0).
+	Delta                      float64
+	RelationshipWeightProperty string
+	WriteRelationshipType      string
+	WriteNodeIds               bool
+	WriteCosts                 bool
+	MutateRelationshipType     string
+}
+
+func (d *DeltaStepping) AlgorithmType() string       { return "gds.allShortestPaths.delta" }
+func (d *DeltaStepping) AlgorithmCategory() Category { return PathFinding }
+
+// AllShortestPaths computes the shortest path distance between all pairs of nodes.
+// It only supports stream mode.
+type AllShortestPaths struct {
+	BaseAlgorithm
+	RelationshipWeightProperty string
+}
+
+func (a *AllShortestPaths) AlgorithmType() string       { return "gds.alpha.allShortestPaths" }
+func (a *AllShortestPaths) AlgorithmCategory() Category { return PathFinding }
+
+// MinimumSpanningTree computes a spanning tree of minimum (or maximum) total weight
+// reachable from a source node.
+type MinimumSpanningTree struct {
+	BaseAlgorithm
+	SourceNode                 any
+	RelationshipWeightProperty string
+	// Objective is "minimum" (default) or "maximum".
+	Objective              string
+	WriteRelationshipType  string
+	WriteProperty          string
+	MutateRelationshipType string
+	MutateProperty         string
+}
+
+func (m *MinimumSpanningTree) AlgorithmType() string       { return "gds.spanningTree" }
+func (m *MinimumSpanningTree) AlgorithmCategory() Category { return PathFinding }
+
+// SteinerTree computes a minimum-weight tree connecting a source node to a set of target nodes.
+type SteinerTree struct {
+	BaseAlgorithm
+	SourceNode any
+	// TargetNodes are the terminal nodes the tree must connect.
+	TargetNodes                []any
+	RelationshipWeightProperty string
+	// Delta is the bucket width for the internal shortest path computation (default: 2.0).
+	Delta float64
+	// ApplyRerouting improves the tree by rerouting paths after construction.
+	ApplyRerouting         bool
+	WriteRelationshipType  string
+	WriteProperty          string
+	MutateRelationshipType string
+	MutateProperty         string
+}
+
+func (s *SteinerTree) AlgorithmType() string       { return "gds.steinerTree" }
+func (s *SteinerTree) AlgorithmCategory() Category { return PathFinding }
+
+// RandomWalk generates random walks from each node or from a set of source nodes.
+// It supports stream and stats modes.
+type RandomWalk struct {
+	BaseAlgorithm
+	// SourceNodes restricts walks to start from these nodes (empty = all nodes).
+	SourceNodes []any
+	// WalkLength is the number of steps in each walk (default: 80).
+	WalkLength int
+	// WalksPerNode is the number of walks started from each node (default: 10).
+	WalksPerNode int
+	// InOutFactor controls the tendency to move away from the start node (default: 1.0).
+	InOutFactor float64
+	// ReturnFactor controls the tendency to return to the previous node (default: 1.0).
+	ReturnFactor               float64
+	RelationshipWeightProperty string
+	// WalkBufferSize is the number of walks buffered before streaming (default: 1000).
+	WalkBufferSize int
+	RandomSeed     int64
+}
+
+func (r *RandomWalk) AlgorithmType() string       { return "gds.randomWalk" }
+func (r *RandomWalk) AlgorithmCategory() Category { return PathFinding }
+
+// LongestPath finds the longest path to each node in a directed acyclic graph.
+// It only supports stream mode.
+type LongestPath struct {
+	BaseAlgorithm
+	RelationshipWeightProperty string
+}
+
+func (l *LongestPath) AlgorithmType() string       { return "gds.dag.longestPath" }
+func (l *LongestPath) AlgorithmCategory() Category { return PathFinding }
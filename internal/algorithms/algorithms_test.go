@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
 )
 
 func TestPageRank_Interface(t *testing.T) {
@@ -51,12 +53,24 @@ func TestAlgorithmCategories(t *testing.T) {
 		{&Betweenness{}, Centrality},
 		{&Degree{}, Centrality},
 		{&Closeness{}, Centrality},
+		{&Eigenvector{}, Centrality},
+		{&HarmonicCentrality{}, Centrality},
+		{&HITS{}, Centrality},
+		{&CELF{}, Centrality},
 		{&Louvain{}, Community},
 		{&Leiden{}, Community},
 		{&LabelPropagation{}, Community},
 		{&WCC{}, Community},
 		{&TriangleCount{}, Community},
 		{&KCore{}, Community},
+		{&SCC{}, Community},
+		{&ModularityOptimization{}, Community},
+		{&SLPA{}, Community},
+		{&KMeans{}, Community},
+		{&K1Coloring{}, Community},
+		{&LocalClusteringCoefficient{}, Community},
+		{&Conductance{}, Community},
+		{&Modularity{}, Community},
 		{&NodeSimilarity{}, Similarity},
 		{&KNN{}, Similarity},
 		{&FastRP{}, Embeddings},
@@ -94,6 +108,18 @@ func TestAlgorithmTypes(t *testing.T) {
 		{&WCC{}, "gds.wcc"},
 		{&TriangleCount{}, "gds.triangleCount"},
 		{&KCore{}, "gds.kcore"},
+		{&Eigenvector{}, "gds.eigenvector"},
+		{&HarmonicCentrality{}, "gds.closeness.harmonic"},
+		{&HITS{}, "gds.hits"},
+		{&CELF{}, "gds.influenceMaximization.celf"},
+		{&SCC{}, "gds.scc"},
+		{&ModularityOptimization{}, "gds.modularityOptimization"},
+		{&SLPA{}, "gds.sllpa"},
+		{&KMeans{}, "gds.kmeans"},
+		{&K1Coloring{}, "gds.k1coloring"},
+		{&LocalClusteringCoefficient{}, "gds.localClusteringCoefficient"},
+		{&Conductance{}, "gds.conductance"},
+		{&Modularity{}, "gds.modularity"},
 		{&NodeSimilarity{}, "gds.nodeSimilarity"},
 		{&KNN{}, "gds.knn"},
 		{&FastRP{}, "gds.fastRP"},
@@ -104,6 +130,14 @@ func TestAlgorithmTypes(t *testing.T) {
 		{&AStar{}, "gds.shortestPath.astar"},
 		{&BFS{}, "gds.bfs"},
 		{&DFS{}, "gds.dfs"},
+		{&BellmanFord{}, "gds.bellmanFord"},
+		{&Yens{}, "gds.shortestPath.yens"},
+		{&DeltaStepping{}, "gds.allShortestPaths.delta"},
+		{&AllShortestPaths{}, "gds.alpha.allShortestPaths"},
+		{&MinimumSpanningTree{}, "gds.spanningTree"},
+		{&SteinerTree{}, "gds.steinerTree"},
+		{&RandomWalk{}, "gds.randomWalk"},
+		{&LongestPath{}, "gds.dag.longestPath"},
 	}
 
 	for _, tt := range tests {
@@ -236,11 +270,99 @@ func TestAlgorithmSerializer_ToCypher_NodeSimilarity(t *testing.T) {
 	if !strings.Contains(result, "CALL gds.nodeSimilarity.stats") {
 		t.Errorf("expected gds.nodeSimilarity.stats, got: %s", result)
 	}
-	if !strings.Contains(result, "YIELD nodeCount, relationshipCount") {
+	if !strings.Contains(result, "YIELD nodesCompared, similarityPairs") {
 		t.Errorf("expected stats YIELD, got: %s", result)
 	}
 }
 
+func TestAlgorithmSerializer_ToCypher_Yens(t *testing.T) {
+	s := NewAlgorithmSerializer()
+	yens := &Yens{
+		BaseAlgorithm: BaseAlgorithm{
+			GraphName: "roads",
+			Mode:      Write,
+		},
+		SourceNode:                 1,
+		TargetNode:                 42,
+		K:                          3,
+		RelationshipWeightProperty: "distance",
+		WriteRelationshipType:      "ROUTE",
+		WriteNodeIds:               true,
+	}
+
+	result, err := s.ToCypher(yens)
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+
+	expected := []string{
+		"CALL gds.shortestPath.yens.write(",
+		"sourceNode: 1",
+		"targetNode: 42",
+		"k: 3",
+		"relationshipWeightProperty: 'distance'",
+		"writeRelationshipType: 'ROUTE'",
+		"writeNodeIds: true",
+		"YIELD relationshipsWritten, computeMillis",
+	}
+	for _, e := range expected {
+		if !strings.Contains(result, e) {
+			t.Errorf("expected %q in output, got: %s", e, result)
+		}
+	}
+}
+
+func TestAlgorithmSerializer_ToCypher_SteinerTree(t *testing.T) {
+	s := NewAlgorithmSerializer()
+	st := &SteinerTree{
+		BaseAlgorithm:              BaseAlgorithm{GraphName: "network"},
+		SourceNode:                 0,
+		TargetNodes:                []any{3, 7},
+		RelationshipWeightProperty: "cost",
+	}
+
+	result, err := s.ToCypher(st)
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+
+	if !strings.Contains(result, "CALL gds.steinerTree.stream(") {
+		t.Errorf("expected gds.steinerTree.stream, got: %s", result)
+	}
+	if !strings.Contains(result, "YIELD nodeId, parentId, weight") {
+		t.Errorf("expected spanning tree YIELD, got: %s", result)
+	}
+}
+
+func TestAlgorithmSerializer_ToEstimateCypher(t *testing.T) {
+	s := NewAlgorithmSerializer()
+	pr := &PageRank{
+		BaseAlgorithm: BaseAlgorithm{
+			GraphName: "my_graph",
+			Mode:      Write,
+		},
+		DampingFactor: 0.85,
+		WriteProperty: "pagerank",
+	}
+
+	result, err := s.ToEstimateCypher(pr)
+	if err != nil {
+		t.Fatalf("ToEstimateCypher failed: %v", err)
+	}
+
+	expected := []string{
+		"CALL gds.pageRank.write.estimate(",
+		"'my_graph'",
+		"writeProperty: 'pagerank'",
+		"YIELD requiredMemory, bytesMin, bytesMax",
+	}
+	for _, e := range expected {
+		if !strings.Contains(result, e) {
+			t.Errorf("expected %q in output, got: %s", e, result)
+		}
+	}
+}
+
 func TestAlgorithmSerializer_ToJSON_PageRank(t *testing.T) {
 	s := NewAlgorithmSerializer()
 	pr := &PageRank{
@@ -384,6 +506,8 @@ func TestFormatValue(t *testing.T) {
 		{"string slice", []string{"a", "b"}, "['a', 'b']"},
 		{"float slice", []float64{1.0, 2.0}, "[1, 2]"},
 		{"int slice", []int{1, 2, 3}, "[1, 2, 3]"},
+		{"any slice", []any{int64(3), "a"}, "[3, 'a']"},
+		{"nested float slice", [][]float64{{1, 2}, {3, 4}}, "[[1, 2], [3, 4]]"},
 	}
 
 	for _, tt := range tests {
@@ -395,6 +519,36 @@ func TestFormatValue(t *testing.T) {
 	}
 }
 
+func TestAlgorithmSerializer_WithTarget(t *testing.T) {
+	leiden := &Leiden{BaseAlgorithm: BaseAlgorithm{Name: "communities", GraphName: "g", Mode: Write}}
+
+	tests := []struct {
+		gds  target.Version
+		want string
+	}{
+		{target.V(2, 2), "CALL gds.alpha.leiden.write("},
+		{target.V(2, 4), "CALL gds.beta.leiden.write("},
+		{target.V(2, 5), "CALL gds.leiden.write("},
+	}
+	for _, tt := range tests {
+		s := NewAlgorithmSerializer().WithTarget(target.Target{GDS: tt.gds})
+		result, err := s.ToCypher(leiden)
+		if err != nil {
+			t.Fatalf("ToCypher failed: %v", err)
+		}
+		if !strings.Contains(result, tt.want) {
+			t.Errorf("GDS %s: expected %q, got: %s", tt.gds, tt.want, result)
+		}
+		estimate, err := s.ToEstimateCypher(leiden)
+		if err != nil {
+			t.Fatalf("ToEstimateCypher failed: %v", err)
+		}
+		if want := strings.TrimSuffix(tt.want, "(") + ".estimate("; !strings.Contains(estimate, want) {
+			t.Errorf("GDS %s: expected %q, got: %s", tt.gds, want, estimate)
+		}
+	}
+}
+
 func TestGetYieldFields(t *testing.T) {
 	s := NewAlgorithmSerializer()
 
@@ -403,16 +557,38 @@ func TestGetYieldFields(t *testing.T) {
 		yield string
 	}{
 		{&PageRank{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, score"},
-		{&PageRank{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "nodeCount, relationshipCount, computeMillis"},
+		{&PageRank{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "ranIterations, didConverge, centralityDistribution, computeMillis"},
+		{&Louvain{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "communityCount, ranLevels, modularity, modularities, communityDistribution, computeMillis"},
+		{&WCC{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "componentCount, componentDistribution, computeMillis"},
+		{&HarmonicCentrality{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "centralityDistribution, computeMillis"},
+		{&Leiden{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "communityCount, ranLevels, modularity, modularities, didConverge, communityDistribution, computeMillis"},
+		{&K1Coloring{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "nodeCount, colorCount, ranIterations, didConverge, computeMillis"},
+		{&Node2Vec{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "computeMillis"},
 		{&PageRank{BaseAlgorithm: BaseAlgorithm{Mode: Write}}, "nodePropertiesWritten, computeMillis"},
 		{&Louvain{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, communityId"},
 		{&NodeSimilarity{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "node1, node2, similarity"},
 		{&FastRP{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, embedding"},
 		{&Dijkstra{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "sourceNode, targetNode, path, totalCost"},
+		{&Dijkstra{BaseAlgorithm: BaseAlgorithm{Mode: Write}}, "relationshipsWritten, computeMillis"},
+		{&BellmanFord{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "index, sourceNode, targetNode, totalCost, nodeIds, costs, route, isNegativeCycle"},
+		{&Yens{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "index, sourceNode, targetNode, totalCost, nodeIds, costs, path"},
+		{&AllShortestPaths{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "sourceNodeId, targetNodeId, distance"},
+		{&MinimumSpanningTree{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, parentId, weight"},
+		{&MinimumSpanningTree{BaseAlgorithm: BaseAlgorithm{Mode: Stats}}, "computeMillis"},
+		{&RandomWalk{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeIds, path"},
+		{&BFS{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "sourceNode, nodeIds, path"},
+		{&WCC{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, componentId"},
+		{&KCore{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, coreValue"},
+		{&Eigenvector{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, score"},
+		{&HITS{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, values"},
+		{&CELF{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, spread"},
+		{&KMeans{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "nodeId, communityId, distanceFromCentroid"},
+		{&KMeans{BaseAlgorithm: BaseAlgorithm{Mode: Write}}, "nodePropertiesWritten, computeMillis"},
+		{&Conductance{BaseAlgorithm: BaseAlgorithm{Mode: Stream}}, "community, conductance"},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.algo.AlgorithmType(), func(t *testing.T) {
+		t.Run(tt.algo.AlgorithmType()+"."+string(tt.algo.GetMode()), func(t *testing.T) {
 			result := s.getYieldFields(tt.algo)
 			if result != tt.yield {
 				t.Errorf("getYieldFields() = %v, want %v", result, tt.yield)
@@ -434,6 +610,18 @@ func TestAlgorithm_ImplementsInterface(t *testing.T) {
 	var _ Algorithm = &WCC{}
 	var _ Algorithm = &TriangleCount{}
 	var _ Algorithm = &KCore{}
+	var _ Algorithm = &Eigenvector{}
+	var _ Algorithm = &HarmonicCentrality{}
+	var _ Algorithm = &HITS{}
+	var _ Algorithm = &CELF{}
+	var _ Algorithm = &SCC{}
+	var _ Algorithm = &ModularityOptimization{}
+	var _ Algorithm = &SLPA{}
+	var _ Algorithm = &KMeans{}
+	var _ Algorithm = &K1Coloring{}
+	var _ Algorithm = &LocalClusteringCoefficient{}
+	var _ Algorithm = &Conductance{}
+	var _ Algorithm = &Modularity{}
 	var _ Algorithm = &NodeSimilarity{}
 	var _ Algorithm = &KNN{}
 	var _ Algorithm = &FastRP{}
@@ -444,4 +632,48 @@ func TestAlgorithm_ImplementsInterface(t *testing.T) {
 	var _ Algorithm = &AStar{}
 	var _ Algorithm = &BFS{}
 	var _ Algorithm = &DFS{}
+	var _ Algorithm = &BellmanFord{}
+	var _ Algorithm = &Yens{}
+	var _ Algorithm = &DeltaStepping{}
+	var _ Algorithm = &AllShortestPaths{}
+	var _ Algorithm = &MinimumSpanningTree{}
+	var _ Algorithm = &SteinerTree{}
+	var _ Algorithm = &RandomWalk{}
+	var _ Algorithm = &LongestPath{}
+}
+
+func TestAlgorithmSerializer_ToCypher_KMeans(t *testing.T) {
+	s := NewAlgorithmSerializer()
+
+	km := &KMeans{
+		BaseAlgorithm: BaseAlgorithm{
+			Name:      "segments",
+			GraphName: "customers",
+			Mode:      Write,
+		},
+		NodeProperty:  "embedding",
+		K:             2,
+		SeedCentroids: [][]float64{{0, 1}, {1, 0}},
+		WriteProperty: "segment",
+	}
+
+	cypher, err := s.ToCypher(km)
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+
+	expected := []string{
+		"CALL gds.kmeans.write(",
+		"'customers'",
+		"nodeProperty: 'embedding'",
+		"k: 2",
+		"seedCentroids: [[0, 1], [1, 0]]",
+		"writeProperty: 'segment'",
+		"YIELD nodePropertiesWritten, computeMillis",
+	}
+	for _, e := range expected {
+		if !strings.Contains(cypher, e) {
+			t.Errorf("expected %q in output, got:\n%s", e, cypher)
+		}
+	}
 }
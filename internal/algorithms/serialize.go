@@ -7,11 +7,15 @@ import (
 	"reflect"
 	"strings"
 	"text/template"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
 )
 
 // AlgorithmSerializer serializes algorithm configurations to Cypher and JSON.
 type AlgorithmSerializer struct {
 	templates *template.Template
+	// target selects the procedure tier of each algorithm.
+	target target.Target
 }
 
 // NewAlgorithmSerializer creates a new algorithm serializer.
@@ -21,6 +25,13 @@ func NewAlgorithmSerializer() *AlgorithmSerializer {
 	return s
 }
 
+// WithTarget sets the GDS version the calls are generated for, which selects
+// the alpha, beta or GA name of each procedure.
+func (s *AlgorithmSerializer) WithTarget(t target.Target) *AlgorithmSerializer {
+	s.target = t
+	return s
+}
+
 func (s *AlgorithmSerializer) initTemplates() *template.Template {
 	tmpl := template.New("gds")
 
@@ -37,9 +48,12 @@ YIELD {{.YieldFields}}`))
 	return tmpl
 }
 
+// estimateYieldFields are the columns returned by every GDS .estimate procedure.
+const estimateYieldFields = "requiredMemory, bytesMin, bytesMax, nodeCount, relationshipCount"
+
 // ToCypher converts an algorithm configuration to a Cypher CALL statement.
 func (s *AlgorithmSerializer) ToCypher(algo Algorithm) (string, error) {
-	procedure := algo.AlgorithmType() + "." + string(algo.GetMode())
+	procedure := s.Procedure(algo)
 	config := s.buildConfig(algo)
 	yieldFields := s.getYieldFields(algo)
 
@@ -58,6 +72,32 @@ func (s *AlgorithmSerializer) ToCypher(algo Algorithm) (string, error) {
 	return buf.String(), nil
 }
 
+// ToEstimateCypher converts an algorithm configuration to a Cypher CALL of
+// the .estimate variant of its mode, e.g. gds.pageRank.write.estimate.
+func (s *AlgorithmSerializer) ToEstimateCypher(algo Algorithm) (string, error) {
+	procedure := s.Procedure(algo) + ".estimate"
+
+	data := map[string]string{
+		"Procedure":   procedure,
+		"GraphName":   algo.GetGraphName(),
+		"Config":      s.buildConfig(algo),
+		"YieldFields": estimateYieldFields,
+	}
+
+	var buf bytes.Buffer
+	if err := s.templates.ExecuteTemplate(&buf, "algorithm_call", data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// Procedure returns the procedure called for the algorithm's mode under the
+// serializer's target, e.g. gds.beta.leiden.stream for GDS 2.4.
+func (s *AlgorithmSerializer) Procedure(algo Algorithm) string {
+	return s.target.Procedure(algo.AlgorithmType()) + "." + string(algo.GetMode())
+}
+
 // ToJSON converts an algorithm configuration to JSON.
 func (s *AlgorithmSerializer) ToJSON(algo Algorithm) ([]byte, error) {
 	params := s.toMap(algo)
@@ -157,14 +197,24 @@ func (s *AlgorithmSerializer) getYieldFields(algo Algorithm) string {
 	mode := algo.GetMode()
 	category := algo.AlgorithmCategory()
 
+	if category == PathFinding {
+		return s.getPathFindingYieldFields(algo)
+	}
+
 	switch mode {
 	case Stats:
-		return "nodeCount, relationshipCount, computeMillis"
+		if fields := statsYieldFields(algo); fields != "" {
+			return fields
+		}
+		return "computeMillis"
 	case Write:
 		return "nodePropertiesWritten, computeMillis"
 	case Mutate:
 		return "nodePropertiesWritten, computeMillis"
 	case Stream:
+		if fields := streamYieldFields(algo); fields != "" {
+			return fields
+		}
 		switch category {
 		case Centrality:
 			return "nodeId, score"
@@ -174,8 +224,6 @@ func (s *AlgorithmSerializer) getYieldFields(algo Algorithm) string {
 			return "node1, node2, similarity"
 		case Embeddings:
 			return "nodeId, embedding"
-		case PathFinding:
-			return "sourceNode, targetNode, path, totalCost"
 		default:
 			return "*"
 		}
@@ -184,6 +232,119 @@ func (s *AlgorithmSerializer) getYieldFields(algo Algorithm) string {
 	return "*"
 }
 
+// YieldColumns returns the columns yielded by the algorithm's Cypher call,
+// in order. It returns nil when the call yields "*".
+func (s *AlgorithmSerializer) YieldColumns(algo Algorithm) []string {
+	fields := s.getYieldFields(algo)
+	if fields == "*" {
+		return nil
+	}
+	return strings.Split(fields, ", ")
+}
+
+// statsYieldFields returns the stats columns of algorithms whose stats
+// procedure summarizes their result, or "" to yield computeMillis only.
+func statsYieldFields(algo Algorithm) string {
+	switch algo.(type) {
+	case *PageRank, *ArticleRank, *Eigenvector:
+		return "ranIterations, didConverge, centralityDistribution, computeMillis"
+	case *Degree, *Betweenness, *Closeness, *HarmonicCentrality:
+		return "centralityDistribution, computeMillis"
+	case *HITS:
+		return "ranIterations, didConverge, computeMillis"
+	case *CELF:
+		return "totalSpread, nodeCount, computeMillis"
+	case *Louvain:
+		return "communityCount, ranLevels, modularity, modularities, communityDistribution, computeMillis"
+	case *Leiden:
+		return "communityCount, ranLevels, modularity, modularities, didConverge, communityDistribution, computeMillis"
+	case *LabelPropagation:
+		return "communityCount, ranIterations, didConverge, communityDistribution, computeMillis"
+	case *ModularityOptimization:
+		return "communityCount, ranIterations, didConverge, modularity, communityDistribution, computeMillis"
+	case *WCC, *SCC:
+		return "componentCount, componentDistribution, computeMillis"
+	case *TriangleCount:
+		return "globalTriangleCount, nodeCount, computeMillis"
+	case *KCore:
+		return "degeneracy, computeMillis"
+	case *SLPA:
+		return "ranIterations, didConverge, computeMillis"
+	case *KMeans:
+		return "communityDistribution, averageDistanceToCentroid, computeMillis"
+	case *K1Coloring:
+		return "nodeCount, colorCount, ranIterations, didConverge, computeMillis"
+	case *LocalClusteringCoefficient:
+		return "averageClusteringCoefficient, nodeCount, computeMillis"
+	case *Modularity:
+		return "nodeCount, relationshipCount, communityCount, modularity, computeMillis"
+	case *NodeSimilarity:
+		return "nodesCompared, similarityPairs, similarityDistribution, computeMillis"
+	case *KNN:
+		return "nodesCompared, nodePairsConsidered, similarityPairs, ranIterations, didConverge, similarityDistribution, computeMillis"
+	case *FastRP:
+		return "nodeCount, computeMillis"
+	}
+	return ""
+}
+
+// streamYieldFields returns the stream columns of algorithms that do not
+// follow their category's default, or "" for the category default.
+func streamYieldFields(algo Algorithm) string {
+	switch algo.(type) {
+	case *WCC, *SCC:
+		return "nodeId, componentId"
+	case *TriangleCount:
+		return "nodeId, triangleCount"
+	case *KCore:
+		return "nodeId, coreValue"
+	case *HITS, *SLPA:
+		return "nodeId, values"
+	case *CELF:
+		return "nodeId, spread"
+	case *KMeans:
+		return "nodeId, communityId, distanceFromCentroid"
+	case *K1Coloring:
+		return "nodeId, color"
+	case *LocalClusteringCoefficient:
+		return "nodeId, localClusteringCoefficient"
+	case *Conductance:
+		return "community, conductance"
+	case *Modularity:
+		return "communityId, modularity"
+	}
+	return ""
+}
+
+// getPathFindingYieldFields returns the YIELD fields for path finding algorithms,
+// which write relationships rather than node properties and stream
+// algorithm-specific columns.
+func (s *AlgorithmSerializer) getPathFindingYieldFields(algo Algorithm) string {
+	switch algo.GetMode() {
+	case Stats:
+		return "computeMillis"
+	case Write, Mutate:
+		return "relationshipsWritten, computeMillis"
+	}
+
+	switch algo.(type) {
+	case *BellmanFord:
+		return "index, sourceNode, targetNode, totalCost, nodeIds, costs, route, isNegativeCycle"
+	case *Yens, *DeltaStepping, *LongestPath:
+		return "index, sourceNode, targetNode, totalCost, nodeIds, costs, path"
+	case *AllShortestPaths:
+		return "sourceNodeId, targetNodeId, distance"
+	case *MinimumSpanningTree, *SteinerTree:
+		return "nodeId, parentId, weight"
+	case *RandomWalk:
+		return "nodeIds, path"
+	case *BFS, *DFS:
+		return "sourceNode, nodeIds, path"
+	default:
+		return "sourceNode, targetNode, path, totalCost"
+	}
+}
+
 // formatValue formats a value for Cypher.
 func formatValue(v any) string {
 	switch val := v.(type) {
@@ -201,6 +362,18 @@ func formatValue(v any) string {
 			strs[i] = fmt.Sprintf("%v", f)
 		}
 		return "[" + strings.Join(strs, ", ") + "]"
+	case [][]float64:
+		strs := make([]string, len(val))
+		for i, v := range val {
+			strs[i] = formatValue(v)
+		}
+		return "[" + strings.Join(strs, ", ") + "]"
+	case []any:
+		strs := make([]string, len(val))
+		for i, v := range val {
+			strs[i] = formatValue(v)
+		}
+		return "[" + strings.Join(strs, ", ") + "]"
 	case []int:
 		strs := make([]string, len(val))
 		for i, n := range val {
@@ -262,7 +435,7 @@ func (s *AlgorithmSerializer) BatchToCypher(algorithms []Algorithm) (string, err
 		if err != nil {
 			return "", fmt.Errorf("failed to serialize %s: %w", algo.AlgorithmName(), err)
 		}
-		comment := fmt.Sprintf("// %s - %s", algo.AlgorithmName(), algo.AlgorithmType())
+		comment := fmt.Sprintf("// %s - %s", algo.AlgorithmName(), s.target.Procedure(algo.AlgorithmType()))
 		statements = append(statements, comment+"\n"+stmt)
 	}
 
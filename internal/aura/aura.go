@@ -19,8 +19,10 @@ package aura
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
 	"github.com/lex00/wetwire-neo4j-go/internal/projections"
 )
 
@@ -30,6 +32,20 @@ type Session struct {
 	Name string
 	// TTLHours is the session time-to-live in hours.
 	TTLHours int
+	// Memory is the session memory size, one of MemorySizes. When empty,
+	// `session up` asks the Aura API to size the session from NodeCount and
+	// RelationshipCount.
+	Memory string
+	// NodeCount is the expected number of nodes, used for memory sizing.
+	NodeCount int64
+	// RelationshipCount is the expected number of relationships, used for
+	// memory sizing.
+	RelationshipCount int64
+	// CloudProvider is the cloud provider to run the session on (aws, gcp
+	// or azure).
+	CloudProvider string
+	// Region is the cloud region to run the session in.
+	Region string
 	// DataSource specifies where to load data from.
 	DataSource DataSource
 	// Projection defines how to project the graph from DataFrames.
@@ -40,18 +56,136 @@ type Session struct {
 
 // Validate checks the session configuration for errors.
 func (s *Session) Validate() error {
+	if err := s.ValidateSettings(); err != nil {
+		return err
+	}
+	if s.DataSource == nil {
+		return fmt.Errorf("data source is required")
+	}
+	if err := validateDataSource(s.DataSource); err != nil {
+		return fmt.Errorf("%s data source: %w", s.DataSource.SourceType(), err)
+	}
+	if s.Projection != nil {
+		if err := validateQueryColumns(s.Projection, s.DataSource); err != nil {
+			return fmt.Errorf("%s data source: %w", s.DataSource.SourceType(), err)
+		}
+	}
+	return nil
+}
+
+// ValidateSettings checks the session settings other than its data source
+// and projection: name, TTL and memory size.
+func (s *Session) ValidateSettings() error {
 	if s.Name == "" {
 		return fmt.Errorf("session name is required")
 	}
 	if s.TTLHours <= 0 {
 		return fmt.Errorf("TTLHours must be positive")
 	}
-	if s.DataSource == nil {
-		return fmt.Errorf("data source is required")
+	if s.Memory != "" && !validMemory(s.Memory) {
+		return fmt.Errorf("unsupported memory size %q, want one of %s", s.Memory, strings.Join(MemorySizes, ", "))
+	}
+	return nil
+}
+
+// MemorySizes are the session memory sizes the Aura API accepts, smallest
+// first.
+var MemorySizes = []string{"1GB", "2GB", "4GB", "8GB", "16GB", "24GB", "32GB", "48GB", "64GB", "96GB", "128GB", "192GB", "256GB", "384GB", "512GB"}
+
+func validMemory(memory string) bool {
+	for _, m := range MemorySizes {
+		if m == memory {
+			return true
+		}
+	}
+	return false
+}
+
+// FromFields builds a session from the constant field values of a
+// discovered aura.Session literal. Data source, projection and algorithms
+// are not constant and are left unset.
+func FromFields(fields map[string]any) (*Session, error) {
+	s := &Session{}
+	for _, f := range []struct {
+		key    string
+		target *string
+	}{
+		{"Name", &s.Name},
+		{"Memory", &s.Memory},
+		{"CloudProvider", &s.CloudProvider},
+		{"Region", &s.Region},
+	} {
+		if v, ok := fields[f.key]; ok {
+			str, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("field %s: expected string, got %T", f.key, v)
+			}
+			*f.target = str
+		}
+	}
+	for _, f := range []struct {
+		key    string
+		target *int64
+	}{
+		{"NodeCount", &s.NodeCount},
+		{"RelationshipCount", &s.RelationshipCount},
+	} {
+		if v, ok := fields[f.key]; ok {
+			n, ok := v.(int64)
+			if !ok {
+				return nil, fmt.Errorf("field %s: expected integer, got %T", f.key, v)
+			}
+			*f.target = n
+		}
+	}
+	if v, ok := fields["TTLHours"]; ok {
+		n, ok := v.(int64)
+		if !ok {
+			return nil, fmt.Errorf("field TTLHours: expected integer, got %T", v)
+		}
+		s.TTLHours = int(n)
+	}
+	return s, nil
+}
+
+// validateDataSource checks the fields a data source needs to connect.
+func validateDataSource(ds DataSource) error {
+	switch d := ds.(type) {
+	case *S3DataSource:
+		if d.Bucket == "" {
+			return fmt.Errorf("bucket is required")
+		}
+		if d.Format != "" && d.Format != "parquet" && d.Format != "csv" {
+			return fmt.Errorf("unsupported format %q, want parquet or csv", d.Format)
+		}
+		if err := checkSecretRef("AccessKeyID", d.AccessKeyID); err != nil {
+			return err
+		}
+		return checkSecretRef("SecretAccessKey", d.SecretAccessKey)
+	case *PostgresDataSource:
+		if d.Host == "" || d.Database == "" {
+			return fmt.Errorf("host and database are required")
+		}
+		return checkSecretRef("Password", d.Password)
+	case *DatabricksDataSource:
+		if d.ServerHostname == "" || d.HTTPPath == "" {
+			return fmt.Errorf("server hostname and HTTP path are required")
+		}
+		return checkSecretRef("AccessToken", d.AccessToken)
 	}
 	return nil
 }
 
+// checkSecretRef rejects a secret field holding a literal value. Generated
+// code only reads secrets from the environment, so a literal would be
+// ignored in favor of the default variable.
+func checkSecretRef(field, value string) error {
+	if value == "" || graphrag.SecretEnv(value, "") != "" {
+		return nil
+	}
+	return fmt.Errorf("%s must be empty or $NAME of an environment variable, not a literal secret", field)
+}
+
 // DataSource is the interface for data source configurations.
 type DataSource interface {
 	// SourceType returns the data source type identifier.
@@ -60,10 +194,23 @@ type DataSource interface {
 
 // PandasDataSource configures loading data from Pandas DataFrames.
 type PandasDataSource struct {
-	// NodeFiles are CSV files containing node data.
+	// NodeFiles are CSV files containing node data. They are concatenated;
+	// when the projection has several node DataFrames, a labels column
+	// selects the rows of each label.
 	NodeFiles []string
-	// RelFiles are CSV files containing relationship data.
+	// RelFiles are CSV files containing relationship data. They are
+	// concatenated; when the projection has several relationship DataFrames,
+	// a relationshipType column selects the rows of each type.
 	RelFiles []string
+	// NodeFilesByLabel are CSV files holding the nodes of a single label.
+	// They take precedence over NodeFiles for that label.
+	NodeFilesByLabel map[string][]string
+	// RelFilesByType are CSV files holding the relationships of a single
+	// type. They take precedence over RelFiles for that type.
+	RelFilesByType map[string][]string
+	// OutputDir is the directory write-mode algorithm results are saved to,
+	// as <name>.csv. Defaults to the working directory.
+	OutputDir string
 }
 
 // SourceType returns "pandas".
@@ -110,3 +257,116 @@ type BigQueryDataSource struct {
 func (b *BigQueryDataSource) SourceType() string {
 	return "bigquery"
 }
+
+// ParquetDataSource configures loading data from Parquet files. Paths may
+// be local or any URL pandas can read.
+type ParquetDataSource struct {
+	// NodeFiles are Parquet files containing node data. They are
+	// concatenated; when the projection has several node DataFrames, a
+	// labels column selects the rows of each label.
+	NodeFiles []string
+	// RelFiles are Parquet files containing relationship data. They are
+	// concatenated; when the projection has several relationship DataFrames,
+	// a relationshipType column selects the rows of each type.
+	RelFiles []string
+	// NodeFilesByLabel are Parquet files holding the nodes of a single
+	// label. They take precedence over NodeFiles for that label.
+	NodeFilesByLabel map[string][]string
+	// RelFilesByType are Parquet files holding the relationships of a single
+	// type. They take precedence over RelFiles for that type.
+	RelFilesByType map[string][]string
+	// OutputDir is the directory write-mode algorithm results are saved to,
+	// as <name>.parquet. Defaults to the working directory.
+	OutputDir string
+}
+
+// SourceType returns "parquet".
+func (p *ParquetDataSource) SourceType() string {
+	return "parquet"
+}
+
+// S3DataSource configures loading data files from Amazon S3.
+type S3DataSource struct {
+	// Bucket is the S3 bucket name.
+	Bucket string
+	// Region is the AWS region of the bucket (optional).
+	Region string
+	// Format is the file format: parquet (default) or csv.
+	Format string
+	// NodeKeys are object keys of node files, concatenated like
+	// ParquetDataSource.NodeFiles.
+	NodeKeys []string
+	// RelKeys are object keys of relationship files, concatenated like
+	// ParquetDataSource.RelFiles.
+	RelKeys []string
+	// NodeKeysByLabel are object keys of files holding a single label.
+	NodeKeysByLabel map[string][]string
+	// RelKeysByType are object keys of files holding a single type.
+	RelKeysByType map[string][]string
+	// OutputPrefix is the key prefix write-mode algorithm results are saved
+	// under, as <name>.<format>.
+	OutputPrefix string
+	// AccessKeyID is the AWS access key ID. Generated code reads it from
+	// AWS_ACCESS_KEY_ID, or from NAME when set to $NAME. Literal values are
+	// rejected by Validate.
+	AccessKeyID string
+	// SecretAccessKey is the AWS secret access key. Generated code reads it
+	// from AWS_SECRET_ACCESS_KEY, or from NAME when set to $NAME. Literal
+	// values are rejected by Validate.
+	SecretAccessKey string
+}
+
+// SourceType returns "s3".
+func (s *S3DataSource) SourceType() string {
+	return "s3"
+}
+
+// PostgresDataSource configures loading data from PostgreSQL.
+type PostgresDataSource struct {
+	// Host is the database host.
+	Host string
+	// Port is the database port (default: 5432).
+	Port int
+	// Database is the database name.
+	Database string
+	// User is the database username.
+	User string
+	// Password is the database password. Generated code reads it from
+	// PGPASSWORD, or from NAME when set to $NAME. Literal values are
+	// rejected by Validate.
+	Password string
+	// NodeQuery is the SQL query to fetch node data.
+	NodeQuery string
+	// RelQuery is the SQL query to fetch relationship data.
+	RelQuery string
+}
+
+// SourceType returns "postgres".
+func (p *PostgresDataSource) SourceType() string {
+	return "postgres"
+}
+
+// DatabricksDataSource configures loading data from Databricks SQL.
+type DatabricksDataSource struct {
+	// ServerHostname is the workspace hostname.
+	ServerHostname string
+	// HTTPPath is the HTTP path of the SQL warehouse or cluster.
+	HTTPPath string
+	// Catalog is the Unity Catalog name (optional).
+	Catalog string
+	// Schema is the schema name (optional).
+	Schema string
+	// AccessToken is the personal access token. Generated code reads it
+	// from DATABRICKS_TOKEN, or from NAME when set to $NAME. Literal values
+	// are rejected by Validate.
+	AccessToken string
+	// NodeQuery is the SQL query to fetch node data.
+	NodeQuery string
+	// RelQuery is the SQL query to fetch relationship data.
+	RelQuery string
+}
+
+// SourceType returns "databricks".
+func (d *DatabricksDataSource) SourceType() string {
+	return "databricks"
+}
@@ -1,6 +1,8 @@
 package aura
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -124,8 +126,141 @@ func TestSessionSerializer_ToPython(t *testing.T) {
 	if !strings.Contains(python, "GdsSessions") {
 		t.Error("expected Python code to use GdsSessions")
 	}
-	if !strings.Contains(python, "page_rank") {
-		t.Errorf("expected Python code to include page_rank algorithm, got: %s", python)
+	if !strings.Contains(python, "gds.pageRank.stream(") {
+		t.Errorf("expected Python code to include pageRank algorithm, got: %s", python)
+	}
+}
+
+func TestSessionSerializer_ToPython_Projection(t *testing.T) {
+	session := &Session{
+		Name:     "fraud",
+		TTLHours: 4,
+		DataSource: &PandasDataSource{
+			RelFiles: []string{"transfers-2024.csv", "transfers-2025.csv"},
+			NodeFilesByLabel: map[string][]string{
+				"Account": {"accounts-eu.csv", "accounts-us.csv"},
+				"Person":  {"people.csv"},
+			},
+			OutputDir: "results",
+		},
+		Projection: &projections.DataFrameProjection{
+			BaseProjection: projections.BaseProjection{Name: "fraud-graph"},
+			NodeDataFrames: []projections.NodeDataFrame{
+				{Label: "Account", IDColumn: "account_id", Properties: []string{"balance"}},
+				{Label: "Person", IDColumn: "person_id"},
+			},
+			RelationshipDataFrames: []projections.RelationshipDataFrame{
+				{Type: "TRANSFERRED", SourceColumn: "from_account", TargetColumn: "to_account", Properties: []string{"amount"}},
+				{Type: "OWNS", SourceColumn: "person_id", TargetColumn: "account_id"},
+			},
+		},
+		Algorithms: []algorithms.Algorithm{
+			&algorithms.WCC{
+				BaseAlgorithm:  algorithms.BaseAlgorithm{GraphName: "fraud-graph", Mode: algorithms.Mutate},
+				MutateProperty: "component",
+				WriteProperty:  "ignored",
+			},
+			&algorithms.PageRank{
+				BaseAlgorithm:              algorithms.BaseAlgorithm{GraphName: "fraud-graph", Mode: algorithms.Write, NodeLabels: []string{"Account"}},
+				DampingFactor:              0.85,
+				MaxIterations:              20,
+				RelationshipWeightProperty: "amount",
+				WriteProperty:              "risk_score",
+			},
+		},
+	}
+
+	python, err := NewSerializer().ToPython(session)
+	if err != nil {
+		t.Fatalf("ToPython failed: %v", err)
+	}
+
+	for _, want := range []string{
+		`rels_df = pd.concat([pd.read_csv("transfers-2024.csv"), pd.read_csv("transfers-2025.csv")], ignore_index=True)`,
+		`    pd.concat([pd.read_csv("accounts-eu.csv"), pd.read_csv("accounts-us.csv")], ignore_index=True)`,
+		`    .rename(columns={"account_id": "nodeId"})`,
+		`    .loc[:, ["nodeId", "labels", "balance"]]`,
+		`    rels_df[rels_df["relationshipType"] == "TRANSFERRED"]`,
+		`    .rename(columns={"from_account": "sourceNodeId", "to_account": "targetNodeId"})`,
+		"    nodes=[account_nodes, person_nodes],\n    relationships=[transferred_rels, owns_rels],",
+		"result = gds.wcc.mutate(\n    G,\n    mutateProperty=\"component\",\n)",
+		"result = gds.pageRank.stream(\n    G,\n    dampingFactor=0.85,\n    maxIterations=20,\n    nodeLabels=[\"Account\"],\n    relationshipWeightProperty=\"amount\",\n)",
+		`result.to_csv("results/risk_score.csv", index=False)`,
+	} {
+		if !strings.Contains(python, want) {
+			t.Errorf("expected Python code to contain %q, got:\n%s", want, python)
+		}
+	}
+	if strings.Contains(python, "ignored") {
+		t.Errorf("unexpected write configuration in mutate call:\n%s", python)
+	}
+}
+
+func TestSessionSerializer_ToPython_WriteBack(t *testing.T) {
+	pageRank := &algorithms.PageRank{
+		BaseAlgorithm: algorithms.BaseAlgorithm{GraphName: "g", Mode: algorithms.Write},
+		WriteProperty: "rank",
+	}
+	projection := &projections.DataFrameProjection{BaseProjection: projections.BaseProjection{Name: "g"}}
+
+	tests := []struct {
+		name string
+		ds   DataSource
+		want string
+	}{
+		{"snowflake", &SnowflakeDataSource{NodeQuery: "SELECT * FROM nodes"}, `write_pandas(conn, result, "RANK", auto_create_table=True, overwrite=True)`},
+		{"bigquery", &BigQueryDataSource{Project: "p", Dataset: "d", NodeQuery: "SELECT * FROM nodes"}, `client.load_table_from_dataframe(result, "p.d.rank").result()`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{Name: "s", TTLHours: 1, DataSource: tt.ds, Projection: projection, Algorithms: []algorithms.Algorithm{pageRank}}
+			python, err := NewSerializer().ToPython(session)
+			if err != nil {
+				t.Fatalf("ToPython failed: %v", err)
+			}
+			if !strings.Contains(python, tt.want) {
+				t.Errorf("expected Python code to contain %q, got:\n%s", tt.want, python)
+			}
+		})
+	}
+}
+
+func TestSessionSerializer_ToPython_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		session *Session
+		wantErr string
+	}{
+		{
+			name: "no projection",
+			session: &Session{
+				Name:       "s",
+				DataSource: &PandasDataSource{NodeFiles: []string{"nodes.csv"}},
+				Algorithms: []algorithms.Algorithm{&algorithms.PageRank{}},
+			},
+			wantErr: "algorithms require a projection",
+		},
+		{
+			name: "no relationship data",
+			session: &Session{
+				Name:       "s",
+				DataSource: &PandasDataSource{NodeFiles: []string{"nodes.csv"}},
+				Projection: &projections.DataFrameProjection{
+					RelationshipDataFrames: []projections.RelationshipDataFrame{{Type: "KNOWS"}},
+				},
+			},
+			wantErr: "relationship type KNOWS: no data loaded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSerializer().ToPython(tt.session)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ToPython() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
 	}
 }
 
@@ -153,6 +288,34 @@ func TestSessionSerializer_ToJSON(t *testing.T) {
 	if jsonMap["dataSourceType"] != "pandas" {
 		t.Errorf("expected dataSourceType 'pandas', got %v", jsonMap["dataSourceType"])
 	}
+	projection := jsonMap["projection"].(map[string]any)
+	if projection["name"] != "test-graph" || projection["graphName"] != "test-graph" {
+		t.Errorf("expected projection name and graphName 'test-graph', got %v", projection)
+	}
+}
+
+func TestSessionSerializer_GraphName(t *testing.T) {
+	session := &Session{
+		Name:       "test-session",
+		DataSource: &PandasDataSource{NodeFiles: []string{"nodes.csv"}},
+		Projection: &projections.DataFrameProjection{
+			BaseProjection: projections.BaseProjection{Name: "people", GraphName: "people-2025"},
+			NodeDataFrames: []projections.NodeDataFrame{{Label: "Person", IDColumn: "id"}},
+		},
+	}
+
+	python, err := NewSerializer().ToPython(session)
+	if err != nil {
+		t.Fatalf("ToPython failed: %v", err)
+	}
+	if !strings.Contains(python, `graph_name="people-2025",`) {
+		t.Errorf("expected the catalog graph name in gds.graph.construct, got:\n%s", python)
+	}
+
+	projection := NewSerializer().ToMap(session)["projection"].(map[string]any)
+	if projection["name"] != "people" || projection["graphName"] != "people-2025" {
+		t.Errorf("unexpected projection map: %v", projection)
+	}
 }
 
 func TestSession_Validate(t *testing.T) {
@@ -196,6 +359,18 @@ func TestSession_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid memory",
+			session: &Session{
+				Name:     "test",
+				TTLHours: 1,
+				Memory:   "10GB",
+				DataSource: &PandasDataSource{
+					NodeFiles: []string{"nodes.csv"},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing data source",
 			session: &Session{
@@ -215,3 +390,252 @@ func TestSession_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionSerializer_ToPython_DataSources(t *testing.T) {
+	projection := &projections.DataFrameProjection{
+		BaseProjection: projections.BaseProjection{Name: "g"},
+		NodeDataFrames: []projections.NodeDataFrame{{Label: "Account", IDColumn: "id"}},
+		RelationshipDataFrames: []projections.RelationshipDataFrame{
+			{Type: "TRANSFERRED", SourceColumn: "src", TargetColumn: "dst"},
+		},
+	}
+	pageRank := &algorithms.PageRank{
+		BaseAlgorithm: algorithms.BaseAlgorithm{GraphName: "g", Mode: algorithms.Write},
+		WriteProperty: "rank",
+	}
+
+	tests := []struct {
+		name string
+		ds   DataSource
+		want []string
+	}{
+		{"parquet", &ParquetDataSource{NodeFiles: []string{"a.parquet", "b.parquet"}, RelFiles: []string{"t.parquet"}}, []string{
+			`nodes_df = pd.concat([pd.read_parquet("a.parquet"), pd.read_parquet("b.parquet")], ignore_index=True)`,
+			`result.to_parquet("rank.parquet", index=False)`,
+		}},
+		{"s3", &S3DataSource{
+			Bucket: "lake", Region: "eu-west-1", NodeKeys: []string{"graph/accounts.parquet"}, RelKeys: []string{"graph/transfers.parquet"},
+			OutputPrefix: "results", SecretAccessKey: "$LAKE_SECRET",
+		}, []string{
+			`"key": os.environ["AWS_ACCESS_KEY_ID"],`,
+			`"secret": os.environ["LAKE_SECRET"],`,
+			`"client_kwargs": {"region_name": "eu-west-1"},`,
+			`nodes_df = pd.read_parquet("s3://lake/graph/accounts.parquet", storage_options=storage_options)`,
+			`result.to_parquet("s3://lake/results/rank.parquet", index=False, storage_options=storage_options)`,
+		}},
+		{"postgres", &PostgresDataSource{
+			Host: "db", Database: "bank", User: "analyst",
+			NodeQuery: "SELECT id FROM accounts", RelQuery: "SELECT src, dst FROM transfers",
+		}, []string{
+			`    "postgresql+psycopg2",`,
+			`    password=os.environ["PGPASSWORD"],`,
+			`    port=5432,`,
+			`nodes_df = pd.read_sql("SELECT id FROM accounts", engine)`,
+			`result.to_sql("rank", engine, if_exists="replace", index=False)`,
+		}},
+		{"databricks", &DatabricksDataSource{
+			ServerHostname: "dbc.cloud.databricks.com", HTTPPath: "/sql/1.0/warehouses/abc", Catalog: "main", AccessToken: "${DBX_TOKEN}",
+			NodeQuery: "SELECT id FROM accounts", RelQuery: "SELECT src, dst FROM transfers",
+		}, []string{
+			`    password=os.environ["DBX_TOKEN"],`,
+			`    query={"http_path": "/sql/1.0/warehouses/abc", "catalog": "main"},`,
+			`rels_df = pd.read_sql("SELECT src, dst FROM transfers", engine)`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{Name: "s", TTLHours: 1, DataSource: tt.ds, Projection: projection, Algorithms: []algorithms.Algorithm{pageRank}}
+			if err := session.Validate(); err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			python, err := NewSerializer().ToPython(session)
+			if err != nil {
+				t.Fatalf("ToPython failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(python, want) {
+					t.Errorf("expected Python code to contain %q, got:\n%s", want, python)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionSerializer_ToMap_Credentials(t *testing.T) {
+	session := &Session{
+		Name:       "s",
+		TTLHours:   1,
+		DataSource: &PostgresDataSource{Host: "db", Database: "bank", Password: "$BANK_PASSWORD"},
+	}
+	ds := NewSerializer().ToMap(session)["dataSource"].(map[string]any)
+	if ds["passwordEnv"] != "BANK_PASSWORD" {
+		t.Errorf("expected passwordEnv BANK_PASSWORD, got %v", ds["passwordEnv"])
+	}
+}
+
+func TestSession_Validate_DataSources(t *testing.T) {
+	projection := &projections.DataFrameProjection{
+		NodeDataFrames: []projections.NodeDataFrame{
+			{Label: "Account", IDColumn: "account_id", Properties: []string{"balance"}},
+			{Label: "Person", IDColumn: "account_id"},
+		},
+		RelationshipDataFrames: []projections.RelationshipDataFrame{{Type: "OWNS", SourceColumn: "person_id", TargetColumn: "account_id"}},
+	}
+
+	tests := []struct {
+		name    string
+		ds      DataSource
+		wantErr string
+	}{
+		{"columns returned", &PostgresDataSource{
+			Host: "db", Database: "bank",
+			NodeQuery: `SELECT a.id AS account_id, a.balance, 'Account' labels FROM accounts a`,
+			RelQuery:  "SELECT person_id, account_id FROM owners",
+		}, ""},
+		{"select star", &DatabricksDataSource{ServerHostname: "h", HTTPPath: "/p", NodeQuery: "SELECT * FROM nodes", RelQuery: "SELECT o.* FROM owners o"}, ""},
+		{"missing node columns", &SnowflakeDataSource{
+			NodeQuery: `SELECT "account_id", COALESCE(balance, 0) FROM accounts`,
+			RelQuery:  `SELECT "person_id", "account_id" FROM owners`,
+		}, "node query does not return columns balance, labels"},
+		{"snowflake upper-cases unquoted aliases", &SnowflakeDataSource{
+			NodeQuery: `SELECT id AS account_id, "balance", 'Account' AS "labels" FROM accounts`,
+			RelQuery:  `SELECT "person_id", "account_id" FROM owners`,
+		}, "account_id is returned as ACCOUNT_ID; quote the alias"},
+		{"postgres lower-cases unquoted aliases", &PostgresDataSource{
+			Host: "db", Database: "bank",
+			NodeQuery: `SELECT ID AS Account_ID, Balance, 'Account' AS LABELS FROM accounts`,
+			RelQuery:  "SELECT PERSON_ID, ACCOUNT_ID FROM owners",
+		}, ""},
+		{"databricks keeps case", &DatabricksDataSource{
+			ServerHostname: "h", HTTPPath: "/p",
+			NodeQuery: "SELECT account_id, Balance, labels FROM nodes",
+			RelQuery:  "SELECT person_id, account_id FROM owners",
+		}, "node query does not return columns balance"},
+		{"missing relationship columns", &BigQueryDataSource{
+			NodeQuery: "SELECT DISTINCT account_id, balance, labels FROM nodes",
+			RelQuery:  "SELECT owner AS person_id FROM owners",
+		}, "relationship query does not return columns account_id"},
+		{"no relationship query", &PostgresDataSource{Host: "db", Database: "bank", NodeQuery: "SELECT * FROM nodes"}, "relationship query is required"},
+		{"postgres host", &PostgresDataSource{Database: "bank"}, "host and database are required"},
+		{"databricks path", &DatabricksDataSource{ServerHostname: "h"}, "HTTP path are required"},
+		{"s3 bucket", &S3DataSource{}, "bucket is required"},
+		{"s3 format", &S3DataSource{Bucket: "lake", Format: "orc"}, "unsupported format"},
+		{"s3 literal secret", &S3DataSource{Bucket: "lake", SecretAccessKey: "wJalrXUtnFEMI"}, "SecretAccessKey must be empty or $NAME"},
+		{"postgres literal password", &PostgresDataSource{Host: "db", Database: "bank", Password: "hunter2"}, "Password must be empty or $NAME"},
+		{"databricks literal token", &DatabricksDataSource{ServerHostname: "h", HTTPPath: "/p", AccessToken: "dapi-secret"}, "AccessToken must be empty or $NAME"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{Name: "s", TTLHours: 1, DataSource: tt.ds, Projection: projection}
+			err := session.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSession_CheckFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"nodes.csv":    "id,labels,balance\n1,Account,10.5\n2,Account,\n3,Person,0\n",
+		"people.csv":   "id,age\n3,42\n4,unknown\n",
+		"owns.csv":     "owner,account\n3,1\n4,2\n5,9\n",
+		"badowns.csv":  "owner\n3\n",
+		"ragged.csv":   "id,balance\n1,2,3\n",
+		"noheader.csv": "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	owns := []projections.RelationshipDataFrame{{Type: "OWNS", SourceColumn: "owner", TargetColumn: "account"}}
+
+	tests := []struct {
+		name       string
+		ds         *PandasDataSource
+		nodes      []projections.NodeDataFrame
+		rels       []projections.RelationshipDataFrame
+		wantErrs   []string
+		wantNoErrs bool
+	}{
+		{
+			name:       "valid shared node file",
+			ds:         &PandasDataSource{NodeFiles: []string{"nodes.csv"}},
+			nodes:      []projections.NodeDataFrame{{Label: "Account", IDColumn: "id", Properties: []string{"balance"}}, {Label: "Person", IDColumn: "id"}},
+			wantNoErrs: true,
+		},
+		{
+			name:  "non-numeric property and dangling endpoints",
+			ds:    &PandasDataSource{NodeFilesByLabel: map[string][]string{"Person": {"people.csv"}}, NodeFiles: []string{"nodes.csv"}, RelFiles: []string{"owns.csv"}},
+			nodes: []projections.NodeDataFrame{{Label: "Account", IDColumn: "id"}, {Label: "Person", IDColumn: "id", Properties: []string{"age"}}},
+			rels:  owns,
+			wantErrs: []string{
+				`node label Person: people.csv:3: property age is not numeric: "unknown"`,
+				`relationship type OWNS: 2 endpoints reference missing nodes, first at owns.csv:4 owner "5"`,
+			},
+		},
+		{
+			name:     "missing columns",
+			ds:       &PandasDataSource{NodeFiles: []string{"people.csv"}, RelFiles: []string{"badowns.csv"}},
+			nodes:    []projections.NodeDataFrame{{Label: "Person", IDColumn: "person_id", Properties: []string{"age", "score"}}},
+			rels:     owns,
+			wantErrs: []string{"people.csv: missing columns person_id, score", "badowns.csv: missing columns account"},
+		},
+		{
+			name:     "labels column required for shared file",
+			ds:       &PandasDataSource{NodeFiles: []string{"people.csv"}},
+			nodes:    []projections.NodeDataFrame{{Label: "Person", IDColumn: "id"}, {Label: "Robot", IDColumn: "id"}},
+			wantErrs: []string{"missing columns labels"},
+		},
+		{
+			name:     "unreadable files",
+			ds:       &PandasDataSource{NodeFiles: []string{"missing.csv"}, NodeFilesByLabel: map[string][]string{"B": {"ragged.csv"}, "C": {"noheader.csv"}}},
+			nodes:    []projections.NodeDataFrame{{Label: "A"}, {Label: "B", IDColumn: "id"}, {Label: "C"}},
+			wantErrs: []string{"node label A: open", "ragged.csv: record on line 2: wrong number of fields", "noheader.csv: failed to read header"},
+		},
+		{
+			name:     "no relationship files",
+			ds:       &PandasDataSource{NodeFiles: []string{"nodes.csv"}},
+			rels:     owns,
+			wantErrs: []string{"relationship type OWNS: no files"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{
+				Name: "s", TTLHours: 1, DataSource: tt.ds,
+				Projection: &projections.DataFrameProjection{NodeDataFrames: tt.nodes, RelationshipDataFrames: tt.rels},
+			}
+			errs := session.CheckFiles(dir)
+			if tt.wantNoErrs && len(errs) > 0 {
+				t.Fatalf("CheckFiles() = %v", errs)
+			}
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			joined := strings.Join(got, "\n")
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(joined, want) {
+					t.Errorf("CheckFiles() = %q, want containing %q", joined, want)
+				}
+			}
+		})
+	}
+
+	parquet := &Session{DataSource: &ParquetDataSource{NodeFiles: []string{"missing.parquet"}}, Projection: &projections.DataFrameProjection{}}
+	if errs := parquet.CheckFiles(dir); len(errs) != 0 {
+		t.Errorf("expected Parquet sources to be skipped, got %v", errs)
+	}
+}
@@ -0,0 +1,301 @@
+package aura
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAPIURL is the base URL of the Aura API.
+const DefaultAPIURL = "https://api.neo4j.io"
+
+// sessionsPath is the Aura API path of graph analytics sessions.
+const sessionsPath = "/v1/graph-analytics/sessions"
+
+// Credentials are Aura API client credentials.
+type Credentials struct {
+	// ClientID is the API client ID.
+	ClientID string
+	// ClientSecret is the API client secret.
+	ClientSecret string
+	// TenantID is the Aura project sessions are created in.
+	TenantID string
+}
+
+// CredentialsFromEnv reads credentials from AURA_CLIENT_ID,
+// AURA_CLIENT_SECRET and AURA_TENANT_ID.
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		ClientID:     os.Getenv("AURA_CLIENT_ID"),
+		ClientSecret: os.Getenv("AURA_CLIENT_SECRET"),
+		TenantID:     os.Getenv("AURA_TENANT_ID"),
+	}
+}
+
+// SessionInfo is a graph analytics session as reported by the Aura API.
+type SessionInfo struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Memory        string    `json:"memory"`
+	Status        string    `json:"status"`
+	Host          string    `json:"host,omitempty"`
+	TenantID      string    `json:"tenant_id"`
+	CloudProvider string    `json:"cloud_provider,omitempty"`
+	Region        string    `json:"region,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	ExpiryDate    time.Time `json:"expiry_date"`
+}
+
+// CreateSessionRequest is the body of a create session call.
+type CreateSessionRequest struct {
+	Name     string `json:"name"`
+	TenantID string `json:"tenant_id"`
+	Memory   string `json:"memory"`
+	// TTL is the time-to-live as a duration, e.g. "24h".
+	TTL           string `json:"ttl,omitempty"`
+	CloudProvider string `json:"cloud_provider,omitempty"`
+	Region        string `json:"region,omitempty"`
+}
+
+// SizingRequest is the body of a session sizing call.
+type SizingRequest struct {
+	NodeCount           int64    `json:"node_count"`
+	RelationshipCount   int64    `json:"relationship_count"`
+	AlgorithmCategories []string `json:"algorithm_categories,omitempty"`
+}
+
+// SizingResponse is the memory the Aura API estimates for a graph.
+type SizingResponse struct {
+	EstimatedMemory string `json:"estimated_memory"`
+	RecommendedSize string `json:"recommended_size"`
+}
+
+// APIError is an error response of the Aura API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("aura API: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound reports whether err is an Aura API 404 response.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client calls the graph analytics session endpoints of the Aura API. It
+// obtains an OAuth token with the client-credentials grant and reuses it
+// until shortly before it expires.
+type Client struct {
+	baseURL     string
+	credentials Credentials
+	httpClient  *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewClient creates a client for the Aura API at baseURL, or DefaultAPIURL
+// when baseURL is empty.
+func NewClient(baseURL string, credentials Credentials) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		credentials: credentials,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// CreateSession creates a session. An empty TenantID is set to the client's
+// tenant.
+func (c *Client) CreateSession(ctx context.Context, req CreateSessionRequest) (*SessionInfo, error) {
+	if req.TenantID == "" {
+		req.TenantID = c.credentials.TenantID
+	}
+	var info SessionInfo
+	if err := c.do(ctx, http.MethodPost, sessionsPath, req, &info); err != nil {
+		return nil, fmt.Errorf("failed to create session %s: %w", req.Name, err)
+	}
+	return &info, nil
+}
+
+// ListSessions lists the sessions of the client's tenant.
+func (c *Client) ListSessions(ctx context.Context) ([]SessionInfo, error) {
+	path := sessionsPath
+	if c.credentials.TenantID != "" {
+		path += "?tenantId=" + url.QueryEscape(c.credentials.TenantID)
+	}
+	var sessions []SessionInfo
+	if err := c.do(ctx, http.MethodGet, path, nil, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// GetSession returns the session with the given ID.
+func (c *Client) GetSession(ctx context.Context, id string) (*SessionInfo, error) {
+	var info SessionInfo
+	if err := c.do(ctx, http.MethodGet, sessionsPath+"/"+url.PathEscape(id), nil, &info); err != nil {
+		return nil, fmt.Errorf("failed to get session %s: %w", id, err)
+	}
+	return &info, nil
+}
+
+// DeleteSession deletes the session with the given ID.
+func (c *Client) DeleteSession(ctx context.Context, id string) error {
+	if err := c.do(ctx, http.MethodDelete, sessionsPath+"/"+url.PathEscape(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete session %s: %w", id, err)
+	}
+	return nil
+}
+
+// EstimateSize asks the Aura API for the memory a graph of the given size
+// needs.
+func (c *Client) EstimateSize(ctx context.Context, req SizingRequest) (*SizingResponse, error) {
+	var sizing SizingResponse
+	if err := c.do(ctx, http.MethodPost, sessionsPath+"/sizing", req, &sizing); err != nil {
+		return nil, fmt.Errorf("failed to estimate session size: %w", err)
+	}
+	return &sizing, nil
+}
+
+// do sends an authenticated request and decodes the data field of the
+// response into out.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+
+	envelope := struct {
+		Data any `json:"data"`
+	}{Data: out}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// accessToken returns a cached token, or requests a new one with the
+// client-credentials grant.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Before(c.expiry) {
+		return c.token, nil
+	}
+	if c.credentials.ClientID == "" || c.credentials.ClientSecret == "" {
+		return "", fmt.Errorf("aura client ID and secret are required")
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(c.credentials.ClientID, c.credentials.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return "", fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token: %w", err)
+	}
+	c.token = token.AccessToken
+	// Renew a minute early so a token does not expire in flight.
+	c.expiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return c.token, nil
+}
+
+// checkResponse returns an APIError for non-2xx responses, using the first
+// message of an Aura error body when there is one.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var body struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	message := strings.TrimSpace(string(data))
+	if json.Unmarshal(data, &body) == nil && len(body.Errors) > 0 {
+		message = body.Errors[0].Message
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: message}
+}
+
+// CreateRequest returns the create session request for s. memory overrides
+// s.Memory when set, e.g. with a size from EstimateSize.
+func (s *Session) CreateRequest(memory string) CreateSessionRequest {
+	if memory == "" {
+		memory = s.Memory
+	}
+	req := CreateSessionRequest{
+		Name:          s.Name,
+		Memory:        memory,
+		CloudProvider: s.CloudProvider,
+		Region:        s.Region,
+	}
+	if s.TTLHours > 0 {
+		req.TTL = fmt.Sprintf("%dh", s.TTLHours)
+	}
+	return req
+}
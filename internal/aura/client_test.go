@@ -0,0 +1,166 @@
+package aura
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func newFakeClient(t *testing.T) (*FakeServer, *Client) {
+	t.Helper()
+	server := NewFakeServer("client", "secret")
+	t.Cleanup(server.Close)
+	return server, NewClient(server.URL, Credentials{ClientID: "client", ClientSecret: "secret", TenantID: "tenant"})
+}
+
+func TestClient_SessionLifecycle(t *testing.T) {
+	server, client := newFakeClient(t)
+	ctx := context.Background()
+
+	session := &Session{Name: "analytics", TTLHours: 2, Memory: "16GB", CloudProvider: "gcp", Region: "europe-west1"}
+	created, err := client.CreateSession(ctx, session.CreateRequest(""))
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if created.Memory != "16GB" || created.TenantID != "tenant" || created.Status != "Ready" {
+		t.Errorf("unexpected session: %+v", created)
+	}
+	if ttl := created.ExpiryDate.Sub(created.CreatedAt); ttl.Hours() != 2 {
+		t.Errorf("expected 2h TTL, got %v", ttl)
+	}
+
+	got, err := client.GetSession(ctx, created.ID)
+	if err != nil || got.Name != "analytics" || got.Region != "europe-west1" {
+		t.Errorf("GetSession() = %+v, %v", got, err)
+	}
+	list, err := client.ListSessions(ctx)
+	if err != nil || len(list) != 1 || list[0].ID != created.ID {
+		t.Errorf("ListSessions() = %+v, %v", list, err)
+	}
+
+	if _, err := client.CreateSession(ctx, session.CreateRequest("")); err == nil || !strings.Contains(err.Error(), "409") {
+		t.Errorf("expected conflict for duplicate session, got %v", err)
+	}
+
+	if err := client.DeleteSession(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+	if _, err := client.GetSession(ctx, created.ID); !IsNotFound(err) {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+	if n := server.TokenRequests(); n != 1 {
+		t.Errorf("expected the token to be reused, got %d token requests", n)
+	}
+}
+
+func TestClient_EstimateSize(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	sizing, err := client.EstimateSize(context.Background(), SizingRequest{NodeCount: 10_000_000, RelationshipCount: 50_000_000})
+	if err != nil {
+		t.Fatalf("EstimateSize failed: %v", err)
+	}
+	if sizing.RecommendedSize != "16GB" {
+		t.Errorf("expected 16GB, got %+v", sizing)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	server := NewFakeServer("client", "secret")
+	defer server.Close()
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		creds   Credentials
+		call    func(*Client) error
+		wantErr string
+	}{
+		{"bad credentials", Credentials{ClientID: "client", ClientSecret: "wrong"}, func(c *Client) error {
+			_, err := c.ListSessions(ctx)
+			return err
+		}, "invalid client credentials"},
+		{"no credentials", Credentials{}, func(c *Client) error {
+			_, err := c.ListSessions(ctx)
+			return err
+		}, "client ID and secret are required"},
+		{"invalid memory", Credentials{ClientID: "client", ClientSecret: "secret", TenantID: "t"}, func(c *Client) error {
+			_, err := c.CreateSession(ctx, CreateSessionRequest{Name: "s", Memory: "3GB"})
+			return err
+		}, `invalid memory "3GB"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(NewClient(server.URL, tt.creds))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFromFields(t *testing.T) {
+	s, err := FromFields(map[string]any{
+		"Name":      "analytics",
+		"TTLHours":  int64(4),
+		"NodeCount": int64(1000),
+		"Memory":    "4GB",
+		"DataSource": map[string]any{
+			"NodeFiles": []any{"nodes.csv"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("FromFields failed: %v", err)
+	}
+	if s.Name != "analytics" || s.TTLHours != 4 || s.NodeCount != 1000 || s.Memory != "4GB" {
+		t.Errorf("unexpected session: %+v", s)
+	}
+
+	if _, err := FromFields(map[string]any{"TTLHours": "4"}); err == nil || !strings.Contains(err.Error(), "TTLHours") {
+		t.Errorf("expected TTLHours type error, got %v", err)
+	}
+}
+
+func TestFromLiteralFields(t *testing.T) {
+	s, err := FromLiteralFields("PandasDataSource", map[string]any{
+		"Name":      "analytics",
+		"TTLHours":  int64(4),
+		"NodeCount": int64(1000),
+		"Memory":    "4GB",
+		"DataSource": map[string]any{
+			"NodeFiles":      []any{"nodes.csv"},
+			"RelFilesByType": map[string]any{"OWNS": []any{"owns.csv"}},
+		},
+		"Projection": map[string]any{
+			"BaseProjection":         map[string]any{"Name": "bank"},
+			"NodeDataFrames":         []any{map[string]any{"Label": "Account", "IDColumn": "id", "Properties": []any{"balance"}}},
+			"RelationshipDataFrames": []any{map[string]any{"Type": "OWNS", "SourceColumn": "owner"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("FromLiteralFields failed: %v", err)
+	}
+	if s.Name != "analytics" || s.TTLHours != 4 || s.NodeCount != 1000 || s.Memory != "4GB" {
+		t.Errorf("unexpected session: %+v", s)
+	}
+	ds, ok := s.DataSource.(*PandasDataSource)
+	if !ok || len(ds.NodeFiles) != 1 || ds.RelFilesByType["OWNS"][0] != "owns.csv" {
+		t.Errorf("unexpected data source: %+v", s.DataSource)
+	}
+	if p := s.Projection; p == nil || p.Name != "bank" || p.NodeDataFrames[0].Properties[0] != "balance" || p.RelationshipDataFrames[0].SourceColumn != "owner" {
+		t.Errorf("unexpected projection: %+v", s.Projection)
+	}
+
+	other, err := FromLiteralFields("SnowflakeDataSource", map[string]any{"DataSource": map[string]any{"Account": "acme"}})
+	if err != nil || other.DataSource != nil {
+		t.Errorf("expected SQL data source to be left unset, got %+v, %v", other, err)
+	}
+
+	if _, err := FromLiteralFields("", map[string]any{"TTLHours": "4"}); err == nil || !strings.Contains(err.Error(), "TTLHours") {
+		t.Errorf("expected TTLHours type error, got %v", err)
+	}
+	if _, err := FromLiteralFields("PandasDataSource", map[string]any{"DataSource": map[string]any{"NodeFiles": "nodes.csv"}}); err == nil || !strings.Contains(err.Error(), "NodeFiles") {
+		t.Errorf("expected NodeFiles type error, got %v", err)
+	}
+}
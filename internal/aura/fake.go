@@ -0,0 +1,236 @@
+package aura
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeServer is an in-memory Aura API for offline tests. It issues tokens
+// to one client and keeps sessions in memory; sessions are Ready as soon as
+// they are created.
+type FakeServer struct {
+	*httptest.Server
+
+	clientID     string
+	clientSecret string
+
+	mu       sync.Mutex
+	tokens   map[string]bool
+	sessions map[string]SessionInfo
+	nextID   int
+	issued   int
+}
+
+// NewFakeServer starts a fake Aura API accepting the given client
+// credentials. Close it when done.
+func NewFakeServer(clientID, clientSecret string) *FakeServer {
+	f := &FakeServer{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		tokens:       make(map[string]bool),
+		sessions:     make(map[string]SessionInfo),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth/token", f.handleToken)
+	mux.HandleFunc("GET "+sessionsPath, f.authorized(f.handleList))
+	mux.HandleFunc("POST "+sessionsPath, f.authorized(f.handleCreate))
+	mux.HandleFunc("POST "+sessionsPath+"/sizing", f.authorized(f.handleSizing))
+	mux.HandleFunc("GET "+sessionsPath+"/{id}", f.authorized(f.handleGet))
+	mux.HandleFunc("DELETE "+sessionsPath+"/{id}", f.authorized(f.handleDelete))
+	f.Server = httptest.NewServer(mux)
+	return f
+}
+
+// Sessions returns the sessions on the server, ordered by name.
+func (f *FakeServer) Sessions() []SessionInfo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sessions := make([]SessionInfo, 0, len(f.sessions))
+	for _, s := range f.sessions {
+		sessions = append(sessions, s)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Name < sessions[j].Name })
+	return sessions
+}
+
+// TokenRequests returns the number of OAuth tokens issued.
+func (f *FakeServer) TokenRequests() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.issued
+}
+
+func (f *FakeServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != f.clientID || secret != f.clientSecret {
+		writeFakeError(w, http.StatusUnauthorized, "invalid client credentials")
+		return
+	}
+	if r.FormValue("grant_type") != "client_credentials" {
+		writeFakeError(w, http.StatusBadRequest, "unsupported grant type")
+		return
+	}
+
+	f.mu.Lock()
+	f.issued++
+	token := fmt.Sprintf("token-%d", f.issued)
+	f.tokens[token] = true
+	f.mu.Unlock()
+
+	writeFakeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"expires_in":   3600,
+		"token_type":   "bearer",
+	})
+}
+
+func (f *FakeServer) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var token string
+		if _, err := fmt.Sscanf(r.Header.Get("Authorization"), "Bearer %s", &token); err != nil {
+			writeFakeError(w, http.StatusUnauthorized, "missing bearer token")
+			return
+		}
+		f.mu.Lock()
+		ok := f.tokens[token]
+		f.mu.Unlock()
+		if !ok {
+			writeFakeError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (f *FakeServer) handleList(w http.ResponseWriter, r *http.Request) {
+	tenant := r.URL.Query().Get("tenantId")
+	var sessions []SessionInfo
+	for _, s := range f.Sessions() {
+		if tenant == "" || s.TenantID == tenant {
+			sessions = append(sessions, s)
+		}
+	}
+	writeFakeData(w, http.StatusOK, sessions)
+}
+
+func (f *FakeServer) handleCreate(w http.ResponseWriter, r *http.Request) {
+	var req CreateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Name == "" || req.TenantID == "" {
+		writeFakeError(w, http.StatusBadRequest, "name and tenant_id are required")
+		return
+	}
+	if !validMemory(req.Memory) {
+		writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("invalid memory %q", req.Memory))
+		return
+	}
+	ttl := time.Hour
+	if req.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.TTL); err != nil {
+			writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("invalid ttl %q", req.TTL))
+			return
+		}
+	}
+
+	f.mu.Lock()
+	for _, s := range f.sessions {
+		if s.Name == req.Name && s.TenantID == req.TenantID {
+			f.mu.Unlock()
+			writeFakeError(w, http.StatusConflict, fmt.Sprintf("session %s already exists", req.Name))
+			return
+		}
+	}
+	f.nextID++
+	now := time.Now().UTC().Truncate(time.Second)
+	info := SessionInfo{
+		ID:            fmt.Sprintf("sess-%04d", f.nextID),
+		Name:          req.Name,
+		Memory:        req.Memory,
+		Status:        "Ready",
+		TenantID:      req.TenantID,
+		CloudProvider: req.CloudProvider,
+		Region:        req.Region,
+		CreatedAt:     now,
+		ExpiryDate:    now.Add(ttl),
+	}
+	info.Host = info.ID + ".sessions.example"
+	f.sessions[info.ID] = info
+	f.mu.Unlock()
+
+	writeFakeData(w, http.StatusAccepted, info)
+}
+
+func (f *FakeServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	info, ok := f.sessions[r.PathValue("id")]
+	f.mu.Unlock()
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+	writeFakeData(w, http.StatusOK, info)
+}
+
+func (f *FakeServer) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	f.mu.Lock()
+	_, ok := f.sessions[id]
+	delete(f.sessions, id)
+	f.mu.Unlock()
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "session not found")
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleSizing recommends the smallest memory size holding the graph,
+// estimated at 200 bytes per node and 100 bytes per relationship, doubled
+// for algorithm working memory.
+func (f *FakeServer) handleSizing(w http.ResponseWriter, r *http.Request) {
+	var req SizingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	bytes := 2 * (req.NodeCount*200 + req.RelationshipCount*100)
+	size := MemorySizes[len(MemorySizes)-1]
+	for _, m := range MemorySizes {
+		var gb int64
+		fmt.Sscanf(m, "%dGB", &gb)
+		if gb<<30 >= bytes {
+			size = m
+			break
+		}
+	}
+	writeFakeData(w, http.StatusOK, SizingResponse{
+		EstimatedMemory: fmt.Sprintf("%dMB", bytes>>20),
+		RecommendedSize: size,
+	})
+}
+
+func writeFakeData(w http.ResponseWriter, status int, data any) {
+	writeFakeJSON(w, status, map[string]any{"data": data})
+}
+
+func writeFakeError(w http.ResponseWriter, status int, message string) {
+	writeFakeJSON(w, status, map[string]any{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,168 @@
+package aura
+
+import (
+	"fmt"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+// FromLiteralFields builds a session like FromFields, and also rebuilds
+// its data source and DataFrame projection. dataSourceType is the Go type
+// name of the data source literal, e.g. PandasDataSource. File data sources
+// are rebuilt from their constant fields; other data sources are left unset.
+func FromLiteralFields(dataSourceType string, fields map[string]any) (*Session, error) {
+	s, err := FromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := fields["DataSource"].(map[string]any); ok {
+		ds, err := dataSourceFromFields(dataSourceType, v)
+		if err != nil {
+			return nil, fmt.Errorf("field DataSource: %w", err)
+		}
+		s.DataSource = ds
+	}
+	if v, ok := fields["Projection"].(map[string]any); ok {
+		p, err := projectionFromFields(v)
+		if err != nil {
+			return nil, fmt.Errorf("field Projection: %w", err)
+		}
+		s.Projection = p
+	}
+	return s, nil
+}
+
+// dataSourceFromFields rebuilds a file data source. It returns nil for
+// other data source types.
+func dataSourceFromFields(typeName string, fields map[string]any) (DataSource, error) {
+	if typeName != "PandasDataSource" && typeName != "ParquetDataSource" {
+		return nil, nil
+	}
+	var nodes, rels []string
+	var byLabel, byType map[string][]string
+	var outputDir string
+	for _, f := range []struct {
+		key    string
+		target *[]string
+	}{
+		{"NodeFiles", &nodes},
+		{"RelFiles", &rels},
+	} {
+		if err := stringsField(fields, f.key, f.target); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range []struct {
+		key    string
+		target *map[string][]string
+	}{
+		{"NodeFilesByLabel", &byLabel},
+		{"RelFilesByType", &byType},
+	} {
+		v, ok := fields[f.key].(map[string]any)
+		if !ok {
+			continue
+		}
+		*f.target = make(map[string][]string, len(v))
+		for k := range v {
+			var files []string
+			if err := stringsField(v, k, &files); err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.key, err)
+			}
+			(*f.target)[k] = files
+		}
+	}
+	if err := stringField(fields, "OutputDir", &outputDir); err != nil {
+		return nil, err
+	}
+
+	if typeName == "ParquetDataSource" {
+		return &ParquetDataSource{NodeFiles: nodes, RelFiles: rels, NodeFilesByLabel: byLabel, RelFilesByType: byType, OutputDir: outputDir}, nil
+	}
+	return &PandasDataSource{NodeFiles: nodes, RelFiles: rels, NodeFilesByLabel: byLabel, RelFilesByType: byType, OutputDir: outputDir}, nil
+}
+
+// projectionFromFields rebuilds a DataFrame projection.
+func projectionFromFields(fields map[string]any) (*projections.DataFrameProjection, error) {
+	p := &projections.DataFrameProjection{}
+	if base, ok := fields["BaseProjection"].(map[string]any); ok {
+		if err := stringField(base, "Name", &p.Name); err != nil {
+			return nil, err
+		}
+		if err := stringField(base, "GraphName", &p.GraphName); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes, _ := fields["NodeDataFrames"].([]any)
+	for i, v := range nodes {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("NodeDataFrames[%d]: expected literal, got %T", i, v)
+		}
+		var df projections.NodeDataFrame
+		for _, err := range []error{
+			stringField(m, "Label", &df.Label),
+			stringField(m, "IDColumn", &df.IDColumn),
+			stringsField(m, "Properties", &df.Properties),
+		} {
+			if err != nil {
+				return nil, fmt.Errorf("NodeDataFrames[%d]: %w", i, err)
+			}
+		}
+		p.NodeDataFrames = append(p.NodeDataFrames, df)
+	}
+
+	rels, _ := fields["RelationshipDataFrames"].([]any)
+	for i, v := range rels {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("RelationshipDataFrames[%d]: expected literal, got %T", i, v)
+		}
+		var df projections.RelationshipDataFrame
+		for _, err := range []error{
+			stringField(m, "Type", &df.Type),
+			stringField(m, "SourceColumn", &df.SourceColumn),
+			stringField(m, "TargetColumn", &df.TargetColumn),
+			stringsField(m, "Properties", &df.Properties),
+		} {
+			if err != nil {
+				return nil, fmt.Errorf("RelationshipDataFrames[%d]: %w", i, err)
+			}
+		}
+		p.RelationshipDataFrames = append(p.RelationshipDataFrames, df)
+	}
+	return p, nil
+}
+
+func stringField(fields map[string]any, key string, target *string) error {
+	v, ok := fields[key]
+	if !ok {
+		return nil
+	}
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("field %s: expected string, got %T", key, v)
+	}
+	*target = str
+	return nil
+}
+
+func stringsField(fields map[string]any, key string, target *[]string) error {
+	v, ok := fields[key]
+	if !ok {
+		return nil
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return fmt.Errorf("field %s: expected string list, got %T", key, v)
+	}
+	for _, item := range list {
+		str, ok := item.(string)
+		if !ok {
+			return fmt.Errorf("field %s: expected string list, got %T element", key, item)
+		}
+		*target = append(*target, str)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package aura
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SampleRows is the number of rows of each DataFrame whose property values
+// CheckFiles type-checks.
+const SampleRows = 1000
+
+// csvFrame is the rows of one node label or relationship type: its files,
+// filtered on column when the files hold several labels or types.
+type csvFrame struct {
+	desc       string
+	files      []string
+	column     string
+	value      string
+	keys       []string
+	properties []string
+}
+
+// CheckFiles checks the CSV files of a PandasDataSource against the
+// session's DataFrame projection without running it. It reads each file's
+// header to find the ID, endpoint, property, labels and relationshipType
+// columns the projection uses, samples SampleRows rows of each DataFrame to
+// confirm property values are numeric, and reports relationships whose
+// endpoints are not among the node IDs. Relative paths are resolved against
+// dir. Sessions with other data sources or without a projection are not
+// checked.
+func (s *Session) CheckFiles(dir string) []error {
+	ds, ok := s.DataSource.(*PandasDataSource)
+	if !ok || s.Projection == nil {
+		return nil
+	}
+	p := s.Projection
+
+	var nodeFrames, relFrames []csvFrame
+	for _, df := range p.NodeDataFrames {
+		f := csvFrame{
+			desc:       "node label " + df.Label,
+			files:      ds.NodeFilesByLabel[df.Label],
+			keys:       []string{columnOr(df.IDColumn, "nodeId")},
+			properties: df.Properties,
+		}
+		if len(f.files) == 0 {
+			f.files = ds.NodeFiles
+			if len(p.NodeDataFrames) > 1 {
+				f.column, f.value = "labels", df.Label
+			}
+		}
+		nodeFrames = append(nodeFrames, f)
+	}
+	if len(p.NodeDataFrames) == 0 && len(ds.NodeFiles) > 0 {
+		nodeFrames = append(nodeFrames, csvFrame{desc: "nodes", files: ds.NodeFiles, keys: []string{"nodeId"}})
+	}
+	for _, df := range p.RelationshipDataFrames {
+		f := csvFrame{
+			desc:       "relationship type " + df.Type,
+			files:      ds.RelFilesByType[df.Type],
+			keys:       []string{columnOr(df.SourceColumn, "sourceNodeId"), columnOr(df.TargetColumn, "targetNodeId")},
+			properties: df.Properties,
+		}
+		if len(f.files) == 0 {
+			f.files = ds.RelFiles
+			if len(p.RelationshipDataFrames) > 1 {
+				f.column, f.value = "relationshipType", df.Type
+			}
+		}
+		relFrames = append(relFrames, f)
+	}
+	if len(p.RelationshipDataFrames) == 0 && len(ds.RelFiles) > 0 {
+		relFrames = append(relFrames, csvFrame{desc: "relationships", files: ds.RelFiles, keys: []string{"sourceNodeId", "targetNodeId"}})
+	}
+
+	var problems []error
+	ids := make(map[string]bool)
+	complete := true
+	for _, f := range nodeFrames {
+		errs, ok := checkFrame(dir, f, func(file string, line int, keys []string) {
+			ids[keys[0]] = true
+		})
+		complete = complete && ok
+		problems = append(problems, errs...)
+	}
+
+	for _, f := range relFrames {
+		var dangling int
+		var first string
+		errs, _ := checkFrame(dir, f, func(file string, line int, keys []string) {
+			for i, id := range keys {
+				if ids[id] {
+					continue
+				}
+				if dangling == 0 {
+					first = fmt.Sprintf("%s:%d %s %q", file, line, f.keys[i], id)
+				}
+				dangling++
+			}
+		})
+		problems = append(problems, errs...)
+		// Endpoints can only be checked against a complete set of node IDs.
+		if complete && len(nodeFrames) > 0 && dangling > 0 {
+			problems = append(problems, fmt.Errorf("%s: %d endpoints reference missing nodes, first at %s", f.desc, dangling, first))
+		}
+	}
+	return problems
+}
+
+// checkFrame checks the header and sampled property values of a frame's
+// files and calls row with the key column values of each of its rows. ok is
+// false when a file could not be read to the end.
+func checkFrame(dir string, f csvFrame, row func(file string, line int, keys []string)) (problems []error, ok bool) {
+	if len(f.files) == 0 {
+		return []error{fmt.Errorf("%s: no files", f.desc)}, false
+	}
+
+	ok = true
+	sampled := 0
+	for _, file := range f.files {
+		errs, read := scanCSV(dir, file, f, &sampled, row)
+		problems = append(problems, errs...)
+		ok = ok && read
+	}
+	return problems, ok
+}
+
+func scanCSV(dir, file string, f csvFrame, sampled *int, row func(file string, line int, keys []string)) ([]error, bool) {
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	fh, err := os.Open(path)
+	if err != nil {
+		return []error{fmt.Errorf("%s: %w", f.desc, err)}, false
+	}
+	defer fh.Close()
+
+	r := csv.NewReader(fh)
+	header, err := r.Read()
+	if err != nil {
+		return []error{fmt.Errorf("%s: %s: failed to read header: %w", f.desc, file, err)}, false
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+
+	var missing []string
+	required := append(append([]string{}, f.keys...), f.properties...)
+	if f.column != "" {
+		required = append(required, f.column)
+	}
+	for _, c := range required {
+		if _, ok := index[c]; !ok {
+			missing = append(missing, c)
+		}
+	}
+	if len(missing) > 0 {
+		return []error{fmt.Errorf("%s: %s: missing columns %s", f.desc, file, strings.Join(missing, ", "))}, false
+	}
+
+	var problems []error
+	reported := make(map[string]bool)
+	keys := make([]string, len(f.keys))
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return append(problems, fmt.Errorf("%s: %s: %w", f.desc, file, err)), false
+		}
+		if f.column != "" && strings.TrimSpace(record[index[f.column]]) != f.value {
+			continue
+		}
+		line, _ := r.FieldPos(0)
+
+		if *sampled < SampleRows {
+			*sampled++
+			for _, prop := range f.properties {
+				v := strings.TrimSpace(record[index[prop]])
+				if v == "" || reported[prop] {
+					continue
+				}
+				if _, err := strconv.ParseFloat(v, 64); err != nil {
+					reported[prop] = true
+					problems = append(problems, fmt.Errorf("%s: %s:%d: property %s is not numeric: %q", f.desc, file, line, prop, v))
+				}
+			}
+		}
+
+		for i, k := range f.keys {
+			keys[i] = strings.TrimSpace(record[index[k]])
+		}
+		row(file, line, keys)
+	}
+	return problems, true
+}
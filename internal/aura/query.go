@@ -0,0 +1,246 @@
+package aura
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+// sourceQueries returns the node and relationship queries of a SQL data
+// source. ok is false for file data sources.
+func sourceQueries(ds DataSource) (nodeQuery, relQuery string, ok bool) {
+	switch d := ds.(type) {
+	case *SnowflakeDataSource:
+		return d.NodeQuery, d.RelQuery, true
+	case *BigQueryDataSource:
+		return d.NodeQuery, d.RelQuery, true
+	case *PostgresDataSource:
+		return d.NodeQuery, d.RelQuery, true
+	case *DatabricksDataSource:
+		return d.NodeQuery, d.RelQuery, true
+	}
+	return "", "", false
+}
+
+// foldIdent returns how a SQL data source names the output column of an
+// unquoted identifier: Snowflake upper-cases it and PostgreSQL lower-cases
+// it. Quoted identifiers keep their case.
+func foldIdent(ds DataSource) func(string) string {
+	switch ds.(type) {
+	case *SnowflakeDataSource:
+		return strings.ToUpper
+	case *PostgresDataSource:
+		return strings.ToLower
+	}
+	return func(s string) string { return s }
+}
+
+// validateQueryColumns checks that the queries of a SQL data source return
+// the columns the projection's DataFrames read. Queries whose columns cannot
+// be determined statically, such as SELECT *, are not checked. Column names
+// are compared case-sensitively, as the DataFrames read them, after applying
+// the source's case folding of unquoted identifiers.
+func validateQueryColumns(p *projections.DataFrameProjection, ds DataSource) error {
+	nodeQuery, relQuery, ok := sourceQueries(ds)
+	if !ok {
+		return nil
+	}
+
+	var nodeColumns []string
+	for _, df := range p.NodeDataFrames {
+		nodeColumns = append(nodeColumns, columnOr(df.IDColumn, "nodeId"))
+		nodeColumns = append(nodeColumns, df.Properties...)
+	}
+	if len(p.NodeDataFrames) > 1 {
+		nodeColumns = append(nodeColumns, "labels")
+	}
+	fold := foldIdent(ds)
+	if err := checkQueryColumns("node", nodeQuery, nodeColumns, fold); err != nil {
+		return err
+	}
+
+	var relColumns []string
+	for _, df := range p.RelationshipDataFrames {
+		relColumns = append(relColumns, columnOr(df.SourceColumn, "sourceNodeId"), columnOr(df.TargetColumn, "targetNodeId"))
+		relColumns = append(relColumns, df.Properties...)
+	}
+	if len(p.RelationshipDataFrames) > 1 {
+		relColumns = append(relColumns, "relationshipType")
+	}
+	return checkQueryColumns("relationship", relQuery, relColumns, fold)
+}
+
+func checkQueryColumns(kind, query string, required []string, fold func(string) string) error {
+	if len(required) == 0 {
+		return nil
+	}
+	if query == "" {
+		return fmt.Errorf("%s query is required by the projection", kind)
+	}
+	columns, ok := selectColumns(query, fold)
+	if !ok {
+		return nil
+	}
+
+	returned := make(map[string]bool)
+	folded := make(map[string]string)
+	for _, c := range columns {
+		returned[c] = true
+		folded[strings.ToLower(c)] = c
+	}
+	var missing, hints []string
+	seen := make(map[string]bool)
+	for _, c := range required {
+		if !returned[c] && !seen[c] {
+			missing = append(missing, c)
+			if got, ok := folded[strings.ToLower(c)]; ok {
+				hints = append(hints, fmt.Sprintf("%s is returned as %s", c, got))
+			}
+		}
+		seen[c] = true
+	}
+	if len(missing) > 0 {
+		err := fmt.Sprintf("%s query does not return columns %s needed by the projection", kind, strings.Join(missing, ", "))
+		if len(hints) > 0 {
+			err += fmt.Sprintf(" (%s; quote the alias to keep its case)", strings.Join(hints, ", "))
+		}
+		return fmt.Errorf("%s", err)
+	}
+	return nil
+}
+
+func columnOr(column, def string) string {
+	if column == "" {
+		return def
+	}
+	return column
+}
+
+// selectColumns returns the output column names of a SELECT query. ok is
+// false when they cannot be determined statically: the query is not a plain
+// SELECT or selects *. Quoted column names are unquoted and unquoted ones
+// are passed through fold; an unaliased expression that is not a column
+// reference yields an empty name.
+func selectColumns(query string, fold func(string) string) (columns []string, ok bool) {
+	q := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	tokens := splitTopLevel(q, unicode.IsSpace)
+	if len(tokens) < 2 || !strings.EqualFold(tokens[0], "SELECT") {
+		return nil, false
+	}
+	tokens = tokens[1:]
+	if strings.EqualFold(tokens[0], "DISTINCT") || strings.EqualFold(tokens[0], "ALL") {
+		tokens = tokens[1:]
+	}
+	for i, t := range tokens {
+		if strings.EqualFold(t, "FROM") {
+			tokens = tokens[:i]
+			break
+		}
+	}
+
+	list := strings.Join(tokens, " ")
+	for _, item := range splitTopLevel(list, func(r rune) bool { return r == ',' }) {
+		item = strings.TrimSpace(item)
+		if item == "*" || strings.HasSuffix(item, ".*") {
+			return nil, false
+		}
+		columns = append(columns, columnName(item, fold))
+	}
+	return columns, true
+}
+
+// columnName returns the output name of a select list item.
+func columnName(item string, fold func(string) string) string {
+	parts := splitTopLevel(item, unicode.IsSpace)
+	last := parts[len(parts)-1]
+	switch {
+	case len(parts) >= 3 && strings.EqualFold(parts[len(parts)-2], "AS"):
+		return outputIdent(last, fold)
+	case len(parts) == 2 && isIdent(last):
+		return outputIdent(last, fold)
+	case len(parts) == 1 && isIdent(last):
+		if i := strings.LastIndex(last, "."); i >= 0 {
+			last = last[i+1:]
+		}
+		return outputIdent(last, fold)
+	}
+	return ""
+}
+
+// outputIdent returns the column name of identifier s: unquoted when
+// quoted, folded otherwise.
+func outputIdent(s string, fold func(string) string) string {
+	if unquoted := unquoteIdent(s); unquoted != s {
+		return unquoted
+	}
+	return fold(s)
+}
+
+// splitTopLevel splits s at runes matching sep that are outside
+// parentheses and quotes, dropping empty fields.
+func splitTopLevel(s string, sep func(rune) bool) []string {
+	var fields []string
+	var b strings.Builder
+	depth := 0
+	var quote rune
+	flush := func() {
+		if field := strings.TrimSpace(b.String()); field != "" {
+			fields = append(fields, field)
+		}
+		b.Reset()
+	}
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth == 0 && sep(r):
+			flush()
+			continue
+		}
+		b.WriteRune(r)
+	}
+	flush()
+	return fields
+}
+
+// isIdent reports whether s is a possibly qualified, possibly quoted
+// identifier.
+func isIdent(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if unquoted := unquoteIdent(part); unquoted != part {
+			if unquoted == "" {
+				return false
+			}
+			continue
+		}
+		if part == "" {
+			return false
+		}
+		for i, r := range part {
+			if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func unquoteIdent(s string) string {
+	if len(s) >= 2 {
+		switch {
+		case s[0] == '"' && s[len(s)-1] == '"', s[0] == '`' && s[len(s)-1] == '`', s[0] == '[' && s[len(s)-1] == ']':
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
@@ -2,9 +2,14 @@ package aura
 
 import (
 	"fmt"
+	"path"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
 )
 
 // Serializer serializes Aura sessions to Python code and JSON.
@@ -15,8 +20,16 @@ func NewSerializer() *Serializer {
 	return &Serializer{}
 }
 
-// ToPython generates Python code for the graphdatascience client.
+// ToPython generates Python code for the graphdatascience client. Every
+// file of a label or type is loaded and concatenated, columns are renamed to
+// the nodeId, sourceNodeId and targetNodeId names graph.construct expects,
+// and each algorithm runs in its mode with its full configuration. Results of
+// write-mode algorithms are streamed and written back to the data source.
 func (s *Serializer) ToPython(session *Session) (string, error) {
+	if len(session.Algorithms) > 0 && session.Projection == nil {
+		return "", fmt.Errorf("session %s: algorithms require a projection", session.Name)
+	}
+
 	var sb strings.Builder
 
 	// Imports
@@ -25,13 +38,22 @@ func (s *Serializer) ToPython(session *Session) (string, error) {
 	sb.WriteString("\n")
 
 	// Data source specific imports and setup
+	writes := hasWriteMode(session.Algorithms)
 	switch ds := session.DataSource.(type) {
 	case *PandasDataSource:
-		s.writePandasSetup(&sb, ds)
+		s.writeFileSetup(&sb, "CSV", filesOf(ds))
+	case *ParquetDataSource:
+		s.writeFileSetup(&sb, "Parquet", filesOf(ds))
+	case *S3DataSource:
+		s.writeS3Setup(&sb, ds)
 	case *SnowflakeDataSource:
-		s.writeSnowflakeSetup(&sb, ds)
+		s.writeSnowflakeSetup(&sb, ds, writes)
 	case *BigQueryDataSource:
 		s.writeBigQuerySetup(&sb, ds)
+	case *PostgresDataSource:
+		s.writePostgresSetup(&sb, ds)
+	case *DatabricksDataSource:
+		s.writeDatabricksSetup(&sb, ds)
 	}
 
 	sb.WriteString("\n")
@@ -46,37 +68,182 @@ func (s *Serializer) ToPython(session *Session) (string, error) {
 
 	// Graph projection
 	if session.Projection != nil {
-		sb.WriteString("# Project graph from DataFrames\n")
-		sb.WriteString("G = gds.graph.construct(\n")
-		fmt.Fprintf(&sb, "    graph_name=%q,\n", session.Projection.ProjectionName())
-		sb.WriteString("    nodes=nodes_df,\n")
-		sb.WriteString("    relationships=rels_df,\n")
-		sb.WriteString(")\n\n")
+		if err := s.writeProjection(&sb, session.Projection, session.DataSource); err != nil {
+			return "", fmt.Errorf("session %s: %w", session.Name, err)
+		}
 	}
 
 	// Algorithms
 	if len(session.Algorithms) > 0 {
 		sb.WriteString("# Run algorithms\n")
 		for _, algo := range session.Algorithms {
-			s.writeAlgorithmCall(&sb, algo)
+			s.writeAlgorithmCall(&sb, algo, session.DataSource)
 		}
 	}
 
 	return sb.String(), nil
 }
 
-func (s *Serializer) writePandasSetup(sb *strings.Builder, ds *PandasDataSource) {
-	sb.WriteString("# Load data from CSV files\n")
-	if len(ds.NodeFiles) > 0 {
-		fmt.Fprintf(sb, "nodes_df = pd.read_csv(%q)\n", ds.NodeFiles[0])
+// fileSet is a data source read from files with pandas.
+type fileSet struct {
+	// reader is the pandas reader function, e.g. read_csv.
+	reader string
+	// writer is the DataFrame method writing results, e.g. to_csv.
+	writer string
+	// options are extra keyword arguments of reader and writer.
+	options         string
+	nodes, rels     []string
+	byLabel, byType map[string][]string
+	// output returns the path results named table are written to.
+	output func(table string) string
+}
+
+// filesOf returns the file set of a file data source, or nil.
+func filesOf(ds DataSource) *fileSet {
+	switch d := ds.(type) {
+	case *PandasDataSource:
+		return &fileSet{
+			reader: "read_csv", writer: "to_csv",
+			nodes: d.NodeFiles, rels: d.RelFiles,
+			byLabel: d.NodeFilesByLabel, byType: d.RelFilesByType,
+			output: func(table string) string { return path.Join(d.OutputDir, table+".csv") },
+		}
+	case *ParquetDataSource:
+		return &fileSet{
+			reader: "read_parquet", writer: "to_parquet",
+			nodes: d.NodeFiles, rels: d.RelFiles,
+			byLabel: d.NodeFilesByLabel, byType: d.RelFilesByType,
+			output: func(table string) string { return path.Join(d.OutputDir, table+".parquet") },
+		}
+	case *S3DataSource:
+		format := d.Format
+		if format == "" {
+			format = "parquet"
+		}
+		url := func(key string) string { return "s3://" + d.Bucket + "/" + strings.TrimPrefix(key, "/") }
+		urls := func(keys []string) []string {
+			result := make([]string, len(keys))
+			for i, k := range keys {
+				result[i] = url(k)
+			}
+			return result
+		}
+		byKey := func(m map[string][]string) map[string][]string {
+			result := make(map[string][]string, len(m))
+			for k, keys := range m {
+				result[k] = urls(keys)
+			}
+			return result
+		}
+		return &fileSet{
+			reader: "read_" + format, writer: "to_" + format,
+			options: ", storage_options=storage_options",
+			nodes:   urls(d.NodeKeys), rels: urls(d.RelKeys),
+			byLabel: byKey(d.NodeKeysByLabel), byType: byKey(d.RelKeysByType),
+			output: func(table string) string { return url(path.Join(d.OutputPrefix, table+"."+format)) },
+		}
+	}
+	return nil
+}
+
+// read returns the expression reading files into one DataFrame.
+func (f *fileSet) read(files []string) string {
+	reads := make([]string, len(files))
+	for i, file := range files {
+		reads[i] = fmt.Sprintf("pd.%s(%q%s)", f.reader, file, f.options)
+	}
+	if len(reads) == 1 {
+		return reads[0]
+	}
+	return "pd.concat([" + strings.Join(reads, ", ") + "], ignore_index=True)"
+}
+
+func (s *Serializer) writeFileSetup(sb *strings.Builder, format string, f *fileSet) {
+	fmt.Fprintf(sb, "# Load data from %s files\n", format)
+	if len(f.nodes) > 0 {
+		fmt.Fprintf(sb, "nodes_df = %s\n", f.read(f.nodes))
+	}
+	if len(f.rels) > 0 {
+		fmt.Fprintf(sb, "rels_df = %s\n", f.read(f.rels))
+	}
+}
+
+func (s *Serializer) writeS3Setup(sb *strings.Builder, ds *S3DataSource) {
+	sb.WriteString("import os\n\n")
+	sb.WriteString("# S3 credentials\n")
+	sb.WriteString("storage_options = {\n")
+	fmt.Fprintf(sb, "    \"key\": %s,\n", graphrag.EnvLookup(graphrag.SecretEnv(ds.AccessKeyID, "AWS_ACCESS_KEY_ID")))
+	fmt.Fprintf(sb, "    \"secret\": %s,\n", graphrag.EnvLookup(graphrag.SecretEnv(ds.SecretAccessKey, "AWS_SECRET_ACCESS_KEY")))
+	if ds.Region != "" {
+		fmt.Fprintf(sb, "    \"client_kwargs\": {\"region_name\": %q},\n", ds.Region)
+	}
+	sb.WriteString("}\n\n")
+	format := "Parquet"
+	if ds.Format == "csv" {
+		format = "CSV"
+	}
+	s.writeFileSetup(sb, format, filesOf(ds))
+}
+
+func (s *Serializer) writePostgresSetup(sb *strings.Builder, ds *PostgresDataSource) {
+	port := ds.Port
+	if port == 0 {
+		port = 5432
+	}
+	sb.WriteString("import os\n")
+	sb.WriteString("from sqlalchemy import create_engine\n")
+	sb.WriteString("from sqlalchemy.engine import URL\n\n")
+	sb.WriteString("# Connect to PostgreSQL\n")
+	sb.WriteString("engine = create_engine(URL.create(\n")
+	sb.WriteString("    \"postgresql+psycopg2\",\n")
+	fmt.Fprintf(sb, "    username=%q,\n", ds.User)
+	fmt.Fprintf(sb, "    password=%s,\n", graphrag.EnvLookup(graphrag.SecretEnv(ds.Password, "PGPASSWORD")))
+	fmt.Fprintf(sb, "    host=%q,\n", ds.Host)
+	fmt.Fprintf(sb, "    port=%d,\n", port)
+	fmt.Fprintf(sb, "    database=%q,\n", ds.Database)
+	sb.WriteString("))\n\n")
+	writeSQLLoad(sb, ds.NodeQuery, ds.RelQuery)
+}
+
+func (s *Serializer) writeDatabricksSetup(sb *strings.Builder, ds *DatabricksDataSource) {
+	sb.WriteString("import os\n")
+	sb.WriteString("from sqlalchemy import create_engine\n")
+	sb.WriteString("from sqlalchemy.engine import URL\n\n")
+	sb.WriteString("# Connect to Databricks\n")
+	sb.WriteString("engine = create_engine(URL.create(\n")
+	sb.WriteString("    \"databricks\",\n")
+	sb.WriteString("    username=\"token\",\n")
+	fmt.Fprintf(sb, "    password=%s,\n", graphrag.EnvLookup(graphrag.SecretEnv(ds.AccessToken, "DATABRICKS_TOKEN")))
+	fmt.Fprintf(sb, "    host=%q,\n", ds.ServerHostname)
+	query := []string{fmt.Sprintf("\"http_path\": %q", ds.HTTPPath)}
+	if ds.Catalog != "" {
+		query = append(query, fmt.Sprintf("\"catalog\": %q", ds.Catalog))
 	}
-	if len(ds.RelFiles) > 0 {
-		fmt.Fprintf(sb, "rels_df = pd.read_csv(%q)\n", ds.RelFiles[0])
+	if ds.Schema != "" {
+		query = append(query, fmt.Sprintf("\"schema\": %q", ds.Schema))
 	}
+	fmt.Fprintf(sb, "    query={%s},\n", strings.Join(query, ", "))
+	sb.WriteString("))\n\n")
+	writeSQLLoad(sb, ds.NodeQuery, ds.RelQuery)
 }
 
-func (s *Serializer) writeSnowflakeSetup(sb *strings.Builder, ds *SnowflakeDataSource) {
-	sb.WriteString("import snowflake.connector\n\n")
+// writeSQLLoad reads the node and relationship queries through engine.
+func writeSQLLoad(sb *strings.Builder, nodeQuery, relQuery string) {
+	sb.WriteString("# Load data\n")
+	if nodeQuery != "" {
+		fmt.Fprintf(sb, "nodes_df = pd.read_sql(%q, engine)\n", nodeQuery)
+	}
+	if relQuery != "" {
+		fmt.Fprintf(sb, "rels_df = pd.read_sql(%q, engine)\n", relQuery)
+	}
+}
+
+func (s *Serializer) writeSnowflakeSetup(sb *strings.Builder, ds *SnowflakeDataSource, writes bool) {
+	sb.WriteString("import snowflake.connector\n")
+	if writes {
+		sb.WriteString("from snowflake.connector.pandas_tools import write_pandas\n")
+	}
+	sb.WriteString("\n")
 	sb.WriteString("# Connect to Snowflake\n")
 	sb.WriteString("conn = snowflake.connector.connect(\n")
 	fmt.Fprintf(sb, "    account=%q,\n", ds.Account)
@@ -86,8 +253,12 @@ func (s *Serializer) writeSnowflakeSetup(sb *strings.Builder, ds *SnowflakeDataS
 	fmt.Fprintf(sb, "    warehouse=%q,\n", ds.Warehouse)
 	sb.WriteString(")\n\n")
 	sb.WriteString("# Load data\n")
-	fmt.Fprintf(sb, "nodes_df = pd.read_sql(%q, conn)\n", ds.NodeQuery)
-	fmt.Fprintf(sb, "rels_df = pd.read_sql(%q, conn)\n", ds.RelQuery)
+	if ds.NodeQuery != "" {
+		fmt.Fprintf(sb, "nodes_df = pd.read_sql(%q, conn)\n", ds.NodeQuery)
+	}
+	if ds.RelQuery != "" {
+		fmt.Fprintf(sb, "rels_df = pd.read_sql(%q, conn)\n", ds.RelQuery)
+	}
 }
 
 func (s *Serializer) writeBigQuerySetup(sb *strings.Builder, ds *BigQueryDataSource) {
@@ -95,16 +266,249 @@ func (s *Serializer) writeBigQuerySetup(sb *strings.Builder, ds *BigQueryDataSou
 	sb.WriteString("# Connect to BigQuery\n")
 	fmt.Fprintf(sb, "client = bigquery.Client(project=%q)\n\n", ds.Project)
 	sb.WriteString("# Load data\n")
-	fmt.Fprintf(sb, "nodes_df = client.query(%q).to_dataframe()\n", ds.NodeQuery)
-	fmt.Fprintf(sb, "rels_df = client.query(%q).to_dataframe()\n", ds.RelQuery)
+	if ds.NodeQuery != "" {
+		fmt.Fprintf(sb, "nodes_df = client.query(%q).to_dataframe()\n", ds.NodeQuery)
+	}
+	if ds.RelQuery != "" {
+		fmt.Fprintf(sb, "rels_df = client.query(%q).to_dataframe()\n", ds.RelQuery)
+	}
 }
 
-func (s *Serializer) writeAlgorithmCall(sb *strings.Builder, algo algorithms.Algorithm) {
-	algoType := algo.AlgorithmType()
-	pythonName := toSnakeCase(algoType)
+// writeProjection builds one DataFrame per node label and relationship type
+// of the projection and constructs the graph from them. Without DataFrame
+// definitions, nodes_df and rels_df are used as they are.
+func (s *Serializer) writeProjection(sb *strings.Builder, p *projections.DataFrameProjection, ds DataSource) error {
+	sharedNodes, sharedRels := sharedFrames(ds)
+	files := filesOf(ds)
+	var labelFiles, typeFiles map[string][]string
+	if files != nil {
+		labelFiles, typeFiles = files.byLabel, files.byType
+	}
+
+	sb.WriteString("# Project graph from DataFrames\n")
+
+	nodes := "nodes_df"
+	if len(p.NodeDataFrames) > 0 {
+		var names []string
+		for _, df := range p.NodeDataFrames {
+			source, err := frameSource(files, labelFiles[df.Label], sharedNodes, "nodes_df", "labels", df.Label, len(p.NodeDataFrames) > 1)
+			if err != nil {
+				return fmt.Errorf("node label %s: %w", df.Label, err)
+			}
+			name := pyIdent(df.Label) + "_nodes"
+			writeFrame(sb, name, source, map[string]string{df.IDColumn: "nodeId"}, "labels", df.Label,
+				append([]string{"nodeId", "labels"}, df.Properties...))
+			names = append(names, name)
+		}
+		nodes = "[" + strings.Join(names, ", ") + "]"
+	} else if !sharedNodes {
+		return fmt.Errorf("no node data")
+	}
 
-	fmt.Fprintf(sb, "result = gds.%s.stream(G)\n", pythonName)
-	sb.WriteString("print(result)\n\n")
+	rels := ""
+	if len(p.RelationshipDataFrames) > 0 {
+		var names []string
+		for _, df := range p.RelationshipDataFrames {
+			source, err := frameSource(files, typeFiles[df.Type], sharedRels, "rels_df", "relationshipType", df.Type, len(p.RelationshipDataFrames) > 1)
+			if err != nil {
+				return fmt.Errorf("relationship type %s: %w", df.Type, err)
+			}
+			name := pyIdent(df.Type) + "_rels"
+			writeFrame(sb, name, source, map[string]string{df.SourceColumn: "sourceNodeId", df.TargetColumn: "targetNodeId"},
+				"relationshipType", df.Type, append([]string{"sourceNodeId", "targetNodeId", "relationshipType"}, df.Properties...))
+			names = append(names, name)
+		}
+		rels = "[" + strings.Join(names, ", ") + "]"
+	} else if sharedRels {
+		rels = "rels_df"
+	}
+
+	sb.WriteString("G = gds.graph.construct(\n")
+	fmt.Fprintf(sb, "    graph_name=%q,\n", p.GetGraphName())
+	fmt.Fprintf(sb, "    nodes=%s,\n", nodes)
+	if rels != "" {
+		fmt.Fprintf(sb, "    relationships=%s,\n", rels)
+	}
+	sb.WriteString(")\n\n")
+	return nil
+}
+
+// sharedFrames reports whether the data source loads nodes_df and rels_df.
+func sharedFrames(ds DataSource) (nodes, rels bool) {
+	if f := filesOf(ds); f != nil {
+		return len(f.nodes) > 0, len(f.rels) > 0
+	}
+	nodeQuery, relQuery, _ := sourceQueries(ds)
+	return nodeQuery != "", relQuery != ""
+}
+
+// frameSource returns the expression loading the rows of one label or type:
+// its own files, or the shared frame, filtered on column when the shared
+// frame holds several labels or types.
+func frameSource(f *fileSet, files []string, hasShared bool, shared, column, value string, filter bool) (string, error) {
+	switch {
+	case len(files) > 0:
+		return f.read(files), nil
+	case !hasShared:
+		return "", fmt.Errorf("no data loaded")
+	case filter:
+		return fmt.Sprintf("%s[%s[%q] == %q]", shared, shared, column, value), nil
+	default:
+		return shared, nil
+	}
+}
+
+// writeFrame assigns a DataFrame built from source with renamed columns, a
+// constant column set to value, and only the given columns kept.
+func writeFrame(sb *strings.Builder, name, source string, renames map[string]string, column, value string, columns []string) {
+	fmt.Fprintf(sb, "%s = (\n", name)
+	fmt.Fprintf(sb, "    %s\n", source)
+	var pairs []string
+	for from, to := range renames {
+		if from != "" && from != to {
+			pairs = append(pairs, fmt.Sprintf("%q: %q", from, to))
+		}
+	}
+	if len(pairs) > 0 {
+		sort.Strings(pairs)
+		fmt.Fprintf(sb, "    .rename(columns={%s})\n", strings.Join(pairs, ", "))
+	}
+	fmt.Fprintf(sb, "    .assign(%s=%q)\n", column, value)
+	fmt.Fprintf(sb, "    .loc[:, %s]\n", pyValue(columns))
+	sb.WriteString(")\n")
+}
+
+// writeAlgorithmCall runs an algorithm with its configuration. Sessions have
+// no database to write to, so write mode streams the results and writes them
+// back to the data source.
+func (s *Serializer) writeAlgorithmCall(sb *strings.Builder, algo algorithms.Algorithm, ds DataSource) {
+	mode := algo.GetMode()
+	if mode == "" {
+		mode = algorithms.Stream
+	}
+	callMode := mode
+	if mode == algorithms.Write {
+		callMode = algorithms.Stream
+	}
+
+	params := algorithms.NewAlgorithmSerializer().ToMap(algo)
+	table := resultName(algo, params)
+	for _, k := range []string{"name", "graphName", "mode", "algorithmType", "category"} {
+		delete(params, k)
+	}
+	var keys []string
+	for k := range params {
+		if strings.HasPrefix(k, "write") || (strings.HasPrefix(k, "mutate") && mode != algorithms.Mutate) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(sb, "# %s (%s)\n", algo.AlgorithmType(), mode)
+	if len(keys) == 0 {
+		fmt.Fprintf(sb, "result = %s.%s(G)\n", algo.AlgorithmType(), callMode)
+	} else {
+		fmt.Fprintf(sb, "result = %s.%s(\n", algo.AlgorithmType(), callMode)
+		sb.WriteString("    G,\n")
+		for _, k := range keys {
+			fmt.Fprintf(sb, "    %s=%s,\n", k, pyValue(params[k]))
+		}
+		sb.WriteString(")\n")
+	}
+
+	if mode == algorithms.Write {
+		s.writeResult(sb, ds, table)
+	} else {
+		sb.WriteString("print(result)\n")
+	}
+	sb.WriteString("\n")
+}
+
+// writeResult writes the result DataFrame back to the data source.
+func (s *Serializer) writeResult(sb *strings.Builder, ds DataSource, table string) {
+	if f := filesOf(ds); f != nil {
+		fmt.Fprintf(sb, "result.%s(%q, index=False%s)\n", f.writer, f.output(table), f.options)
+		return
+	}
+	switch d := ds.(type) {
+	case *SnowflakeDataSource:
+		fmt.Fprintf(sb, "write_pandas(conn, result, %q, auto_create_table=True, overwrite=True)\n", strings.ToUpper(table))
+	case *BigQueryDataSource:
+		fmt.Fprintf(sb, "client.load_table_from_dataframe(result, %q).result()\n", d.Project+"."+d.Dataset+"."+table)
+	case *PostgresDataSource, *DatabricksDataSource:
+		fmt.Fprintf(sb, "result.to_sql(%q, engine, if_exists=\"replace\", index=False)\n", table)
+	default:
+		sb.WriteString("print(result)\n")
+	}
+}
+
+// resultName names the table or file written results go to: the write
+// property, the written relationship type, the configuration name, or the
+// algorithm.
+func resultName(algo algorithms.Algorithm, params map[string]any) string {
+	for _, k := range []string{"writeProperty", "writeRelationshipType", "name"} {
+		if v, ok := params[k].(string); ok && v != "" {
+			return pyIdent(v)
+		}
+	}
+	t := algo.AlgorithmType()
+	return toSnakeCase(t[strings.LastIndex(t, ".")+1:])
+}
+
+func hasWriteMode(algos []algorithms.Algorithm) bool {
+	for _, algo := range algos {
+		if algo.GetMode() == algorithms.Write {
+			return true
+		}
+	}
+	return false
+}
+
+// pyValue formats a configuration value as a Python literal.
+func pyValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "None"
+	case string:
+		return graphrag.PyString(val)
+	case bool:
+		return graphrag.PyBool(val)
+	case float64:
+		return graphrag.PyFloat(val)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = pyValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// pyIdent converts a label or type to a snake_case Python identifier.
+func pyIdent(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			if i > 0 && !strings.HasSuffix(b.String(), "_") && !isUpper(s[i-1]) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r + 'a' - 'A')
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
 }
 
 // ToMap converts a session to a map for JSON serialization.
@@ -121,7 +525,8 @@ func (s *Serializer) ToMap(session *Session) map[string]any {
 
 	if session.Projection != nil {
 		result["projection"] = map[string]any{
-			"name": session.Projection.ProjectionName(),
+			"name":      session.Projection.ProjectionName(),
+			"graphName": session.Projection.GetGraphName(),
 		}
 	}
 
@@ -142,10 +547,87 @@ func (s *Serializer) ToMap(session *Session) map[string]any {
 func (s *Serializer) dataSourceToMap(ds DataSource) map[string]any {
 	switch d := ds.(type) {
 	case *PandasDataSource:
-		return map[string]any{
+		m := map[string]any{
 			"nodeFiles": d.NodeFiles,
 			"relFiles":  d.RelFiles,
 		}
+		if len(d.NodeFilesByLabel) > 0 {
+			m["nodeFilesByLabel"] = d.NodeFilesByLabel
+		}
+		if len(d.RelFilesByType) > 0 {
+			m["relFilesByType"] = d.RelFilesByType
+		}
+		if d.OutputDir != "" {
+			m["outputDir"] = d.OutputDir
+		}
+		return m
+	case *ParquetDataSource:
+		m := map[string]any{
+			"nodeFiles": d.NodeFiles,
+			"relFiles":  d.RelFiles,
+		}
+		if len(d.NodeFilesByLabel) > 0 {
+			m["nodeFilesByLabel"] = d.NodeFilesByLabel
+		}
+		if len(d.RelFilesByType) > 0 {
+			m["relFilesByType"] = d.RelFilesByType
+		}
+		if d.OutputDir != "" {
+			m["outputDir"] = d.OutputDir
+		}
+		return m
+	case *S3DataSource:
+		m := map[string]any{
+			"bucket":             d.Bucket,
+			"nodeKeys":           d.NodeKeys,
+			"relKeys":            d.RelKeys,
+			"accessKeyIdEnv":     graphrag.SecretEnv(d.AccessKeyID, "AWS_ACCESS_KEY_ID"),
+			"secretAccessKeyEnv": graphrag.SecretEnv(d.SecretAccessKey, "AWS_SECRET_ACCESS_KEY"),
+		}
+		if d.Region != "" {
+			m["region"] = d.Region
+		}
+		if d.Format != "" {
+			m["format"] = d.Format
+		}
+		if len(d.NodeKeysByLabel) > 0 {
+			m["nodeKeysByLabel"] = d.NodeKeysByLabel
+		}
+		if len(d.RelKeysByType) > 0 {
+			m["relKeysByType"] = d.RelKeysByType
+		}
+		if d.OutputPrefix != "" {
+			m["outputPrefix"] = d.OutputPrefix
+		}
+		return m
+	case *PostgresDataSource:
+		m := map[string]any{
+			"host":        d.Host,
+			"database":    d.Database,
+			"user":        d.User,
+			"passwordEnv": graphrag.SecretEnv(d.Password, "PGPASSWORD"),
+			"nodeQuery":   d.NodeQuery,
+			"relQuery":    d.RelQuery,
+		}
+		if d.Port != 0 {
+			m["port"] = d.Port
+		}
+		return m
+	case *DatabricksDataSource:
+		m := map[string]any{
+			"serverHostname": d.ServerHostname,
+			"httpPath":       d.HTTPPath,
+			"accessTokenEnv": graphrag.SecretEnv(d.AccessToken, "DATABRICKS_TOKEN"),
+			"nodeQuery":      d.NodeQuery,
+			"relQuery":       d.RelQuery,
+		}
+		if d.Catalog != "" {
+			m["catalog"] = d.Catalog
+		}
+		if d.Schema != "" {
+			m["schema"] = d.Schema
+		}
+		return m
 	case *SnowflakeDataSource:
 		return map[string]any{
 			"account":   d.Account,
@@ -16,6 +16,8 @@ import (
 	"github.com/lex00/wetwire-neo4j-go/internal/projections"
 	"github.com/lex00/wetwire-neo4j-go/internal/retrievers"
 	"github.com/lex00/wetwire-neo4j-go/internal/serializer"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
+	"github.com/lex00/wetwire-neo4j-go/internal/workflows"
 	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
 )
 
@@ -29,6 +31,7 @@ type Builder struct {
 	projSerializer   *projections.ProjectionSerializer
 	retSerializer    *retrievers.RetrieverSerializer
 	kgSerializer     *kg.KGSerializer
+	wfSerializer     *workflows.WorkflowSerializer
 }
 
 // NewBuilder creates a new Builder.
@@ -42,9 +45,19 @@ func NewBuilder() *Builder {
 		projSerializer:   projections.NewProjectionSerializer(),
 		retSerializer:    retrievers.NewRetrieverSerializer(),
 		kgSerializer:     kg.NewKGSerializer(),
+		wfSerializer:     workflows.NewWorkflowSerializer(),
 	}
 }
 
+// WithTarget sets the Neo4j and GDS versions the Cypher is generated for.
+func (b *Builder) WithTarget(t target.Target) *Builder {
+	b.cypherSerializer.WithTarget(t)
+	b.algoSerializer.WithTarget(t)
+	b.projSerializer.WithTarget(t)
+	b.wfSerializer.WithTarget(t)
+	return b
+}
+
 // Build implements Builder.Build.
 func (b *Builder) Build(ctx context.Context, path string, opts BuildOptions) error {
 	// Discover resources
@@ -144,22 +157,89 @@ func (b *Builder) generateCypher(resources []discover.DiscoveredResource, verbos
 	// In a full implementation, we'd need to load and parse the actual definitions
 	for _, r := range resources {
 		switch r.Kind {
-		case discover.KindNodeType:
-			statements = append(statements, fmt.Sprintf("// NodeType: %s (from %s:%d)", r.Name, r.File, r.Line))
-		case discover.KindRelationshipType:
-			statements = append(statements, fmt.Sprintf("// RelationshipType: %s (from %s:%d)", r.Name, r.File, r.Line))
+		case discover.KindNodeType, discover.KindRelationshipType:
+			section, err := SchemaCypher(b.cypherSerializer, r)
+			if err != nil {
+				section = fmt.Sprintf("// %s: %s (from %s:%d)\n// %v", r.Kind, r.Name, r.File, r.Line, err)
+			}
+			statements = append(statements, section)
 		case discover.KindAlgorithm:
 			statements = append(statements, fmt.Sprintf("// Algorithm: %s (from %s:%d)", r.Name, r.File, r.Line))
 		case discover.KindPipeline:
 			statements = append(statements, fmt.Sprintf("// Pipeline: %s (from %s:%d)", r.Name, r.File, r.Line))
 		case discover.KindRetriever:
-			statements = append(statements, fmt.Sprintf("// Retriever: %s (from %s:%d)", r.Name, r.File, r.Line))
+			section, err := RetrieverCypher(b.retSerializer, r)
+			if err != nil {
+				section = fmt.Sprintf("// Retriever: %s (from %s:%d)\n// %v", r.Name, r.File, r.Line, err)
+			}
+			statements = append(statements, section)
+		case discover.KindProjection:
+			statements = append(statements, fmt.Sprintf("// Projection: %s (from %s:%d)", r.Name, r.File, r.Line))
+		case discover.KindCatalogOperation:
+			statements = append(statements, fmt.Sprintf("// CatalogOperation: %s (from %s:%d)", r.Name, r.File, r.Line))
+		case discover.KindWorkflow:
+			statements = append(statements, fmt.Sprintf("// Workflow: %s (from %s:%d)", r.Name, r.File, r.Line))
+		case discover.KindSession:
+			statements = append(statements, fmt.Sprintf("// Session: %s (from %s:%d)", r.Name, r.File, r.Line))
 		}
 	}
 
 	return strings.Join(statements, "\n"), nil
 }
 
+// SchemaCypher renders a discovered node or relationship type as a comment
+// naming its source, followed by the DDL for its constraints, indexes and
+// required properties in the serializer's target dialect. Constraints and
+// indexes without a name are named <label>_<properties>_<type>.
+func SchemaCypher(s *serializer.CypherSerializer, r discover.DiscoveredResource) (string, error) {
+	comment := fmt.Sprintf("// %s: %s (from %s:%d)", r.Kind, r.Name, r.File, r.Line)
+
+	var cypher string
+	var err error
+	switch r.Kind {
+	case discover.KindNodeType:
+		node := (&discover.LintableNodeType{
+			Label:       r.Name,
+			Properties:  r.Properties,
+			Constraints: r.Constraints,
+			Indexes:     r.Indexes,
+		}).ToSchemaNodeType()
+		for i, c := range node.Constraints {
+			if c.Name == "" {
+				node.Constraints[i].Name = schemaName(node.Label, c.Properties, string(c.Type))
+			}
+		}
+		for i, idx := range node.Indexes {
+			if idx.Name == "" {
+				node.Indexes[i].Name = schemaName(node.Label, idx.Properties, string(idx.Type))
+			}
+		}
+		cypher, err = s.SerializeNodeType(node)
+	case discover.KindRelationshipType:
+		cypher, err = s.SerializeRelationshipType((&discover.LintableRelationshipType{
+			Label:      r.Name,
+			Source:     r.Source,
+			Target:     r.Target,
+			Properties: r.Properties,
+		}).ToSchemaRelationshipType())
+	default:
+		return "", fmt.Errorf("%s %s is not a schema type", r.Kind, r.Name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", r.Kind, r.Name, err)
+	}
+	if cypher == "" {
+		return comment, nil
+	}
+	return comment + "\n" + cypher, nil
+}
+
+// schemaName names a constraint or index, e.g. person_email_unique.
+func schemaName(label string, properties []string, typ string) string {
+	parts := append([]string{label}, properties...)
+	return strings.ToLower(strings.Join(append(parts, typ), "_"))
+}
+
 // generateJSON generates JSON output for all resources.
 func (b *Builder) generateJSON(resources []discover.DiscoveredResource, verbose bool) (string, error) {
 	output := make(map[string][]map[string]any)
@@ -197,13 +277,14 @@ func (b *Builder) BuildFromResources(
 	projs []projections.Projection,
 	rets []retrievers.Retriever,
 	kgPipes []kg.KGPipeline,
+	wfs []*workflows.Workflow,
 	format string,
 ) (string, error) {
 	switch format {
 	case "cypher":
-		return b.buildCypherFromResources(nodeTypes, relTypes, algos, pipes, projs)
+		return b.buildCypherFromResources(nodeTypes, relTypes, algos, pipes, projs, rets, kgPipes, wfs)
 	case "json":
-		return b.buildJSONFromResources(nodeTypes, relTypes, algos, pipes, projs, rets, kgPipes)
+		return b.buildJSONFromResources(nodeTypes, relTypes, algos, pipes, projs, rets, kgPipes, wfs)
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
@@ -216,6 +297,9 @@ func (b *Builder) buildCypherFromResources(
 	algos []algorithms.Algorithm,
 	pipes []pipelines.Pipeline,
 	projs []projections.Projection,
+	rets []retrievers.Retriever,
+	kgPipes []kg.KGPipeline,
+	wfs []*workflows.Workflow,
 ) (string, error) {
 	var sections []string
 
@@ -257,6 +341,40 @@ func (b *Builder) buildCypherFromResources(
 		sections = append(sections, cypher)
 	}
 
+	// Retriever Cypher. Text2Cypher retrievers have no fixed query.
+	for _, ret := range rets {
+		if ret.RetrieverType() == retrievers.Text2Cypher {
+			continue
+		}
+		section, err := retrieverSection(b.retSerializer, ret, "// Retriever: "+ret.RetrieverName())
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize retriever: %w", err)
+		}
+		sections = append(sections, section)
+	}
+
+	// KG pipeline Cypher. Only community summary pipelines run as Cypher;
+	// extraction pipelines run through neo4j-graphrag.
+	for _, p := range kgPipes {
+		if p.PipelineType() != kg.CommunitySummary {
+			continue
+		}
+		cypher, err := b.kgSerializer.ToCypher(p)
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize KG pipeline: %w", err)
+		}
+		sections = append(sections, strings.TrimSuffix(cypher, "\n"))
+	}
+
+	// Workflow scripts
+	for _, w := range wfs {
+		cypher, err := b.wfSerializer.ToCypher(w)
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize workflow: %w", err)
+		}
+		sections = append(sections, cypher)
+	}
+
 	return strings.Join(sections, "\n\n"), nil
 }
 
@@ -269,6 +387,7 @@ func (b *Builder) buildJSONFromResources(
 	projs []projections.Projection,
 	rets []retrievers.Retriever,
 	kgPipes []kg.KGPipeline,
+	wfs []*workflows.Workflow,
 ) (string, error) {
 	output := make(map[string]any)
 
@@ -334,6 +453,15 @@ func (b *Builder) buildJSONFromResources(
 		output["kgPipelines"] = kgMaps
 	}
 
+	// Workflows
+	if len(wfs) > 0 {
+		wfMaps := make([]map[string]any, len(wfs))
+		for i, w := range wfs {
+			wfMaps[i] = b.wfSerializer.ToMap(w)
+		}
+		output["workflows"] = wfMaps
+	}
+
 	data, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal JSON: %w", err)
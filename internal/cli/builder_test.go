@@ -12,6 +12,8 @@ import (
 	"github.com/lex00/wetwire-neo4j-go/internal/pipelines"
 	"github.com/lex00/wetwire-neo4j-go/internal/projections"
 	"github.com/lex00/wetwire-neo4j-go/internal/retrievers"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
+	"github.com/lex00/wetwire-neo4j-go/internal/workflows"
 	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
 )
 
@@ -56,6 +58,35 @@ func TestBuilder_DetectFormat(t *testing.T) {
 	}
 }
 
+func TestBuilder_Build_Target(t *testing.T) {
+	tmpDir := t.TempDir()
+	code := `package schema
+
+import "github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+
+var Person = schema.NodeType{
+	Label:       "Person",
+	Constraints: []schema.Constraint{{Name: "person_id", Type: schema.NODE_KEY, Properties: []string{"id"}}},
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "schema.go"), []byte(code), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(t.TempDir(), "schema.cypher")
+
+	b := NewBuilder().WithTarget(target.Target{Neo4j: target.V(4, 3)})
+	if err := b.Build(context.Background(), tmpDir, BuildOptions{Output: output}); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "CREATE CONSTRAINT person_id IF NOT EXISTS ON (n:Person) ASSERT (n.id) IS NODE KEY"; !strings.Contains(string(data), want) {
+		t.Errorf("expected %q in output:\n%s", want, data)
+	}
+}
+
 func TestBuilder_Build_EmptyDir(t *testing.T) {
 	b := NewBuilder()
 
@@ -127,7 +158,7 @@ func TestBuilder_BuildFromResources_Cypher(t *testing.T) {
 		},
 	}
 
-	output, err := b.BuildFromResources(nodeTypes, relTypes, nil, nil, nil, nil, nil, "cypher")
+	output, err := b.BuildFromResources(nodeTypes, relTypes, nil, nil, nil, nil, nil, nil, "cypher")
 	if err != nil {
 		t.Fatalf("BuildFromResources failed: %v", err)
 	}
@@ -140,6 +171,60 @@ func TestBuilder_BuildFromResources_Cypher(t *testing.T) {
 	}
 }
 
+func TestBuilder_BuildFromResources_CypherRetrievers(t *testing.T) {
+	b := NewBuilder()
+
+	rets := []retrievers.Retriever{
+		&retrievers.HybridRetriever{
+			BaseRetriever:     retrievers.BaseRetriever{Name: "hybrid"},
+			VectorIndexName:   "chunks",
+			FulltextIndexName: "chunk_text",
+		},
+		&retrievers.Text2CypherRetriever{BaseRetriever: retrievers.BaseRetriever{Name: "ask"}},
+	}
+
+	output, err := b.BuildFromResources(nil, nil, nil, nil, nil, rets, nil, nil, "cypher")
+	if err != nil {
+		t.Fatalf("BuildFromResources failed: %v", err)
+	}
+
+	for _, e := range []string{"// Retriever: hybrid", ":params {fulltextIndexName: 'chunk_text'", "UNION ALL"} {
+		if !strings.Contains(output, e) {
+			t.Errorf("expected %q in output, got:\n%s", e, output)
+		}
+	}
+	if strings.Contains(output, "ask") {
+		t.Errorf("Text2Cypher retrievers should be skipped, got:\n%s", output)
+	}
+}
+
+func TestBuilder_BuildFromResources_WorkflowTarget(t *testing.T) {
+	b := NewBuilder().WithTarget(target.Target{GDS: target.V(2, 2)})
+
+	graph := &projections.NativeProjection{
+		BaseProjection:  projections.BaseProjection{Name: "people"},
+		NodeProjections: []projections.NodeProjection{{Label: "Person"}},
+	}
+	wfs := []*workflows.Workflow{{
+		Name:       "communities",
+		Projection: graph,
+		Steps: []workflows.Step{{Algorithm: &algorithms.Leiden{
+			BaseAlgorithm: algorithms.BaseAlgorithm{Name: "leiden", GraphName: "people", Mode: algorithms.Write},
+			WriteProperty: "community",
+		}}},
+	}}
+
+	output, err := b.BuildFromResources(nil, nil, nil, nil, nil, nil, nil, wfs, "cypher")
+	if err != nil {
+		t.Fatalf("BuildFromResources failed: %v", err)
+	}
+	for _, e := range []string{"// Workflow: communities", "CALL gds.alpha.leiden.write("} {
+		if !strings.Contains(output, e) {
+			t.Errorf("expected %q in output, got:\n%s", e, output)
+		}
+	}
+}
+
 func TestBuilder_BuildFromResources_JSON(t *testing.T) {
 	b := NewBuilder()
 
@@ -174,7 +259,7 @@ func TestBuilder_BuildFromResources_JSON(t *testing.T) {
 		},
 	}
 
-	output, err := b.BuildFromResources(nodeTypes, nil, algos, pipes, projs, rets, kgPipes, "json")
+	output, err := b.BuildFromResources(nodeTypes, nil, algos, pipes, projs, rets, kgPipes, nil, "json")
 	if err != nil {
 		t.Fatalf("BuildFromResources failed: %v", err)
 	}
@@ -202,7 +287,7 @@ func TestBuilder_BuildFromResources_JSON(t *testing.T) {
 func TestBuilder_BuildFromResources_InvalidFormat(t *testing.T) {
 	b := NewBuilder()
 
-	_, err := b.BuildFromResources(nil, nil, nil, nil, nil, nil, nil, "invalid")
+	_, err := b.BuildFromResources(nil, nil, nil, nil, nil, nil, nil, nil, "invalid")
 	if err == nil {
 		t.Error("expected error for invalid format")
 	}
@@ -212,7 +297,7 @@ func TestBuilder_GenerateJSON(t *testing.T) {
 	b := NewBuilder()
 
 	// This tests the internal generateJSON method indirectly
-	output, err := b.BuildFromResources([]*schema.NodeType{{Label: "Test"}}, nil, nil, nil, nil, nil, nil, "json")
+	output, err := b.BuildFromResources([]*schema.NodeType{{Label: "Test"}}, nil, nil, nil, nil, nil, nil, nil, "json")
 	if err != nil {
 		t.Fatalf("BuildFromResources failed: %v", err)
 	}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/discover"
+	"github.com/lex00/wetwire-neo4j-go/internal/retrievers"
+)
+
+// Evaluator scores discovered retrievers against a golden question set.
+type Evaluator struct {
+	scanner *discover.Scanner
+}
+
+// NewEvaluator creates a new Evaluator.
+func NewEvaluator() *Evaluator {
+	return &Evaluator{
+		scanner: discover.NewScanner(),
+	}
+}
+
+// Evaluate runs the named retrievers found in path against the fixture
+// corpus and writes recall@k, MRR and nDCG for each. With two names, a
+// comparison of the second against the first is written as well.
+func (e *Evaluator) Evaluate(ctx context.Context, w io.Writer, path string, names []string, opts EvalOptions) error {
+	if len(names) == 0 || len(names) > 2 {
+		return fmt.Errorf("expected one or two retriever names, got %d", len(names))
+	}
+
+	golden, err := retrievers.LoadGoldenSet(opts.Golden)
+	if err != nil {
+		return err
+	}
+	corpus, err := retrievers.LoadCorpus(opts.Corpus)
+	if err != nil {
+		return err
+	}
+
+	resources, err := e.scanner.ScanDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to scan directory: %w", err)
+	}
+
+	reports := make([]*retrievers.EvalReport, 0, len(names))
+	for _, name := range names {
+		ret, err := findRetriever(resources, name)
+		if err != nil {
+			return err
+		}
+		report, err := retrievers.Evaluate(ctx, corpus, ret, golden, opts.K)
+		if err != nil {
+			return fmt.Errorf("retriever %s: %w", name, err)
+		}
+		report.Retriever = name
+		reports = append(reports, report)
+
+		if err := retrievers.WriteReport(w, report); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(reports) == 2 {
+		return retrievers.WriteComparison(w, reports[0], reports[1])
+	}
+	return nil
+}
+
+// findRetriever rebuilds the configuration of the retriever variable name.
+func findRetriever(resources []discover.DiscoveredResource, name string) (retrievers.Retriever, error) {
+	for _, r := range resources {
+		if r.Kind == discover.KindRetriever && r.Name == name {
+			ret, err := retrievers.FromFields(r.Type, r.Fields)
+			if err != nil {
+				return nil, fmt.Errorf("retriever %s: %w", name, err)
+			}
+			return ret, nil
+		}
+	}
+	return nil, fmt.Errorf("retriever %s not found", name)
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const evalSource = `package rag
+
+import "github.com/lex00/wetwire-neo4j-go/internal/retrievers"
+
+var Narrow = &retrievers.VectorRetriever{IndexName: "chunks", TopK: 1}
+
+var Wide = &retrievers.VectorRetriever{IndexName: "chunks", TopK: 3}
+`
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "rag.go"), []byte(evalSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opts := EvalOptions{
+		Golden: "../retrievers/testdata/eval/golden.json",
+		Corpus: "../retrievers/testdata/eval/corpus.json",
+	}
+
+	var buf bytes.Buffer
+	if err := NewEvaluator().Evaluate(context.Background(), &buf, dir, []string{"Narrow", "Wide"}, opts); err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+
+	for _, e := range []string{"MEAN (Narrow)", "MEAN (Wide)", "METRIC  Narrow  Wide", "+0.250"} {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("expected %q in output, got:\n%s", e, buf.String())
+		}
+	}
+
+	err := NewEvaluator().Evaluate(context.Background(), &buf, dir, []string{"Missing"}, opts)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/discover"
+	"github.com/lex00/wetwire-neo4j-go/internal/retrievers"
+)
+
+// Explainer prints the Cypher that discovered retrievers run against Neo4j.
+type Explainer struct {
+	scanner    *discover.Scanner
+	serializer *retrievers.RetrieverSerializer
+}
+
+// NewExplainer creates a new Explainer.
+func NewExplainer() *Explainer {
+	return &Explainer{
+		scanner:    discover.NewScanner(),
+		serializer: retrievers.NewRetrieverSerializer(),
+	}
+}
+
+// Explain writes a :params header and the Cypher of each retriever found in
+// path to w, ready to paste into Neo4j Browser. If name is set, only that
+// retriever is explained.
+func (e *Explainer) Explain(w io.Writer, path, name string) error {
+	resources, err := e.scanner.ScanDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to scan directory: %w", err)
+	}
+
+	var rets []discover.DiscoveredResource
+	for _, r := range resources {
+		if r.Kind == discover.KindRetriever && (name == "" || r.Name == name) {
+			rets = append(rets, r)
+		}
+	}
+	if len(rets) == 0 {
+		if name != "" {
+			return fmt.Errorf("retriever %s not found", name)
+		}
+		return fmt.Errorf("no retrievers found in %s", path)
+	}
+	sort.Slice(rets, func(i, j int) bool { return rets[i].Name < rets[j].Name })
+
+	for i, r := range rets {
+		section, err := RetrieverCypher(e.serializer, r)
+		if err != nil {
+			if name != "" {
+				return err
+			}
+			section = fmt.Sprintf("// Retriever: %s (from %s:%d)\n// %v", r.Name, r.File, r.Line, err)
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, section)
+	}
+	return nil
+}
+
+// RetrieverCypher renders a discovered retriever as a comment naming its
+// source, a :params header and its Cypher query.
+func RetrieverCypher(s *retrievers.RetrieverSerializer, r discover.DiscoveredResource) (string, error) {
+	ret, err := retrievers.FromFields(r.Type, r.Fields)
+	if err != nil {
+		return "", fmt.Errorf("retriever %s: %w", r.Name, err)
+	}
+	return retrieverSection(s, ret, fmt.Sprintf("// Retriever: %s (from %s:%d)", r.Name, r.File, r.Line))
+}
+
+func retrieverSection(s *retrievers.RetrieverSerializer, ret retrievers.Retriever, comment string) (string, error) {
+	cypher, err := s.ToCypher(ret)
+	if err != nil {
+		return "", err
+	}
+	return comment + "\n" + retrievers.ParamsHeader(s.Parameters(ret)) + "\n" + cypher, nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const explainSource = `package rag
+
+import "github.com/lex00/wetwire-neo4j-go/internal/retrievers"
+
+var ChunkSearch = &retrievers.VectorRetriever{
+	BaseRetriever:    retrievers.BaseRetriever{Name: "chunks"},
+	IndexName:        "chunk_embeddings",
+	TopK:             3,
+	ReturnProperties: []string{"text"},
+}
+
+var AskGraph = &retrievers.Text2CypherRetriever{
+	BaseRetriever: retrievers.BaseRetriever{Name: "ask"},
+}
+`
+
+func writeExplainSource(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "rag.go"), []byte(explainSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestExplainer_Explain(t *testing.T) {
+	dir := writeExplainSource(t)
+
+	var buf bytes.Buffer
+	if err := NewExplainer().Explain(&buf, dir, "ChunkSearch"); err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	out := buf.String()
+	expected := []string{
+		"// Retriever: ChunkSearch (from ",
+		":params {indexName: 'chunk_embeddings', topK: 3}",
+		"CALL db.index.vector.queryNodes($indexName, $topK, $embedding)",
+		"RETURN elementId(node) AS id, node {.text} AS properties, score",
+	}
+	for _, e := range expected {
+		if !strings.Contains(out, e) {
+			t.Errorf("expected %q in output, got:\n%s", e, out)
+		}
+	}
+	if strings.Contains(out, "AskGraph") {
+		t.Errorf("expected only the named retriever, got:\n%s", out)
+	}
+}
+
+func TestExplainer_Explain_All(t *testing.T) {
+	dir := writeExplainSource(t)
+
+	var buf bytes.Buffer
+	if err := NewExplainer().Explain(&buf, dir, ""); err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "generated by the LLM at search time") {
+		t.Errorf("expected a note for the Text2Cypher retriever, got:\n%s", buf.String())
+	}
+}
+
+func TestExplainer_Explain_Errors(t *testing.T) {
+	dir := writeExplainSource(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		target  string
+		wantErr string
+	}{
+		{"unknown retriever", dir, "Missing", "not found"},
+		{"text2cypher", dir, "AskGraph", "generated by the LLM"},
+		{"no retrievers", t.TempDir(), "", "no retrievers found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewExplainer().Explain(&bytes.Buffer{}, tt.path, tt.target)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Explain() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
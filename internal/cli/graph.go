@@ -53,6 +53,10 @@ func (g *GraphCLI) generateDOT(resources []discover.DiscoveredResource, graph *d
 		discover.KindAlgorithm:        "lightyellow",
 		discover.KindPipeline:         "lightpink",
 		discover.KindRetriever:        "lavender",
+		discover.KindProjection:       "lightcyan",
+		discover.KindCatalogOperation: "wheat",
+		discover.KindWorkflow:         "thistle",
+		discover.KindSession:          "lightsalmon",
 	}
 
 	// Sort resources for deterministic output
@@ -82,6 +86,10 @@ func (g *GraphCLI) generateDOT(resources []discover.DiscoveredResource, graph *d
 		for _, dep := range deps {
 			_, _ = fmt.Fprintf(w, "  %q -> %q;\n", r.Name, dep)
 		}
+		// Workflow steps run in order
+		for i := 1; i < len(r.Steps); i++ {
+			_, _ = fmt.Fprintf(w, "  %q -> %q [style=dashed, label=\"then\"];\n", r.Steps[i-1], r.Steps[i])
+		}
 	}
 
 	_, _ = fmt.Fprintln(w, "}")
@@ -117,6 +125,10 @@ func (g *GraphCLI) generateMermaid(resources []discover.DiscoveredResource, grap
 			toID := sanitizeMermaidID(dep)
 			_, _ = fmt.Fprintf(w, "  %s --> %s\n", fromID, toID)
 		}
+		// Workflow steps run in order
+		for i := 1; i < len(r.Steps); i++ {
+			_, _ = fmt.Fprintf(w, "  %s -. then .-> %s\n", sanitizeMermaidID(r.Steps[i-1]), sanitizeMermaidID(r.Steps[i]))
+		}
 	}
 
 	return nil
@@ -119,3 +119,56 @@ func TestGraphCLI_EmptyDirectory(t *testing.T) {
 		t.Errorf("expected DOT output even for empty graph, got: %s", output)
 	}
 }
+
+func TestGraphCLI_WorkflowStepOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	workflowContent := `package analytics
+
+import (
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+	"github.com/lex00/wetwire-neo4j-go/internal/workflows"
+)
+
+var CustomerGraph = &projections.NativeProjection{
+	BaseProjection: projections.BaseProjection{Name: "customers"},
+}
+
+var Embeddings = &algorithms.FastRP{
+	BaseAlgorithm: algorithms.BaseAlgorithm{Name: "embeddings", GraphName: "customers"},
+}
+
+var Segments = &algorithms.Louvain{
+	BaseAlgorithm: algorithms.BaseAlgorithm{Name: "segments", GraphName: "customers"},
+}
+
+var CustomerSegments = &workflows.Workflow{
+	Name:       "customer_segments",
+	Projection: CustomerGraph,
+	Steps:      []workflows.Step{{Algorithm: Embeddings}, {Algorithm: Segments}},
+}
+`
+	err := os.WriteFile(filepath.Join(tmpDir, "workflow.go"), []byte(workflowContent), 0644)
+	if err != nil {
+		t.Fatalf("failed to write workflow file: %v", err)
+	}
+
+	graph := NewGraphCLI()
+
+	var dot bytes.Buffer
+	if err := graph.Generate(tmpDir, "dot", &dot); err != nil {
+		t.Fatalf("generate DOT failed: %v", err)
+	}
+	if !strings.Contains(dot.String(), `"Embeddings" -> "Segments" [style=dashed, label="then"];`) {
+		t.Errorf("expected step order edge in DOT output, got: %s", dot.String())
+	}
+
+	var mermaid bytes.Buffer
+	if err := graph.Generate(tmpDir, "mermaid", &mermaid); err != nil {
+		t.Fatalf("generate Mermaid failed: %v", err)
+	}
+	if !strings.Contains(mermaid.String(), "Embeddings -. then .-> Segments") {
+		t.Errorf("expected step order edge in Mermaid output, got: %s", mermaid.String())
+	}
+}
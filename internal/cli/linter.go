@@ -9,6 +9,7 @@ import (
 	"github.com/lex00/wetwire-neo4j-go/internal/kg"
 	"github.com/lex00/wetwire-neo4j-go/internal/lint"
 	"github.com/lex00/wetwire-neo4j-go/internal/pipelines"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
 	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
 )
 
@@ -26,6 +27,13 @@ func NewLinter() *Linter {
 	}
 }
 
+// WithTarget enables the lint rules for features unavailable or deprecated
+// in the target's Neo4j and GDS versions.
+func (l *Linter) WithTarget(t target.Target) *Linter {
+	l.linter.WithTarget(t)
+	return l
+}
+
 // Lint implements Linter.Lint.
 func (l *Linter) Lint(ctx context.Context, path string, opts LintOptions) ([]Issue, error) {
 	// Discover resources
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/aura"
+	"github.com/lex00/wetwire-neo4j-go/internal/discover"
+)
+
+// DefaultSessionMemory is the memory of sessions with neither Memory nor
+// node and relationship counts to size from.
+const DefaultSessionMemory = "8GB"
+
+// SessionCLI creates and deletes the Aura Graph Analytics sessions defined
+// as aura.Session literals in Go source.
+type SessionCLI struct {
+	scanner *discover.Scanner
+	client  *aura.Client
+}
+
+// NewSessionCLI creates a SessionCLI calling the Aura API through client.
+func NewSessionCLI(client *aura.Client) *SessionCLI {
+	return &SessionCLI{
+		scanner: discover.NewScanner(),
+		client:  client,
+	}
+}
+
+// Up creates the sessions defined in path that are not running yet. If name
+// is set, only the session of that name is created.
+func (c *SessionCLI) Up(ctx context.Context, w io.Writer, path, name string) error {
+	sessions, err := c.definitions(path, name)
+	if err != nil {
+		return err
+	}
+	running, err := c.running(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		if info, ok := running[s.Name]; ok {
+			fmt.Fprintf(w, "%s: already running (%s)\n", s.Name, info.ID)
+			continue
+		}
+		memory, err := c.memory(ctx, s)
+		if err != nil {
+			return fmt.Errorf("session %s: %w", s.Name, err)
+		}
+		info, err := c.client.CreateSession(ctx, s.CreateRequest(memory))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s: created %s (%s, expires %s)\n", s.Name, info.ID, info.Memory, info.ExpiryDate.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Down deletes the running sessions defined in path. If name is set, only
+// the session of that name is deleted.
+func (c *SessionCLI) Down(ctx context.Context, w io.Writer, path, name string) error {
+	sessions, err := c.definitions(path, name)
+	if err != nil {
+		return err
+	}
+	running, err := c.running(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		info, ok := running[s.Name]
+		if !ok {
+			fmt.Fprintf(w, "%s: not running\n", s.Name)
+			continue
+		}
+		if err := c.client.DeleteSession(ctx, info.ID); err != nil && !aura.IsNotFound(err) {
+			return err
+		}
+		fmt.Fprintf(w, "%s: deleted %s\n", s.Name, info.ID)
+	}
+	return nil
+}
+
+// List writes a table of the tenant's running sessions to w.
+func (c *SessionCLI) List(ctx context.Context, w io.Writer) error {
+	sessions, err := c.client.ListSessions(ctx)
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		fmt.Fprintln(w, "No sessions running")
+		return nil
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Name < sessions[j].Name })
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tID\tMEMORY\tSTATUS\tEXPIRES")
+	for _, s := range sessions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.Name, s.ID, s.Memory, s.Status, s.ExpiryDate.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+// definitions returns the sessions defined in path, ordered by name.
+func (c *SessionCLI) definitions(path, name string) ([]*aura.Session, error) {
+	resources, err := c.scanner.ScanDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan directory: %w", err)
+	}
+
+	var sessions []*aura.Session
+	for _, r := range resources {
+		if r.Kind != discover.KindSession {
+			continue
+		}
+		s, err := aura.FromLiteralFields(r.DataSourceType, r.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("session %s (%s:%d): %w", r.Name, r.File, r.Line, err)
+		}
+		if s.Name == "" {
+			return nil, fmt.Errorf("session %s (%s:%d): Name must be a constant", r.Name, r.File, r.Line)
+		}
+		if name == "" || s.Name == name || r.Name == name {
+			sessions = append(sessions, s)
+		}
+	}
+	if len(sessions) == 0 {
+		if name != "" {
+			return nil, fmt.Errorf("session %s not found", name)
+		}
+		return nil, fmt.Errorf("no sessions found in %s", path)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Name < sessions[j].Name })
+	return sessions, nil
+}
+
+// running returns the tenant's sessions by name.
+func (c *SessionCLI) running(ctx context.Context) (map[string]aura.SessionInfo, error) {
+	sessions, err := c.client.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]aura.SessionInfo, len(sessions))
+	for _, s := range sessions {
+		byName[s.Name] = s
+	}
+	return byName, nil
+}
+
+// memory returns the session's Memory, or the size the Aura API recommends
+// for its node and relationship counts.
+func (c *SessionCLI) memory(ctx context.Context, s *aura.Session) (string, error) {
+	if s.Memory != "" {
+		return s.Memory, nil
+	}
+	if s.NodeCount == 0 && s.RelationshipCount == 0 {
+		return DefaultSessionMemory, nil
+	}
+	sizing, err := c.client.EstimateSize(ctx, aura.SizingRequest{
+		NodeCount:         s.NodeCount,
+		RelationshipCount: s.RelationshipCount,
+	})
+	if err != nil {
+		return "", err
+	}
+	return sizing.RecommendedSize, nil
+}
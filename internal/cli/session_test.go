@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/aura"
+)
+
+const sessionSource = `package analytics
+
+import "github.com/lex00/wetwire-neo4j-go/internal/aura"
+
+var Fraud = &aura.Session{
+	Name:              "fraud-analytics",
+	TTLHours:          4,
+	NodeCount:         10000000,
+	RelationshipCount: 50000000,
+	DataSource:        &aura.PandasDataSource{NodeFiles: []string{"accounts.csv"}},
+}
+
+var Recs = &aura.Session{
+	Name:     "recommendations",
+	TTLHours: 1,
+	Memory:   "4GB",
+}
+`
+
+func TestSessionCLI_UpListDown(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "analytics.go"), []byte(sessionSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+	server := aura.NewFakeServer("id", "secret")
+	defer server.Close()
+	sessions := NewSessionCLI(aura.NewClient(server.URL, aura.Credentials{ClientID: "id", ClientSecret: "secret", TenantID: "tenant"}))
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := sessions.Up(ctx, &buf, dir, ""); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	running := server.Sessions()
+	if len(running) != 2 || running[0].Memory != "16GB" || running[1].Memory != "4GB" {
+		t.Fatalf("unexpected sessions: %+v", running)
+	}
+	if !strings.Contains(buf.String(), "fraud-analytics: created sess-") {
+		t.Errorf("unexpected Up output:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := sessions.Up(ctx, &buf, dir, "recommendations"); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "recommendations: already running") || len(server.Sessions()) != 2 {
+		t.Errorf("expected Up to be idempotent, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := sessions.List(ctx, &buf); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	for _, want := range []string{"NAME", "fraud-analytics", "recommendations", "Ready"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected %q in List output:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := sessions.Down(ctx, &buf, dir, "Fraud"); err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+	if running := server.Sessions(); len(running) != 1 || running[0].Name != "recommendations" {
+		t.Errorf("unexpected sessions after Down: %+v", running)
+	}
+
+	if err := sessions.Up(ctx, &buf, dir, "missing"); err == nil || !strings.Contains(err.Error(), "session missing not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
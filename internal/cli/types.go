@@ -42,3 +42,13 @@ type Issue struct {
 	Message  string
 	Rule     string
 }
+
+// EvalOptions contains options for evaluating retrievers.
+type EvalOptions struct {
+	// Golden is the path of the golden question set (JSON).
+	Golden string
+	// Corpus is the path of the in-memory fixture corpus (JSON).
+	Corpus string
+	// K is the cutoff for the metrics (default: each retriever's TopK).
+	K int
+}
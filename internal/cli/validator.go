@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
+	"github.com/lex00/wetwire-neo4j-go/internal/aura"
 	"github.com/lex00/wetwire-neo4j-go/internal/discover"
 	"github.com/lex00/wetwire-neo4j-go/internal/validator"
 )
@@ -118,6 +120,64 @@ func (v *ValidatorCLI) ValidateDryRun(path string, w io.Writer) error {
 	return nil
 }
 
+// ValidateOffline checks the Aura sessions discovered in path against their
+// CSV files without connecting to Neo4j or Aura. Each session is validated,
+// then its PandasDataSource files are checked against its DataFrame
+// projection with aura.Session.CheckFiles. Relative file paths are resolved
+// against the directory of the file defining the session. Data sources that
+// cannot be rebuilt from the literal, such as SQL sources or sources set from
+// a variable, are not checked: only the session settings are validated.
+func (v *ValidatorCLI) ValidateOffline(path string, w io.Writer) error {
+	scanner := discover.NewScanner()
+	resources, err := scanner.ScanDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to scan directory: %w", err)
+	}
+
+	sessions, problems := 0, 0
+	for _, r := range resources {
+		if r.Kind != discover.KindSession {
+			continue
+		}
+		sessions++
+		_, _ = fmt.Fprintf(w, "  [%s] %s (%s:%d)\n", r.Kind, r.Name, r.File, r.Line)
+
+		var errs []error
+		s, err := aura.FromLiteralFields(r.DataSourceType, r.Fields)
+		if err != nil {
+			errs = append(errs, err)
+		} else if s.DataSource == nil {
+			if err := s.ValidateSettings(); err != nil {
+				errs = append(errs, err)
+			}
+			source := "data source"
+			if r.DataSourceType != "" {
+				source = r.DataSourceType
+			}
+			_, _ = fmt.Fprintf(w, "    %s not checked offline\n", source)
+		} else {
+			if err := s.Validate(); err != nil {
+				errs = append(errs, err)
+			}
+			errs = append(errs, s.CheckFiles(filepath.Dir(r.File))...)
+		}
+		for _, err := range errs {
+			_, _ = fmt.Fprintf(w, "    - %v\n", err)
+		}
+		problems += len(errs)
+	}
+
+	if sessions == 0 {
+		_, _ = fmt.Fprintln(w, "No sessions discovered to validate")
+		return nil
+	}
+	if problems > 0 {
+		return fmt.Errorf("%d problems found in %d sessions", problems, sessions)
+	}
+	_, _ = fmt.Fprintf(w, "Validated %d sessions\n", sessions)
+	return nil
+}
+
 // Validator implements Validator for Neo4j configuration validation.
 // It uses environment variables for connection configuration:
 // - NEO4J_URI: Connection URI (e.g., bolt://localhost:7687)
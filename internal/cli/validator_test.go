@@ -76,6 +76,98 @@ var Person = &schema.NodeType{
 	}
 }
 
+func TestValidatorCLI_ValidateOffline(t *testing.T) {
+	tmpDir := t.TempDir()
+	sessionContent := `package analytics
+
+import (
+	"github.com/lex00/wetwire-neo4j-go/internal/aura"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+var Bank = &aura.Session{
+	Name:       "bank",
+	TTLHours:   2,
+	DataSource: &aura.PandasDataSource{NodeFiles: []string{"accounts.csv"}, RelFiles: []string{"transfers.csv"}},
+	Projection: &projections.DataFrameProjection{
+		NodeDataFrames:         []projections.NodeDataFrame{{Label: "Account", IDColumn: "id", Properties: []string{"balance"}}},
+		RelationshipDataFrames: []projections.RelationshipDataFrame{{Type: "TRANSFER", SourceColumn: "from", TargetColumn: "to"}},
+	},
+}
+`
+	files := map[string]string{
+		"analytics.go":  sessionContent,
+		"accounts.csv":  "id,balance\n1,100\n2,250.5\n",
+		"transfers.csv": "from,to\n1,2\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	validator := NewValidatorCLI()
+	var buf bytes.Buffer
+	if err := validator.ValidateOffline(tmpDir, &buf); err != nil {
+		t.Fatalf("ValidateOffline failed: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "Validated 1 sessions") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	// A dangling endpoint is reported and fails validation.
+	if err := os.WriteFile(filepath.Join(tmpDir, "transfers.csv"), []byte("from,to\n1,3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	err := validator.ValidateOffline(tmpDir, &buf)
+	if err == nil || !strings.Contains(err.Error(), "1 problems found") {
+		t.Errorf("expected problems error, got %v", err)
+	}
+	if !strings.Contains(buf.String(), `1 endpoints reference missing nodes, first at transfers.csv:2 to "3"`) {
+		t.Errorf("expected dangling endpoint in output, got: %s", buf.String())
+	}
+}
+
+func TestValidatorCLI_ValidateOffline_SQLSource(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `package analytics
+
+import "github.com/lex00/wetwire-neo4j-go/internal/aura"
+
+var Sales = &aura.Session{
+	Name:     "sales",
+	TTLHours: 2,
+	DataSource: &aura.SnowflakeDataSource{
+		Account:   "acme",
+		Database:  "SALES",
+		NodeQuery: "SELECT id FROM customers",
+	},
+}
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "analytics.go"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	validator := NewValidatorCLI()
+	var buf bytes.Buffer
+	if err := validator.ValidateOffline(tmpDir, &buf); err != nil {
+		t.Fatalf("ValidateOffline failed: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "SnowflakeDataSource not checked offline") || !strings.Contains(buf.String(), "Validated 1 sessions") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	// Session settings are still validated.
+	if err := os.WriteFile(filepath.Join(tmpDir, "analytics.go"), []byte(strings.Replace(content, "TTLHours: 2", "TTLHours: 0", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := validator.ValidateOffline(tmpDir, &buf); err == nil || !strings.Contains(buf.String(), "TTLHours must be positive") {
+		t.Errorf("expected TTLHours problem, got %v\n%s", err, buf.String())
+	}
+}
+
 func TestValidatorCLI_FormatConfig(t *testing.T) {
 	validator := NewValidatorCLI()
 
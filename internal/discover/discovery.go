@@ -41,6 +41,14 @@ const (
 	KindRetriever ResourceKind = "Retriever"
 	// KindSchema represents a Schema definition with AgentContext.
 	KindSchema ResourceKind = "Schema"
+	// KindProjection represents a GDS graph projection or derived subgraph.
+	KindProjection ResourceKind = "Projection"
+	// KindCatalogOperation represents a graph catalog write-back or export step.
+	KindCatalogOperation ResourceKind = "CatalogOperation"
+	// KindWorkflow represents an ordered analytics workflow.
+	KindWorkflow ResourceKind = "Workflow"
+	// KindSession represents an Aura Graph Analytics session.
+	KindSession ResourceKind = "Session"
 )
 
 // PropertyInfo describes a property on a node or relationship type.
@@ -52,12 +60,14 @@ type PropertyInfo struct {
 
 // ConstraintInfo describes a constraint on a node type.
 type ConstraintInfo struct {
+	Name       string   `json:"name,omitempty"`
 	Type       string   `json:"type"`
 	Properties []string `json:"properties"`
 }
 
 // IndexInfo describes an index on a node type.
 type IndexInfo struct {
+	Name       string   `json:"name,omitempty"`
 	Type       string   `json:"type"`
 	Properties []string `json:"properties"`
 }
@@ -89,6 +99,17 @@ type DiscoveredResource struct {
 	Target string `json:"target,omitempty"`
 	// AgentContext contains instructions for AI agents (from Schema.AgentContext).
 	AgentContext string `json:"agentContext,omitempty"`
+	// Steps are the referenced step names of a Workflow, in execution order.
+	Steps []string `json:"steps,omitempty"`
+	// Type is the Go type name of a Retriever literal, e.g. VectorRetriever.
+	Type string `json:"type,omitempty"`
+	// DataSourceType is the Go type name of a Session literal's data source
+	// literal, e.g. PandasDataSource.
+	DataSourceType string `json:"dataSourceType,omitempty"`
+	// Fields are the constant field values of a Retriever or Session literal,
+	// keyed by Go field name. Fields set from variables or function calls are
+	// omitted.
+	Fields map[string]any `json:"fields,omitempty"`
 }
 
 // Scanner discovers resources in Go source files.
@@ -107,32 +128,64 @@ var neo4jTypeAliases = map[string]ResourceKind{
 	"NodeType":         KindNodeType,
 	"RelationshipType": KindRelationshipType,
 	// Algorithm types
-	"PageRank":         KindAlgorithm,
-	"Louvain":          KindAlgorithm,
-	"Leiden":           KindAlgorithm,
-	"LabelPropagation": KindAlgorithm,
-	"WCC":              KindAlgorithm,
-	"Betweenness":      KindAlgorithm,
-	"Closeness":        KindAlgorithm,
-	"Degree":           KindAlgorithm,
-	"ArticleRank":      KindAlgorithm,
-	"KCore":            KindAlgorithm,
-	"TriangleCount":    KindAlgorithm,
-	"NodeSimilarity":   KindAlgorithm,
-	"KNN":              KindAlgorithm,
-	"Dijkstra":         KindAlgorithm,
-	"AStar":            KindAlgorithm,
-	"BellmanFord":      KindAlgorithm,
-	"BFS":              KindAlgorithm,
-	"DFS":              KindAlgorithm,
-	"FastRP":           KindAlgorithm,
-	"GraphSAGE":        KindAlgorithm,
-	"Node2Vec":         KindAlgorithm,
-	"HashGNN":          KindAlgorithm,
+	"PageRank":                   KindAlgorithm,
+	"Louvain":                    KindAlgorithm,
+	"Leiden":                     KindAlgorithm,
+	"LabelPropagation":           KindAlgorithm,
+	"WCC":                        KindAlgorithm,
+	"Betweenness":                KindAlgorithm,
+	"Closeness":                  KindAlgorithm,
+	"Degree":                     KindAlgorithm,
+	"ArticleRank":                KindAlgorithm,
+	"Eigenvector":                KindAlgorithm,
+	"HarmonicCentrality":         KindAlgorithm,
+	"HITS":                       KindAlgorithm,
+	"CELF":                       KindAlgorithm,
+	"KCore":                      KindAlgorithm,
+	"TriangleCount":              KindAlgorithm,
+	"SCC":                        KindAlgorithm,
+	"ModularityOptimization":     KindAlgorithm,
+	"SLPA":                       KindAlgorithm,
+	"KMeans":                     KindAlgorithm,
+	"K1Coloring":                 KindAlgorithm,
+	"LocalClusteringCoefficient": KindAlgorithm,
+	"Conductance":                KindAlgorithm,
+	"Modularity":                 KindAlgorithm,
+	"NodeSimilarity":             KindAlgorithm,
+	"KNN":                        KindAlgorithm,
+	"Dijkstra":                   KindAlgorithm,
+	"AStar":                      KindAlgorithm,
+	"BellmanFord":                KindAlgorithm,
+	"Yens":                       KindAlgorithm,
+	"DeltaStepping":              KindAlgorithm,
+	"AllShortestPaths":           KindAlgorithm,
+	"MinimumSpanningTree":        KindAlgorithm,
+	"SteinerTree":                KindAlgorithm,
+	"RandomWalk":                 KindAlgorithm,
+	"LongestPath":                KindAlgorithm,
+	"BFS":                        KindAlgorithm,
+	"DFS":                        KindAlgorithm,
+	"FastRP":                     KindAlgorithm,
+	"GraphSAGE":                  KindAlgorithm,
+	"Node2Vec":                   KindAlgorithm,
+	"HashGNN":                    KindAlgorithm,
 	// Pipeline types
 	"NodeClassificationPipeline": KindPipeline,
 	"LinkPredictionPipeline":     KindPipeline,
 	"NodeRegressionPipeline":     KindPipeline,
+	// Projection types
+	"NativeProjection":    KindProjection,
+	"CypherProjection":    KindProjection,
+	"DataFrameProjection": KindProjection,
+	"FilteredGraph":       KindProjection,
+	"SampledGraph":        KindProjection,
+	// Catalog operation types
+	"NodePropertiesWrite": KindCatalogOperation,
+	"RelationshipWrite":   KindCatalogOperation,
+	"NodeLabelMutate":     KindCatalogOperation,
+	"GraphExport":         KindCatalogOperation,
+	// Workflow types
+	"Workflow": KindWorkflow,
 	// Retriever types
 	"VectorRetriever":        KindRetriever,
 	"VectorCypherRetriever":  KindRetriever,
@@ -142,6 +195,9 @@ var neo4jTypeAliases = map[string]ResourceKind{
 	"WeaviateNeo4jRetriever": KindRetriever,
 	"PineconeNeo4jRetriever": KindRetriever,
 	"QdrantNeo4jRetriever":   KindRetriever,
+	"WeaviateRetriever":      KindRetriever,
+	"PineconeRetriever":      KindRetriever,
+	"QdrantRetriever":        KindRetriever,
 }
 
 // Neo4jTypeMatcher returns a corediscover.TypeMatcher for Neo4j resource types.
@@ -159,6 +215,8 @@ func Neo4jTypeMatcher() corediscover.TypeMatcher {
 					!strings.Contains(importPath, "schema") &&
 					!strings.Contains(importPath, "algorithms") &&
 					!strings.Contains(importPath, "pipelines") &&
+					!strings.Contains(importPath, "projections") &&
+					!strings.Contains(importPath, "workflows") &&
 					!strings.Contains(importPath, "retrievers") {
 					return "", false
 				}
@@ -308,6 +366,20 @@ func (s *Scanner) ScanFile(filename string) ([]DiscoveredResource, error) {
 				if kind == KindSchema {
 					res.AgentContext = s.extractAgentContext(compLit)
 				}
+				// Extract step order for Workflow
+				if kind == KindWorkflow {
+					res.Steps = s.extractWorkflowSteps(compLit)
+				}
+				// Extract literal configuration for Retriever
+				if kind == KindRetriever {
+					res.Type, _ = coreast.ExtractTypeName(compLit.Type)
+					res.Fields = s.extractLiteralFields(compLit)
+				}
+				// Extract literal configuration for Session
+				if kind == KindSession {
+					res.DataSourceType = s.extractFieldTypeName(compLit, "DataSource")
+					res.Fields = s.extractLiteralFields(compLit)
+				}
 
 				resources = append(resources, res)
 			}
@@ -375,9 +447,13 @@ func (s *Scanner) detectResourceKind(structType *ast.StructType) ResourceKind {
 func (s *Scanner) detectCompositeLitKind(lit *ast.CompositeLit) ResourceKind {
 	// Use coreast.ExtractTypeName which returns (typeName, pkgName).
 	// It unwraps pointers and selectors, returning just the base type name.
-	typeName, _ := coreast.ExtractTypeName(lit.Type)
+	typeName, pkgName := coreast.ExtractTypeName(lit.Type)
 	if kind, ok := s.typeAliases[typeName]; ok {
 		return kind
 	}
+	// Session is too common a name to match without its package.
+	if typeName == "Session" && pkgName == "aura" {
+		return KindSession
+	}
 	return ""
 }
@@ -260,3 +260,126 @@ var worksFor = &RelationshipType{
 		t.Fatalf("expected 2 resources, got %d", len(resources))
 	}
 }
+
+func TestScanner_ScanFile_CatalogOperationDependsOnProjection(t *testing.T) {
+	content := `package main
+
+import "github.com/lex00/wetwire-neo4j-go/internal/projections"
+
+var SocialGraph = &projections.NativeProjection{
+	BaseProjection: projections.BaseProjection{Name: "social"},
+	NodeLabels:     []string{"Person"},
+}
+
+var Adults = &projections.FilteredGraph{
+	BaseProjection: projections.BaseProjection{Name: "adults"},
+	Parent:         SocialGraph,
+	NodeFilter:     "n.age > 18",
+}
+
+var WriteScores = &projections.NodePropertiesWrite{
+	BaseOperation:  projections.BaseOperation{Name: "write_scores", Graph: Adults},
+	NodeProperties: []string{"pagerank"},
+}
+`
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "catalog.go")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	s := NewScanner()
+	resources, err := s.ScanFile(tmpFile)
+	if err != nil {
+		t.Fatalf("ScanFile failed: %v", err)
+	}
+
+	kinds := make(map[string]ResourceKind)
+	for _, r := range resources {
+		kinds[r.Name] = r.Kind
+	}
+	if kinds["SocialGraph"] != KindProjection || kinds["Adults"] != KindProjection {
+		t.Errorf("expected projections to be discovered, got %v", kinds)
+	}
+	if kinds["WriteScores"] != KindCatalogOperation {
+		t.Errorf("expected WriteScores to be a CatalogOperation, got %v", kinds["WriteScores"])
+	}
+
+	g := NewDependencyGraph(resources)
+	deps := g.GetDependencies("WriteScores")
+	if len(deps) != 2 || deps[0] != "Adults" || deps[1] != "SocialGraph" {
+		t.Errorf("WriteScores dependencies = %v, want [Adults SocialGraph]", deps)
+	}
+
+	sorted, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort failed: %v", err)
+	}
+	if sorted[0].Name != "SocialGraph" || sorted[2].Name != "WriteScores" {
+		t.Errorf("unexpected build order: %v", sorted)
+	}
+}
+
+func TestScanner_ScanFile_WorkflowSteps(t *testing.T) {
+	content := `package main
+
+import (
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+	"github.com/lex00/wetwire-neo4j-go/internal/workflows"
+)
+
+var CustomerGraph = &projections.NativeProjection{
+	BaseProjection: projections.BaseProjection{Name: "customers"},
+}
+
+var Embeddings = &algorithms.FastRP{
+	BaseAlgorithm: algorithms.BaseAlgorithm{Name: "embeddings", GraphName: "customers"},
+}
+
+var Segments = &algorithms.Louvain{
+	BaseAlgorithm: algorithms.BaseAlgorithm{Name: "segments", GraphName: "customers"},
+}
+
+var CustomerSegments = &workflows.Workflow{
+	Name:       "customer_segments",
+	Projection: CustomerGraph,
+	Steps: []workflows.Step{
+		{Algorithm: Embeddings},
+		{Algorithm: Segments},
+	},
+}
+`
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "workflow.go")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	s := NewScanner()
+	resources, err := s.ScanFile(tmpFile)
+	if err != nil {
+		t.Fatalf("ScanFile failed: %v", err)
+	}
+
+	var workflow *DiscoveredResource
+	for i := range resources {
+		if resources[i].Name == "CustomerSegments" {
+			workflow = &resources[i]
+		}
+	}
+	if workflow == nil {
+		t.Fatalf("expected CustomerSegments to be discovered, got %v", resources)
+	}
+	if workflow.Kind != KindWorkflow {
+		t.Errorf("Kind = %v, want Workflow", workflow.Kind)
+	}
+	if len(workflow.Steps) != 2 || workflow.Steps[0] != "Embeddings" || workflow.Steps[1] != "Segments" {
+		t.Errorf("Steps = %v, want [Embeddings Segments]", workflow.Steps)
+	}
+
+	deps := NewDependencyGraph(resources).GetDependencies("CustomerSegments")
+	if len(deps) != 3 {
+		t.Errorf("CustomerSegments dependencies = %v, want projection and both steps", deps)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"go/ast"
 	"go/token"
 	"sort"
+	"strconv"
 	"strings"
 
 	coreast "github.com/lex00/wetwire-core-go/ast"
@@ -92,6 +93,31 @@ func (s *Scanner) extractStringField(lit *ast.CompositeLit, fieldName string) st
 	return ""
 }
 
+// extractFieldTypeName returns the type name of the composite literal, or
+// pointer to one, assigned to a field of lit. Returns empty string if the
+// field is not set to a composite literal.
+func (s *Scanner) extractFieldTypeName(lit *ast.CompositeLit, fieldName string) string {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		keyIdent, ok := kv.Key.(*ast.Ident)
+		if !ok || keyIdent.Name != fieldName {
+			continue
+		}
+		value := kv.Value
+		if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			value = unary.X
+		}
+		if compLit, ok := value.(*ast.CompositeLit); ok {
+			name, _ := coreast.ExtractTypeName(compLit.Type)
+			return name
+		}
+	}
+	return ""
+}
+
 // extractProperties extracts property definitions from a composite literal.
 func (s *Scanner) extractProperties(lit *ast.CompositeLit) []PropertyInfo {
 	var props []PropertyInfo
@@ -207,6 +233,8 @@ func (s *Scanner) extractConstraints(lit *ast.CompositeLit) []ConstraintInfo {
 				}
 
 				switch cKey.Name {
+				case "Name":
+					c.Name, _ = s.stringValue(cKV.Value)
 				case "Type":
 					c.Type = s.extractTypeConstant(cKV.Value)
 				case "Properties":
@@ -262,6 +290,8 @@ func (s *Scanner) extractIndexes(lit *ast.CompositeLit) []IndexInfo {
 				}
 
 				switch iKey.Name {
+				case "Name":
+					idx.Name, _ = s.stringValue(iKV.Value)
 				case "Type":
 					idx.Type = s.extractTypeConstant(iKV.Value)
 				case "Properties":
@@ -335,6 +365,150 @@ func (s *Scanner) extractAgentContext(lit *ast.CompositeLit) string {
 	return ""
 }
 
+// extractLiteralFields extracts the constant keyed fields of a composite literal.
+// Nested keyed literals, including map literals with string keys, become maps
+// and other composite literals become slices.
+func (s *Scanner) extractLiteralFields(lit *ast.CompositeLit) map[string]any {
+	fields := make(map[string]any)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		var key string
+		switch k := kv.Key.(type) {
+		case *ast.Ident:
+			key = k.Name
+		case *ast.BasicLit:
+			// String keys of map literals, e.g. map[string][]string.
+			if k.Kind != token.STRING {
+				continue
+			}
+			var err error
+			if key, err = strconv.Unquote(k.Value); err != nil {
+				continue
+			}
+		default:
+			continue
+		}
+		if v, ok := s.literalValue(kv.Value); ok {
+			fields[key] = v
+		}
+	}
+	return fields
+}
+
+// stringValue returns the value of a string literal.
+func (s *Scanner) stringValue(expr ast.Expr) (string, bool) {
+	v, ok := s.literalValue(expr)
+	str, isString := v.(string)
+	return str, ok && isString
+}
+
+// literalValue evaluates a constant expression: basic literals, true/false,
+// negated numbers and composite literals of those.
+func (s *Scanner) literalValue(expr ast.Expr) (any, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			v, err := strconv.Unquote(e.Value)
+			return v, err == nil
+		case token.INT:
+			v, err := strconv.ParseInt(e.Value, 0, 64)
+			return v, err == nil
+		case token.FLOAT:
+			v, err := strconv.ParseFloat(e.Value, 64)
+			return v, err == nil
+		}
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case token.AND:
+			return s.literalValue(e.X)
+		case token.SUB:
+			v, ok := s.literalValue(e.X)
+			switch n := v.(type) {
+			case int64:
+				return -n, ok
+			case float64:
+				return -n, ok
+			}
+		}
+	case *ast.CompositeLit:
+		if len(e.Elts) > 0 {
+			if _, keyed := e.Elts[0].(*ast.KeyValueExpr); keyed {
+				return s.extractLiteralFields(e), true
+			}
+		}
+		var values []any
+		for _, elt := range e.Elts {
+			if v, ok := s.literalValue(elt); ok {
+				values = append(values, v)
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// extractWorkflowSteps extracts the referenced step names from a Workflow composite literal.
+// Each step is a Step literal whose Algorithm or Operation field references a variable.
+func (s *Scanner) extractWorkflowSteps(lit *ast.CompositeLit) []string {
+	var steps []string
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		keyIdent, ok := kv.Key.(*ast.Ident)
+		if !ok || keyIdent.Name != "Steps" {
+			continue
+		}
+
+		stepsLit, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+
+		for _, stepElt := range stepsLit.Elts {
+			stepLit, ok := stepElt.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+
+			for _, field := range stepLit.Elts {
+				fieldKV, ok := field.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+
+				fieldKey, ok := fieldKV.Key.(*ast.Ident)
+				if !ok || (fieldKey.Name != "Algorithm" && fieldKey.Name != "Operation") {
+					continue
+				}
+
+				switch v := fieldKV.Value.(type) {
+				case *ast.Ident:
+					steps = append(steps, v.Name)
+				case *ast.SelectorExpr:
+					steps = append(steps, v.Sel.Name)
+				}
+			}
+		}
+	}
+
+	return steps
+}
+
 // walkExprForDeps walks an expression tree looking for identifier references.
 func (s *Scanner) walkExprForDeps(expr ast.Expr, deps *[]string, seen map[string]bool) {
 	if expr == nil {
@@ -401,8 +575,10 @@ func isValidIdentifier(name string) bool {
 
 // LintableNodeType is a wrapper that converts DiscoveredResource to schema.NodeType for linting.
 type LintableNodeType struct {
-	Label      string
-	Properties []PropertyInfo
+	Label       string
+	Properties  []PropertyInfo
+	Constraints []ConstraintInfo
+	Indexes     []IndexInfo
 }
 
 // ToSchemaNodeType converts to a schema.NodeType suitable for linting.
@@ -416,9 +592,24 @@ func (l *LintableNodeType) ToSchemaNodeType() *schema.NodeType {
 			Required: p.Required,
 		}
 	}
+	constraints := make([]schema.Constraint, len(l.Constraints))
+	for i, c := range l.Constraints {
+		constraints[i] = schema.Constraint{Name: c.Name, Type: schema.ConstraintType(c.Type), Properties: c.Properties}
+	}
+	indexes := make([]schema.Index, len(l.Indexes))
+	for i, idx := range l.Indexes {
+		// Index types are discovered by constant name: POINT_INDEX is "POINT".
+		typ := schema.IndexType(idx.Type)
+		if idx.Type == "POINT_INDEX" {
+			typ = schema.POINT_INDEX
+		}
+		indexes[i] = schema.Index{Name: idx.Name, Type: typ, Properties: idx.Properties}
+	}
 	return &schema.NodeType{
-		Label:      l.Label,
-		Properties: props,
+		Label:       l.Label,
+		Properties:  props,
+		Constraints: constraints,
+		Indexes:     indexes,
 	}
 }
 
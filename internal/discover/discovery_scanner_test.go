@@ -197,6 +197,51 @@ type HybridSearch struct {
 	}
 }
 
+func TestScanner_ScanFile_RetrieverFields(t *testing.T) {
+	content := "package rag\n\n" +
+		"import \"github.com/lex00/wetwire-neo4j-go/internal/retrievers\"\n\n" +
+		"var ChunkSearch = &retrievers.VectorCypherRetriever{\n" +
+		"\tBaseRetriever: retrievers.BaseRetriever{Name: \"chunks\"},\n" +
+		"\tTopK:          3,\n" +
+		"\tIndexName:     \"chunk_embeddings\",\n" +
+		"\tScoreThreshold: 0.7,\n" +
+		"\tRetrievalQuery: `MATCH (node)<-[:HAS_CHUNK]-(d) RETURN d.title AS title`,\n" +
+		"\tEmbedderConfig: &retrievers.EmbedderConfig{Provider: \"openai\"},\n" +
+		"}\n"
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "rag.go")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	resources, err := NewScanner().ScanFile(tmpFile)
+	if err != nil {
+		t.Fatalf("ScanFile failed: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resources))
+	}
+
+	r := resources[0]
+	if r.Type != "VectorCypherRetriever" {
+		t.Errorf("expected type VectorCypherRetriever, got %q", r.Type)
+	}
+	if r.Fields["IndexName"] != "chunk_embeddings" || r.Fields["ScoreThreshold"] != 0.7 {
+		t.Errorf("unexpected fields: %v", r.Fields)
+	}
+	if r.Fields["RetrievalQuery"] != "MATCH (node)<-[:HAS_CHUNK]-(d) RETURN d.title AS title" {
+		t.Errorf("unexpected retrieval query: %v", r.Fields["RetrievalQuery"])
+	}
+	base, _ := r.Fields["BaseRetriever"].(map[string]any)
+	if base["Name"] != "chunks" || r.Fields["TopK"] != int64(3) {
+		t.Errorf("unexpected base fields: %v", r.Fields["BaseRetriever"])
+	}
+	embedder, _ := r.Fields["EmbedderConfig"].(map[string]any)
+	if embedder["Provider"] != "openai" {
+		t.Errorf("unexpected embedder config: %v", r.Fields["EmbedderConfig"])
+	}
+}
+
 func TestScanner_ScanFile_NoResources(t *testing.T) {
 	content := `package main
 
@@ -488,3 +533,34 @@ type IndexedNodes struct {
 		t.Fatalf("expected 1 resource, got %d", len(resources))
 	}
 }
+
+func TestScanner_ScanFile_Session(t *testing.T) {
+	content := "package analytics\n\n" +
+		"import \"github.com/lex00/wetwire-neo4j-go/internal/aura\"\n\n" +
+		"var Fraud = &aura.Session{Name: \"fraud\", TTLHours: 4, Memory: \"8GB\",\n" +
+		"\tDataSource: &aura.PandasDataSource{NodeFilesByLabel: map[string][]string{\"Account\": {\"accounts.csv\"}}}}\n\n" +
+		"var Other = &Session{Name: \"not-aura\"}\n"
+	tmpFile := filepath.Join(t.TempDir(), "analytics.go")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+
+	resources, err := NewScanner().ScanFile(tmpFile)
+	if err != nil {
+		t.Fatalf("ScanFile failed: %v", err)
+	}
+	if len(resources) != 1 || resources[0].Kind != KindSession {
+		t.Fatalf("expected one Session, got %+v", resources)
+	}
+	if r := resources[0]; r.Name != "Fraud" || r.Fields["Name"] != "fraud" || r.Fields["TTLHours"] != int64(4) {
+		t.Errorf("unexpected session resource: %+v", r)
+	}
+	if r := resources[0]; r.DataSourceType != "PandasDataSource" {
+		t.Errorf("DataSourceType = %q, want PandasDataSource", r.DataSourceType)
+	}
+	ds, _ := resources[0].Fields["DataSource"].(map[string]any)
+	byLabel, _ := ds["NodeFilesByLabel"].(map[string]any)
+	if files, _ := byLabel["Account"].([]any); len(files) != 1 || files[0] != "accounts.csv" {
+		t.Errorf("expected map literal keys in fields, got %+v", ds)
+	}
+}
@@ -0,0 +1,71 @@
+package estimation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// byteUnits are the suffixes ParseBytes accepts. Decimal and binary
+// suffixes both use powers of 1024, like Aura memory sizes.
+var byteUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"TB", 1 << 40},
+	{"B", 1},
+}
+
+// ParseBytes parses a memory size such as "16GB", "1.5GiB" or "512MB".
+// A number without a unit is a byte count.
+func ParseBytes(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+	unit := int64(1)
+	for _, u := range byteUnits {
+		if len(value) > len(u.suffix) && strings.EqualFold(value[len(value)-len(u.suffix):], u.suffix) {
+			value = strings.TrimSpace(value[:len(value)-len(u.suffix)])
+			unit = u.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid memory size %q", s)
+	}
+	return int64(n * float64(unit)), nil
+}
+
+// BudgetFromEnv reads a memory budget from $GDS_MEMORY_BUDGET, such as
+// "16GB", and the declared graph size from the JSON file named by
+// $GDS_GRAPH_SIZE:
+//
+//	{"nodeCounts": {"Customer": 2000000}, "relationshipCounts": {"BOUGHT": 30000000}}
+//
+// The budget is 0 when $GDS_MEMORY_BUDGET is unset.
+func BudgetFromEnv() (int64, GraphSize, error) {
+	value := os.Getenv("GDS_MEMORY_BUDGET")
+	if value == "" {
+		return 0, GraphSize{}, nil
+	}
+	budget, err := ParseBytes(value)
+	if err != nil {
+		return 0, GraphSize{}, fmt.Errorf("GDS_MEMORY_BUDGET: %w", err)
+	}
+
+	path := os.Getenv("GDS_GRAPH_SIZE")
+	if path == "" {
+		return 0, GraphSize{}, fmt.Errorf("GDS_MEMORY_BUDGET requires GDS_GRAPH_SIZE, the graph size file")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, GraphSize{}, fmt.Errorf("GDS_GRAPH_SIZE: %w", err)
+	}
+	var size GraphSize
+	if err := json.Unmarshal(data, &size); err != nil {
+		return 0, GraphSize{}, fmt.Errorf("GDS_GRAPH_SIZE: %s: %w", path, err)
+	}
+	return budget, size, nil
+}
@@ -0,0 +1,83 @@
+package estimation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"16GB", 16 << 30, false},
+		{"16GiB", 16 << 30, false},
+		{"1.5gb", 3 << 29, false},
+		{"512 MB", 512 << 20, false},
+		{"2048", 2048, false},
+		{"GB", 0, true},
+		{"-1GB", 0, true},
+		{"lots", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBytes(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseBytes(%q) = %d, %v, want %d (error %v)", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestBudgetFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	sizeFile := filepath.Join(dir, "size.json")
+	if err := os.WriteFile(sizeFile, []byte(`{"nodeCounts": {"Customer": 1000}, "relationshipCounts": {"BOUGHT": 10000}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	badFile := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(badFile, []byte(`{"nodeCounts": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		budget     string
+		size       string
+		wantBudget int64
+		wantErr    string
+	}{
+		{name: "unset"},
+		{name: "budget and size", budget: "8GB", size: sizeFile, wantBudget: 8 << 30},
+		{name: "invalid budget", budget: "eight", size: sizeFile, wantErr: "GDS_MEMORY_BUDGET: invalid memory size"},
+		{name: "missing size", budget: "8GB", wantErr: "requires GDS_GRAPH_SIZE"},
+		{name: "missing size file", budget: "8GB", size: filepath.Join(dir, "none.json"), wantErr: "GDS_GRAPH_SIZE"},
+		{name: "invalid size file", budget: "8GB", size: badFile, wantErr: "bad.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GDS_MEMORY_BUDGET", tt.budget)
+			t.Setenv("GDS_GRAPH_SIZE", tt.size)
+
+			budget, size, err := BudgetFromEnv()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("BudgetFromEnv() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BudgetFromEnv() error = %v", err)
+			}
+			if budget != tt.wantBudget {
+				t.Errorf("budget = %d, want %d", budget, tt.wantBudget)
+			}
+			if tt.wantBudget > 0 && (size.NodeCounts["Customer"] != 1000 || size.RelationshipCounts["BOUGHT"] != 10000) {
+				t.Errorf("unexpected size: %+v", size)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+// Package estimation provides offline heap estimates for GDS projections and algorithms.
+//
+// GDS estimates memory with its .estimate procedures, but only against a running
+// database. This package applies approximations of the GDS memory formulas to a
+// declared graph size, so heap budgets can be checked before anything is deployed.
+// Use AlgorithmSerializer.ToEstimateCypher and ProjectionSerializer.ToEstimateCypher
+// for exact figures from a live database.
+//
+// Example usage:
+//
+//	size := estimation.GraphSize{
+//		NodeCounts:         map[string]int64{"Customer": 2000000},
+//		RelationshipCounts: map[string]int64{"BOUGHT": 30000000},
+//	}
+//	graph := estimation.EstimateProjection(CustomerGraph, size)
+//	algo := estimation.AlgorithmMemory(PageRank, graph.NodeCount, graph.RelationshipCount)
+//	fmt.Println(graph.Memory.Add(algo)) // [181 MiB ... 550 MiB]
+package estimation
+
+import (
+	"fmt"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+// Approximate per-element costs of the GDS in-memory graph, in bytes.
+const (
+	// nodeBytesMin and nodeBytesMax cover the id map for dense and sparse node ids.
+	nodeBytesMin = 16
+	nodeBytesMax = 40
+	// relBytesMin and relBytesMax cover compressed and uncompressed adjacency lists.
+	relBytesMin = 2
+	relBytesMax = 12
+	// offsetBytes is the adjacency offset stored per node and relationship type.
+	offsetBytes = 8
+	// propertyBytes is the cost of one double or long property value.
+	propertyBytes = 8
+)
+
+// Algorithm defaults used when a configuration leaves a size parameter unset.
+const (
+	defaultConcurrency        = 4
+	defaultTopK               = 10
+	defaultEmbeddingDimension = 128
+	defaultSageDimension      = 64
+	defaultSageLayers         = 2
+	defaultWalksPerNode       = 10
+	defaultWalkLength         = 80
+	defaultMaxLevels          = 10
+	defaultSamplingRatio      = 0.15
+)
+
+// GraphSize declares the expected size of the database by label and relationship type.
+type GraphSize struct {
+	// NodeCounts maps node labels to node counts.
+	NodeCounts map[string]int64 `json:"nodeCounts"`
+	// RelationshipCounts maps relationship types to relationship counts.
+	RelationshipCounts map[string]int64 `json:"relationshipCounts"`
+}
+
+// Nodes returns the number of nodes with any of the given labels.
+// No labels or the '*' wildcard count every declared node.
+func (s GraphSize) Nodes(labels []string) int64 {
+	return sumCounts(s.NodeCounts, labels)
+}
+
+// Relationships returns the number of relationships of any of the given types.
+// No types or the '*' wildcard count every declared relationship.
+func (s GraphSize) Relationships(types []string) int64 {
+	return sumCounts(s.RelationshipCounts, types)
+}
+
+func sumCounts(counts map[string]int64, keys []string) int64 {
+	var total int64
+	for _, k := range keys {
+		if k == "*" {
+			keys = nil
+			break
+		}
+	}
+	if len(keys) == 0 {
+		for _, c := range counts {
+			total += c
+		}
+		return total
+	}
+	for _, k := range keys {
+		total += counts[k]
+	}
+	return total
+}
+
+// Estimate is a heap estimate range in bytes.
+type Estimate struct {
+	// BytesMin is the lower bound of the estimate.
+	BytesMin int64
+	// BytesMax is the upper bound of the estimate.
+	BytesMax int64
+}
+
+// Add returns the sum of two estimates.
+func (e Estimate) Add(other Estimate) Estimate {
+	return Estimate{BytesMin: e.BytesMin + other.BytesMin, BytesMax: e.BytesMax + other.BytesMax}
+}
+
+// String formats the estimate like the GDS requiredMemory column.
+func (e Estimate) String() string {
+	if e.BytesMin == e.BytesMax {
+		return FormatBytes(e.BytesMax)
+	}
+	return fmt.Sprintf("[%s ... %s]", FormatBytes(e.BytesMin), FormatBytes(e.BytesMax))
+}
+
+// fixed returns an estimate with equal bounds.
+func fixed(bytes int64) Estimate {
+	return Estimate{BytesMin: bytes, BytesMax: bytes}
+}
+
+// intermediateCommunities returns the cost of keeping one community id per
+// node and level, or nothing when intermediate communities are not kept.
+func intermediateCommunities(include bool, maxLevels int, nodes int64) Estimate {
+	if !include {
+		return Estimate{}
+	}
+	levels := int64(maxLevels)
+	if levels <= 0 {
+		levels = defaultMaxLevels
+	}
+	return fixed(8 * nodes * levels)
+}
+
+// FormatBytes formats a byte count with binary units, e.g. "1.5 GiB".
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d Bytes", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit && exp < 3; n /= unit {
+		div *= unit
+		exp++
+	}
+	value := float64(bytes) / float64(div)
+	suffix := []string{"KiB", "MiB", "GiB", "TiB"}[exp]
+	if value >= 100 {
+		return fmt.Sprintf("%.0f %s", value, suffix)
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// GraphEstimate is the estimated size and heap of a projected graph.
+type GraphEstimate struct {
+	// NodeCount is the number of projected nodes.
+	NodeCount int64
+	// RelationshipCount is the number of projected relationships, counting
+	// undirected relationships twice.
+	RelationshipCount int64
+	// Memory is the estimated heap of the graph.
+	Memory Estimate
+}
+
+// EstimateProjection estimates a projection against a declared graph size.
+// Cypher projections cannot be analysed statically and count the whole declared
+// graph without properties. Filtered graphs are bounded by their parent; sampled
+// graphs scale the parent by the sampling ratio.
+func EstimateProjection(p projections.Projection, size GraphSize) GraphEstimate {
+	if sampled, ok := p.(*projections.SampledGraph); ok {
+		if sampled.Parent == nil {
+			return GraphEstimate{}
+		}
+		ratio := sampled.SamplingRatio
+		if ratio <= 0 {
+			ratio = defaultSamplingRatio
+		}
+		parent := EstimateProjection(sampled.Parent, size)
+		return GraphEstimate{
+			NodeCount:         int64(float64(parent.NodeCount) * ratio),
+			RelationshipCount: int64(float64(parent.RelationshipCount) * ratio),
+			Memory: Estimate{
+				BytesMin: int64(float64(parent.Memory.BytesMin) * ratio),
+				BytesMax: int64(float64(parent.Memory.BytesMax) * ratio),
+			},
+		}
+	}
+
+	var est GraphEstimate
+
+	nodeProjections := p.GetNodeProjections()
+	if len(nodeProjections) == 0 {
+		est.NodeCount = size.Nodes(nil)
+	}
+	var propertyMemory int64
+	for _, np := range nodeProjections {
+		count := size.Nodes([]string{np.Label})
+		est.NodeCount += count
+		propertyMemory += count * propertyBytes * int64(len(np.Properties))
+	}
+
+	relProjections := p.GetRelationshipProjections()
+	if len(relProjections) == 0 {
+		relProjections = []projections.RelationshipProjection{{Type: "*"}}
+	}
+	for _, rp := range relProjections {
+		count := size.Relationships([]string{rp.Type})
+		if rp.Orientation == projections.Undirected {
+			count *= 2
+		}
+		est.RelationshipCount += count
+		propertyMemory += count * propertyBytes * int64(len(rp.Properties))
+	}
+
+	offsets := est.NodeCount * offsetBytes * int64(len(relProjections))
+	est.Memory = Estimate{
+		BytesMin: est.NodeCount*nodeBytesMin + est.RelationshipCount*relBytesMin + offsets + propertyMemory,
+		BytesMax: est.NodeCount*nodeBytesMax + est.RelationshipCount*relBytesMax + offsets + propertyMemory,
+	}
+
+	return est
+}
+
+// AlgorithmMemory estimates the working heap of an algorithm run on a graph with
+// the given node and relationship counts. The graph itself is not included.
+// Algorithms without a specific formula use a per-node default for their category.
+func AlgorithmMemory(algo algorithms.Algorithm, nodes, rels int64) Estimate {
+	concurrency := int64(algo.GetConcurrency())
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	switch a := algo.(type) {
+	case *algorithms.PageRank, *algorithms.ArticleRank, *algorithms.Eigenvector:
+		// scores, deltas and next-iteration scores
+		return fixed(3 * 8 * nodes)
+	case *algorithms.HITS:
+		// hub and authority scores, each with a next-iteration copy
+		return fixed(4 * 8 * nodes)
+	case *algorithms.Degree, *algorithms.WCC, *algorithms.TriangleCount, *algorithms.SCC:
+		return fixed(8 * nodes)
+	case *algorithms.LabelPropagation, *algorithms.KCore, *algorithms.K1Coloring,
+		*algorithms.LocalClusteringCoefficient:
+		return fixed(16 * nodes)
+	case *algorithms.Closeness:
+		// result array plus per-thread multi-source BFS state
+		return fixed(8*nodes + concurrency*16*nodes)
+	case *algorithms.Betweenness:
+		// per-thread Brandes state; predecessor lists grow with relationships
+		base := 8*nodes + concurrency*28*nodes
+		return Estimate{BytesMin: base, BytesMax: base + concurrency*8*rels}
+	case *algorithms.Louvain:
+		// community, volume and delta arrays; each level aggregates the graph
+		base := 32 * nodes
+		est := Estimate{BytesMin: base, BytesMax: base + relBytesMax*rels}
+		return est.Add(intermediateCommunities(a.IncludeIntermediateCommunities, a.MaxLevels, nodes))
+	case *algorithms.Leiden:
+		base := 40 * nodes
+		est := Estimate{BytesMin: base, BytesMax: base + relBytesMax*rels}
+		return est.Add(intermediateCommunities(a.IncludeIntermediateCommunities, a.MaxLevels, nodes))
+	case *algorithms.NodeSimilarity:
+		topK := int64(a.TopK)
+		if topK <= 0 {
+			topK = defaultTopK
+		}
+		// top-k heaps per node plus a copy of the neighbour vectors
+		base := nodes * topK * 16
+		return Estimate{BytesMin: base, BytesMax: base + 8*rels}
+	case *algorithms.KNN:
+		k := int64(a.K)
+		if k <= 0 {
+			k = defaultTopK
+		}
+		// old and new neighbour lists of (node, similarity) pairs
+		return fixed(2 * nodes * k * 16)
+	case *algorithms.FastRP:
+		// float embeddings: current, previous and property vectors
+		return fixed(3 * 4 * embeddingDimension(a.EmbeddingDimension, defaultEmbeddingDimension) * nodes)
+	case *algorithms.Node2Vec:
+		dim := embeddingDimension(a.EmbeddingDimension, defaultEmbeddingDimension)
+		walks, length := int64(a.WalksPerNode), int64(a.WalkLength)
+		if walks <= 0 {
+			walks = defaultWalksPerNode
+		}
+		if length <= 0 {
+			length = defaultWalkLength
+		}
+		// center and context embeddings plus the stored walks
+		return fixed(2*4*dim*nodes + nodes*walks*length*8)
+	case *algorithms.GraphSAGE:
+		dim := embeddingDimension(a.EmbeddingDimension, defaultSageDimension)
+		layers := int64(len(a.SampleSizes))
+		if layers == 0 {
+			layers = defaultSageLayers
+		}
+		return fixed(8 * dim * nodes * (layers + 1))
+	case *algorithms.Dijkstra, *algorithms.AStar:
+		// costs, predecessors and priority queue; paths add at most one entry per node
+		return Estimate{BytesMin: 24 * nodes, BytesMax: 32 * nodes}
+	case *algorithms.BFS, *algorithms.DFS:
+		return fixed(16 * nodes)
+	case *algorithms.Yens:
+		k := int64(a.K)
+		if k <= 0 {
+			k = 1
+		}
+		// one Dijkstra state per candidate path
+		return Estimate{BytesMin: 24 * nodes, BytesMax: k * 32 * nodes}
+	case *algorithms.AllShortestPaths:
+		// one single-source state per thread
+		return fixed(concurrency * 24 * nodes)
+	case *algorithms.RandomWalk:
+		length, buffer := int64(a.WalkLength), int64(a.WalkBufferSize)
+		if length <= 0 {
+			length = defaultWalkLength
+		}
+		if buffer <= 0 {
+			buffer = 1000
+		}
+		// buffered walks per thread; walks are streamed, not stored
+		return fixed(concurrency * buffer * length * 8)
+	}
+
+	switch algo.AlgorithmCategory() {
+	case algorithms.Community, algorithms.PathFinding:
+		return fixed(24 * nodes)
+	case algorithms.Similarity:
+		return fixed(2 * nodes * defaultTopK * 16)
+	case algorithms.Embeddings:
+		return fixed(2 * 4 * defaultEmbeddingDimension * nodes)
+	default:
+		return fixed(16 * nodes)
+	}
+}
+
+// Output is what a mutate-mode algorithm adds to the in-memory graph.
+type Output struct {
+	// Memory is the heap held by the mutated property or relationships.
+	Memory Estimate
+	// Relationships is the number of relationships added, if any.
+	Relationships int64
+}
+
+// AlgorithmOutput estimates what an algorithm in mutate mode adds to a graph
+// with the given node count. Other modes add nothing.
+func AlgorithmOutput(algo algorithms.Algorithm, nodes int64) Output {
+	if algo.GetMode() != algorithms.Mutate {
+		return Output{}
+	}
+
+	var topK int64
+	switch a := algo.(type) {
+	case *algorithms.KNN:
+		if a.MutateRelationshipType != "" {
+			topK = int64(a.K)
+		}
+	case *algorithms.NodeSimilarity:
+		if a.MutateRelationshipType != "" {
+			topK = int64(a.TopK)
+		}
+	case *algorithms.FastRP:
+		return Output{Memory: fixed(4 * embeddingDimension(a.EmbeddingDimension, defaultEmbeddingDimension) * nodes)}
+	case *algorithms.Node2Vec:
+		return Output{Memory: fixed(4 * embeddingDimension(a.EmbeddingDimension, defaultEmbeddingDimension) * nodes)}
+	case *algorithms.GraphSAGE:
+		return Output{Memory: fixed(8 * embeddingDimension(a.EmbeddingDimension, defaultSageDimension) * nodes)}
+	default:
+		return Output{Memory: fixed(propertyBytes * nodes)}
+	}
+
+	if topK == 0 {
+		if algo.AlgorithmCategory() == algorithms.Similarity {
+			// mutated similarity relationships with the default top-k
+			topK = defaultTopK
+		} else {
+			return Output{Memory: fixed(propertyBytes * nodes)}
+		}
+	}
+
+	// new relationships with a similarity property each
+	rels := nodes * topK
+	return Output{
+		Memory: Estimate{
+			BytesMin: rels*(relBytesMin+propertyBytes) + nodes*offsetBytes,
+			BytesMax: rels*(relBytesMax+propertyBytes) + nodes*offsetBytes,
+		},
+		Relationships: rels,
+	}
+}
+
+func embeddingDimension(dim, fallback int) int64 {
+	if dim <= 0 {
+		return int64(fallback)
+	}
+	return int64(dim)
+}
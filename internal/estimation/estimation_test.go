@@ -0,0 +1,170 @@
+package estimation
+
+import (
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+func newRetailSize() GraphSize {
+	return GraphSize{
+		NodeCounts:         map[string]int64{"Customer": 1000, "Product": 500},
+		RelationshipCounts: map[string]int64{"BOUGHT": 10000, "VIEWED": 40000},
+	}
+}
+
+func TestGraphSize_Counts(t *testing.T) {
+	size := newRetailSize()
+
+	tests := []struct {
+		name string
+		got  int64
+		want int64
+	}{
+		{"all nodes", size.Nodes(nil), 1500},
+		{"wildcard nodes", size.Nodes([]string{"*"}), 1500},
+		{"one label", size.Nodes([]string{"Customer"}), 1000},
+		{"unknown label", size.Nodes([]string{"Store"}), 0},
+		{"all relationships", size.Relationships(nil), 50000},
+		{"two types", size.Relationships([]string{"BOUGHT", "VIEWED"}), 50000},
+		{"one type", size.Relationships([]string{"BOUGHT"}), 10000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{512, "512 Bytes"},
+		{1536, "1.5 KiB"},
+		{10 * 1024 * 1024, "10.0 MiB"},
+		{300 * 1024 * 1024, "300 MiB"},
+		{3 * 1024 * 1024 * 1024, "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatBytes(tt.bytes); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestEstimate_String(t *testing.T) {
+	if got := fixed(2048).String(); got != "2.0 KiB" {
+		t.Errorf("String() = %q, want 2.0 KiB", got)
+	}
+	if got := (Estimate{BytesMin: 1024, BytesMax: 2048}).String(); got != "[1.0 KiB ... 2.0 KiB]" {
+		t.Errorf("String() = %q, want range", got)
+	}
+}
+
+func TestEstimateProjection(t *testing.T) {
+	size := newRetailSize()
+
+	native := &projections.NativeProjection{
+		BaseProjection: projections.BaseProjection{Name: "retail"},
+		NodeProjections: []projections.NodeProjection{
+			{Label: "Customer", Properties: []string{"age"}},
+		},
+		RelationshipProjections: []projections.RelationshipProjection{
+			{Type: "BOUGHT", Orientation: projections.Undirected},
+		},
+	}
+
+	est := EstimateProjection(native, size)
+	if est.NodeCount != 1000 {
+		t.Errorf("NodeCount = %d, want 1000", est.NodeCount)
+	}
+	if est.RelationshipCount != 20000 {
+		t.Errorf("RelationshipCount = %d, want 20000 (undirected counts twice)", est.RelationshipCount)
+	}
+	// 1000 nodes, 20000 relationships, one offset array, one node property
+	wantMin := int64(1000*nodeBytesMin + 20000*relBytesMin + 1000*offsetBytes + 1000*propertyBytes)
+	wantMax := int64(1000*nodeBytesMax + 20000*relBytesMax + 1000*offsetBytes + 1000*propertyBytes)
+	if est.Memory.BytesMin != wantMin || est.Memory.BytesMax != wantMax {
+		t.Errorf("Memory = %+v, want [%d, %d]", est.Memory, wantMin, wantMax)
+	}
+
+	cypher := &projections.CypherProjection{BaseProjection: projections.BaseProjection{Name: "all"}}
+	if got := EstimateProjection(cypher, size); got.NodeCount != 1500 || got.RelationshipCount != 50000 {
+		t.Errorf("Cypher projection should count the whole declared graph, got %+v", got)
+	}
+
+	sampled := &projections.SampledGraph{
+		BaseProjection: projections.BaseProjection{Name: "sample"},
+		Parent:         native,
+		SamplingRatio:  0.5,
+	}
+	if got := EstimateProjection(sampled, size); got.NodeCount != 500 {
+		t.Errorf("sampled NodeCount = %d, want 500", got.NodeCount)
+	}
+}
+
+func TestAlgorithmMemory(t *testing.T) {
+	const nodes, rels = 1000, 10000
+
+	tests := []struct {
+		name string
+		algo algorithms.Algorithm
+		want Estimate
+	}{
+		{"pagerank", &algorithms.PageRank{}, fixed(24 * nodes)},
+		{"wcc", &algorithms.WCC{}, fixed(8 * nodes)},
+		{"knn default k", &algorithms.KNN{}, fixed(2 * nodes * 10 * 16)},
+		{"fastrp", &algorithms.FastRP{EmbeddingDimension: 64}, fixed(3 * 4 * 64 * nodes)},
+		{
+			"louvain intermediate communities",
+			&algorithms.Louvain{MaxLevels: 3, IncludeIntermediateCommunities: true},
+			Estimate{BytesMin: 32*nodes + 3*8*nodes, BytesMax: 32*nodes + 12*rels + 3*8*nodes},
+		},
+		{
+			"leiden intermediate communities",
+			&algorithms.Leiden{IncludeIntermediateCommunities: true},
+			Estimate{BytesMin: 40*nodes + 10*8*nodes, BytesMax: 40*nodes + 12*rels + 10*8*nodes},
+		},
+		{
+			"betweenness uses concurrency",
+			&algorithms.Betweenness{BaseAlgorithm: algorithms.BaseAlgorithm{Concurrency: 2}},
+			Estimate{BytesMin: 8*nodes + 2*28*nodes, BytesMax: 8*nodes + 2*28*nodes + 2*8*rels},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AlgorithmMemory(tt.algo, nodes, rels); got != tt.want {
+				t.Errorf("AlgorithmMemory() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgorithmOutput(t *testing.T) {
+	const nodes = 1000
+	mutate := algorithms.BaseAlgorithm{Mode: algorithms.Mutate}
+
+	if out := AlgorithmOutput(&algorithms.PageRank{}, nodes); out.Memory != (Estimate{}) {
+		t.Errorf("stream mode should add nothing, got %+v", out)
+	}
+	if out := AlgorithmOutput(&algorithms.PageRank{BaseAlgorithm: mutate}, nodes); out.Memory != fixed(8*nodes) {
+		t.Errorf("mutated node property = %+v, want %d bytes", out.Memory, 8*nodes)
+	}
+
+	knn := &algorithms.KNN{BaseAlgorithm: mutate, K: 5, MutateRelationshipType: "SIMILAR"}
+	out := AlgorithmOutput(knn, nodes)
+	if out.Relationships != 5*nodes {
+		t.Errorf("Relationships = %d, want %d", out.Relationships, 5*nodes)
+	}
+	if out.Memory.BytesMax <= out.Memory.BytesMin {
+		t.Errorf("expected a range for mutated relationships, got %+v", out.Memory)
+	}
+}
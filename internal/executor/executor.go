@@ -0,0 +1,206 @@
+// Package executor runs GDS algorithm configurations against Neo4j and
+// decodes their records into typed rows.
+//
+// An Executor serializes an algorithms.Algorithm with the
+// AlgorithmSerializer, runs the call through a Session and, while the call
+// is running, polls gds.listProgress to report progress:
+//
+//	exec := executor.NewExecutor(executor.NewDriverSession(driver, "neo4j"))
+//	exec.OnProgress = func(p executor.Progress) { log.Println(p.TaskName, p.Progress) }
+//	rows, err := executor.Run[executor.PageRankStreamRow](ctx, exec, &algorithms.PageRank{...})
+//
+// Tests use a FakeSession instead of a database.
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// DefaultPollInterval is the interval between gds.listProgress calls when
+// Executor.PollInterval is 0.
+const DefaultPollInterval = time.Second
+
+// progressQuery lists the progress of the running GDS jobs.
+const progressQuery = "CALL gds.listProgress() YIELD jobId, taskName, progress, status"
+
+// Session runs a Cypher query and returns its records as maps. Run is called
+// concurrently while progress is polled.
+type Session interface {
+	Run(ctx context.Context, query string, params map[string]any) ([]map[string]any, error)
+}
+
+// driverSession is a Session backed by a neo4j-go-driver driver.
+type driverSession struct {
+	driver   neo4j.DriverWithContext
+	database string
+}
+
+// NewDriverSession creates a Session that runs each query in its own write
+// transaction on database (default: neo4j) through driver.
+func NewDriverSession(driver neo4j.DriverWithContext, database string) Session {
+	if database == "" {
+		database = "neo4j"
+	}
+	return &driverSession{driver: driver, database: database}
+}
+
+// Run executes query in a write transaction and collects all records.
+func (s *driverSession) Run(ctx context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	session := s.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: s.database,
+		AccessMode:   neo4j.AccessModeWrite,
+	})
+	defer func() { _ = session.Close(ctx) }()
+
+	rows, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]map[string]any, len(records))
+		for i, record := range records {
+			rows[i] = record.AsMap()
+		}
+		return rows, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows.([]map[string]any), nil
+}
+
+// Progress is a row of gds.listProgress.
+type Progress struct {
+	JobID    string `gds:"jobId"`
+	TaskName string `gds:"taskName"`
+	// Progress is the completed share of the task, such as "42.5%", or
+	// "n/a" when GDS cannot tell.
+	Progress string `gds:"progress"`
+	Status   string `gds:"status"`
+}
+
+// Executor runs algorithms through a Session.
+type Executor struct {
+	session    Session
+	serializer *algorithms.AlgorithmSerializer
+	// OnProgress, when set, is called with every row of gds.listProgress
+	// polled while an algorithm runs. It is called from another goroutine.
+	OnProgress func(Progress)
+	// PollInterval is the interval between progress polls (default:
+	// DefaultPollInterval).
+	PollInterval time.Duration
+}
+
+// NewExecutor creates an Executor that runs algorithms through session.
+func NewExecutor(session Session) *Executor {
+	return &Executor{session: session, serializer: algorithms.NewAlgorithmSerializer()}
+}
+
+// WithTarget sets the GDS version of the database, which selects the alpha,
+// beta or GA name of each procedure.
+func (e *Executor) WithTarget(t target.Target) *Executor {
+	e.serializer.WithTarget(t)
+	return e
+}
+
+// Records runs algo and returns its records undecoded. Cancelling ctx stops
+// the call and progress polling.
+func (e *Executor) Records(ctx context.Context, algo algorithms.Algorithm) ([]map[string]any, error) {
+	query, err := e.serializer.ToCypher(algo)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if e.OnProgress != nil {
+		pollCtx, stop := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.poll(pollCtx)
+		}()
+		defer func() {
+			stop()
+			wg.Wait()
+		}()
+	}
+
+	records, err := e.session.Run(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", e.serializer.Procedure(algo), err)
+	}
+	return records, nil
+}
+
+// poll reports gds.listProgress rows every PollInterval until ctx is done.
+// Failed polls are skipped: progress is informational.
+func (e *Executor) poll(ctx context.Context) {
+	interval := e.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		records, err := e.session.Run(ctx, progressQuery, nil)
+		if err != nil {
+			continue
+		}
+		rows, err := Decode[Progress](records)
+		if err != nil {
+			continue
+		}
+		for _, p := range rows {
+			if ctx.Err() != nil {
+				return
+			}
+			e.OnProgress(p)
+		}
+	}
+}
+
+// Run runs algo through e and decodes its records into rows of type T, such
+// as PageRankStreamRow. It fails before running algo when T reads a column
+// the algorithm's call does not yield.
+func Run[T any](ctx context.Context, e *Executor, algo algorithms.Algorithm) ([]T, error) {
+	if yielded := e.serializer.YieldColumns(algo); yielded != nil {
+		columns, err := columnsOf[T]()
+		if err != nil {
+			return nil, err
+		}
+		available := make(map[string]bool, len(yielded))
+		for _, c := range yielded {
+			available[c] = true
+		}
+		for _, c := range columns {
+			if !available[c] {
+				return nil, fmt.Errorf("%s does not yield column %s", e.serializer.Procedure(algo), c)
+			}
+		}
+	}
+
+	records, err := e.Records(ctx, algo)
+	if err != nil {
+		return nil, err
+	}
+	return Decode[T](records)
+}
@@ -0,0 +1,191 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+)
+
+func TestRun_Stream(t *testing.T) {
+	session := NewFakeSession().On("gds.pageRank.stream",
+		map[string]any{"nodeId": int64(0), "score": 0.42},
+		map[string]any{"nodeId": int64(1), "score": int64(1)},
+	)
+	algo := &algorithms.PageRank{BaseAlgorithm: algorithms.BaseAlgorithm{GraphName: "social", Mode: algorithms.Stream}}
+
+	rows, err := Run[PageRankStreamRow](context.Background(), NewExecutor(session), algo)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0] != (PageRankStreamRow{NodeID: 0, Score: 0.42}) || rows[1].Score != 1 {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+	queries := session.Queries()
+	if len(queries) != 1 || !strings.Contains(queries[0], "CALL gds.pageRank.stream(\n  'social'") {
+		t.Errorf("unexpected queries: %q", queries)
+	}
+}
+
+func TestRun_Stats(t *testing.T) {
+	session := NewFakeSession().On("gds.louvain.stats", map[string]any{
+		"communityCount":        int64(3),
+		"ranLevels":             int64(2),
+		"modularity":            0.41,
+		"modularities":          []any{0.35, 0.41},
+		"communityDistribution": map[string]any{"max": int64(4)},
+		"computeMillis":         int64(12),
+	})
+	algo := &algorithms.Louvain{BaseAlgorithm: algorithms.BaseAlgorithm{GraphName: "social", Mode: algorithms.Stats}}
+
+	rows, err := Run[LouvainStatsRow](context.Background(), NewExecutor(session), algo)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected one row, got %+v", rows)
+	}
+	got := rows[0]
+	if got.CommunityCount != 3 || got.RanLevels != 2 || got.Modularity != 0.41 || len(got.Modularities) != 2 || got.CommunityDistribution["max"] != int64(4) {
+		t.Errorf("unexpected row: %+v", got)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	louvain := &algorithms.Louvain{BaseAlgorithm: algorithms.BaseAlgorithm{GraphName: "g", Mode: algorithms.Stream}}
+
+	tests := []struct {
+		name    string
+		session *FakeSession
+		run     func(*Executor) error
+		wantErr string
+	}{
+		{
+			name:    "column not yielded",
+			session: NewFakeSession(),
+			run: func(e *Executor) error {
+				_, err := Run[PageRankStreamRow](context.Background(), e, louvain)
+				return err
+			},
+			wantErr: "gds.louvain.stream does not yield column score",
+		},
+		{
+			name:    "missing column",
+			session: NewFakeSession().On("gds.louvain", map[string]any{"nodeId": int64(1)}),
+			run: func(e *Executor) error {
+				_, err := Run[LouvainStreamRow](context.Background(), e, louvain)
+				return err
+			},
+			wantErr: "row 0: missing column communityId",
+		},
+		{
+			name:    "wrong type",
+			session: NewFakeSession().On("gds.louvain", map[string]any{"nodeId": int64(1), "communityId": "a"}),
+			run: func(e *Executor) error {
+				_, err := Run[LouvainStreamRow](context.Background(), e, louvain)
+				return err
+			},
+			wantErr: "column communityId: expected an integer, got string",
+		},
+		{
+			name:    "session error",
+			session: &FakeSession{Err: errors.New("graph g does not exist")},
+			run: func(e *Executor) error {
+				_, err := Run[LouvainStreamRow](context.Background(), e, louvain)
+				return err
+			},
+			wantErr: "gds.louvain.stream failed: graph g does not exist",
+		},
+		{
+			name:    "not a struct",
+			session: NewFakeSession(),
+			run: func(e *Executor) error {
+				_, err := Run[int](context.Background(), e, louvain)
+				return err
+			},
+			wantErr: "row type int is not a struct",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(NewExecutor(tt.session))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExecutor_Progress(t *testing.T) {
+	session := NewFakeSession().On("gds.listProgress", map[string]any{
+		"jobId": "42", "taskName": "Louvain", "progress": "50%", "status": "RUNNING",
+	})
+	session.Delay = 50 * time.Millisecond
+
+	exec := NewExecutor(session)
+	exec.PollInterval = 5 * time.Millisecond
+	var mu sync.Mutex
+	var seen []Progress
+	exec.OnProgress = func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, p)
+	}
+
+	algo := &algorithms.WCC{BaseAlgorithm: algorithms.BaseAlgorithm{GraphName: "g", Mode: algorithms.Stream}}
+	if _, err := exec.Records(context.Background(), algo); err != nil {
+		t.Fatalf("Records failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) == 0 || seen[0] != (Progress{JobID: "42", TaskName: "Louvain", Progress: "50%", Status: "RUNNING"}) {
+		t.Errorf("unexpected progress: %+v", seen)
+	}
+}
+
+func TestExecutor_Cancel(t *testing.T) {
+	session := NewFakeSession()
+	session.Delay = time.Minute
+	exec := NewExecutor(session)
+	exec.OnProgress = func(Progress) {}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	algo := &algorithms.WCC{BaseAlgorithm: algorithms.BaseAlgorithm{GraphName: "g", Mode: algorithms.Stream}}
+	if _, err := exec.Records(ctx, algo); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	if _, err := exec.Records(ctx, algo); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected cancelled context to fail before running, got %v", err)
+	}
+	if n := len(session.Queries()); n != 1 {
+		t.Errorf("expected only the first call to run, got %d queries", n)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	rows, err := Decode[TraversalStreamRow]([]map[string]any{
+		{"sourceNode": int64(3), "nodeIds": []any{int64(3), int64(5)}, "path": nil},
+	})
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].SourceNode != 3 || len(rows[0].NodeIDs) != 2 || rows[0].NodeIDs[1] != 5 {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+
+	embeddings, err := Decode[FastRPStreamRow]([]map[string]any{{"nodeId": int64(1), "embedding": []any{0.5, int64(1)}}})
+	if err != nil || len(embeddings[0].Embedding) != 2 || embeddings[0].Embedding[1] != 1 {
+		t.Errorf("unexpected embeddings: %+v, %v", embeddings, err)
+	}
+	if _, err := Decode[FastRPStreamRow]([]map[string]any{{"nodeId": int64(1), "embedding": []any{"x"}}}); err == nil || !strings.Contains(err.Error(), "item 0: expected a number") {
+		t.Errorf("expected list item error, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeSession is an in-memory Session for tests. It answers each query with
+// the records of the first registered fragment the query contains, and
+// records the queries it receives.
+type FakeSession struct {
+	// Delay is how long queries other than gds.listProgress take to run.
+	// Cancelling the context ends the wait with the context's error.
+	Delay time.Duration
+	// Err, when set, is returned by queries other than gds.listProgress.
+	Err error
+
+	mu      sync.Mutex
+	results []fakeResult
+	queries []string
+}
+
+type fakeResult struct {
+	fragment string
+	records  []map[string]any
+}
+
+// NewFakeSession creates a FakeSession that answers every query with no
+// records.
+func NewFakeSession() *FakeSession {
+	return &FakeSession{}
+}
+
+// On answers queries containing fragment, such as a procedure name, with
+// records. Fragments are matched in the order they were registered.
+func (f *FakeSession) On(fragment string, records ...map[string]any) *FakeSession {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, fakeResult{fragment: fragment, records: records})
+	return f
+}
+
+// Queries returns the queries received so far, in order.
+func (f *FakeSession) Queries() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.queries...)
+}
+
+// Run records query and returns the records registered for it.
+func (f *FakeSession) Run(ctx context.Context, query string, _ map[string]any) ([]map[string]any, error) {
+	f.mu.Lock()
+	f.queries = append(f.queries, query)
+	var records []map[string]any
+	for _, r := range f.results {
+		if strings.Contains(query, r.fragment) {
+			records = r.records
+			break
+		}
+	}
+	f.mu.Unlock()
+
+	if !strings.Contains(query, "gds.listProgress") {
+		if f.Delay > 0 {
+			timer := time.NewTimer(f.Delay)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if f.Err != nil {
+			return nil, f.Err
+		}
+	}
+	return records, nil
+}
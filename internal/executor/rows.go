@@ -0,0 +1,230 @@
+package executor
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Row types decode the columns yielded by the AlgorithmSerializer's Cypher
+// calls. Each field's gds tag names its column; node IDs are the internal
+// IDs GDS streams.
+
+// PageRankStreamRow is a row of gds.pageRank.stream.
+type PageRankStreamRow struct {
+	NodeID int64   `gds:"nodeId"`
+	Score  float64 `gds:"score"`
+}
+
+// PageRankStatsRow is the row of gds.pageRank.stats, also yielded by
+// gds.articleRank.stats and gds.eigenvector.stats.
+type PageRankStatsRow struct {
+	RanIterations int64 `gds:"ranIterations"`
+	DidConverge   bool  `gds:"didConverge"`
+	// CentralityDistribution summarizes the scores, keyed by min, max,
+	// mean and percentiles such as p50.
+	CentralityDistribution map[string]any `gds:"centralityDistribution"`
+	ComputeMillis          int64          `gds:"computeMillis"`
+}
+
+// DegreeStreamRow is a row of gds.degree.stream.
+type DegreeStreamRow struct {
+	NodeID int64   `gds:"nodeId"`
+	Score  float64 `gds:"score"`
+}
+
+// DegreeStatsRow is the row of gds.degree.stats, also yielded by
+// gds.betweenness.stats.
+type DegreeStatsRow struct {
+	CentralityDistribution map[string]any `gds:"centralityDistribution"`
+	ComputeMillis          int64          `gds:"computeMillis"`
+}
+
+// LouvainStreamRow is a row of gds.louvain.stream.
+type LouvainStreamRow struct {
+	NodeID      int64 `gds:"nodeId"`
+	CommunityID int64 `gds:"communityId"`
+}
+
+// LouvainStatsRow is the row of gds.louvain.stats.
+type LouvainStatsRow struct {
+	CommunityCount int64   `gds:"communityCount"`
+	RanLevels      int64   `gds:"ranLevels"`
+	Modularity     float64 `gds:"modularity"`
+	// Modularities is the modularity after each level.
+	Modularities          []float64      `gds:"modularities"`
+	CommunityDistribution map[string]any `gds:"communityDistribution"`
+	ComputeMillis         int64          `gds:"computeMillis"`
+}
+
+// LabelPropagationStreamRow is a row of gds.labelPropagation.stream.
+type LabelPropagationStreamRow struct {
+	NodeID      int64 `gds:"nodeId"`
+	CommunityID int64 `gds:"communityId"`
+}
+
+// LabelPropagationStatsRow is the row of gds.labelPropagation.stats.
+type LabelPropagationStatsRow struct {
+	CommunityCount        int64          `gds:"communityCount"`
+	RanIterations         int64          `gds:"ranIterations"`
+	DidConverge           bool           `gds:"didConverge"`
+	CommunityDistribution map[string]any `gds:"communityDistribution"`
+	ComputeMillis         int64          `gds:"computeMillis"`
+}
+
+// WCCStreamRow is a row of gds.wcc.stream.
+type WCCStreamRow struct {
+	NodeID      int64 `gds:"nodeId"`
+	ComponentID int64 `gds:"componentId"`
+}
+
+// WCCStatsRow is the row of gds.wcc.stats.
+type WCCStatsRow struct {
+	ComponentCount        int64          `gds:"componentCount"`
+	ComponentDistribution map[string]any `gds:"componentDistribution"`
+	ComputeMillis         int64          `gds:"computeMillis"`
+}
+
+// TriangleCountStreamRow is a row of gds.triangleCount.stream. TriangleCount
+// is -1 for nodes excluded by MaxDegree.
+type TriangleCountStreamRow struct {
+	NodeID        int64 `gds:"nodeId"`
+	TriangleCount int64 `gds:"triangleCount"`
+}
+
+// TriangleCountStatsRow is the row of gds.triangleCount.stats.
+type TriangleCountStatsRow struct {
+	GlobalTriangleCount int64 `gds:"globalTriangleCount"`
+	NodeCount           int64 `gds:"nodeCount"`
+	ComputeMillis       int64 `gds:"computeMillis"`
+}
+
+// NodeSimilarityStreamRow is a row of gds.nodeSimilarity.stream.
+type NodeSimilarityStreamRow struct {
+	Node1      int64   `gds:"node1"`
+	Node2      int64   `gds:"node2"`
+	Similarity float64 `gds:"similarity"`
+}
+
+// NodeSimilarityStatsRow is the row of gds.nodeSimilarity.stats.
+type NodeSimilarityStatsRow struct {
+	NodesCompared          int64          `gds:"nodesCompared"`
+	SimilarityPairs        int64          `gds:"similarityPairs"`
+	SimilarityDistribution map[string]any `gds:"similarityDistribution"`
+	ComputeMillis          int64          `gds:"computeMillis"`
+}
+
+// FastRPStreamRow is a row of gds.fastRP.stream.
+type FastRPStreamRow struct {
+	NodeID    int64     `gds:"nodeId"`
+	Embedding []float64 `gds:"embedding"`
+}
+
+// DijkstraStreamRow is a row of gds.shortestPath.dijkstra.stream.
+type DijkstraStreamRow struct {
+	SourceNode int64   `gds:"sourceNode"`
+	TargetNode int64   `gds:"targetNode"`
+	TotalCost  float64 `gds:"totalCost"`
+}
+
+// TraversalStreamRow is the row of gds.bfs.stream and gds.dfs.stream.
+type TraversalStreamRow struct {
+	SourceNode int64   `gds:"sourceNode"`
+	NodeIDs    []int64 `gds:"nodeIds"`
+}
+
+// WriteRow is the row of the write and mutate modes of node property
+// algorithms.
+type WriteRow struct {
+	NodePropertiesWritten int64 `gds:"nodePropertiesWritten"`
+	ComputeMillis         int64 `gds:"computeMillis"`
+}
+
+// Decode decodes records into rows of type T, a struct whose gds tags name
+// the columns of its fields. Integer columns decode into int and int64
+// fields and, like float columns, into float64 fields; list columns decode
+// into slices of those.
+func Decode[T any](records []map[string]any) ([]T, error) {
+	if _, err := columnsOf[T](); err != nil {
+		return nil, err
+	}
+	rows := make([]T, len(records))
+	for i, record := range records {
+		v := reflect.ValueOf(&rows[i]).Elem()
+		t := v.Type()
+		for j := 0; j < t.NumField(); j++ {
+			column := t.Field(j).Tag.Get("gds")
+			if column == "" {
+				continue
+			}
+			value, ok := record[column]
+			if !ok {
+				return nil, fmt.Errorf("row %d: missing column %s", i, column)
+			}
+			if err := assign(v.Field(j), value); err != nil {
+				return nil, fmt.Errorf("row %d: column %s: %w", i, column, err)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// columnsOf returns the columns read by the row type T.
+func columnsOf[T any]() ([]string, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("row type %s is not a struct", t)
+	}
+	var columns []string
+	for i := 0; i < t.NumField(); i++ {
+		if column := t.Field(i).Tag.Get("gds"); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
+
+// assign stores a driver value in field, converting numbers and lists.
+func assign(field reflect.Value, value any) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int64:
+		n, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("expected an integer, got %T", value)
+		}
+		field.SetInt(n)
+		return nil
+	case reflect.Float64:
+		switch n := value.(type) {
+		case float64:
+			field.SetFloat(n)
+		case int64:
+			field.SetFloat(float64(n))
+		default:
+			return fmt.Errorf("expected a number, got %T", value)
+		}
+		return nil
+	case reflect.Slice:
+		list, ok := value.([]any)
+		if !ok {
+			break
+		}
+		slice := reflect.MakeSlice(field.Type(), len(list), len(list))
+		for i, item := range list {
+			if err := assign(slice.Index(i), item); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if !v.Type().AssignableTo(field.Type()) {
+		return fmt.Errorf("cannot decode %T into %s", value, field.Type())
+	}
+	field.Set(v)
+	return nil
+}
@@ -0,0 +1,9 @@
+package graphrag
+
+import "strings"
+
+// CypherName quotes a label, relationship type or property name with
+// backticks.
+func CypherName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,186 @@
+// Package graphrag provides the helpers shared by the neo4j-graphrag
+// integrations: Python code generation for retriever, KG pipeline and Aura
+// session scripts, the neo4j-graphrag provider classes, and the vector and
+// Cypher utilities used by the Go retriever and KG runtimes.
+//
+// Example usage:
+//
+//	var imports graphrag.Imports
+//	p, _ := graphrag.LookupProvider("openai")
+//	imports.AddGraphRAG("embeddings", p.EmbedderClass)
+//	graphrag.WriteDriver(&sb, "", "", "$NEO4J_PASSWORD")
+package graphrag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Provider describes how a provider is constructed in neo4j-graphrag.
+type Provider struct {
+	// EmbedderClass is the neo4j_graphrag.embeddings class, or "" if the
+	// provider has no embedding models.
+	EmbedderClass string
+	// LLMClass is the neo4j_graphrag.llm class, or "" if the provider has no
+	// LLMs.
+	LLMClass string
+	// APIKeyEnv is the default environment variable holding the API key, or
+	// "" if the provider authenticates without one.
+	APIKeyEnv string
+	// EndpointEnv is the environment variable holding the service endpoint,
+	// for providers that need one.
+	EndpointEnv string
+}
+
+// Providers maps lower-cased provider names to neo4j-graphrag classes.
+var Providers = map[string]Provider{
+	"openai":                {EmbedderClass: "OpenAIEmbeddings", LLMClass: "OpenAILLM", APIKeyEnv: "OPENAI_API_KEY"},
+	"azure":                 {EmbedderClass: "AzureOpenAIEmbeddings", LLMClass: "AzureOpenAILLM", APIKeyEnv: "AZURE_OPENAI_API_KEY", EndpointEnv: "AZURE_OPENAI_ENDPOINT"},
+	"azureopenai":           {EmbedderClass: "AzureOpenAIEmbeddings", LLMClass: "AzureOpenAILLM", APIKeyEnv: "AZURE_OPENAI_API_KEY", EndpointEnv: "AZURE_OPENAI_ENDPOINT"},
+	"anthropic":             {LLMClass: "AnthropicLLM", APIKeyEnv: "ANTHROPIC_API_KEY"},
+	"vertexai":              {EmbedderClass: "VertexAIEmbeddings", LLMClass: "VertexAILLM"},
+	"mistral":               {EmbedderClass: "MistralAIEmbeddings", LLMClass: "MistralAILLM", APIKeyEnv: "MISTRAL_API_KEY"},
+	"mistralai":             {EmbedderClass: "MistralAIEmbeddings", LLMClass: "MistralAILLM", APIKeyEnv: "MISTRAL_API_KEY"},
+	"cohere":                {EmbedderClass: "CohereEmbeddings", LLMClass: "CohereLLM", APIKeyEnv: "CO_API_KEY"},
+	"ollama":                {EmbedderClass: "OllamaEmbeddings", LLMClass: "OllamaLLM"},
+	"sentence-transformers": {EmbedderClass: "SentenceTransformerEmbeddings"},
+}
+
+// DefaultProvider is used when a configuration names a model but no
+// provider.
+const DefaultProvider = "openai"
+
+// LookupProvider returns the provider named name, case-insensitively, or
+// DefaultProvider when name is empty.
+func LookupProvider(name string) (Provider, error) {
+	if name == "" {
+		name = DefaultProvider
+	}
+	p, ok := Providers[strings.ToLower(name)]
+	if !ok {
+		return p, fmt.Errorf("unknown provider %q", name)
+	}
+	return p, nil
+}
+
+// WriteCredentials writes the api_key and endpoint arguments of a provider
+// class, read from the environment.
+func WriteCredentials(sb *strings.Builder, p Provider, apiKey string) {
+	if p.APIKeyEnv != "" {
+		fmt.Fprintf(sb, "    api_key=%s,\n", EnvLookup(SecretEnv(apiKey, p.APIKeyEnv)))
+	}
+	if p.EndpointEnv != "" {
+		fmt.Fprintf(sb, "    azure_endpoint=%s,\n", EnvLookup(p.EndpointEnv))
+	}
+}
+
+// WriteDriver writes the driver variable. An empty uri or user is read from
+// NEO4J_URI or NEO4J_USERNAME; the password is always read from the
+// environment, as selected by SecretEnv.
+func WriteDriver(sb *strings.Builder, uri, user, password string) {
+	uriArg := EnvLookup("NEO4J_URI")
+	if uri != "" {
+		uriArg = PyString(uri)
+	}
+	userArg := EnvLookup("NEO4J_USERNAME")
+	if user != "" {
+		userArg = PyString(user)
+	}
+
+	sb.WriteString("driver = neo4j.GraphDatabase.driver(\n")
+	fmt.Fprintf(sb, "    %s,\n", uriArg)
+	fmt.Fprintf(sb, "    auth=(%s, %s),\n", userArg, EnvLookup(SecretEnv(password, "NEO4J_PASSWORD")))
+	sb.WriteString(")\n\n")
+}
+
+// Imports collects the import lines of a generated script, without
+// duplicates and in the order they were first added.
+type Imports struct {
+	// GraphRAG are the neo4j_graphrag imports.
+	GraphRAG []string
+	// ThirdParty are the other imports, written before neo4j.
+	ThirdParty []string
+}
+
+// AddGraphRAG imports name from the neo4j_graphrag module.
+func (i *Imports) AddGraphRAG(module, name string) {
+	i.GraphRAG = appendUnique(i.GraphRAG, fmt.Sprintf("from neo4j_graphrag.%s import %s", module, name))
+}
+
+// AddThirdParty adds an import line for a package other than neo4j_graphrag.
+func (i *Imports) AddThirdParty(line string) {
+	i.ThirdParty = appendUnique(i.ThirdParty, line)
+}
+
+// Write writes the third-party, neo4j and neo4j_graphrag imports followed by
+// a blank line.
+func (i *Imports) Write(sb *strings.Builder) {
+	for _, imp := range i.ThirdParty {
+		sb.WriteString(imp + "\n")
+	}
+	sb.WriteString("import neo4j\n")
+	for _, imp := range i.GraphRAG {
+		sb.WriteString(imp + "\n")
+	}
+	sb.WriteString("\n")
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// SecretEnv returns the environment variable to read a secret from: the
+// variable named by a $NAME or ${NAME} value, otherwise fallback. The value
+// itself is never returned, so literal secrets cannot leak into output.
+func SecretEnv(value, fallback string) string {
+	if name, ok := strings.CutPrefix(value, "$"); ok {
+		name = strings.TrimSuffix(strings.TrimPrefix(name, "{"), "}")
+		if name != "" {
+			return name
+		}
+	}
+	return fallback
+}
+
+// EnvLookup returns the Python expression reading environment variable name.
+func EnvLookup(name string) string {
+	return fmt.Sprintf("os.environ[%s]", PyString(name))
+}
+
+// PyString quotes s as a Python string literal.
+func PyString(s string) string {
+	return strconv.Quote(s)
+}
+
+// PyStringList formats list as a Python list of string literals.
+func PyStringList(list []string) string {
+	quoted := make([]string, len(list))
+	for i, s := range list {
+		quoted[i] = PyString(s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// PyFloat formats f as a Python float literal in decimal notation, such as
+// 1.0 or 0.25.
+func PyFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// PyBool formats b as a Python bool literal.
+func PyBool(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
@@ -0,0 +1,85 @@
+package graphrag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPyFloat(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{1, "1.0"},
+		{0.25, "0.25"},
+		{1e6, "1000000.0"},
+		{-3, "-3.0"},
+	}
+
+	for _, tt := range tests {
+		if got := PyFloat(tt.in); got != tt.want {
+			t.Errorf("PyFloat(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSecretEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", "OPENAI_API_KEY"},
+		{"$MY_KEY", "MY_KEY"},
+		{"${MY_KEY}", "MY_KEY"},
+		{"sk-literal", "OPENAI_API_KEY"},
+		{"$", "OPENAI_API_KEY"},
+	}
+
+	for _, tt := range tests {
+		if got := SecretEnv(tt.value, "OPENAI_API_KEY"); got != tt.want {
+			t.Errorf("SecretEnv(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLookupProvider(t *testing.T) {
+	p, err := LookupProvider("")
+	if err != nil || p.EmbedderClass != "OpenAIEmbeddings" {
+		t.Errorf("LookupProvider(\"\") = %+v, %v, want openai", p, err)
+	}
+	p, err = LookupProvider("Anthropic")
+	if err != nil || p.LLMClass != "AnthropicLLM" || p.EmbedderClass != "" {
+		t.Errorf("LookupProvider(Anthropic) = %+v, %v", p, err)
+	}
+	if _, err := LookupProvider("acme"); err == nil || !strings.Contains(err.Error(), `unknown provider "acme"`) {
+		t.Errorf("LookupProvider(acme) error = %v", err)
+	}
+}
+
+func TestImports_Write(t *testing.T) {
+	var imports Imports
+	imports.AddGraphRAG("embeddings", "OpenAIEmbeddings")
+	imports.AddThirdParty("import cohere")
+	imports.AddGraphRAG("embeddings", "OpenAIEmbeddings")
+
+	var sb strings.Builder
+	imports.Write(&sb)
+	want := "import cohere\nimport neo4j\nfrom neo4j_graphrag.embeddings import OpenAIEmbeddings\n\n"
+	if sb.String() != want {
+		t.Errorf("Write() = %q, want %q", sb.String(), want)
+	}
+}
+
+func TestWriteDriver(t *testing.T) {
+	var sb strings.Builder
+	WriteDriver(&sb, "neo4j://db:7687", "", "secret")
+	got := sb.String()
+	for _, want := range []string{`"neo4j://db:7687",`, `auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "secret") {
+		t.Errorf("password inlined:\n%s", got)
+	}
+}
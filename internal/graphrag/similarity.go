@@ -0,0 +1,21 @@
+package graphrag
+
+import "math"
+
+// CosineSimilarity returns the cosine similarity of a and b, or 0 when
+// their lengths differ or either is a zero vector.
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
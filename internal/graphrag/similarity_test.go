@@ -0,0 +1,32 @@
+package graphrag
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{"identical", []float64{1, 2}, []float64{2, 4}, 1},
+		{"orthogonal", []float64{1, 0}, []float64{0, 1}, 0},
+		{"opposite", []float64{1, 0}, []float64{-1, 0}, -1},
+		{"zero vector", []float64{0, 0}, []float64{1, 0}, 0},
+		{"length mismatch", []float64{1, 0}, nil, 0},
+	}
+
+	for _, tt := range tests {
+		if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: CosineSimilarity() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCypherName(t *testing.T) {
+	if got := CypherName("my`Label"); got != "`my``Label`" {
+		t.Errorf("CypherName() = %s", got)
+	}
+}
@@ -0,0 +1,379 @@
+package kg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+	"github.com/lex00/wetwire-neo4j-go/internal/serializer"
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+// CommunityPromptPlaceholder is replaced by the entities and relationships
+// of a community in the summary prompt.
+const CommunityPromptPlaceholder = "{community}"
+
+// DefaultCommunityPrompt is the summary prompt used when SummaryPrompt is
+// empty.
+const DefaultCommunityPrompt = `You are summarizing a community of entities in a knowledge graph.
+Write a concise report of who or what the community is about, its key
+entities and how they are related. Use only the information below.
+
+` + CommunityPromptPlaceholder
+
+// CommunitySummaryPipeline detects communities over the extracted entity
+// graph with Leiden, stores them as a hierarchy of community nodes, and
+// summarizes and embeds every community for global-search GraphRAG.
+type CommunitySummaryPipeline struct {
+	BasePipeline
+	// Leiden detects the communities. It runs in write mode on a projection
+	// of the entity graph named by its GraphName (default: <Name>_entities).
+	// With IncludeIntermediateCommunities, every level becomes a community
+	// node linked to its parent.
+	Leiden *algorithms.Leiden
+	// EntityLabel is the label of extracted entities (default: __Entity__).
+	EntityLabel string
+	// RelationshipTypes restricts the projected entity relationships
+	// (default: all).
+	RelationshipTypes []string
+	// CommunityLabel is the community node label (default: __Community__).
+	CommunityLabel string
+	// InCommunityType links an entity to its lowest-level community
+	// (default: IN_COMMUNITY).
+	InCommunityType string
+	// ParentType links a community to the community one level up (default:
+	// PARENT_COMMUNITY).
+	ParentType string
+	// SummaryPrompt asks the LLM for a community summary; {community} is
+	// replaced by the community's entities and relationships (default:
+	// DefaultCommunityPrompt).
+	SummaryPrompt string
+	// MinCommunitySize skips summarizing communities with fewer entities
+	// (default: 1).
+	MinCommunitySize int
+	// VectorIndexName is the vector index on community summary embeddings
+	// (default: community_summaries).
+	VectorIndexName string
+}
+
+func (p *CommunitySummaryPipeline) PipelineType() PipelineType { return CommunitySummary }
+
+// Validate checks that the pipeline has a Leiden configuration that can run
+// in write mode, an LLM, an embedder with dimensions and a usable prompt.
+func (p *CommunitySummaryPipeline) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("community summary pipeline name is required")
+	}
+	if p.Leiden == nil {
+		return fmt.Errorf("pipeline %s has no Leiden configuration", p.Name)
+	}
+	if p.Leiden.Mode != "" && p.Leiden.Mode != algorithms.Write {
+		return fmt.Errorf("pipeline %s runs Leiden in %s mode, want write", p.Name, p.Leiden.Mode)
+	}
+	if p.LLMConfig == nil || p.LLMConfig.Model == "" {
+		return fmt.Errorf("pipeline %s has no LLM model for summaries", p.Name)
+	}
+	if p.EmbedderConfig == nil || p.EmbedderConfig.Model == "" || p.EmbedderConfig.Dimensions <= 0 {
+		return fmt.Errorf("pipeline %s needs an embedder model with dimensions for summary embeddings", p.Name)
+	}
+	if p.SummaryPrompt != "" && !strings.Contains(p.SummaryPrompt, CommunityPromptPlaceholder) {
+		return fmt.Errorf("pipeline %s summary prompt has no %s placeholder", p.Name, CommunityPromptPlaceholder)
+	}
+	return nil
+}
+
+// communityNames holds the resolved names of a community pipeline.
+type communityNames struct {
+	graph, entity, community, inCommunity, parent, writeProperty, index string
+}
+
+func (p *CommunitySummaryPipeline) names() communityNames {
+	n := communityNames{
+		graph:         p.Name + "_entities",
+		entity:        "__Entity__",
+		community:     "__Community__",
+		inCommunity:   "IN_COMMUNITY",
+		parent:        "PARENT_COMMUNITY",
+		writeProperty: "communities",
+		index:         "community_summaries",
+	}
+	for _, f := range []struct {
+		value *string
+		set   string
+	}{
+		{&n.entity, p.EntityLabel},
+		{&n.community, p.CommunityLabel},
+		{&n.inCommunity, p.InCommunityType},
+		{&n.parent, p.ParentType},
+		{&n.index, p.VectorIndexName},
+	} {
+		if f.set != "" {
+			*f.value = f.set
+		}
+	}
+	if p.Leiden != nil {
+		if p.Leiden.GraphName != "" {
+			n.graph = p.Leiden.GraphName
+		}
+		if p.Leiden.WriteProperty != "" {
+			n.writeProperty = p.Leiden.WriteProperty
+		}
+	}
+	return n
+}
+
+// CommunitySchema returns the community node and relationship types written
+// by the pipeline, with an ID constraint and the summary vector index.
+func (p *CommunitySummaryPipeline) CommunitySchema() *schema.Schema {
+	n := p.names()
+	dimensions := 0
+	if p.EmbedderConfig != nil {
+		dimensions = p.EmbedderConfig.Dimensions
+	}
+
+	community := &schema.NodeType{
+		Label: n.community,
+		Properties: []schema.Property{
+			{Name: "id", Type: schema.STRING, Required: true, Unique: true},
+			{Name: "level", Type: schema.INTEGER},
+			{Name: "size", Type: schema.INTEGER},
+			{Name: "summary", Type: schema.STRING},
+			{Name: "embedding", Type: schema.LIST_FLOAT},
+		},
+		Constraints: []schema.Constraint{
+			{Name: constraintName(n.community, "id"), Type: schema.UNIQUE, Properties: []string{"id"}},
+		},
+		Indexes: []schema.Index{{
+			Name:       n.index,
+			Type:       schema.VECTOR,
+			Properties: []string{"embedding"},
+			Options:    map[string]any{"dimensions": dimensions, "similarity_function": "cosine"},
+		}},
+		Description: "Leiden community of entities, summarized by an LLM",
+	}
+
+	return &schema.Schema{
+		Name:  p.Name + " communities",
+		Nodes: []*schema.NodeType{community},
+		Relationships: []*schema.RelationshipType{
+			{Label: n.inCommunity, Source: n.entity, Target: n.community, Cardinality: schema.MANY_TO_ONE},
+			{Label: n.parent, Source: n.community, Target: n.community, Cardinality: schema.MANY_TO_ONE},
+		},
+	}
+}
+
+// JobStepKind is the kind of work a JobStep does.
+type JobStepKind string
+
+const (
+	// JobCypher runs Cypher.
+	JobCypher JobStepKind = "cypher"
+	// JobSummarize runs Cypher for one row per community, asks the LLM for a
+	// summary of each row, and writes the summaries as $rows of
+	// {communityId, summary}.
+	JobSummarize JobStepKind = "summarize"
+	// JobEmbed runs Cypher for one row per community, embeds each row's text,
+	// and writes the embeddings as $rows of {communityId, embedding}.
+	JobEmbed JobStepKind = "embed"
+)
+
+// CommunityJob is a runnable description of a community summary pipeline:
+// steps to run in order by a job runner.
+type CommunityJob struct {
+	Name  string    `json:"name"`
+	Steps []JobStep `json:"steps"`
+}
+
+// JobStep is one step of a CommunityJob.
+type JobStep struct {
+	Name string      `json:"name"`
+	Kind JobStepKind `json:"kind"`
+	// Cypher is the statement of a cypher step, or the query reading the
+	// input rows of a summarize or embed step.
+	Cypher string `json:"cypher"`
+	// Prompt is the summary prompt of a summarize step.
+	Prompt string `json:"prompt,omitempty"`
+	// Provider and Model select the LLM or embedder.
+	Provider string `json:"provider,omitempty"`
+	Model    string `json:"model,omitempty"`
+	// WriteCypher stores the results of a summarize or embed step.
+	WriteCypher string `json:"writeCypher,omitempty"`
+}
+
+// ToJob converts a community summary pipeline to a job: create the community
+// constraint and summary index, project the entity graph, run Leiden, drop
+// the graph, build the community hierarchy, then summarize and embed the
+// communities.
+func (s *KGSerializer) ToJob(p *CommunitySummaryPipeline) (*CommunityJob, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	n := p.names()
+
+	leiden := *p.Leiden
+	leiden.GraphName, leiden.Mode, leiden.WriteProperty = n.graph, algorithms.Write, n.writeProperty
+	if leiden.Name == "" {
+		leiden.Name = p.Name + "_leiden"
+	}
+	leidenCypher, err := algorithms.NewAlgorithmSerializer().ToCypher(&leiden)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline %s: failed to serialize Leiden: %w", p.Name, err)
+	}
+	communityType := p.CommunitySchema().Nodes[0]
+	schemaCypher, err := serializer.NewCypherSerializer().SerializeNodeType(&schema.NodeType{
+		Label:       communityType.Label,
+		Constraints: communityType.Constraints,
+		Indexes:     communityType.Indexes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pipeline %s: failed to serialize community index: %w", p.Name, err)
+	}
+
+	prompt := p.SummaryPrompt
+	if prompt == "" {
+		prompt = DefaultCommunityPrompt
+	}
+	minSize := max(p.MinCommunitySize, 1)
+	community, entity := graphrag.CypherName(n.community), graphrag.CypherName(n.entity)
+
+	// The constraint and vector index come first, so the hierarchy MERGEs
+	// are indexed.
+	var steps []JobStep
+	for _, stmt := range strings.Split(schemaCypher, "\n") {
+		stmt = strings.TrimSuffix(strings.TrimSpace(stmt), ";")
+		switch {
+		case strings.HasPrefix(stmt, "CREATE CONSTRAINT"):
+			steps = append(steps, JobStep{Name: "constraint", Kind: JobCypher, Cypher: stmt})
+		case strings.HasPrefix(stmt, "CREATE VECTOR INDEX"):
+			steps = append(steps, JobStep{Name: "index", Kind: JobCypher, Cypher: stmt})
+		}
+	}
+
+	return &CommunityJob{
+		Name: p.Name,
+		Steps: append(steps, []JobStep{
+			{Name: "project", Kind: JobCypher, Cypher: projectEntitiesCypher(n, p.RelationshipTypes)},
+			{Name: "leiden", Kind: JobCypher, Cypher: leidenCypher},
+			{Name: "drop", Kind: JobCypher, Cypher: fmt.Sprintf("CALL gds.graph.drop(%s, false) YIELD graphName", cypherString(n.graph))},
+			{Name: "hierarchy", Kind: JobCypher, Cypher: hierarchyCypher(n, leiden.IncludeIntermediateCommunities)},
+			{Name: "size", Kind: JobCypher, Cypher: `MATCH (c:` + community + `)<-[:` + graphrag.CypherName(n.parent) + `*0..]-(:` + community + `)<-[:` + graphrag.CypherName(n.inCommunity) + `]-(e:` + entity + `)
+WITH c, count(DISTINCT e) AS size
+SET c.size = size`},
+			{
+				Name:     "summarize",
+				Kind:     JobSummarize,
+				Cypher:   communityContextCypher(n, minSize),
+				Prompt:   prompt,
+				Provider: providerOrDefault(p.LLMConfig.Provider),
+				Model:    p.LLMConfig.Model,
+				WriteCypher: `UNWIND $rows AS row
+MATCH (c:` + community + ` {id: row.communityId})
+SET c.summary = row.summary`,
+			},
+			{
+				Name:     "embed",
+				Kind:     JobEmbed,
+				Provider: providerOrDefault(p.EmbedderConfig.Provider),
+				Model:    p.EmbedderConfig.Model,
+				Cypher: `MATCH (c:` + community + `)
+WHERE c.summary IS NOT NULL
+RETURN c.id AS communityId, c.summary AS text`,
+				WriteCypher: `UNWIND $rows AS row
+MATCH (c:` + community + ` {id: row.communityId})
+CALL db.create.setNodeVectorProperty(c, 'embedding', row.embedding)`,
+			},
+		}...),
+	}, nil
+}
+
+// ToCypher converts a community summary pipeline to a cypher-shell script.
+// Summarize and embed steps appear as comments with the query reading
+// their input; they run through the job from ToJob.
+func (s *KGSerializer) ToCypher(pipeline KGPipeline) (string, error) {
+	p, ok := pipeline.(*CommunitySummaryPipeline)
+	if !ok {
+		return "", fmt.Errorf("pipeline %s: %s pipelines have no Cypher form", pipeline.PipelineName(), pipeline.PipelineType())
+	}
+	job, err := s.ToJob(p)
+	if err != nil {
+		return "", err
+	}
+
+	sections := []string{fmt.Sprintf("// Community summary pipeline: %s", p.Name)}
+	for i, step := range job.Steps {
+		if step.Kind == JobCypher {
+			sections = append(sections, fmt.Sprintf("// Step %d: %s\n%s;", i+1, step.Name, step.Cypher))
+			continue
+		}
+		sections = append(sections, fmt.Sprintf("// Step %d: %s - runs outside Cypher with %s %s\n// Input: %s",
+			i+1, step.Name, step.Provider, step.Model, strings.ReplaceAll(step.Cypher, "\n", "\n// ")))
+	}
+	return strings.Join(sections, "\n\n") + "\n", nil
+}
+
+func providerOrDefault(provider string) string {
+	if provider == "" {
+		return "openai"
+	}
+	return provider
+}
+
+// projectEntitiesCypher projects the entity graph, undirected, with a Cypher
+// aggregation.
+func projectEntitiesCypher(n communityNames, relTypes []string) string {
+	entity := graphrag.CypherName(n.entity)
+	var sb strings.Builder
+	sb.WriteString("MATCH (source:" + entity + ")-[r]->(target:" + entity + ")\n")
+	if len(relTypes) > 0 {
+		quoted := make([]string, len(relTypes))
+		for i, t := range relTypes {
+			quoted[i] = cypherString(t)
+		}
+		sb.WriteString("WHERE type(r) IN [" + strings.Join(quoted, ", ") + "]\n")
+	}
+	fmt.Fprintf(&sb, "WITH gds.graph.project(%s, source, target, {}, {undirectedRelationshipTypes: ['*']}) AS g\n", cypherString(n.graph))
+	sb.WriteString("RETURN g.graphName AS graphName, g.nodeCount AS nodeCount, g.relationshipCount AS relationshipCount")
+	return sb.String()
+}
+
+// hierarchyCypher merges a community node per entity and level. Community
+// IDs are "<level>-<community>"; level 0 is the finest.
+func hierarchyCypher(n communityNames, intermediate bool) string {
+	community := graphrag.CypherName(n.community)
+	communities := "e." + graphrag.CypherName(n.writeProperty)
+	if !intermediate {
+		communities = "[" + communities + "]"
+	}
+	return `MATCH (e:` + graphrag.CypherName(n.entity) + `)
+WHERE e.` + graphrag.CypherName(n.writeProperty) + ` IS NOT NULL
+WITH e, ` + communities + ` AS communities
+UNWIND range(0, size(communities) - 1) AS level
+MERGE (c:` + community + ` {id: toString(level) + '-' + toString(communities[level])})
+ON CREATE SET c.level = level
+FOREACH (_ IN CASE WHEN level = 0 THEN [1] ELSE [] END |
+  MERGE (e)-[:` + graphrag.CypherName(n.inCommunity) + `]->(c))
+FOREACH (_ IN CASE WHEN level > 0 THEN [1] ELSE [] END |
+  MERGE (child:` + community + ` {id: toString(level - 1) + '-' + toString(communities[level - 1])})
+  MERGE (child)-[:` + graphrag.CypherName(n.parent) + `]->(c))`
+}
+
+// communityContextCypher returns one row per community with its entities and
+// the relationships between them, without embeddings.
+func communityContextCypher(n communityNames, minSize int) string {
+	community := graphrag.CypherName(n.community)
+	return fmt.Sprintf(`MATCH (c:%s)<-[:%s*0..]-(:%s)<-[:%s]-(e:%s)
+WITH c, collect(DISTINCT e) AS nodes
+WHERE size(nodes) >= %d
+CALL {
+  WITH nodes
+  UNWIND nodes AS n
+  MATCH (n)-[r]->(m)
+  WHERE m IN nodes
+  RETURN collect(DISTINCT {start: n.id, type: type(r), end: m.id}) AS rels
+}
+RETURN c.id AS communityId,
+       [n IN nodes | n {.*, embedding: null, labels: [l IN labels(n) WHERE l <> %s]}] AS nodes,
+       rels`,
+		community, graphrag.CypherName(n.parent), community, graphrag.CypherName(n.inCommunity), graphrag.CypherName(n.entity), minSize, cypherString(n.entity))
+}
@@ -0,0 +1,146 @@
+package kg
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+)
+
+func communityPipeline() *CommunitySummaryPipeline {
+	return &CommunitySummaryPipeline{
+		BasePipeline: BasePipeline{
+			Name:           "movie_communities",
+			LLMConfig:      &LLMConfig{Model: "gpt-4o"},
+			EmbedderConfig: &EmbedderConfig{Model: "text-embedding-3-small", Dimensions: 1536},
+		},
+		Leiden:            &algorithms.Leiden{IncludeIntermediateCommunities: true, MaxLevels: 3},
+		RelationshipTypes: []string{"ACTED_IN", "DIRECTED"},
+		MinCommunitySize:  2,
+	}
+}
+
+func TestKGSerializer_ToJob(t *testing.T) {
+	job, err := NewKGSerializer().ToJob(communityPipeline())
+	if err != nil {
+		t.Fatalf("ToJob failed: %v", err)
+	}
+
+	var names []string
+	steps := make(map[string]JobStep)
+	for _, step := range job.Steps {
+		names = append(names, step.Name)
+		steps[step.Name] = step
+	}
+	if got := strings.Join(names, ","); got != "constraint,index,project,leiden,drop,hierarchy,size,summarize,embed" {
+		t.Fatalf("unexpected steps: %s", got)
+	}
+
+	checks := []struct {
+		step string
+		want string
+	}{
+		{"index", "`vector.dimensions`: 1536"},
+		{"project", "WHERE type(r) IN ['ACTED_IN', 'DIRECTED']"},
+		{"project", "gds.graph.project('movie_communities_entities', source, target"},
+		{"leiden", "CALL gds.leiden.write(\n  'movie_communities_entities'"},
+		{"leiden", "includeIntermediateCommunities: true"},
+		{"hierarchy", "WITH e, e.`communities` AS communities"},
+		{"hierarchy", "MERGE (child)-[:`PARENT_COMMUNITY`]->(c)"},
+		{"summarize", "WHERE size(nodes) >= 2"},
+	}
+	for _, c := range checks {
+		if !strings.Contains(steps[c.step].Cypher, c.want) {
+			t.Errorf("expected %s step to contain %q, got:\n%s", c.step, c.want, steps[c.step].Cypher)
+		}
+	}
+
+	summarize := steps["summarize"]
+	if summarize.Kind != JobSummarize || summarize.Prompt != DefaultCommunityPrompt || summarize.Provider != "openai" || summarize.Model != "gpt-4o" {
+		t.Errorf("unexpected summarize step: %+v", summarize)
+	}
+	if embed := steps["embed"]; embed.Kind != JobEmbed || !strings.Contains(embed.WriteCypher, "db.create.setNodeVectorProperty") {
+		t.Errorf("unexpected embed step: %+v", embed)
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"kind":"summarize"`) || !strings.Contains(string(data), `"writeCypher":`) {
+		t.Errorf("unexpected job JSON: %s", data)
+	}
+}
+
+func TestKGSerializer_ToJob_SingleLevel(t *testing.T) {
+	p := communityPipeline()
+	p.Leiden = &algorithms.Leiden{WriteProperty: "community"}
+	p.CommunityLabel = "Community"
+
+	job, err := NewKGSerializer().ToJob(p)
+	if err != nil {
+		t.Fatalf("ToJob failed: %v", err)
+	}
+	for _, step := range job.Steps {
+		if step.Name == "hierarchy" && !strings.Contains(step.Cypher, "WITH e, [e.`community`] AS communities") {
+			t.Errorf("expected single community level, got:\n%s", step.Cypher)
+		}
+		if strings.Contains(step.Cypher+step.WriteCypher, "__Community__") {
+			t.Errorf("expected the configured community label, got:\n%s", step.Cypher)
+		}
+	}
+}
+
+func TestKGSerializer_ToCypher_Community(t *testing.T) {
+	s := NewKGSerializer()
+	cypher, err := s.ToCypher(communityPipeline())
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+	for _, want := range []string{
+		"// Step 4: leiden\nCALL gds.leiden.write(",
+		"CALL gds.graph.drop('movie_communities_entities', false) YIELD graphName;",
+		"// Step 8: summarize - runs outside Cypher with openai gpt-4o\n// Input: MATCH (c:`__Community__`)",
+	} {
+		if !strings.Contains(cypher, want) {
+			t.Errorf("expected Cypher to contain %q, got:\n%s", want, cypher)
+		}
+	}
+
+	if _, err := s.ToCypher(&SimpleKGPipeline{BasePipeline: BasePipeline{Name: "docs"}}); err == nil || !strings.Contains(err.Error(), "no Cypher form") {
+		t.Errorf("expected no Cypher form error, got %v", err)
+	}
+}
+
+func TestCommunitySummaryPipeline_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(p *CommunitySummaryPipeline)
+		wantErr string
+	}{
+		{"valid", func(p *CommunitySummaryPipeline) {}, ""},
+		{"no leiden", func(p *CommunitySummaryPipeline) { p.Leiden = nil }, "no Leiden configuration"},
+		{"mutate mode", func(p *CommunitySummaryPipeline) { p.Leiden.Mode = algorithms.Mutate }, "want write"},
+		{"no llm", func(p *CommunitySummaryPipeline) { p.LLMConfig = nil }, "no LLM model"},
+		{"no dimensions", func(p *CommunitySummaryPipeline) { p.EmbedderConfig.Dimensions = 0 }, "with dimensions"},
+		{"prompt placeholder", func(p *CommunitySummaryPipeline) { p.SummaryPrompt = "Summarize." }, "no {community} placeholder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := communityPipeline()
+			tt.modify(p)
+			err := p.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@
 // This package implements type-safe configurations for KG construction including:
 // - SimpleKGPipeline: Standard entity and relationship extraction
 // - CustomKGPipeline: Custom extraction with user-defined prompts
+// - CommunitySummaryPipeline: Community summaries for global search
 // - Text splitters: FixedSizeSplitter, LangChainSplitter
 // - Entity resolvers: ExactMatch, FuzzyMatch, SemanticMatch
 //
@@ -18,6 +19,11 @@
 //		},
 //	}
 //	config, err := serializer.ToJSON(pipeline)
+//
+// Pipelines can also be run from Go with a Runtime and an LLM:
+//
+//	rt := kg.NewRuntime(kg.NewDriverRunner(driver, "neo4j"), llm)
+//	result, err := rt.Run(ctx, pipeline, kg.Document{ID: "report.txt", Text: text})
 package kg
 
 // PipelineType represents the type of KG construction pipeline.
@@ -28,6 +34,8 @@ const (
 	SimpleKG PipelineType = "SimpleKG"
 	// CustomKG is a custom KG pipeline with user-defined prompts.
 	CustomKG PipelineType = "CustomKG"
+	// CommunitySummary summarizes Leiden communities of extracted entities.
+	CommunitySummary PipelineType = "CommunitySummary"
 )
 
 // KGPipeline is the interface that all KG construction pipelines implement.
@@ -54,6 +62,9 @@ type BasePipeline struct {
 	LLMConfig *LLMConfig
 	// EmbedderConfig configures the embedder for similarity.
 	EmbedderConfig *EmbedderConfig
+	// LexicalGraph names the Document and Chunk nodes, their relationships
+	// and indexes (default: the neo4j-graphrag lexical graph).
+	LexicalGraph *LexicalGraphConfig
 }
 
 // PipelineName returns the pipeline name.
@@ -89,6 +100,41 @@ type EmbedderConfig struct {
 	Dimensions int
 }
 
+// LexicalGraphConfig configures the lexical graph written alongside the
+// extracted entities. Empty fields take the neo4j-graphrag defaults.
+type LexicalGraphConfig struct {
+	// DocumentLabel is the document node label (default: Document).
+	DocumentLabel string
+	// ChunkLabel is the chunk node label (default: Chunk).
+	ChunkLabel string
+	// ChunkToDocumentType links a chunk to its document (default:
+	// FROM_DOCUMENT).
+	ChunkToDocumentType string
+	// NextChunkType links consecutive chunks (default: NEXT_CHUNK).
+	NextChunkType string
+	// NodeToChunkType links an entity to the chunks it was extracted from
+	// (default: FROM_CHUNK).
+	NodeToChunkType string
+	// ChunkIDProperty is the chunk ID property (default: id).
+	ChunkIDProperty string
+	// ChunkIndexProperty is the chunk position property (default: index).
+	ChunkIndexProperty string
+	// ChunkTextProperty is the chunk text property (default: text).
+	ChunkTextProperty string
+	// ChunkEmbeddingProperty is the chunk embedding property (default:
+	// embedding).
+	ChunkEmbeddingProperty string
+	// VectorIndexName is the chunk embedding vector index (default:
+	// chunk_embeddings).
+	VectorIndexName string
+	// FulltextIndexName is the chunk text fulltext index (default:
+	// chunk_fulltext).
+	FulltextIndexName string
+	// SimilarityFunction is the vector index similarity function (default:
+	// cosine).
+	SimilarityFunction string
+}
+
 // EntityType defines an entity type for extraction.
 type EntityType struct {
 	// Name is the entity type name (will become a node label).
@@ -147,6 +193,9 @@ type FixedSizeSplitter struct {
 	ChunkSize int
 	// ChunkOverlap is the overlap between chunks.
 	ChunkOverlap int
+	// Tokenizer measures ChunkSize and ChunkOverlap when splitting in Go
+	// (default: characters). It is not serialized.
+	Tokenizer Tokenizer
 }
 
 func (s *FixedSizeSplitter) SplitterType() string { return "fixed_size" }
@@ -161,6 +210,9 @@ type LangChainSplitter struct {
 	ChunkOverlap int
 	// Separators are the separators for recursive splitting.
 	Separators []string
+	// Tokenizer measures ChunkSize and ChunkOverlap when splitting in Go
+	// (default: characters). It is not serialized.
+	Tokenizer Tokenizer
 }
 
 func (s *LangChainSplitter) SplitterType() string { return "langchain" }
@@ -185,6 +237,9 @@ type FuzzyMatchResolver struct {
 	ResolveProperty string
 	// Threshold is the similarity threshold (0-1).
 	Threshold float64
+	// Metric is the string similarity used by Resolve, Levenshtein (default)
+	// or JaroWinkler. It is not serialized.
+	Metric string
 }
 
 func (r *FuzzyMatchResolver) ResolverType() string { return "fuzzy_match" }
@@ -0,0 +1,139 @@
+package kg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+// DefaultLexicalGraphConfig returns the neo4j-graphrag lexical graph.
+func DefaultLexicalGraphConfig() LexicalGraphConfig {
+	return LexicalGraphConfig{
+		DocumentLabel:          "Document",
+		ChunkLabel:             "Chunk",
+		ChunkToDocumentType:    "FROM_DOCUMENT",
+		NextChunkType:          "NEXT_CHUNK",
+		NodeToChunkType:        "FROM_CHUNK",
+		ChunkIDProperty:        "id",
+		ChunkIndexProperty:     "index",
+		ChunkTextProperty:      "text",
+		ChunkEmbeddingProperty: "embedding",
+		VectorIndexName:        "chunk_embeddings",
+		FulltextIndexName:      "chunk_fulltext",
+		SimilarityFunction:     "cosine",
+	}
+}
+
+// resolved returns c with empty fields set to their defaults. c may be nil.
+func (c *LexicalGraphConfig) resolved() LexicalGraphConfig {
+	d := DefaultLexicalGraphConfig()
+	if c == nil {
+		return d
+	}
+	r := *c
+	for _, f := range []struct {
+		value *string
+		def   string
+	}{
+		{&r.DocumentLabel, d.DocumentLabel},
+		{&r.ChunkLabel, d.ChunkLabel},
+		{&r.ChunkToDocumentType, d.ChunkToDocumentType},
+		{&r.NextChunkType, d.NextChunkType},
+		{&r.NodeToChunkType, d.NodeToChunkType},
+		{&r.ChunkIDProperty, d.ChunkIDProperty},
+		{&r.ChunkIndexProperty, d.ChunkIndexProperty},
+		{&r.ChunkTextProperty, d.ChunkTextProperty},
+		{&r.ChunkEmbeddingProperty, d.ChunkEmbeddingProperty},
+		{&r.VectorIndexName, d.VectorIndexName},
+		{&r.FulltextIndexName, d.FulltextIndexName},
+		{&r.SimilarityFunction, d.SimilarityFunction},
+	} {
+		if *f.value == "" {
+			*f.value = f.def
+		}
+	}
+	return r
+}
+
+// LexicalGraphSchema returns the node and relationship types of the lexical
+// graph a pipeline writes: the Document and Chunk nodes with ID
+// constraints, a vector index on chunk embeddings sized by
+// EmbedderConfig.Dimensions and a fulltext index on chunk text. For a
+// SimpleKGPipeline, the node-to-chunk relationship is declared from every
+// entity type. Retrievers over the chunks can be linted against the result.
+func LexicalGraphSchema(pipeline KGPipeline) (*schema.Schema, error) {
+	var base *BasePipeline
+	var entityTypes []EntityType
+	switch p := pipeline.(type) {
+	case *SimpleKGPipeline:
+		base, entityTypes = &p.BasePipeline, p.EntityTypes
+	case *CustomKGPipeline:
+		base = &p.BasePipeline
+	default:
+		return nil, fmt.Errorf("unsupported pipeline type %T", pipeline)
+	}
+	if base.EmbedderConfig == nil || base.EmbedderConfig.Dimensions <= 0 {
+		return nil, fmt.Errorf("pipeline %s: embedder dimensions are required for the chunk vector index", base.Name)
+	}
+	c := base.LexicalGraph.resolved()
+
+	document := &schema.NodeType{
+		Label: c.DocumentLabel,
+		Properties: []schema.Property{
+			{Name: "id", Type: schema.STRING, Required: true, Unique: true},
+			{Name: "text", Type: schema.STRING},
+		},
+		Constraints: []schema.Constraint{
+			{Name: constraintName(c.DocumentLabel, "id"), Type: schema.UNIQUE, Properties: []string{"id"}},
+		},
+		Description: "Source document of the knowledge graph",
+	}
+	chunk := &schema.NodeType{
+		Label: c.ChunkLabel,
+		Properties: []schema.Property{
+			{Name: c.ChunkIDProperty, Type: schema.STRING, Required: true, Unique: true},
+			{Name: c.ChunkIndexProperty, Type: schema.INTEGER},
+			{Name: c.ChunkTextProperty, Type: schema.STRING},
+			{Name: c.ChunkEmbeddingProperty, Type: schema.LIST_FLOAT},
+		},
+		Constraints: []schema.Constraint{
+			{Name: constraintName(c.ChunkLabel, c.ChunkIDProperty), Type: schema.UNIQUE, Properties: []string{c.ChunkIDProperty}},
+		},
+		Indexes: []schema.Index{
+			{
+				Name:       c.VectorIndexName,
+				Type:       schema.VECTOR,
+				Properties: []string{c.ChunkEmbeddingProperty},
+				Options: map[string]any{
+					"dimensions":          base.EmbedderConfig.Dimensions,
+					"similarity_function": c.SimilarityFunction,
+				},
+			},
+			{Name: c.FulltextIndexName, Type: schema.FULLTEXT, Properties: []string{c.ChunkTextProperty}},
+		},
+		Description: "Text chunk of a document",
+	}
+
+	sch := &schema.Schema{
+		Name:  base.Name + " lexical graph",
+		Nodes: []*schema.NodeType{document, chunk},
+		Relationships: []*schema.RelationshipType{
+			{Label: c.ChunkToDocumentType, Source: c.ChunkLabel, Target: c.DocumentLabel, Cardinality: schema.MANY_TO_ONE},
+			{Label: c.NextChunkType, Source: c.ChunkLabel, Target: c.ChunkLabel, Cardinality: schema.ONE_TO_ONE},
+		},
+	}
+	for _, e := range entityTypes {
+		sch.Relationships = append(sch.Relationships, &schema.RelationshipType{
+			Label:       c.NodeToChunkType,
+			Source:      e.Name,
+			Target:      c.ChunkLabel,
+			Cardinality: schema.MANY_TO_MANY,
+		})
+	}
+	return sch, nil
+}
+
+func constraintName(label, property string) string {
+	return strings.Trim(strings.ToLower(label), "_") + "_" + property + "_unique"
+}
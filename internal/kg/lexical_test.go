@@ -0,0 +1,55 @@
+package kg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+func TestLexicalGraphSchema(t *testing.T) {
+	pipeline := moviePipeline()
+	pipeline.EmbedderConfig = &EmbedderConfig{Model: "text-embedding-3-small", Dimensions: 1536}
+	pipeline.LexicalGraph = &LexicalGraphConfig{ChunkLabel: "Passage", VectorIndexName: "passage_vectors"}
+
+	sch, err := LexicalGraphSchema(pipeline)
+	if err != nil {
+		t.Fatalf("LexicalGraphSchema failed: %v", err)
+	}
+	if len(sch.Nodes) != 2 || sch.Nodes[0].Label != "Document" || sch.Nodes[1].Label != "Passage" {
+		t.Fatalf("unexpected nodes: %+v", sch.Nodes)
+	}
+
+	indexes := sch.Nodes[1].Indexes
+	if len(indexes) != 2 || indexes[0].Name != "passage_vectors" || indexes[0].Type != schema.VECTOR || indexes[0].Options["dimensions"] != 1536 {
+		t.Errorf("unexpected vector index: %+v", indexes)
+	}
+	if indexes[1].Name != "chunk_fulltext" || indexes[1].Type != schema.FULLTEXT || indexes[1].Properties[0] != "text" {
+		t.Errorf("unexpected fulltext index: %+v", indexes[1])
+	}
+
+	var rels []string
+	for _, r := range sch.Relationships {
+		rels = append(rels, r.Source+"-"+r.Label+"->"+r.Target)
+	}
+	want := "Passage-FROM_DOCUMENT->Document Passage-NEXT_CHUNK->Passage Person-FROM_CHUNK->Passage Movie-FROM_CHUNK->Passage"
+	if strings.Join(rels, " ") != want {
+		t.Errorf("relationships = %v, want %s", rels, want)
+	}
+
+	v := schema.NewValidator()
+	v.Register(sch.Nodes[0], sch.Nodes[1], &schema.NodeType{Label: "Person"}, &schema.NodeType{Label: "Movie"})
+	for _, r := range sch.Relationships {
+		v.Register(r)
+	}
+	if result := v.ValidateAll(); !result.Valid {
+		t.Errorf("lexical graph schema is invalid: %v", result.Errors)
+	}
+}
+
+func TestLexicalGraphSchema_Dimensions(t *testing.T) {
+	_, err := LexicalGraphSchema(&CustomKGPipeline{BasePipeline: BasePipeline{Name: "docs"}})
+	if err == nil || !strings.Contains(err.Error(), "embedder dimensions are required") {
+		t.Errorf("expected dimensions error, got %v", err)
+	}
+}
@@ -0,0 +1,476 @@
+package kg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+)
+
+const (
+	componentsModule = "experimental.components"
+	fixedSizeModule  = componentsModule + ".text_splitters.fixed_size_splitter"
+	langchainModule  = componentsModule + ".text_splitters.langchain"
+)
+
+// ToPython generates a Python script that runs the pipeline with the
+// neo4j-graphrag package on the file named by its first argument.
+//
+// A SimpleKGPipeline maps to neo4j-graphrag's SimpleKGPipeline, with the
+// schema dict built from EntityTypes and RelationTypes. A CustomKGPipeline
+// maps to a Pipeline of splitter, chunk embedder, extractor and writer
+// components using its prompts. Resolvers other than exact matching on name,
+// which SimpleKGPipeline performs itself, run after the pipeline.
+//
+// Secrets are never inlined. The Neo4j password and provider API keys are
+// read from environment variables: a field value of the form $NAME or
+// ${NAME} names the variable to read, otherwise the provider default
+// (NEO4J_PASSWORD, OPENAI_API_KEY, ...) is used.
+func (s *KGSerializer) ToPython(pipeline KGPipeline) (string, error) {
+	w := &pythonWriter{}
+
+	var body strings.Builder
+	var err error
+	var base *BasePipeline
+	switch p := pipeline.(type) {
+	case *SimpleKGPipeline:
+		base = &p.BasePipeline
+		err = w.writeSimple(&body, p)
+	case *CustomKGPipeline:
+		base = &p.BasePipeline
+		err = w.writeCustom(&body, p)
+	default:
+		return "", fmt.Errorf("unsupported pipeline type %T", pipeline)
+	}
+	if err != nil {
+		return "", fmt.Errorf("pipeline %s: %w", pipeline.PipelineName(), err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# KG pipeline: %s\n", pipeline.PipelineName())
+	sb.WriteString("import asyncio\n")
+	sb.WriteString("import os\n")
+	sb.WriteString("import sys\n\n")
+	w.Write(&sb)
+	graphrag.WriteDriver(&sb, base.Neo4jURI, base.Neo4jUser, base.Neo4jPassword)
+	sb.WriteString(body.String())
+
+	return sb.String(), nil
+}
+
+// pythonWriter collects the imports needed by a generated script.
+type pythonWriter struct {
+	graphrag.Imports
+}
+
+// writeModels writes the llm and embedder variables.
+func (w *pythonWriter) writeModels(sb *strings.Builder, base *BasePipeline) error {
+	llm := base.LLMConfig
+	if llm == nil || llm.Model == "" {
+		return fmt.Errorf("no LLM model configured")
+	}
+	p, err := graphrag.LookupProvider(llm.Provider)
+	if err != nil || p.LLMClass == "" {
+		return fmt.Errorf("unsupported LLM provider %q", llm.Provider)
+	}
+	w.AddGraphRAG("llm", p.LLMClass)
+
+	fmt.Fprintf(sb, "llm = %s(\n", p.LLMClass)
+	fmt.Fprintf(sb, "    model_name=%s,\n", graphrag.PyString(llm.Model))
+	var params []string
+	if llm.Temperature > 0 {
+		params = append(params, fmt.Sprintf("%s: %s", graphrag.PyString("temperature"), graphrag.PyFloat(llm.Temperature)))
+	}
+	if llm.MaxTokens > 0 {
+		params = append(params, fmt.Sprintf("%s: %d", graphrag.PyString("max_tokens"), llm.MaxTokens))
+	}
+	if llm.TopP > 0 {
+		params = append(params, fmt.Sprintf("%s: %s", graphrag.PyString("top_p"), graphrag.PyFloat(llm.TopP)))
+	}
+	if len(params) > 0 {
+		fmt.Fprintf(sb, "    model_params={%s},\n", strings.Join(params, ", "))
+	}
+	graphrag.WriteCredentials(sb, p, llm.APIKey)
+	sb.WriteString(")\n\n")
+
+	emb := base.EmbedderConfig
+	if emb == nil || emb.Model == "" {
+		return fmt.Errorf("no embedder model configured")
+	}
+	p, err = graphrag.LookupProvider(emb.Provider)
+	if err != nil || p.EmbedderClass == "" {
+		return fmt.Errorf("unsupported embedder provider %q", emb.Provider)
+	}
+	w.AddGraphRAG("embeddings", p.EmbedderClass)
+
+	fmt.Fprintf(sb, "embedder = %s(\n", p.EmbedderClass)
+	fmt.Fprintf(sb, "    model=%s,\n", graphrag.PyString(emb.Model))
+	graphrag.WriteCredentials(sb, p, emb.APIKey)
+	sb.WriteString(")\n\n")
+	return nil
+}
+
+// writeSplitter writes the text_splitter variable.
+func (w *pythonWriter) writeSplitter(sb *strings.Builder, splitter TextSplitter) error {
+	switch sp := splitter.(type) {
+	case nil:
+		w.AddGraphRAG(fixedSizeModule, "FixedSizeSplitter")
+		sb.WriteString("text_splitter = FixedSizeSplitter()\n\n")
+	case *FixedSizeSplitter:
+		w.AddGraphRAG(fixedSizeModule, "FixedSizeSplitter")
+		sb.WriteString("text_splitter = FixedSizeSplitter(")
+		var args []string
+		if sp.ChunkSize > 0 {
+			args = append(args, fmt.Sprintf("chunk_size=%d", sp.ChunkSize))
+		}
+		if sp.ChunkSize > 0 || sp.ChunkOverlap > 0 {
+			args = append(args, fmt.Sprintf("chunk_overlap=%d", sp.ChunkOverlap))
+		}
+		sb.WriteString(strings.Join(args, ", ") + ")\n\n")
+	case *LangChainSplitter:
+		class := sp.SplitterClass
+		if class == "" {
+			class = "RecursiveCharacterTextSplitter"
+		}
+		w.AddThirdParty("from langchain_text_splitters import " + class)
+		w.AddGraphRAG(langchainModule, "LangChainTextSplitterAdapter")
+		sb.WriteString("text_splitter = LangChainTextSplitterAdapter(\n")
+		fmt.Fprintf(sb, "    %s(\n", class)
+		if sp.ChunkSize > 0 {
+			fmt.Fprintf(sb, "        chunk_size=%d,\n", sp.ChunkSize)
+		}
+		if sp.ChunkSize > 0 || sp.ChunkOverlap > 0 {
+			fmt.Fprintf(sb, "        chunk_overlap=%d,\n", sp.ChunkOverlap)
+		}
+		if len(sp.Separators) > 0 {
+			if class == "CharacterTextSplitter" {
+				fmt.Fprintf(sb, "        separator=%s,\n", graphrag.PyString(sp.Separators[0]))
+			} else {
+				fmt.Fprintf(sb, "        separators=%s,\n", graphrag.PyStringList(sp.Separators))
+			}
+		}
+		sb.WriteString("    )\n")
+		sb.WriteString(")\n\n")
+	default:
+		return fmt.Errorf("unsupported text splitter %T", splitter)
+	}
+	return nil
+}
+
+// writeSchema writes the schema dict of a SimpleKGPipeline. Patterns are
+// every combination of a relation's source and target types.
+func writeSchema(sb *strings.Builder, entityTypes []EntityType, relationTypes []RelationType) {
+	sb.WriteString("schema = {\n")
+	sb.WriteString("    \"node_types\": [\n")
+	for _, e := range entityTypes {
+		fmt.Fprintf(sb, "        {\"label\": %s", graphrag.PyString(e.Name))
+		if e.Description != "" {
+			fmt.Fprintf(sb, ", \"description\": %s", graphrag.PyString(e.Description))
+		}
+		if len(e.Properties) > 0 {
+			sb.WriteString(", \"properties\": [\n")
+			for _, p := range e.Properties {
+				fmt.Fprintf(sb, "            %s,\n", pyProperty(p.Name, p.Type, p.Description, p.Required))
+			}
+			sb.WriteString("        ]")
+		}
+		sb.WriteString("},\n")
+	}
+	sb.WriteString("    ],\n")
+
+	sb.WriteString("    \"relationship_types\": [\n")
+	for _, r := range relationTypes {
+		fmt.Fprintf(sb, "        {\"label\": %s", graphrag.PyString(r.Name))
+		if r.Description != "" {
+			fmt.Fprintf(sb, ", \"description\": %s", graphrag.PyString(r.Description))
+		}
+		if len(r.Properties) > 0 {
+			sb.WriteString(", \"properties\": [\n")
+			for _, p := range r.Properties {
+				fmt.Fprintf(sb, "            %s,\n", pyProperty(p.Name, p.Type, p.Description, false))
+			}
+			sb.WriteString("        ]")
+		}
+		sb.WriteString("},\n")
+	}
+	sb.WriteString("    ],\n")
+
+	sb.WriteString("    \"patterns\": [\n")
+	for _, r := range relationTypes {
+		for _, source := range r.SourceTypes {
+			for _, target := range r.TargetTypes {
+				fmt.Fprintf(sb, "        (%s, %s, %s),\n", graphrag.PyString(source), graphrag.PyString(r.Name), graphrag.PyString(target))
+			}
+		}
+	}
+	sb.WriteString("    ],\n")
+	sb.WriteString("}\n\n")
+}
+
+func pyProperty(name, typ, description string, required bool) string {
+	fields := []string{
+		fmt.Sprintf("\"name\": %s", graphrag.PyString(name)),
+		fmt.Sprintf("\"type\": %s", graphrag.PyString(propertyTypeOrString(typ))),
+	}
+	if description != "" {
+		fields = append(fields, fmt.Sprintf("\"description\": %s", graphrag.PyString(description)))
+	}
+	if required {
+		fields = append(fields, "\"required\": True")
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+func (w *pythonWriter) writeSimple(sb *strings.Builder, p *SimpleKGPipeline) error {
+	if err := w.writeModels(sb, &p.BasePipeline); err != nil {
+		return err
+	}
+	if err := w.writeSplitter(sb, p.TextSplitter); err != nil {
+		return err
+	}
+	writeSchema(sb, p.EntityTypes, p.RelationTypes)
+
+	resolve := p.PerformEntityResolution == nil || *p.PerformEntityResolution
+	// SimpleKGPipeline resolves exact matches on name itself; other
+	// resolvers run once the pipeline has written the graph.
+	builtIn := true
+	switch r := p.EntityResolver.(type) {
+	case nil:
+	case *ExactMatchResolver:
+		builtIn = resolveProperty(r.ResolveProperty) == "name"
+	default:
+		builtIn = false
+	}
+
+	lexical := w.writeLexicalGraph(sb, p.LexicalGraph)
+
+	w.AddGraphRAG("experimental.pipeline.kg_builder", "SimpleKGPipeline")
+	sb.WriteString("kg_builder = SimpleKGPipeline(\n")
+	sb.WriteString("    llm=llm,\n")
+	sb.WriteString("    driver=driver,\n")
+	sb.WriteString("    embedder=embedder,\n")
+	sb.WriteString("    schema=schema,\n")
+	sb.WriteString("    text_splitter=text_splitter,\n")
+	fmt.Fprintf(sb, "    from_pdf=%s,\n", graphrag.PyBool(p.FromPDF))
+	if p.OnError != "" {
+		onError, err := pythonOnError(p.OnError)
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(p.OnError, OnErrorWarn) {
+			sb.WriteString("    " + warnNote + "\n")
+		}
+		fmt.Fprintf(sb, "    on_error=%s,\n", graphrag.PyString(onError))
+	}
+	if !resolve || !builtIn {
+		sb.WriteString("    perform_entity_resolution=False,\n")
+	}
+	if lexical {
+		sb.WriteString("    lexical_graph_config=lexical_graph_config,\n")
+	}
+	writeDatabase(sb, &p.BasePipeline)
+	sb.WriteString(")\n\n")
+
+	var resolver EntityResolver
+	if resolve && !builtIn {
+		resolver = p.EntityResolver
+	}
+	return w.writeMain(sb, resolver, &p.BasePipeline, func(sb *strings.Builder) {
+		if p.FromPDF {
+			sb.WriteString("    asyncio.run(kg_builder.run_async(file_path=sys.argv[1]))\n")
+		} else {
+			sb.WriteString("    with open(sys.argv[1], encoding=\"utf-8\") as f:\n")
+			sb.WriteString("        asyncio.run(kg_builder.run_async(text=f.read()))\n")
+		}
+	})
+}
+
+func (w *pythonWriter) writeCustom(sb *strings.Builder, p *CustomKGPipeline) error {
+	if p.ExtractionPrompt == "" {
+		return fmt.Errorf("extraction prompt is required")
+	}
+	if err := w.writeModels(sb, &p.BasePipeline); err != nil {
+		return err
+	}
+	if err := w.writeSplitter(sb, p.TextSplitter); err != nil {
+		return err
+	}
+
+	template := p.ExtractionPrompt
+	if p.SchemaPrompt != "" {
+		template = p.SchemaPrompt + "\n\n" + template
+	}
+	w.AddGraphRAG("generation.prompts", "ERExtractionTemplate")
+	fmt.Fprintf(sb, "prompt_template = ERExtractionTemplate(template=%s)\n\n", graphrag.PyString(promptTemplate(template)))
+
+	lexical := w.writeLexicalGraph(sb, p.LexicalGraph)
+
+	onError := OnErrorRaise
+	if p.OnError != "" {
+		var err error
+		if onError, err = pythonOnError(p.OnError); err != nil {
+			return err
+		}
+	}
+	w.AddGraphRAG("experimental.pipeline", "Pipeline")
+	w.AddGraphRAG(componentsModule+".embedder", "TextChunkEmbedder")
+	w.AddGraphRAG(componentsModule+".entity_relation_extractor", "LLMEntityRelationExtractor, OnError")
+	w.AddGraphRAG(componentsModule+".kg_writer", "Neo4jWriter")
+
+	sb.WriteString("pipe = Pipeline()\n")
+	sb.WriteString("pipe.add_component(text_splitter, \"splitter\")\n")
+	sb.WriteString("pipe.add_component(TextChunkEmbedder(embedder=embedder), \"chunk_embedder\")\n")
+	sb.WriteString("pipe.add_component(\n")
+	sb.WriteString("    LLMEntityRelationExtractor(\n")
+	sb.WriteString("        llm=llm,\n")
+	sb.WriteString("        prompt_template=prompt_template,\n")
+	if strings.EqualFold(p.OnError, OnErrorWarn) {
+		sb.WriteString("        " + warnNote + "\n")
+	}
+	fmt.Fprintf(sb, "        on_error=OnError.%s,\n", onError)
+	sb.WriteString("    ),\n")
+	sb.WriteString("    \"extractor\",\n")
+	sb.WriteString(")\n")
+	if p.Neo4jDatabase != "" {
+		fmt.Fprintf(sb, "pipe.add_component(Neo4jWriter(driver, neo4j_database=%s), \"writer\")\n", graphrag.PyString(p.Neo4jDatabase))
+	} else {
+		sb.WriteString("pipe.add_component(Neo4jWriter(driver), \"writer\")\n")
+	}
+	sb.WriteString("pipe.connect(\"splitter\", \"chunk_embedder\", input_config={\"text_chunks\": \"splitter\"})\n")
+	sb.WriteString("pipe.connect(\"chunk_embedder\", \"extractor\", input_config={\"chunks\": \"chunk_embedder\"})\n")
+	sb.WriteString("pipe.connect(\"extractor\", \"writer\", input_config={\"graph\": \"extractor\"})\n\n")
+
+	return w.writeMain(sb, p.EntityResolver, &p.BasePipeline, func(sb *strings.Builder) {
+		sb.WriteString("    with open(sys.argv[1], encoding=\"utf-8\") as f:\n")
+		if lexical {
+			sb.WriteString("        inputs = {\n")
+			sb.WriteString("            \"splitter\": {\"text\": f.read()},\n")
+			sb.WriteString("            \"extractor\": {\"lexical_graph_config\": lexical_graph_config},\n")
+			sb.WriteString("        }\n")
+			sb.WriteString("        asyncio.run(pipe.run(inputs))\n")
+		} else {
+			sb.WriteString("        asyncio.run(pipe.run({\"splitter\": {\"text\": f.read()}}))\n")
+		}
+	})
+}
+
+// writeLexicalGraph writes the lexical_graph_config variable and reports
+// whether it did. Index names are not part of the neo4j-graphrag config.
+// warnNote explains the OnError mode generated for WARN.
+const warnNote = "# WARN: neo4j-graphrag has no WARN mode; IGNORE skips failed chunks and logs them at ERROR level."
+
+// pythonOnError returns the neo4j-graphrag OnError member for onError.
+// neo4j-graphrag only defines RAISE and IGNORE; WARN maps to IGNORE, which
+// also logs each failed chunk.
+func pythonOnError(onError string) (string, error) {
+	switch strings.ToUpper(onError) {
+	case OnErrorRaise:
+		return OnErrorRaise, nil
+	case OnErrorIgnore, OnErrorWarn:
+		return OnErrorIgnore, nil
+	default:
+		return "", fmt.Errorf("unknown OnError %q", onError)
+	}
+}
+
+func (w *pythonWriter) writeLexicalGraph(sb *strings.Builder, c *LexicalGraphConfig) bool {
+	if c == nil {
+		return false
+	}
+	var args []string
+	for _, f := range []struct{ name, value string }{
+		{"document_node_label", c.DocumentLabel},
+		{"chunk_node_label", c.ChunkLabel},
+		{"chunk_to_document_relationship_type", c.ChunkToDocumentType},
+		{"next_chunk_relationship_type", c.NextChunkType},
+		{"node_to_chunk_relationship_type", c.NodeToChunkType},
+		{"chunk_id_property", c.ChunkIDProperty},
+		{"chunk_index_property", c.ChunkIndexProperty},
+		{"chunk_text_property", c.ChunkTextProperty},
+		{"chunk_embedding_property", c.ChunkEmbeddingProperty},
+	} {
+		if f.value != "" {
+			args = append(args, fmt.Sprintf("%s=%s", f.name, graphrag.PyString(f.value)))
+		}
+	}
+	if len(args) == 0 {
+		return false
+	}
+
+	w.AddGraphRAG(componentsModule+".types", "LexicalGraphConfig")
+	sb.WriteString("lexical_graph_config = LexicalGraphConfig(\n")
+	for _, arg := range args {
+		fmt.Fprintf(sb, "    %s,\n", arg)
+	}
+	sb.WriteString(")\n\n")
+	return true
+}
+
+// promptTemplate escapes the braces of a prompt for Python's str.format,
+// keeping the {text} placeholder.
+func promptTemplate(prompt string) string {
+	escaped := strings.NewReplacer("{", "{{", "}", "}}").Replace(prompt)
+	return strings.ReplaceAll(escaped, "{{text}}", "{text}")
+}
+
+// writeMain writes the script entry point: the run, then the resolver if
+// any, then closing the driver.
+func (w *pythonWriter) writeMain(sb *strings.Builder, resolver EntityResolver, base *BasePipeline, run func(sb *strings.Builder)) error {
+	var resolverCall strings.Builder
+	if resolver != nil {
+		if err := w.writeResolver(&resolverCall, resolver, base); err != nil {
+			return err
+		}
+	}
+
+	sb.WriteString("if __name__ == \"__main__\":\n")
+	run(sb)
+	sb.WriteString(resolverCall.String())
+	sb.WriteString("    driver.close()\n")
+	return nil
+}
+
+// writeResolver writes the resolver run that follows the pipeline.
+func (w *pythonWriter) writeResolver(sb *strings.Builder, resolver EntityResolver, base *BasePipeline) error {
+	const module = componentsModule + ".resolver"
+	var args []string
+	switch r := resolver.(type) {
+	case *ExactMatchResolver:
+		w.AddGraphRAG(module, "SinglePropertyExactMatchResolver")
+		args = append(args, "SinglePropertyExactMatchResolver(", "driver", fmt.Sprintf("resolve_property=%s", graphrag.PyString(resolveProperty(r.ResolveProperty))))
+	case *FuzzyMatchResolver:
+		w.AddGraphRAG(module, "FuzzyMatchResolver")
+		args = append(args, "FuzzyMatchResolver(", "driver", fmt.Sprintf("resolve_properties=[%s]", graphrag.PyString(resolveProperty(r.ResolveProperty))))
+		if r.Threshold > 0 {
+			args = append(args, fmt.Sprintf("similarity_threshold=%s", graphrag.PyFloat(r.Threshold)))
+		}
+	case *SemanticMatchResolver:
+		w.AddGraphRAG(module, "SpaCySemanticMatchResolver")
+		args = append(args, "SpaCySemanticMatchResolver(", "driver", fmt.Sprintf("resolve_properties=[%s]", graphrag.PyString(resolveProperty(r.ResolveProperty))))
+		if r.Threshold > 0 {
+			args = append(args, fmt.Sprintf("similarity_threshold=%s", graphrag.PyFloat(r.Threshold)))
+		}
+		if r.Model != "" {
+			args = append(args, fmt.Sprintf("spacy_model=%s", graphrag.PyString(r.Model)))
+		}
+	default:
+		return fmt.Errorf("unsupported entity resolver %T", resolver)
+	}
+	if base.Neo4jDatabase != "" {
+		args = append(args, fmt.Sprintf("neo4j_database=%s", graphrag.PyString(base.Neo4jDatabase)))
+	}
+
+	fmt.Fprintf(sb, "    resolver = %s\n", args[0])
+	for _, arg := range args[1:] {
+		fmt.Fprintf(sb, "        %s,\n", arg)
+	}
+	sb.WriteString("    )\n")
+	sb.WriteString("    asyncio.run(resolver.run())\n")
+	return nil
+}
+
+func writeDatabase(sb *strings.Builder, base *BasePipeline) {
+	if base.Neo4jDatabase != "" {
+		fmt.Fprintf(sb, "    neo4j_database=%s,\n", graphrag.PyString(base.Neo4jDatabase))
+	}
+}
@@ -0,0 +1,203 @@
+package kg
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestKGSerializer_ToPython_Golden(t *testing.T) {
+	base := BasePipeline{
+		Name:           "movies",
+		Neo4jPassword:  "s3cret-password",
+		Neo4jDatabase:  "neo4j",
+		LLMConfig:      &LLMConfig{Provider: "openai", Model: "gpt-4o", Temperature: 0.2, MaxTokens: 2000},
+		EmbedderConfig: &EmbedderConfig{Provider: "openai", Model: "text-embedding-3-small"},
+	}
+	entityTypes := []EntityType{
+		{Name: "Person", Description: "A person", Properties: []EntityProperty{{Name: "name", Type: "STRING", Required: true}}},
+		{Name: "Movie", Properties: []EntityProperty{{Name: "title", Required: true}, {Name: "released", Type: "INTEGER"}}},
+		{Name: "Show"},
+	}
+	relationTypes := []RelationType{
+		{Name: "ACTED_IN", SourceTypes: []string{"Person"}, TargetTypes: []string{"Movie", "Show"},
+			Properties: []RelationProperty{{Name: "role", Type: "STRING"}}},
+	}
+
+	tests := []struct {
+		golden   string
+		pipeline KGPipeline
+	}{
+		{"simple", &SimpleKGPipeline{
+			BasePipeline:  base,
+			EntityTypes:   entityTypes,
+			RelationTypes: relationTypes,
+			TextSplitter:  &FixedSizeSplitter{ChunkSize: 500, ChunkOverlap: 50},
+			FromPDF:       true,
+			OnError:       OnErrorIgnore,
+		}},
+		{"langchain_fuzzy", &SimpleKGPipeline{
+			BasePipeline: BasePipeline{
+				Name:           "articles",
+				LLMConfig:      &LLMConfig{Provider: "anthropic", Model: "claude-sonnet", APIKey: "$LLM_KEY"},
+				EmbedderConfig: &EmbedderConfig{Provider: "ollama", Model: "nomic-embed-text"},
+			},
+			EntityTypes:    entityTypes[:2],
+			RelationTypes:  []RelationType{{Name: "DIRECTED", SourceTypes: []string{"Person"}, TargetTypes: []string{"Movie"}}},
+			TextSplitter:   &LangChainSplitter{ChunkSize: 1000, ChunkOverlap: 100, Separators: []string{"\n\n", "\n"}},
+			EntityResolver: &FuzzyMatchResolver{ResolveProperty: "name", Threshold: 0.85},
+		}},
+		{"custom", &CustomKGPipeline{
+			BasePipeline: BasePipeline{
+				Name:           base.Name,
+				Neo4jDatabase:  base.Neo4jDatabase,
+				LLMConfig:      base.LLMConfig,
+				EmbedderConfig: base.EmbedderConfig,
+				LexicalGraph:   &LexicalGraphConfig{ChunkLabel: "Passage", VectorIndexName: "passage_vectors"},
+			},
+			SchemaPrompt:     "Extract Person and Movie nodes.",
+			ExtractionPrompt: "Return JSON like {\"nodes\": []} for:\n{text}",
+			EntityResolver:   &SemanticMatchResolver{Threshold: 0.9, Model: "en_core_web_lg"},
+			OnError:          OnErrorWarn,
+		}},
+	}
+
+	s := NewKGSerializer()
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			got, err := s.ToPython(tt.pipeline)
+			if err != nil {
+				t.Fatalf("ToPython failed: %v", err)
+			}
+
+			path := filepath.Join("testdata", "python", tt.golden+".py")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file (run with -update to create): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("ToPython output does not match %s:\n%s", path, got)
+			}
+		})
+	}
+}
+
+func TestKGSerializer_ToPython_NoInlineSecrets(t *testing.T) {
+	p := &SimpleKGPipeline{
+		BasePipeline: BasePipeline{
+			Name:           "docs",
+			Neo4jPassword:  "neo4j-secret",
+			LLMConfig:      &LLMConfig{Model: "gpt-4o", APIKey: "sk-llm-secret"},
+			EmbedderConfig: &EmbedderConfig{Model: "text-embedding-3-small", APIKey: "sk-embed-secret"},
+		},
+	}
+
+	python, err := NewKGSerializer().ToPython(p)
+	if err != nil {
+		t.Fatalf("ToPython failed: %v", err)
+	}
+	for _, secret := range []string{"neo4j-secret", "sk-llm-secret", "sk-embed-secret"} {
+		if strings.Contains(python, secret) {
+			t.Errorf("secret %q inlined in output:\n%s", secret, python)
+		}
+	}
+	for _, env := range []string{`os.environ["NEO4J_PASSWORD"]`, `os.environ["OPENAI_API_KEY"]`} {
+		if !strings.Contains(python, env) {
+			t.Errorf("expected %s in output:\n%s", env, python)
+		}
+	}
+}
+
+func TestKGSerializer_ToPython_OnErrorWarn(t *testing.T) {
+	p := &SimpleKGPipeline{
+		BasePipeline: BasePipeline{
+			Name:           "docs",
+			LLMConfig:      &LLMConfig{Model: "gpt-4o"},
+			EmbedderConfig: &EmbedderConfig{Model: "text-embedding-3-small"},
+		},
+		OnError: OnErrorWarn,
+	}
+
+	python, err := NewKGSerializer().ToPython(p)
+	if err != nil {
+		t.Fatalf("ToPython failed: %v", err)
+	}
+	if !strings.Contains(python, `on_error="IGNORE"`) || strings.Contains(python, `on_error="WARN"`) {
+		t.Errorf("expected WARN to map to IGNORE:\n%s", python)
+	}
+	if !strings.Contains(python, "# WARN: neo4j-graphrag has no WARN mode") {
+		t.Errorf("expected a note on WARN:\n%s", python)
+	}
+}
+
+func TestKGSerializer_ToPython_ChunkOverlap(t *testing.T) {
+	base := BasePipeline{
+		Name:           "docs",
+		LLMConfig:      &LLMConfig{Model: "gpt-4o"},
+		EmbedderConfig: &EmbedderConfig{Model: "text-embedding-3-small"},
+	}
+
+	tests := []struct {
+		name     string
+		splitter TextSplitter
+		want     string
+	}{
+		{"defaults", &FixedSizeSplitter{}, "text_splitter = FixedSizeSplitter()"},
+		{"size without overlap", &FixedSizeSplitter{ChunkSize: 500}, "FixedSizeSplitter(chunk_size=500, chunk_overlap=0)"},
+		{"langchain size without overlap", &LangChainSplitter{ChunkSize: 500}, "        chunk_overlap=0,\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			python, err := NewKGSerializer().ToPython(&SimpleKGPipeline{BasePipeline: base, TextSplitter: tt.splitter})
+			if err != nil {
+				t.Fatalf("ToPython failed: %v", err)
+			}
+			if !strings.Contains(python, tt.want) {
+				t.Errorf("expected %q in:\n%s", tt.want, python)
+			}
+		})
+	}
+}
+
+func TestKGSerializer_ToPython_Errors(t *testing.T) {
+	models := BasePipeline{
+		LLMConfig:      &LLMConfig{Model: "gpt-4o"},
+		EmbedderConfig: &EmbedderConfig{Model: "text-embedding-3-small"},
+	}
+
+	tests := []struct {
+		name     string
+		pipeline KGPipeline
+		wantErr  string
+	}{
+		{"no llm model", &SimpleKGPipeline{}, "no LLM model"},
+		{"no embedder model", &SimpleKGPipeline{BasePipeline: BasePipeline{LLMConfig: models.LLMConfig}}, "no embedder model"},
+		{"llm provider", &SimpleKGPipeline{BasePipeline: BasePipeline{LLMConfig: &LLMConfig{Provider: "sentence-transformers", Model: "m"}}}, "unsupported LLM provider"},
+		{"embedder provider", &SimpleKGPipeline{BasePipeline: BasePipeline{LLMConfig: models.LLMConfig, EmbedderConfig: &EmbedderConfig{Provider: "acme", Model: "m"}}}, "unsupported embedder provider"},
+		{"no extraction prompt", &CustomKGPipeline{BasePipeline: models}, "extraction prompt is required"},
+		{"on error", &SimpleKGPipeline{BasePipeline: models, OnError: "SKIP"}, `unknown OnError "SKIP"`},
+	}
+
+	s := NewKGSerializer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ToPython(tt.pipeline)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ToPython() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
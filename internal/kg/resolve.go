@@ -0,0 +1,385 @@
+package kg
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+)
+
+// DefaultResolveThreshold is the similarity threshold used when a fuzzy or
+// semantic resolver has no Threshold, as in neo4j-graphrag.
+const DefaultResolveThreshold = 0.8
+
+// Fuzzy matching metrics.
+const (
+	// Levenshtein scores 1 - edit distance / length of the longer string.
+	Levenshtein = "levenshtein"
+	// JaroWinkler scores the Jaro-Winkler similarity, favoring common
+	// prefixes.
+	JaroWinkler = "jaro_winkler"
+)
+
+// Entity is an extracted entity node considered for resolution.
+type Entity struct {
+	// ID identifies the node; its element ID when read from Neo4j.
+	ID string
+	// Label is the entity type. Only entities with the same label merge.
+	Label string
+	// Properties holds the node properties, including the resolve property.
+	Properties map[string]any
+	// Embedding is the vector compared by SemanticMatchResolver.
+	Embedding []float64
+}
+
+// MergeDecision merges duplicate entities into the one kept.
+type MergeDecision struct {
+	// Label is the label shared by the merged entities.
+	Label string
+	// Keep is the entity whose properties survive the merge.
+	Keep Entity
+	// Merge are the entities merged into Keep.
+	Merge []Entity
+	// Score is the weakest similarity that linked the cluster, 1 for exact
+	// matches.
+	Score float64
+}
+
+// Resolution is the outcome of resolving a set of entities.
+type Resolution struct {
+	// Resolver is the resolver type that produced the decisions.
+	Resolver string
+	// Property is the resolve property compared.
+	Property string
+	// Threshold is the similarity threshold applied, 0 for exact matches.
+	Threshold float64
+	// Entities is the number of entities considered.
+	Entities int
+	// Decisions lists one merge per cluster of duplicates, ordered by label
+	// and by the position of the kept entity in the input.
+	Decisions []MergeDecision
+}
+
+// Resolve groups entities by label and clusters those the resolver matches.
+// Clusters are linked transitively: if A matches B and B matches C, all three
+// merge. The first entity of a cluster, in input order, is kept.
+//
+// ExactMatchResolver matches equal resolve property values.
+// FuzzyMatchResolver compares normalized values (lower-cased, punctuation
+// removed) with its Metric. SemanticMatchResolver compares the cosine
+// similarity of entity embeddings. Entities without a resolve property value,
+// or without an embedding for semantic matching, are left alone.
+func Resolve(resolver EntityResolver, entities []Entity) (*Resolution, error) {
+	res := &Resolution{Resolver: resolver.ResolverType(), Entities: len(entities)}
+
+	var similarity func(a, b Entity) (float64, bool)
+	switch r := resolver.(type) {
+	case *ExactMatchResolver:
+		res.Property = resolveProperty(r.ResolveProperty)
+		similarity = func(a, b Entity) (float64, bool) {
+			va, okA := propertyString(a, res.Property)
+			vb, okB := propertyString(b, res.Property)
+			if !okA || !okB || va != vb {
+				return 0, false
+			}
+			return 1, true
+		}
+	case *FuzzyMatchResolver:
+		res.Property = resolveProperty(r.ResolveProperty)
+		res.Threshold = resolveThreshold(r.Threshold)
+		var metric func(a, b string) float64
+		switch r.Metric {
+		case "", Levenshtein:
+			metric = levenshteinSimilarity
+		case JaroWinkler:
+			metric = jaroWinklerSimilarity
+		default:
+			return nil, fmt.Errorf("unknown fuzzy match metric %q", r.Metric)
+		}
+		similarity = func(a, b Entity) (float64, bool) {
+			va, okA := propertyString(a, res.Property)
+			vb, okB := propertyString(b, res.Property)
+			if !okA || !okB {
+				return 0, false
+			}
+			score := metric(normalizeName(va), normalizeName(vb))
+			return score, score >= res.Threshold
+		}
+	case *SemanticMatchResolver:
+		res.Property = resolveProperty(r.ResolveProperty)
+		res.Threshold = resolveThreshold(r.Threshold)
+		similarity = func(a, b Entity) (float64, bool) {
+			if len(a.Embedding) == 0 || len(a.Embedding) != len(b.Embedding) {
+				return 0, false
+			}
+			score := graphrag.CosineSimilarity(a.Embedding, b.Embedding)
+			return score, score >= res.Threshold
+		}
+	default:
+		return nil, fmt.Errorf("unsupported entity resolver %T", resolver)
+	}
+
+	if res.Threshold < 0 || res.Threshold > 1 {
+		return nil, fmt.Errorf("threshold %v must be between 0 and 1", res.Threshold)
+	}
+
+	byLabel := make(map[string][]int)
+	var labels []string
+	for i, e := range entities {
+		if _, ok := byLabel[e.Label]; !ok {
+			labels = append(labels, e.Label)
+		}
+		byLabel[e.Label] = append(byLabel[e.Label], i)
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		res.Decisions = append(res.Decisions, clusterEntities(entities, byLabel[label], similarity)...)
+	}
+	return res, nil
+}
+
+// clusterEntities links the matching pairs among the entities at indexes and
+// returns a decision per cluster of two or more.
+func clusterEntities(entities []Entity, indexes []int, similarity func(a, b Entity) (float64, bool)) []MergeDecision {
+	parent := make(map[int]int, len(indexes))
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, i := range indexes {
+		parent[i] = i
+	}
+
+	weakest := make(map[int]float64)
+	for x, i := range indexes {
+		for _, j := range indexes[x+1:] {
+			score, ok := similarity(entities[i], entities[j])
+			if !ok {
+				continue
+			}
+			ri, rj := find(i), find(j)
+			if ri == rj {
+				continue
+			}
+			// Keep the earlier entity as the root, so it is the one kept.
+			if rj < ri {
+				ri, rj = rj, ri
+			}
+			low := score
+			for _, root := range []int{ri, rj} {
+				if w, ok := weakest[root]; ok && w < low {
+					low = w
+				}
+			}
+			parent[rj] = ri
+			delete(weakest, rj)
+			weakest[ri] = low
+		}
+	}
+
+	var decisions []MergeDecision
+	byRoot := make(map[int]int)
+	for _, i := range indexes {
+		root := find(i)
+		if root == i {
+			continue
+		}
+		d, ok := byRoot[root]
+		if !ok {
+			decisions = append(decisions, MergeDecision{
+				Label: entities[root].Label,
+				Keep:  entities[root],
+				Score: weakest[root],
+			})
+			d = len(decisions) - 1
+			byRoot[root] = d
+		}
+		decisions[d].Merge = append(decisions[d].Merge, entities[i])
+	}
+	return decisions
+}
+
+func resolveProperty(property string) string {
+	if property == "" {
+		return "name"
+	}
+	return property
+}
+
+func resolveThreshold(threshold float64) float64 {
+	if threshold == 0 {
+		return DefaultResolveThreshold
+	}
+	return threshold
+}
+
+func propertyString(e Entity, property string) (string, bool) {
+	v, ok := e.Properties[property]
+	if !ok || v == nil {
+		return "", false
+	}
+	s := fmt.Sprint(v)
+	return s, s != ""
+}
+
+// normalizeName lower-cases s and reduces punctuation and spacing to single
+// spaces.
+func normalizeName(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func levenshteinSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+func jaroWinklerSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(max(len(ra), len(rb))/2-1, 0)
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		for j := max(0, i-window); j < min(len(rb), i+window+1); j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// mergeNodesCypher merges a cluster by element ID, keeping the properties
+// of the first node, as the neo4j-graphrag resolvers do.
+const mergeNodesCypher = `MATCH (n) WHERE elementId(n) IN [%s]
+WITH n ORDER BY CASE elementId(n) WHEN %s THEN 0 ELSE 1 END
+WITH collect(n) AS nodes
+CALL apoc.refactor.mergeNodes(nodes, {properties: 'discard', mergeRels: true}) YIELD node
+RETURN elementId(node) AS id;
+`
+
+// ToCypher returns an apoc.refactor.mergeNodes statement per decision. The
+// kept node's properties win and relationships are moved to it.
+func (r *Resolution) ToCypher() string {
+	var sb strings.Builder
+	for i, d := range r.Decisions {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		ids := []string{cypherString(d.Keep.ID)}
+		for _, e := range d.Merge {
+			ids = append(ids, cypherString(e.ID))
+		}
+		fmt.Fprintf(&sb, "// Merge %d %s into %s (score %.3f)\n", len(d.Merge), d.Label, r.describe(d.Keep), d.Score)
+		fmt.Fprintf(&sb, mergeNodesCypher, strings.Join(ids, ", "), ids[0])
+	}
+	return sb.String()
+}
+
+func cypherString(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", `\'`) + "'"
+}
+
+// describe names an entity by its resolve property value and ID.
+func (r *Resolution) describe(e Entity) string {
+	if v, ok := propertyString(e, r.Property); ok {
+		return fmt.Sprintf("%q (%s)", v, e.ID)
+	}
+	return e.ID
+}
+
+// WriteReport writes a dry-run summary of the clusters r would merge, with
+// the weakest link score of each, for tuning thresholds.
+func (r *Resolution) WriteReport(w io.Writer) error {
+	merged := 0
+	for _, d := range r.Decisions {
+		merged += len(d.Merge)
+	}
+
+	fmt.Fprintf(w, "Resolver: %s on %s", r.Resolver, r.Property)
+	if r.Threshold > 0 {
+		fmt.Fprintf(w, " (threshold %.2f)", r.Threshold)
+	}
+	fmt.Fprintf(w, "\nEntities: %d, clusters: %d, merged: %d\n", r.Entities, len(r.Decisions), merged)
+	if len(r.Decisions) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LABEL\tSCORE\tKEEP\tMERGE")
+	for _, d := range r.Decisions {
+		merge := make([]string, len(d.Merge))
+		for i, e := range d.Merge {
+			merge[i] = r.describe(e)
+		}
+		fmt.Fprintf(tw, "%s\t%.3f\t%s\t%s\n", d.Label, d.Score, r.describe(d.Keep), strings.Join(merge, ", "))
+	}
+	return tw.Flush()
+}
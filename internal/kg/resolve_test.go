@@ -0,0 +1,148 @@
+package kg
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func person(id, name string) Entity {
+	return Entity{ID: id, Label: "Person", Properties: map[string]any{"name": name}}
+}
+
+func mergedIDs(d MergeDecision) string {
+	ids := []string{d.Keep.ID}
+	for _, e := range d.Merge {
+		ids = append(ids, e.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestResolve(t *testing.T) {
+	entities := []Entity{
+		person("p1", "Alice Smith"),
+		person("p2", "alice smith"),
+		person("p3", "Alice Smyth"),
+		person("p4", "Bob Jones"),
+		{ID: "o1", Label: "Organization", Properties: map[string]any{"name": "Alice Smith"}},
+		{ID: "p5", Label: "Person"},
+	}
+
+	tests := []struct {
+		name     string
+		resolver EntityResolver
+		want     []string
+	}{
+		{"exact", &ExactMatchResolver{}, nil},
+		{"levenshtein", &FuzzyMatchResolver{Threshold: 0.9}, []string{"p1,p2,p3"}},
+		{"levenshtein strict", &FuzzyMatchResolver{Threshold: 0.95}, []string{"p1,p2"}},
+		{"jaro winkler", &FuzzyMatchResolver{Threshold: 0.9, Metric: JaroWinkler}, []string{"p1,p2,p3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Resolve(tt.resolver, entities)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			var got []string
+			for _, d := range res.Decisions {
+				got = append(got, mergedIDs(d))
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got clusters %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve_ExactMatch(t *testing.T) {
+	entities := []Entity{person("p1", "Ada"), person("p2", "Grace"), person("p3", "Ada")}
+	res, err := Resolve(&ExactMatchResolver{}, entities)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if len(res.Decisions) != 1 || mergedIDs(res.Decisions[0]) != "p1,p3" || res.Decisions[0].Score != 1 {
+		t.Errorf("unexpected decisions: %+v", res.Decisions)
+	}
+}
+
+func TestResolve_Semantic(t *testing.T) {
+	entities := []Entity{
+		{ID: "c1", Label: "Concept", Embedding: []float64{1, 0}},
+		{ID: "c2", Label: "Concept", Embedding: []float64{0.95, 0.1}},
+		{ID: "c3", Label: "Concept", Embedding: []float64{0, 1}},
+		{ID: "c4", Label: "Concept"},
+	}
+	res, err := Resolve(&SemanticMatchResolver{Threshold: 0.9}, entities)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if len(res.Decisions) != 1 || mergedIDs(res.Decisions[0]) != "c1,c2" {
+		t.Fatalf("unexpected decisions: %+v", res.Decisions)
+	}
+	if math.Abs(res.Decisions[0].Score-0.9945) > 0.001 {
+		t.Errorf("unexpected score %v", res.Decisions[0].Score)
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver EntityResolver
+		errMsg   string
+	}{
+		{"threshold", &FuzzyMatchResolver{Threshold: 1.5}, "between 0 and 1"},
+		{"metric", &FuzzyMatchResolver{Metric: "soundex"}, "unknown fuzzy match metric"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Resolve(tt.resolver, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestStringSimilarity(t *testing.T) {
+	if got := levenshteinSimilarity("kitten", "sitting"); math.Abs(got-(1-3.0/7)) > 1e-9 {
+		t.Errorf("levenshtein = %v", got)
+	}
+	if got := jaroWinklerSimilarity("martha", "marhta"); math.Abs(got-0.9611) > 0.001 {
+		t.Errorf("jaro-winkler = %v", got)
+	}
+	if got := jaroWinklerSimilarity("abc", "xyz"); got != 0 {
+		t.Errorf("jaro-winkler of disjoint strings = %v", got)
+	}
+}
+
+func TestResolution_Output(t *testing.T) {
+	res, err := Resolve(&FuzzyMatchResolver{Threshold: 0.9}, []Entity{person("p1", "Alice Smith"), person("p2", "Alice Smyth")})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	cypher := res.ToCypher()
+	for _, want := range []string{
+		"WHERE elementId(n) IN ['p1', 'p2']",
+		"CASE elementId(n) WHEN 'p1' THEN 0",
+		"apoc.refactor.mergeNodes(nodes, {properties: 'discard', mergeRels: true})",
+	} {
+		if !strings.Contains(cypher, want) {
+			t.Errorf("expected Cypher to contain %q, got:\n%s", want, cypher)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := res.WriteReport(&buf); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	for _, want := range []string{"fuzzy_match on name (threshold 0.90)", "clusters: 1, merged: 1", `"Alice Smith" (p1)`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,770 @@
+package kg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// DefaultBatchSize is the number of rows written per query when
+// Runtime.BatchSize is 0.
+const DefaultBatchSize = 1000
+
+// Error handling modes for OnError.
+const (
+	// OnErrorRaise stops the run at the first chunk that fails extraction.
+	OnErrorRaise = "RAISE"
+	// OnErrorIgnore skips chunks that fail extraction.
+	OnErrorIgnore = "IGNORE"
+	// OnErrorWarn skips chunks that fail extraction and records a warning.
+	OnErrorWarn = "WARN"
+)
+
+// LLM completes a prompt. Implementations wrap a provider API.
+type LLM interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// ScriptedLLM is a fake LLM that replays canned responses in order, for
+// tests. It records the prompts it receives.
+type ScriptedLLM struct {
+	// Responses are returned one per call.
+	Responses []string
+	// Prompts holds the prompts received so far.
+	Prompts []string
+}
+
+// NewScriptedLLM creates a ScriptedLLM returning responses in order.
+func NewScriptedLLM(responses ...string) *ScriptedLLM {
+	return &ScriptedLLM{Responses: responses}
+}
+
+// Complete returns the next response, or an error when none are left.
+func (l *ScriptedLLM) Complete(_ context.Context, prompt string) (string, error) {
+	l.Prompts = append(l.Prompts, prompt)
+	if len(l.Prompts) > len(l.Responses) {
+		return "", fmt.Errorf("scripted LLM has no response for call %d", len(l.Prompts))
+	}
+	return l.Responses[len(l.Prompts)-1], nil
+}
+
+// QueryRunner runs a Cypher query and returns its records as maps.
+type QueryRunner interface {
+	Run(ctx context.Context, query string, params map[string]any) ([]map[string]any, error)
+}
+
+// driverRunner is a QueryRunner backed by a neo4j-go-driver session.
+type driverRunner struct {
+	driver   neo4j.DriverWithContext
+	database string
+}
+
+// NewDriverRunner creates a QueryRunner that runs each query in a write
+// transaction on database (default: neo4j) through driver.
+func NewDriverRunner(driver neo4j.DriverWithContext, database string) QueryRunner {
+	if database == "" {
+		database = "neo4j"
+	}
+	return &driverRunner{driver: driver, database: database}
+}
+
+// Run executes query in a write transaction and collects all records.
+func (r *driverRunner) Run(ctx context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: r.database,
+		AccessMode:   neo4j.AccessModeWrite,
+	})
+	defer func() { _ = session.Close(ctx) }()
+
+	rows, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]map[string]any, len(records))
+		for i, record := range records {
+			rows[i] = record.AsMap()
+		}
+		return rows, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows.([]map[string]any), nil
+}
+
+// Document is a source document to ingest.
+type Document struct {
+	// ID identifies the document, such as its path. Re-ingesting a document
+	// with the same ID replaces its chunks. Defaults to a hash of Text.
+	ID string
+	// Text is the document content.
+	Text string
+	// Metadata is stored as properties of the Document node.
+	Metadata map[string]any
+}
+
+// ExtractedNode is an entity returned by the LLM for a chunk.
+type ExtractedNode struct {
+	ID         string         `json:"id"`
+	Label      string         `json:"label"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// ExtractedRelationship is a relationship returned by the LLM for a chunk.
+type ExtractedRelationship struct {
+	Type        string         `json:"type"`
+	StartNodeID string         `json:"start_node_id"`
+	EndNodeID   string         `json:"end_node_id"`
+	Properties  map[string]any `json:"properties,omitempty"`
+}
+
+// extraction is the JSON the LLM is asked to return.
+type extraction struct {
+	Nodes         []ExtractedNode         `json:"nodes"`
+	Relationships []ExtractedRelationship `json:"relationships"`
+}
+
+// RunResult summarizes the ingestion of one document.
+type RunResult struct {
+	// DocumentID is the ID of the Document node.
+	DocumentID string
+	// Chunks is the number of chunks written.
+	Chunks int
+	// Nodes is the number of entity nodes written after resolution.
+	Nodes int
+	// Relationships is the number of entity relationships written.
+	Relationships int
+	// Merged is the number of entities merged by entity resolution.
+	Merged int
+	// Pruned is the number of nodes, relationships and properties dropped
+	// because the schema does not declare them.
+	Pruned int
+	// Skipped is the number of chunks that failed extraction.
+	Skipped int
+	// Warnings describes the skipped chunks when OnError is WARN.
+	Warnings []string
+}
+
+// Runtime runs KG construction pipelines from Go: it splits a document,
+// extracts entities and relationships from each chunk with an LLM, prunes
+// them to the pipeline schema, resolves duplicate entities and writes the
+// result to Neo4j as a lexical graph.
+//
+// The graph follows neo4j-graphrag: (:Chunk)-[:FROM_DOCUMENT]->(:Document),
+// (:Chunk)-[:NEXT_CHUNK]->(:Chunk) and (entity)-[:FROM_CHUNK]->(:Chunk),
+// with entities also labeled __Entity__, renamed by the pipeline's
+// LexicalGraph. Every write is a MERGE on an ID
+// derived from the document ID and chunk offsets, so re-ingesting a document
+// with the same LLM output leaves the graph unchanged.
+type Runtime struct {
+	runner QueryRunner
+	llm    LLM
+	// BatchSize is the number of rows written per query (default:
+	// DefaultBatchSize).
+	BatchSize int
+}
+
+// NewRuntime creates a Runtime that writes through runner and extracts with
+// llm.
+func NewRuntime(runner QueryRunner, llm LLM) *Runtime {
+	return &Runtime{runner: runner, llm: llm}
+}
+
+// Run ingests doc with a SimpleKGPipeline or CustomKGPipeline. Chunks that
+// fail extraction stop the run unless the pipeline's OnError is IGNORE or
+// WARN; database errors always stop it.
+func (rt *Runtime) Run(ctx context.Context, pipeline KGPipeline, doc Document) (*RunResult, error) {
+	var splitter TextSplitter
+	var resolver EntityResolver
+	var onError string
+	var prompt func(text string) string
+	var schema *extractionSchema
+	var lexical *LexicalGraphConfig
+	resolve := true
+
+	switch p := pipeline.(type) {
+	case *SimpleKGPipeline:
+		splitter, resolver, onError, lexical = p.TextSplitter, p.EntityResolver, p.OnError, p.LexicalGraph
+		if p.PerformEntityResolution != nil {
+			resolve = *p.PerformEntityResolution
+		}
+		schema = newExtractionSchema(p.EntityTypes, p.RelationTypes)
+		prompt = func(text string) string { return extractionPrompt(p.EntityTypes, p.RelationTypes, text) }
+	case *CustomKGPipeline:
+		if p.ExtractionPrompt == "" {
+			return nil, fmt.Errorf("pipeline %s: extraction prompt is required", p.Name)
+		}
+		splitter, resolver, onError, lexical = p.TextSplitter, p.EntityResolver, p.OnError, p.LexicalGraph
+		prompt = func(text string) string {
+			body := strings.ReplaceAll(p.ExtractionPrompt, "{text}", text)
+			if p.SchemaPrompt != "" {
+				body = p.SchemaPrompt + "\n\n" + body
+			}
+			return body
+		}
+	default:
+		return nil, fmt.Errorf("unsupported pipeline type %T", pipeline)
+	}
+
+	switch onError {
+	case "":
+		onError = OnErrorRaise
+	case OnErrorRaise, OnErrorIgnore, OnErrorWarn:
+	default:
+		return nil, fmt.Errorf("pipeline %s: unknown OnError %q", pipeline.PipelineName(), onError)
+	}
+	if splitter == nil {
+		splitter = &FixedSizeSplitter{}
+	}
+	if resolver == nil {
+		resolver = &ExactMatchResolver{}
+	}
+	if _, ok := resolver.(*SemanticMatchResolver); ok && resolve {
+		return nil, fmt.Errorf("pipeline %s: SemanticMatchResolver needs entity embeddings, which the Go runtime does not compute; use ExactMatchResolver or FuzzyMatchResolver, or run the pipeline with neo4j-graphrag", pipeline.PipelineName())
+	}
+
+	if doc.ID == "" {
+		sum := sha256.Sum256([]byte(doc.Text))
+		doc.ID = hex.EncodeToString(sum[:8])
+	}
+	chunks, err := SplitText(splitter, doc.Text)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline %s: %w", pipeline.PipelineName(), err)
+	}
+
+	result := &RunResult{DocumentID: doc.ID, Chunks: len(chunks)}
+	graph := &lexicalGraph{}
+	for _, chunk := range chunks {
+		chunkID := doc.ID + ":" + chunk.ID
+		graph.chunks = append(graph.chunks, map[string]any{
+			"id": chunkID, "index": chunk.Index, "text": chunk.Text, "start": chunk.Start, "end": chunk.End,
+		})
+
+		ext, err := rt.extract(ctx, prompt(chunk.Text))
+		if err != nil {
+			switch onError {
+			case OnErrorRaise:
+				return nil, fmt.Errorf("pipeline %s: chunk %d: %w", pipeline.PipelineName(), chunk.Index, err)
+			case OnErrorWarn:
+				result.Warnings = append(result.Warnings, fmt.Sprintf("chunk %d: %v", chunk.Index, err))
+			}
+			result.Skipped++
+			continue
+		}
+		if schema != nil {
+			result.Pruned += schema.prune(ext)
+		}
+		graph.add(chunkID, ext)
+	}
+
+	if resolve {
+		merged, err := graph.resolve(resolver)
+		if err != nil {
+			return nil, fmt.Errorf("pipeline %s: %w", pipeline.PipelineName(), err)
+		}
+		result.Merged = merged
+	}
+	result.Nodes, result.Relationships = len(graph.entities), len(graph.relationships)
+
+	if err := rt.write(ctx, doc, graph, newLexicalQueries(lexical.resolved())); err != nil {
+		return nil, fmt.Errorf("pipeline %s: failed to write document %s: %w", pipeline.PipelineName(), doc.ID, err)
+	}
+	return result, nil
+}
+
+// extract asks the LLM for the entities of one chunk and parses its JSON,
+// tolerating a Markdown code fence around it.
+func (rt *Runtime) extract(ctx context.Context, prompt string) (*extraction, error) {
+	response, err := rt.llm.Complete(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("LLM call failed: %w", err)
+	}
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+
+	var ext extraction
+	if err := json.Unmarshal([]byte(response), &ext); err != nil {
+		return nil, fmt.Errorf("invalid extraction JSON: %w", err)
+	}
+	return &ext, nil
+}
+
+// extractionPrompt follows the neo4j-graphrag ERExtractionTemplate,
+// constrained to the pipeline's entity and relation types.
+func extractionPrompt(entityTypes []EntityType, relationTypes []RelationType, text string) string {
+	var sb strings.Builder
+	sb.WriteString("You are a top-tier algorithm designed for extracting information in structured formats to build a knowledge graph.\n")
+	sb.WriteString("Extract the entities (nodes) and specify their type from the following text.\n")
+	sb.WriteString("Also extract the relationships between these nodes.\n\n")
+	sb.WriteString("Return result as JSON using the following format:\n")
+	sb.WriteString(`{"nodes": [{"id": "0", "label": "Person", "properties": {"name": "John"}}],` + "\n")
+	sb.WriteString(`"relationships": [{"type": "KNOWS", "start_node_id": "0", "end_node_id": "1", "properties": {"since": "2024-08-01"}}]}` + "\n\n")
+
+	if len(entityTypes) > 0 {
+		sb.WriteString("Use only the following node types:\n")
+		for _, e := range entityTypes {
+			fmt.Fprintf(&sb, "- %s", e.Name)
+			if e.Description != "" {
+				fmt.Fprintf(&sb, ": %s", e.Description)
+			}
+			if len(e.Properties) > 0 {
+				props := make([]string, len(e.Properties))
+				for i, p := range e.Properties {
+					props[i] = fmt.Sprintf("%s (%s)", p.Name, propertyTypeOrString(p.Type))
+					if p.Required {
+						props[i] = fmt.Sprintf("%s (%s, required)", p.Name, propertyTypeOrString(p.Type))
+					}
+				}
+				fmt.Fprintf(&sb, " Properties: %s", strings.Join(props, ", "))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	if len(relationTypes) > 0 {
+		sb.WriteString("Use only the following relationship types:\n")
+		for _, r := range relationTypes {
+			fmt.Fprintf(&sb, "- %s", r.Name)
+			if len(r.SourceTypes) > 0 || len(r.TargetTypes) > 0 {
+				fmt.Fprintf(&sb, " (%s -> %s)", strings.Join(r.SourceTypes, "|"), strings.Join(r.TargetTypes, "|"))
+			}
+			if r.Description != "" {
+				fmt.Fprintf(&sb, ": %s", r.Description)
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("Assign a unique ID (string) to each node, and reuse it to define relationships.\n")
+	sb.WriteString("Do respect the source and target node types for relationship and the relationship direction.\n")
+	sb.WriteString("Do not return any additional information other than the JSON.\n\n")
+	sb.WriteString("Input text:\n\n")
+	sb.WriteString(text)
+	return sb.String()
+}
+
+func propertyTypeOrString(t string) string {
+	if t == "" {
+		return "STRING"
+	}
+	return t
+}
+
+// extractionSchema indexes the declared entity and relation types.
+type extractionSchema struct {
+	entities  map[string]EntityType
+	relations map[string]RelationType
+}
+
+func newExtractionSchema(entityTypes []EntityType, relationTypes []RelationType) *extractionSchema {
+	s := &extractionSchema{
+		entities:  make(map[string]EntityType, len(entityTypes)),
+		relations: make(map[string]RelationType, len(relationTypes)),
+	}
+	for _, e := range entityTypes {
+		s.entities[e.Name] = e
+	}
+	for _, r := range relationTypes {
+		s.relations[r.Name] = r
+	}
+	return s
+}
+
+// prune drops from ext the nodes of undeclared types or missing required
+// properties, the undeclared properties of declared types, and relationships
+// of undeclared types, wrong endpoint types or dropped endpoints. It returns
+// the number of items dropped. An empty type list allows any type.
+func (s *extractionSchema) prune(ext *extraction) int {
+	pruned := 0
+	labels := make(map[string]string)
+
+	nodes := ext.Nodes[:0]
+	for _, n := range ext.Nodes {
+		if n.ID == "" {
+			pruned++
+			continue
+		}
+		if len(s.entities) > 0 {
+			et, ok := s.entities[n.Label]
+			if !ok || !hasRequiredProperties(et, n.Properties) {
+				pruned++
+				continue
+			}
+			pruned += pruneProperties(n.Properties, func(name string) bool {
+				for _, p := range et.Properties {
+					if p.Name == name {
+						return true
+					}
+				}
+				// Entities are resolved on name, so it is always kept.
+				return len(et.Properties) == 0 || name == "name"
+			})
+		} else {
+			pruned += pruneProperties(n.Properties, func(string) bool { return true })
+		}
+		labels[n.ID] = n.Label
+		nodes = append(nodes, n)
+	}
+	ext.Nodes = nodes
+
+	rels := ext.Relationships[:0]
+	for _, r := range ext.Relationships {
+		source, okS := labels[r.StartNodeID]
+		target, okT := labels[r.EndNodeID]
+		if !okS || !okT {
+			pruned++
+			continue
+		}
+		if len(s.relations) > 0 {
+			rt, ok := s.relations[r.Type]
+			if !ok || !allowsType(rt.SourceTypes, source) || !allowsType(rt.TargetTypes, target) {
+				pruned++
+				continue
+			}
+			pruned += pruneProperties(r.Properties, func(name string) bool {
+				for _, p := range rt.Properties {
+					if p.Name == name {
+						return true
+					}
+				}
+				return len(rt.Properties) == 0
+			})
+		} else {
+			pruned += pruneProperties(r.Properties, func(string) bool { return true })
+		}
+		rels = append(rels, r)
+	}
+	ext.Relationships = rels
+	return pruned
+}
+
+func hasRequiredProperties(et EntityType, props map[string]any) bool {
+	for _, p := range et.Properties {
+		if v, ok := props[p.Name]; p.Required && (!ok || v == nil || v == "") {
+			return false
+		}
+	}
+	return true
+}
+
+// pruneProperties deletes the properties not allowed, and those whose values
+// Neo4j cannot store, such as maps.
+func pruneProperties(props map[string]any, allowed func(name string) bool) int {
+	pruned := 0
+	for name, v := range props {
+		if !allowed(name) || !storableValue(v) {
+			delete(props, name)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+func storableValue(v any) bool {
+	switch val := v.(type) {
+	case nil, map[string]any:
+		return false
+	case []any:
+		for _, item := range val {
+			switch item.(type) {
+			case nil, map[string]any, []any:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func allowsType(types []string, label string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == label {
+			return true
+		}
+	}
+	return false
+}
+
+// lexicalGraph accumulates the rows written for a document.
+type lexicalGraph struct {
+	chunks        []map[string]any
+	entities      []*graphEntity
+	relationships []*graphRelationship
+}
+
+type graphEntity struct {
+	id         string
+	label      string
+	chunkIDs   []string
+	properties map[string]any
+}
+
+type graphRelationship struct {
+	relType    string
+	startID    string
+	endID      string
+	properties map[string]any
+}
+
+// add records the entities and relationships extracted from a chunk. Node
+// IDs are scoped to the chunk they were extracted from.
+func (g *lexicalGraph) add(chunkID string, ext *extraction) {
+	ids := make(map[string]string, len(ext.Nodes))
+	for _, n := range ext.Nodes {
+		sum := sha256.Sum256([]byte(chunkID + "/" + n.ID))
+		id := hex.EncodeToString(sum[:8])
+		ids[n.ID] = id
+		props := n.Properties
+		if props == nil {
+			props = make(map[string]any)
+		}
+		g.entities = append(g.entities, &graphEntity{id: id, label: n.Label, chunkIDs: []string{chunkID}, properties: props})
+	}
+	for _, r := range ext.Relationships {
+		startID, okS := ids[r.StartNodeID]
+		endID, okT := ids[r.EndNodeID]
+		if !okS || !okT {
+			continue
+		}
+		props := r.Properties
+		if props == nil {
+			props = make(map[string]any)
+		}
+		g.relationships = append(g.relationships, &graphRelationship{relType: r.Type, startID: startID, endID: endID, properties: props})
+	}
+}
+
+// resolve merges duplicate entities with resolver, keeping the properties
+// of the first and filling in those it lacks, and points relationships at
+// the kept entities. It returns the number of entities merged.
+func (g *lexicalGraph) resolve(resolver EntityResolver) (int, error) {
+	entities := make([]Entity, len(g.entities))
+	byID := make(map[string]*graphEntity, len(g.entities))
+	for i, e := range g.entities {
+		entities[i] = Entity{ID: e.id, Label: e.label, Properties: e.properties}
+		byID[e.id] = e
+	}
+	res, err := Resolve(resolver, entities)
+	if err != nil {
+		return 0, err
+	}
+
+	replaced := make(map[string]string)
+	for _, d := range res.Decisions {
+		keep := byID[d.Keep.ID]
+		for _, m := range d.Merge {
+			dup := byID[m.ID]
+			for k, v := range dup.properties {
+				if _, ok := keep.properties[k]; !ok {
+					keep.properties[k] = v
+				}
+			}
+			keep.chunkIDs = append(keep.chunkIDs, dup.chunkIDs...)
+			replaced[m.ID] = keep.id
+		}
+	}
+	if len(replaced) == 0 {
+		return 0, nil
+	}
+
+	entitiesLeft := g.entities[:0]
+	for _, e := range g.entities {
+		if _, ok := replaced[e.id]; !ok {
+			entitiesLeft = append(entitiesLeft, e)
+		}
+	}
+	g.entities = entitiesLeft
+
+	seen := make(map[string]bool)
+	relsLeft := g.relationships[:0]
+	for _, r := range g.relationships {
+		if id, ok := replaced[r.startID]; ok {
+			r.startID = id
+		}
+		if id, ok := replaced[r.endID]; ok {
+			r.endID = id
+		}
+		key := r.relType + "/" + r.startID + "/" + r.endID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		relsLeft = append(relsLeft, r)
+	}
+	g.relationships = relsLeft
+	return len(replaced), nil
+}
+
+// lexicalQueries builds the write queries for a lexical graph
+// configuration. Labels, types and properties are quoted, since Cypher cannot
+// parameterize them.
+type lexicalQueries struct {
+	document, chunk                    string
+	fromDocument, nextChunk, fromChunk string
+	id, index, text                    string
+}
+
+func newLexicalQueries(c LexicalGraphConfig) lexicalQueries {
+	return lexicalQueries{
+		document:     graphrag.CypherName(c.DocumentLabel),
+		chunk:        graphrag.CypherName(c.ChunkLabel),
+		fromDocument: graphrag.CypherName(c.ChunkToDocumentType),
+		nextChunk:    graphrag.CypherName(c.NextChunkType),
+		fromChunk:    graphrag.CypherName(c.NodeToChunkType),
+		id:           graphrag.CypherName(c.ChunkIDProperty),
+		index:        graphrag.CypherName(c.ChunkIndexProperty),
+		text:         graphrag.CypherName(c.ChunkTextProperty),
+	}
+}
+
+func (q lexicalQueries) documentQuery() string {
+	return `MERGE (d:` + q.document + ` {id: $id})
+SET d += $metadata, d.text = $text`
+}
+
+// staleChunksQuery deletes the chunks of a document that are no longer
+// produced, then the entities extracted from them that have no other chunk.
+func (q lexicalQueries) staleChunksQuery() string {
+	return `MATCH (c:` + q.chunk + `)-[:` + q.fromDocument + `]->(:` + q.document + ` {id: $id})
+WHERE NOT c.` + q.id + ` IN $chunkIds
+OPTIONAL MATCH (e:__Entity__)-[:` + q.fromChunk + `]->(c)
+WITH collect(DISTINCT c) AS chunks, collect(DISTINCT e) AS entities
+FOREACH (c IN chunks | DETACH DELETE c)
+WITH entities
+UNWIND entities AS e
+WITH e WHERE NOT (e)-[:` + q.fromChunk + `]->()
+DETACH DELETE e`
+}
+
+func (q lexicalQueries) chunksQuery() string {
+	return `UNWIND $rows AS row
+MATCH (d:` + q.document + ` {id: $documentId})
+MERGE (c:` + q.chunk + ` {` + q.id + `: row.id})
+SET c.` + q.index + ` = row.index, c.` + q.text + ` = row.text, c.start = row.start, c.end = row.end
+MERGE (c)-[:` + q.fromDocument + `]->(d)`
+}
+
+func (q lexicalQueries) nextChunkQuery() string {
+	return `UNWIND $rows AS row
+MATCH (a:` + q.chunk + ` {` + q.id + `: row.from}), (b:` + q.chunk + ` {` + q.id + `: row.to})
+MERGE (a)-[:` + q.nextChunk + `]->(b)`
+}
+
+func (q lexicalQueries) entitiesQuery(label string) string {
+	return `UNWIND $rows AS row
+MERGE (n:__Entity__ {id: row.id})
+SET n:` + graphrag.CypherName(label) + `, n += row.properties
+WITH n, row
+UNWIND row.chunkIds AS chunkId
+MATCH (c:` + q.chunk + ` {` + q.id + `: chunkId})
+MERGE (n)-[:` + q.fromChunk + `]->(c)`
+}
+
+func (q lexicalQueries) relationshipsQuery(relType string) string {
+	return `UNWIND $rows AS row
+MATCH (a:__Entity__ {id: row.startId}), (b:__Entity__ {id: row.endId})
+MERGE (a)-[r:` + graphrag.CypherName(relType) + `]->(b)
+SET r += row.properties`
+}
+
+// write stores the document graph. Entities and relationships are written
+// per label and type, since Cypher cannot parameterize them.
+func (rt *Runtime) write(ctx context.Context, doc Document, g *lexicalGraph, q lexicalQueries) error {
+	metadata := doc.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	if _, err := rt.runner.Run(ctx, q.documentQuery(), map[string]any{"id": doc.ID, "text": doc.Text, "metadata": metadata}); err != nil {
+		return err
+	}
+
+	chunkIDs := make([]any, len(g.chunks))
+	chunkRows := make([]any, len(g.chunks))
+	var nextRows []any
+	for i, c := range g.chunks {
+		chunkIDs[i] = c["id"]
+		chunkRows[i] = c
+		if i > 0 {
+			nextRows = append(nextRows, map[string]any{"from": g.chunks[i-1]["id"], "to": c["id"]})
+		}
+	}
+	if _, err := rt.runner.Run(ctx, q.staleChunksQuery(), map[string]any{"id": doc.ID, "chunkIds": chunkIDs}); err != nil {
+		return err
+	}
+	if err := rt.runBatches(ctx, q.chunksQuery(), chunkRows, map[string]any{"documentId": doc.ID}); err != nil {
+		return err
+	}
+	if err := rt.runBatches(ctx, q.nextChunkQuery(), nextRows, nil); err != nil {
+		return err
+	}
+
+	byLabel := make(map[string][]any)
+	for _, e := range g.entities {
+		chunks := make([]any, len(e.chunkIDs))
+		for i, id := range e.chunkIDs {
+			chunks[i] = id
+		}
+		byLabel[e.label] = append(byLabel[e.label], map[string]any{"id": e.id, "properties": e.properties, "chunkIds": chunks})
+	}
+	for _, label := range sortedGroupKeys(byLabel) {
+		if err := rt.runBatches(ctx, q.entitiesQuery(label), byLabel[label], nil); err != nil {
+			return err
+		}
+	}
+
+	byType := make(map[string][]any)
+	for _, r := range g.relationships {
+		byType[r.relType] = append(byType[r.relType], map[string]any{"startId": r.startID, "endId": r.endID, "properties": r.properties})
+	}
+	for _, relType := range sortedGroupKeys(byType) {
+		if err := rt.runBatches(ctx, q.relationshipsQuery(relType), byType[relType], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runBatches runs query once per BatchSize rows, passed as $rows along with
+// params.
+func (rt *Runtime) runBatches(ctx context.Context, query string, rows []any, params map[string]any) error {
+	size := rt.BatchSize
+	if size <= 0 {
+		size = DefaultBatchSize
+	}
+	for start := 0; start < len(rows); start += size {
+		batch := map[string]any{"rows": rows[start:min(start+size, len(rows))]}
+		for k, v := range params {
+			batch[k] = v
+		}
+		if _, err := rt.runner.Run(ctx, query, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedGroupKeys(m map[string][]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
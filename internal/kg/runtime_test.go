@@ -0,0 +1,281 @@
+package kg
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeRunner records every query and its parameters.
+type fakeRunner struct {
+	err     error
+	queries []string
+	params  []map[string]any
+}
+
+func (f *fakeRunner) Run(_ context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	f.queries = append(f.queries, query)
+	f.params = append(f.params, params)
+	return nil, f.err
+}
+
+// rows returns the $rows written by queries containing fragment.
+func (f *fakeRunner) rows(fragment string) []map[string]any {
+	var rows []map[string]any
+	for i, q := range f.queries {
+		if !strings.Contains(q, fragment) {
+			continue
+		}
+		batch, _ := f.params[i]["rows"].([]any)
+		for _, r := range batch {
+			rows = append(rows, r.(map[string]any))
+		}
+	}
+	return rows
+}
+
+func moviePipeline() *SimpleKGPipeline {
+	return &SimpleKGPipeline{
+		BasePipeline: BasePipeline{Name: "movies"},
+		EntityTypes: []EntityType{
+			{Name: "Person", Properties: []EntityProperty{{Name: "name", Required: true}}},
+			{Name: "Movie", Properties: []EntityProperty{{Name: "title", Required: true}}},
+		},
+		RelationTypes: []RelationType{
+			{Name: "ACTED_IN", SourceTypes: []string{"Person"}, TargetTypes: []string{"Movie"}},
+		},
+		TextSplitter: &LangChainSplitter{ChunkSize: 40, Separators: []string{"\n\n"}},
+	}
+}
+
+const movieText = "Keanu Reeves starred in The Matrix.\n\nKeanu Reeves also acted in John Wick."
+
+var movieResponses = []string{
+	`{"nodes": [
+		{"id": "0", "label": "Person", "properties": {"name": "Keanu Reeves", "age": {"years": 59}}},
+		{"id": "1", "label": "Movie", "properties": {"title": "The Matrix"}},
+		{"id": "2", "label": "Studio", "properties": {"name": "Warner"}}
+	], "relationships": [
+		{"type": "ACTED_IN", "start_node_id": "0", "end_node_id": "1"},
+		{"type": "DIRECTED", "start_node_id": "0", "end_node_id": "1"}
+	]}`,
+	"```json\n" + `{"nodes": [
+		{"id": "0", "label": "Person", "properties": {"name": "Keanu Reeves"}},
+		{"id": "1", "label": "Movie", "properties": {"title": "John Wick"}},
+		{"id": "2", "label": "Person"}
+	], "relationships": [
+		{"type": "ACTED_IN", "start_node_id": "0", "end_node_id": "1"},
+		{"type": "ACTED_IN", "start_node_id": "1", "end_node_id": "0"}
+	]}` + "\n```",
+}
+
+func TestRuntime_Run(t *testing.T) {
+	runner := &fakeRunner{}
+	llm := NewScriptedLLM(movieResponses...)
+	rt := NewRuntime(runner, llm)
+
+	result, err := rt.Run(context.Background(), moviePipeline(), Document{ID: "doc1", Text: movieText})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.Chunks != 2 || result.Nodes != 3 || result.Relationships != 2 || result.Merged != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	// Studio, DIRECTED, the nested age property, the nameless Person and
+	// the reversed ACTED_IN are pruned.
+	if result.Pruned != 5 {
+		t.Errorf("expected 5 pruned items, got %d", result.Pruned)
+	}
+	if len(llm.Prompts) != 2 || !strings.Contains(llm.Prompts[0], "- ACTED_IN (Person -> Movie)") {
+		t.Errorf("unexpected prompts: %q", llm.Prompts)
+	}
+
+	people := runner.rows("SET n:`Person`")
+	if len(people) != 1 {
+		t.Fatalf("expected one resolved Person, got %v", people)
+	}
+	if chunks := people[0]["chunkIds"].([]any); len(chunks) != 2 {
+		t.Errorf("expected the merged Person to link both chunks, got %v", chunks)
+	}
+	if len(runner.rows("MERGE (a)-[:`NEXT_CHUNK`]->(b)")) != 1 {
+		t.Error("expected one NEXT_CHUNK row")
+	}
+}
+
+func TestRuntime_Run_Idempotent(t *testing.T) {
+	first, second := &fakeRunner{}, &fakeRunner{}
+	ctx := context.Background()
+	doc := Document{ID: "doc1", Text: movieText}
+
+	if _, err := NewRuntime(first, NewScriptedLLM(movieResponses...)).Run(ctx, moviePipeline(), doc); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if _, err := NewRuntime(second, NewScriptedLLM(movieResponses...)).Run(ctx, moviePipeline(), doc); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(first.queries, second.queries) || !reflect.DeepEqual(first.params, second.params) {
+		t.Error("expected re-ingesting the document to issue identical writes")
+	}
+	for _, q := range first.queries {
+		if strings.Contains(q, "CREATE") {
+			t.Errorf("expected only MERGE writes, got:\n%s", q)
+		}
+	}
+}
+
+func TestRuntime_Run_StaleChunks(t *testing.T) {
+	runner := &fakeRunner{}
+	if _, err := NewRuntime(runner, NewScriptedLLM(movieResponses...)).Run(context.Background(), moviePipeline(), Document{ID: "doc1", Text: movieText}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var stale string
+	var params map[string]any
+	for i, q := range runner.queries {
+		if strings.Contains(q, "DETACH DELETE c") {
+			stale, params = q, runner.params[i]
+		}
+	}
+	if stale == "" {
+		t.Fatal("expected a stale chunk query")
+	}
+	// Entities of deleted chunks are removed once no chunk mentions them;
+	// entities of other chunks and documents are left alone.
+	for _, e := range []string{
+		"OPTIONAL MATCH (e:__Entity__)-[:`FROM_CHUNK`]->(c)",
+		"FOREACH (c IN chunks | DETACH DELETE c)",
+		"WITH e WHERE NOT (e)-[:`FROM_CHUNK`]->()\nDETACH DELETE e",
+	} {
+		if !strings.Contains(stale, e) {
+			t.Errorf("expected %q in stale chunk query:\n%s", e, stale)
+		}
+	}
+	if ids := params["chunkIds"].([]any); params["id"] != "doc1" || len(ids) != 2 {
+		t.Errorf("unexpected params: %v", params)
+	}
+}
+
+func TestRuntime_Run_Batches(t *testing.T) {
+	runner := &fakeRunner{}
+	rt := NewRuntime(runner, NewScriptedLLM(`{"nodes": []}`, `{"nodes": []}`, `{"nodes": []}`))
+	rt.BatchSize = 2
+
+	pipeline := &SimpleKGPipeline{TextSplitter: &FixedSizeSplitter{ChunkSize: 4}}
+	if _, err := rt.Run(context.Background(), pipeline, Document{Text: "abcdefghij"}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	batches := 0
+	for _, q := range runner.queries {
+		if strings.Contains(q, "MERGE (c:`Chunk`") {
+			batches++
+		}
+	}
+	if batches != 2 {
+		t.Errorf("expected 3 chunks in 2 batches, got %d batches", batches)
+	}
+}
+
+func TestRuntime_Run_OnError(t *testing.T) {
+	tests := []struct {
+		onError  string
+		wantErr  bool
+		warnings int
+	}{
+		{"", true, 0},
+		{OnErrorRaise, true, 0},
+		{OnErrorIgnore, false, 0},
+		{OnErrorWarn, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.onError, func(t *testing.T) {
+			pipeline := moviePipeline()
+			pipeline.OnError = tt.onError
+			rt := NewRuntime(&fakeRunner{}, NewScriptedLLM(movieResponses[0], "not json"))
+
+			result, err := rt.Run(context.Background(), pipeline, Document{Text: movieText})
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "invalid extraction JSON") {
+					t.Errorf("expected extraction error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if result.Skipped != 1 || len(result.Warnings) != tt.warnings {
+				t.Errorf("unexpected result: %+v", result)
+			}
+		})
+	}
+}
+
+func TestRuntime_Run_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewRuntime(&fakeRunner{}, NewScriptedLLM()).Run(ctx, &SimpleKGPipeline{OnError: "RETRY"}, Document{Text: "x"})
+	if err == nil || !strings.Contains(err.Error(), "unknown OnError") {
+		t.Errorf("expected OnError error, got %v", err)
+	}
+
+	pipeline := moviePipeline()
+	pipeline.EntityResolver = &SemanticMatchResolver{Threshold: 0.9}
+	_, err = NewRuntime(&fakeRunner{}, NewScriptedLLM(movieResponses...)).Run(ctx, pipeline, Document{Text: movieText})
+	if err == nil || !strings.Contains(err.Error(), "SemanticMatchResolver needs entity embeddings") {
+		t.Errorf("expected semantic resolver error, got %v", err)
+	}
+	disabled := false
+	pipeline.PerformEntityResolution = &disabled
+	if _, err := NewRuntime(&fakeRunner{}, NewScriptedLLM(movieResponses...)).Run(ctx, pipeline, Document{Text: movieText}); err != nil {
+		t.Errorf("expected no resolver error without entity resolution, got %v", err)
+	}
+
+	runner := &fakeRunner{err: errors.New("connection refused")}
+	_, err = NewRuntime(runner, NewScriptedLLM(`{"nodes": []}`)).Run(ctx, &SimpleKGPipeline{OnError: OnErrorIgnore}, Document{Text: "x"})
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected write error, got %v", err)
+	}
+}
+
+func TestRuntime_Run_CustomPipeline(t *testing.T) {
+	llm := NewScriptedLLM(`{"nodes": [{"id": "a", "label": "Anything", "properties": {"name": "x"}}]}`)
+	pipeline := &CustomKGPipeline{SchemaPrompt: "Schema: free-form", ExtractionPrompt: "Extract from: {text}"}
+
+	result, err := NewRuntime(&fakeRunner{}, llm).Run(context.Background(), pipeline, Document{Text: "hello"})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if llm.Prompts[0] != "Schema: free-form\n\nExtract from: hello" {
+		t.Errorf("unexpected prompt: %q", llm.Prompts[0])
+	}
+	if result.Nodes != 1 {
+		t.Errorf("expected 1 node, got %d", result.Nodes)
+	}
+}
+
+func TestRuntime_Run_LexicalGraph(t *testing.T) {
+	runner := &fakeRunner{}
+	pipeline := moviePipeline()
+	pipeline.LexicalGraph = &LexicalGraphConfig{ChunkLabel: "Passage", NextChunkType: "FOLLOWED_BY", ChunkTextProperty: "content"}
+
+	if _, err := NewRuntime(runner, NewScriptedLLM(movieResponses...)).Run(context.Background(), pipeline, Document{ID: "doc1", Text: movieText}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(runner.rows("MERGE (a)-[:`FOLLOWED_BY`]->(b)")) != 1 {
+		t.Error("expected one FOLLOWED_BY row")
+	}
+	if len(runner.rows("c.`content` = row.text")) != 2 {
+		t.Error("expected chunk text in the content property")
+	}
+	for _, q := range runner.queries {
+		if strings.Contains(q, "`Chunk`") || strings.Contains(q, "NEXT_CHUNK") {
+			t.Errorf("expected configured names only, got:\n%s", q)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package kg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+// propertyTypes maps EntityProperty types, including the neo4j-graphrag
+// names, to schema property types. LIST has no element type in
+// neo4j-graphrag and maps to LIST_STRING.
+var propertyTypes = map[string]schema.PropertyType{
+	"STRING":         schema.STRING,
+	"INTEGER":        schema.INTEGER,
+	"INT":            schema.INTEGER,
+	"LONG":           schema.INTEGER,
+	"FLOAT":          schema.FLOAT,
+	"DOUBLE":         schema.FLOAT,
+	"BOOLEAN":        schema.BOOLEAN,
+	"BOOL":           schema.BOOLEAN,
+	"DATE":           schema.DATE,
+	"DATETIME":       schema.DATETIME,
+	"LOCAL_DATETIME": schema.DATETIME,
+	"ZONED_DATETIME": schema.DATETIME,
+	"POINT":          schema.POINT,
+	"LIST":           schema.LIST_STRING,
+	"LIST_STRING":    schema.LIST_STRING,
+	"LIST_INTEGER":   schema.LIST_INTEGER,
+	"LIST_FLOAT":     schema.LIST_FLOAT,
+}
+
+// PropertyType returns the schema property type of an EntityProperty or
+// RelationProperty type. An empty type is STRING, as in neo4j-graphrag.
+func PropertyType(t string) (schema.PropertyType, error) {
+	if t == "" {
+		return schema.STRING, nil
+	}
+	pt, ok := propertyTypes[strings.ToUpper(t)]
+	if !ok {
+		return "", fmt.Errorf("unknown property type %q", t)
+	}
+	return pt, nil
+}
+
+// ToNodeType converts an entity type to a node type. Properties with an
+// unknown type are STRING.
+func ToNodeType(e EntityType) *schema.NodeType {
+	node := &schema.NodeType{Label: e.Name, Description: e.Description}
+	for _, p := range e.Properties {
+		pt, err := PropertyType(p.Type)
+		if err != nil {
+			pt = schema.STRING
+		}
+		node.Properties = append(node.Properties, schema.Property{
+			Name:        p.Name,
+			Type:        pt,
+			Required:    p.Required,
+			Description: p.Description,
+		})
+	}
+	return node
+}
+
+// FromNodeType converts a node type to an entity type.
+func FromNodeType(n *schema.NodeType) EntityType {
+	e := EntityType{Name: n.Label, Description: n.Description}
+	for _, p := range n.Properties {
+		e.Properties = append(e.Properties, EntityProperty{
+			Name:        p.Name,
+			Type:        string(p.Type),
+			Description: p.Description,
+			Required:    p.Required,
+		})
+	}
+	return e
+}
+
+// ToRelationshipTypes converts a relation type to one relationship type per
+// pair of source and target types.
+func ToRelationshipTypes(r RelationType) []*schema.RelationshipType {
+	var props []schema.Property
+	for _, p := range r.Properties {
+		pt, err := PropertyType(p.Type)
+		if err != nil {
+			pt = schema.STRING
+		}
+		props = append(props, schema.Property{Name: p.Name, Type: pt, Description: p.Description})
+	}
+
+	var rels []*schema.RelationshipType
+	for _, source := range r.SourceTypes {
+		for _, target := range r.TargetTypes {
+			rels = append(rels, &schema.RelationshipType{
+				Label:       r.Name,
+				Source:      source,
+				Target:      target,
+				Properties:  props,
+				Description: r.Description,
+			})
+		}
+	}
+	return rels
+}
+
+// FromRelationshipTypes converts relationship types to relation types,
+// merging the sources and targets of relationship types with the same label.
+// Properties and description come from the first relationship type of each
+// label.
+func FromRelationshipTypes(rels []*schema.RelationshipType) []RelationType {
+	var result []RelationType
+	index := make(map[string]int)
+	for _, rel := range rels {
+		i, ok := index[rel.Label]
+		if !ok {
+			r := RelationType{Name: rel.Label, Description: rel.Description}
+			for _, p := range rel.Properties {
+				r.Properties = append(r.Properties, RelationProperty{
+					Name:        p.Name,
+					Type:        string(p.Type),
+					Description: p.Description,
+				})
+			}
+			i = len(result)
+			index[rel.Label] = i
+			result = append(result, r)
+		}
+		r := &result[i]
+		if rel.Source != "" && !contains(r.SourceTypes, rel.Source) {
+			r.SourceTypes = append(r.SourceTypes, rel.Source)
+		}
+		if rel.Target != "" && !contains(r.TargetTypes, rel.Target) {
+			r.TargetTypes = append(r.TargetTypes, rel.Target)
+		}
+	}
+	return result
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// MismatchKind classifies a SchemaMismatch.
+type MismatchKind string
+
+const (
+	// MismatchMissing is an entity or relation type with no node or
+	// relationship type of the same label.
+	MismatchMissing MismatchKind = "missing"
+	// MismatchPropertyType is a property typed differently in the schema.
+	MismatchPropertyType MismatchKind = "property_type"
+	// MismatchEndpoint is a relation source or target type that the
+	// schema's relationship types of that label do not allow.
+	MismatchEndpoint MismatchKind = "endpoint"
+)
+
+// SchemaMismatch is a disagreement between extraction types and the schema.
+type SchemaMismatch struct {
+	Kind MismatchKind
+	// Element is the entity or relation type name, or Type.property for
+	// property mismatches.
+	Element string
+	// Relation reports whether Element belongs to a relation type.
+	Relation bool
+	Message  string
+}
+
+// Reconcile compares extraction types with the node and relationship types
+// of a schema. Properties the schema does not declare, and properties
+// without an explicit type, are not compared.
+func Reconcile(entityTypes []EntityType, relationTypes []RelationType, sch *schema.Schema) []SchemaMismatch {
+	nodes := make(map[string]*schema.NodeType)
+	for _, n := range sch.Nodes {
+		nodes[n.Label] = n
+	}
+	rels := make(map[string][]*schema.RelationshipType)
+	for _, r := range sch.Relationships {
+		rels[r.Label] = append(rels[r.Label], r)
+	}
+
+	var mismatches []SchemaMismatch
+	for _, e := range entityTypes {
+		node, ok := nodes[e.Name]
+		if !ok {
+			mismatches = append(mismatches, SchemaMismatch{
+				Kind:    MismatchMissing,
+				Element: e.Name,
+				Message: fmt.Sprintf("entity type %s is not a node type in the schema", e.Name),
+			})
+			continue
+		}
+		for _, p := range e.Properties {
+			mismatches = append(mismatches, comparePropertyType(e.Name, p.Name, p.Type, node.Properties)...)
+		}
+	}
+
+	for _, r := range relationTypes {
+		defined := rels[r.Name]
+		if len(defined) == 0 {
+			mismatches = append(mismatches, SchemaMismatch{
+				Kind:     MismatchMissing,
+				Element:  r.Name,
+				Relation: true,
+				Message:  fmt.Sprintf("relation type %s is not a relationship type in the schema", r.Name),
+			})
+			continue
+		}
+
+		var allowed []string
+		for _, rel := range defined {
+			allowed = append(allowed, fmt.Sprintf("%s -> %s", rel.Source, rel.Target))
+		}
+		for _, source := range r.SourceTypes {
+			for _, target := range r.TargetTypes {
+				if hasEndpoints(defined, source, target) {
+					continue
+				}
+				mismatches = append(mismatches, SchemaMismatch{
+					Kind:     MismatchEndpoint,
+					Element:  r.Name,
+					Relation: true,
+					Message:  fmt.Sprintf("relation type %s goes from %s to %s, but the schema only allows %s", r.Name, source, target, strings.Join(allowed, ", ")),
+				})
+			}
+		}
+
+		for _, p := range r.Properties {
+			for _, m := range comparePropertyType(r.Name, p.Name, p.Type, defined[0].Properties) {
+				m.Relation = true
+				mismatches = append(mismatches, m)
+			}
+		}
+	}
+	return mismatches
+}
+
+// hasEndpoints reports whether a relationship type goes from source to
+// target. An empty Source or Target allows any type.
+func hasEndpoints(rels []*schema.RelationshipType, source, target string) bool {
+	for _, rel := range rels {
+		if (rel.Source == "" || rel.Source == source) && (rel.Target == "" || rel.Target == target) {
+			return true
+		}
+	}
+	return false
+}
+
+func comparePropertyType(owner, name, typ string, props []schema.Property) []SchemaMismatch {
+	if typ == "" {
+		return nil
+	}
+	for _, sp := range props {
+		if sp.Name != name {
+			continue
+		}
+		pt, err := PropertyType(typ)
+		switch {
+		case err != nil:
+			return []SchemaMismatch{{
+				Kind:    MismatchPropertyType,
+				Element: owner + "." + name,
+				Message: fmt.Sprintf("%s.%s: %v, the schema declares %s", owner, name, err, sp.Type),
+			}}
+		case pt != sp.Type && !(strings.EqualFold(typ, "LIST") && strings.HasPrefix(string(sp.Type), "LIST_")):
+			return []SchemaMismatch{{
+				Kind:    MismatchPropertyType,
+				Element: owner + "." + name,
+				Message: fmt.Sprintf("%s.%s is extracted as %s, but the schema declares %s", owner, name, strings.ToUpper(typ), sp.Type),
+			}}
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package kg
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+func TestSchemaConversion(t *testing.T) {
+	entity := EntityType{
+		Name:        "Person",
+		Description: "A person",
+		Properties: []EntityProperty{
+			{Name: "name", Type: "STRING", Required: true},
+			{Name: "born", Type: "INTEGER", Description: "Birth year"},
+		},
+	}
+	node := ToNodeType(entity)
+	if node.Label != "Person" || len(node.Properties) != 2 || node.Properties[1].Type != schema.INTEGER || !node.Properties[0].Required {
+		t.Errorf("unexpected node type: %+v", node)
+	}
+	if got := FromNodeType(node); !reflect.DeepEqual(got, entity) {
+		t.Errorf("round trip = %+v, want %+v", got, entity)
+	}
+
+	relation := RelationType{
+		Name:        "ACTED_IN",
+		SourceTypes: []string{"Person"},
+		TargetTypes: []string{"Movie", "Show"},
+		Properties:  []RelationProperty{{Name: "role", Type: "STRING"}},
+	}
+	rels := ToRelationshipTypes(relation)
+	if len(rels) != 2 || rels[1].Source != "Person" || rels[1].Target != "Show" {
+		t.Fatalf("unexpected relationship types: %+v", rels)
+	}
+	if got := FromRelationshipTypes(rels); len(got) != 1 || !reflect.DeepEqual(got[0], relation) {
+		t.Errorf("round trip = %+v, want %+v", got, relation)
+	}
+}
+
+func TestPropertyType(t *testing.T) {
+	tests := []struct {
+		in   string
+		want schema.PropertyType
+	}{
+		{"", schema.STRING},
+		{"integer", schema.INTEGER},
+		{"LOCAL_DATETIME", schema.DATETIME},
+		{"LIST", schema.LIST_STRING},
+	}
+	for _, tt := range tests {
+		if got, err := PropertyType(tt.in); err != nil || got != tt.want {
+			t.Errorf("PropertyType(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := PropertyType("MAP"); err == nil || !strings.Contains(err.Error(), "unknown property type") {
+		t.Errorf("expected unknown type error, got %v", err)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	sch := &schema.Schema{
+		Nodes: []*schema.NodeType{
+			{Label: "Person", Properties: []schema.Property{{Name: "born", Type: schema.INTEGER}, {Name: "aliases", Type: schema.LIST_STRING}}},
+			{Label: "Movie"},
+		},
+		Relationships: []*schema.RelationshipType{
+			{Label: "ACTED_IN", Source: "Person", Target: "Movie", Properties: []schema.Property{{Name: "roles", Type: schema.LIST_STRING}}},
+		},
+	}
+	entityTypes := []EntityType{
+		{Name: "Person", Properties: []EntityProperty{{Name: "born", Type: "STRING"}, {Name: "aliases", Type: "LIST"}, {Name: "name"}}},
+		{Name: "Studio"},
+	}
+	relationTypes := []RelationType{
+		{Name: "ACTED_IN", SourceTypes: []string{"Person"}, TargetTypes: []string{"Movie", "Show"}, Properties: []RelationProperty{{Name: "roles", Type: "INTEGER"}}},
+		{Name: "PRODUCED"},
+	}
+
+	var got []string
+	for _, m := range Reconcile(entityTypes, relationTypes, sch) {
+		got = append(got, string(m.Kind)+" "+m.Message)
+	}
+	want := []string{
+		"property_type Person.born is extracted as STRING, but the schema declares INTEGER",
+		"missing entity type Studio is not a node type in the schema",
+		"endpoint relation type ACTED_IN goes from Person to Show, but the schema only allows Person -> Movie",
+		"property_type ACTED_IN.roles is extracted as INTEGER, but the schema declares LIST_STRING",
+		"missing relation type PRODUCED is not a relationship type in the schema",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reconcile() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
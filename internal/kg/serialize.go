@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
 )
 
 // KGSerializer serializes KG pipeline configurations to JSON.
@@ -69,6 +71,27 @@ func (s *KGSerializer) ToMap(pipeline KGPipeline) map[string]any {
 		if p.OnError != "" {
 			result["onError"] = p.OnError
 		}
+
+	case *CommunitySummaryPipeline:
+		s.addBaseFields(result, &p.BasePipeline)
+		if p.Leiden != nil {
+			result["leiden"] = algorithms.NewAlgorithmSerializer().ToMap(p.Leiden)
+		}
+		n := p.names()
+		result["entityLabel"] = n.entity
+		if len(p.RelationshipTypes) > 0 {
+			result["relationshipTypes"] = p.RelationshipTypes
+		}
+		result["communityLabel"] = n.community
+		result["inCommunityType"] = n.inCommunity
+		result["parentType"] = n.parent
+		if p.SummaryPrompt != "" {
+			result["summaryPrompt"] = p.SummaryPrompt
+		}
+		if p.MinCommunitySize > 0 {
+			result["minCommunitySize"] = p.MinCommunitySize
+		}
+		result["vectorIndexName"] = n.index
 	}
 
 	return result
@@ -94,6 +117,33 @@ func (s *KGSerializer) addBaseFields(result map[string]any, base *BasePipeline)
 	if base.EmbedderConfig != nil {
 		result["embedderConfig"] = s.embedderConfigToMap(base.EmbedderConfig)
 	}
+	if base.LexicalGraph != nil {
+		result["lexicalGraphConfig"] = s.lexicalGraphConfigToMap(base.LexicalGraph)
+	}
+}
+
+// lexicalGraphConfigToMap converts a LexicalGraphConfig to a map.
+func (s *KGSerializer) lexicalGraphConfigToMap(config *LexicalGraphConfig) map[string]any {
+	result := make(map[string]any)
+	for key, value := range map[string]string{
+		"documentLabel":          config.DocumentLabel,
+		"chunkLabel":             config.ChunkLabel,
+		"chunkToDocumentType":    config.ChunkToDocumentType,
+		"nextChunkType":          config.NextChunkType,
+		"nodeToChunkType":        config.NodeToChunkType,
+		"chunkIdProperty":        config.ChunkIDProperty,
+		"chunkIndexProperty":     config.ChunkIndexProperty,
+		"chunkTextProperty":      config.ChunkTextProperty,
+		"chunkEmbeddingProperty": config.ChunkEmbeddingProperty,
+		"vectorIndexName":        config.VectorIndexName,
+		"fulltextIndexName":      config.FulltextIndexName,
+		"similarityFunction":     config.SimilarityFunction,
+	} {
+		if value != "" {
+			result[key] = value
+		}
+	}
+	return result
 }
 
 // llmConfigToMap converts an LLMConfig to a map.
@@ -0,0 +1,315 @@
+package kg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultChunkSize is the neo4j-graphrag chunk size used when ChunkSize is 0.
+const DefaultChunkSize = 4000
+
+// DefaultChunkOverlap is the neo4j-graphrag chunk overlap used when both
+// ChunkSize and ChunkOverlap are 0. A splitter with a ChunkSize and no
+// ChunkOverlap does not overlap chunks, and its generated Python passes
+// chunk_overlap=0 to match.
+const DefaultChunkOverlap = 200
+
+// defaultSeparators are the RecursiveCharacterTextSplitter separators.
+var defaultSeparators = []string{"\n\n", "\n", " ", ""}
+
+// Chunk is a piece of a document produced by a text splitter.
+type Chunk struct {
+	// ID is derived from the offsets and text, so re-splitting the same
+	// document with the same settings yields the same IDs.
+	ID string
+	// Index is the position of the chunk in the document.
+	Index int
+	// Text is the chunk text, text[Start:End] of the document.
+	Text string
+	// Start is the byte offset of the chunk in the document.
+	Start int
+	// End is the byte offset just past the chunk.
+	End int
+	// Tokens is the size of the chunk as measured by the tokenizer.
+	Tokens int
+}
+
+// Token is the byte span of one token in a text.
+type Token struct {
+	Start int
+	End   int
+}
+
+// Tokenizer measures chunk sizes. ChunkSize and ChunkOverlap count tokens.
+type Tokenizer interface {
+	// Tokenize returns the spans of the tokens of text, in order.
+	Tokenize(text string) []Token
+}
+
+// CharTokenizer treats each character as a token, as the Python splitters
+// do. It is used when a splitter has no Tokenizer.
+type CharTokenizer struct{}
+
+// Tokenize returns one token per rune.
+func (CharTokenizer) Tokenize(text string) []Token {
+	tokens := make([]Token, 0, len(text))
+	for i, r := range text {
+		tokens = append(tokens, Token{Start: i, End: i + utf8.RuneLen(r)})
+	}
+	return tokens
+}
+
+// WordTokenizer treats each run of non-space characters as a token, a cheap
+// approximation of LLM tokens.
+type WordTokenizer struct{}
+
+// Tokenize returns one token per word.
+func (WordTokenizer) Tokenize(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			tokens = append(tokens, Token{Start: start, End: i})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Start: start, End: len(text)})
+	}
+	return tokens
+}
+
+// SplitText splits text with the Go implementation of splitter.
+func SplitText(splitter TextSplitter, text string) ([]Chunk, error) {
+	switch sp := splitter.(type) {
+	case *FixedSizeSplitter:
+		return sp.Split(text)
+	case *LangChainSplitter:
+		return sp.Split(text)
+	default:
+		return nil, fmt.Errorf("unsupported text splitter %T", splitter)
+	}
+}
+
+// Split cuts text into windows of ChunkSize tokens, each starting
+// ChunkSize-ChunkOverlap tokens after the previous one. Unset sizes default
+// as in neo4j-graphrag.
+func (s *FixedSizeSplitter) Split(text string) ([]Chunk, error) {
+	size, overlap, err := chunkSizes(s.ChunkSize, s.ChunkOverlap)
+	if err != nil {
+		return nil, err
+	}
+	tok := tokenizerOrDefault(s.Tokenizer)
+
+	tokens := tok.Tokenize(text)
+	var spans []Token
+	for i := 0; i < len(tokens); i += size - overlap {
+		j := min(i+size, len(tokens))
+		spans = append(spans, Token{Start: tokens[i].Start, End: tokens[j-1].End})
+		if j == len(tokens) {
+			break
+		}
+	}
+	return newChunks(text, spans, tok), nil
+}
+
+// Split cuts text at the first of Separators that occurs in it, merges the
+// pieces into chunks of up to ChunkSize tokens overlapping by up to
+// ChunkOverlap tokens, and splits oversized pieces again with the remaining
+// separators, as LangChain's RecursiveCharacterTextSplitter does.
+// CharacterTextSplitter uses only the first separator. Separators stay at
+// the end of the piece they follow, and chunks are trimmed of surrounding
+// whitespace.
+func (s *LangChainSplitter) Split(text string) ([]Chunk, error) {
+	size, overlap, err := chunkSizes(s.ChunkSize, s.ChunkOverlap)
+	if err != nil {
+		return nil, err
+	}
+	r := &recursiveSplitter{
+		text:    text,
+		tok:     tokenizerOrDefault(s.Tokenizer),
+		size:    size,
+		overlap: overlap,
+	}
+
+	seps := s.Separators
+	switch s.SplitterClass {
+	case "", "RecursiveCharacterTextSplitter":
+		if len(seps) == 0 {
+			seps = defaultSeparators
+		}
+	case "CharacterTextSplitter":
+		if len(seps) == 0 {
+			seps = defaultSeparators[:1]
+		}
+		seps = seps[:1]
+		r.keepOversized = true
+	default:
+		return nil, fmt.Errorf("splitter class %s has no Go implementation", s.SplitterClass)
+	}
+
+	return newChunks(text, r.split(Token{Start: 0, End: len(text)}, seps), r.tok), nil
+}
+
+// chunkSizes returns the effective chunk size and overlap.
+func chunkSizes(size, overlap int) (int, int, error) {
+	if size == 0 {
+		size = DefaultChunkSize
+		if overlap == 0 {
+			overlap = DefaultChunkOverlap
+		}
+	}
+	if size < 0 || overlap < 0 {
+		return 0, 0, fmt.Errorf("chunk size and overlap must not be negative")
+	}
+	if overlap >= size {
+		return 0, 0, fmt.Errorf("chunk overlap %d must be smaller than chunk size %d", overlap, size)
+	}
+	return size, overlap, nil
+}
+
+func tokenizerOrDefault(tok Tokenizer) Tokenizer {
+	if tok == nil {
+		return CharTokenizer{}
+	}
+	return tok
+}
+
+// recursiveSplitter holds the state of one LangChainSplitter.Split call.
+// Spans are byte ranges of text.
+type recursiveSplitter struct {
+	text          string
+	tok           Tokenizer
+	size          int
+	overlap       int
+	keepOversized bool
+}
+
+// count measures span without surrounding whitespace, as its chunk will be.
+func (r *recursiveSplitter) count(span Token) int {
+	return len(r.tok.Tokenize(strings.TrimSpace(r.text[span.Start:span.End])))
+}
+
+// split returns the chunk spans of span using seps.
+func (r *recursiveSplitter) split(span Token, seps []string) []Token {
+	sep, rest := seps[len(seps)-1], []string(nil)
+	for i, s := range seps {
+		if s == "" || strings.Contains(r.text[span.Start:span.End], s) {
+			sep, rest = s, seps[i+1:]
+			break
+		}
+	}
+	if sep == "" {
+		return r.window(span)
+	}
+
+	var chunks, pending []Token
+	for _, piece := range r.pieces(span, sep) {
+		if r.count(piece) <= r.size || r.keepOversized {
+			pending = append(pending, piece)
+			continue
+		}
+		chunks = append(chunks, r.merge(pending)...)
+		pending = nil
+		if len(rest) > 0 {
+			chunks = append(chunks, r.split(piece, rest)...)
+		} else {
+			chunks = append(chunks, r.window(piece)...)
+		}
+	}
+	return append(chunks, r.merge(pending)...)
+}
+
+// pieces cuts span after each occurrence of sep.
+func (r *recursiveSplitter) pieces(span Token, sep string) []Token {
+	var pieces []Token
+	start := span.Start
+	for start < span.End {
+		i := strings.Index(r.text[start:span.End], sep)
+		if i < 0 {
+			pieces = append(pieces, Token{Start: start, End: span.End})
+			break
+		}
+		end := start + i + len(sep)
+		pieces = append(pieces, Token{Start: start, End: end})
+		start = end
+	}
+	return pieces
+}
+
+// merge joins consecutive pieces into chunks of at most size tokens. Each
+// new chunk starts with the trailing pieces of the previous one that fit in
+// overlap tokens.
+func (r *recursiveSplitter) merge(pieces []Token) []Token {
+	var chunks []Token
+	var current []Token
+	for _, piece := range pieces {
+		if len(current) > 0 && r.count(Token{Start: current[0].Start, End: piece.End}) > r.size {
+			chunks = append(chunks, Token{Start: current[0].Start, End: current[len(current)-1].End})
+			for len(current) > 0 {
+				kept := Token{Start: current[0].Start, End: current[len(current)-1].End}
+				if r.count(kept) <= r.overlap && r.count(Token{Start: kept.Start, End: piece.End}) <= r.size {
+					break
+				}
+				current = current[1:]
+			}
+		}
+		current = append(current, piece)
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, Token{Start: current[0].Start, End: current[len(current)-1].End})
+	}
+	return chunks
+}
+
+// window cuts span into consecutive windows of size tokens, overlapping by
+// overlap tokens.
+func (r *recursiveSplitter) window(span Token) []Token {
+	tokens := r.tok.Tokenize(r.text[span.Start:span.End])
+	var windows []Token
+	for i := 0; i < len(tokens); i += r.size - r.overlap {
+		j := min(i+r.size, len(tokens))
+		windows = append(windows, Token{Start: span.Start + tokens[i].Start, End: span.Start + tokens[j-1].End})
+		if j == len(tokens) {
+			break
+		}
+	}
+	return windows
+}
+
+// newChunks trims the spans of surrounding whitespace, drops empty ones and
+// numbers the rest.
+func newChunks(text string, spans []Token, tok Tokenizer) []Chunk {
+	chunks := make([]Chunk, 0, len(spans))
+	for _, span := range spans {
+		raw := text[span.Start:span.End]
+		trimmed := strings.TrimLeftFunc(raw, unicode.IsSpace)
+		start := span.Start + len(raw) - len(trimmed)
+		trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+		if trimmed == "" {
+			continue
+		}
+		end := start + len(trimmed)
+		chunks = append(chunks, Chunk{
+			ID:     chunkID(start, end, trimmed),
+			Index:  len(chunks),
+			Text:   trimmed,
+			Start:  start,
+			End:    end,
+			Tokens: len(tok.Tokenize(trimmed)),
+		})
+	}
+	return chunks
+}
+
+func chunkID(start, end int, text string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%s", start, end, text)))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,167 @@
+package kg
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func chunkTexts(chunks []Chunk) []string {
+	texts := make([]string, len(chunks))
+	for i, c := range chunks {
+		texts[i] = c.Text
+	}
+	return texts
+}
+
+func TestFixedSizeSplitter_Split(t *testing.T) {
+	text := "abcdefghij"
+	chunks, err := (&FixedSizeSplitter{ChunkSize: 4, ChunkOverlap: 1}).Split(text)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+
+	got := strings.Join(chunkTexts(chunks), ",")
+	if got != "abcd,defg,ghij" {
+		t.Errorf("unexpected chunks: %s", got)
+	}
+	for i, c := range chunks {
+		if c.Index != i || text[c.Start:c.End] != c.Text {
+			t.Errorf("chunk %d has wrong index or offsets: %+v", i, c)
+		}
+	}
+}
+
+func TestSplitter_DefaultOverlap(t *testing.T) {
+	text := strings.Repeat("a", 4300)
+
+	tests := []struct {
+		name     string
+		splitter TextSplitter
+		starts   []int
+	}{
+		{"defaults overlap like neo4j-graphrag", &FixedSizeSplitter{}, []int{0, 3800}},
+		{"chunk size without overlap", &FixedSizeSplitter{ChunkSize: 4000}, []int{0, 4000}},
+		{"overlap without chunk size", &FixedSizeSplitter{ChunkOverlap: 100}, []int{0, 3900}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks, err := SplitText(tt.splitter, text)
+			if err != nil {
+				t.Fatalf("SplitText failed: %v", err)
+			}
+			var starts []int
+			for _, c := range chunks {
+				starts = append(starts, c.Start)
+			}
+			if fmt.Sprint(starts) != fmt.Sprint(tt.starts) {
+				t.Errorf("chunk starts = %v, want %v", starts, tt.starts)
+			}
+		})
+	}
+}
+
+func TestFixedSizeSplitter_Tokenizer(t *testing.T) {
+	s := &FixedSizeSplitter{ChunkSize: 3, ChunkOverlap: 1, Tokenizer: WordTokenizer{}}
+	chunks, err := s.Split("one two  three four five")
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+
+	got := strings.Join(chunkTexts(chunks), "|")
+	if got != "one two  three|three four five" {
+		t.Errorf("unexpected chunks: %s", got)
+	}
+	if chunks[0].Tokens != 3 {
+		t.Errorf("expected 3 tokens, got %d", chunks[0].Tokens)
+	}
+}
+
+func TestLangChainSplitter_Split(t *testing.T) {
+	text := "Graphs store nodes.\n\nNodes have labels and properties. Relationships connect them.\n\nIndexes speed up lookups."
+	s := &LangChainSplitter{ChunkSize: 40, ChunkOverlap: 0, Separators: []string{"\n\n", ". ", " "}}
+
+	chunks, err := s.Split(text)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+
+	expected := []string{
+		"Graphs store nodes.",
+		"Nodes have labels and properties.",
+		"Relationships connect them.",
+		"Indexes speed up lookups.",
+	}
+	if got := chunkTexts(chunks); strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected chunks:\n got: %q\nwant: %q", got, expected)
+	}
+	for _, c := range chunks {
+		if len(c.Text) > 40 || text[c.Start:c.End] != c.Text {
+			t.Errorf("bad chunk: %+v", c)
+		}
+	}
+}
+
+func TestLangChainSplitter_Overlap(t *testing.T) {
+	s := &LangChainSplitter{ChunkSize: 11, ChunkOverlap: 5}
+	chunks, err := s.Split("aaa bbb ccc ddd eee")
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+
+	got := strings.Join(chunkTexts(chunks), "|")
+	if got != "aaa bbb ccc|ccc ddd eee" {
+		t.Errorf("unexpected chunks: %s", got)
+	}
+}
+
+func TestLangChainSplitter_OversizedWord(t *testing.T) {
+	chunks, err := (&LangChainSplitter{ChunkSize: 4}).Split("ab abcdefghij")
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	for _, c := range chunks {
+		if c.Tokens > 4 {
+			t.Errorf("chunk exceeds size: %+v", c)
+		}
+	}
+}
+
+func TestSplitText_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		splitter TextSplitter
+		errMsg   string
+	}{
+		{"overlap too large", &FixedSizeSplitter{ChunkSize: 10, ChunkOverlap: 10}, "must be smaller"},
+		{"negative size", &LangChainSplitter{ChunkSize: -1}, "must not be negative"},
+		{"unknown class", &LangChainSplitter{SplitterClass: "MarkdownTextSplitter"}, "no Go implementation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SplitText(tt.splitter, "text")
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestSplitText_StableIDs(t *testing.T) {
+	s := &FixedSizeSplitter{ChunkSize: 5, ChunkOverlap: 2}
+	first, _ := SplitText(s, "stable chunk identifiers")
+	second, _ := SplitText(s, "stable chunk identifiers")
+
+	seen := make(map[string]bool)
+	for i := range first {
+		if first[i].ID != second[i].ID {
+			t.Errorf("chunk %d ID changed: %s != %s", i, first[i].ID, second[i].ID)
+		}
+		if seen[first[i].ID] {
+			t.Errorf("duplicate ID %s", first[i].ID)
+		}
+		seen[first[i].ID] = true
+	}
+}
@@ -0,0 +1,74 @@
+# KG pipeline: movies
+import asyncio
+import os
+import sys
+
+import neo4j
+from neo4j_graphrag.llm import OpenAILLM
+from neo4j_graphrag.embeddings import OpenAIEmbeddings
+from neo4j_graphrag.experimental.components.text_splitters.fixed_size_splitter import FixedSizeSplitter
+from neo4j_graphrag.generation.prompts import ERExtractionTemplate
+from neo4j_graphrag.experimental.components.types import LexicalGraphConfig
+from neo4j_graphrag.experimental.pipeline import Pipeline
+from neo4j_graphrag.experimental.components.embedder import TextChunkEmbedder
+from neo4j_graphrag.experimental.components.entity_relation_extractor import LLMEntityRelationExtractor, OnError
+from neo4j_graphrag.experimental.components.kg_writer import Neo4jWriter
+from neo4j_graphrag.experimental.components.resolver import SpaCySemanticMatchResolver
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+llm = OpenAILLM(
+    model_name="gpt-4o",
+    model_params={"temperature": 0.2, "max_tokens": 2000},
+    api_key=os.environ["OPENAI_API_KEY"],
+)
+
+embedder = OpenAIEmbeddings(
+    model="text-embedding-3-small",
+    api_key=os.environ["OPENAI_API_KEY"],
+)
+
+text_splitter = FixedSizeSplitter()
+
+prompt_template = ERExtractionTemplate(template="Extract Person and Movie nodes.\n\nReturn JSON like {{\"nodes\": []}} for:\n{text}")
+
+lexical_graph_config = LexicalGraphConfig(
+    chunk_node_label="Passage",
+)
+
+pipe = Pipeline()
+pipe.add_component(text_splitter, "splitter")
+pipe.add_component(TextChunkEmbedder(embedder=embedder), "chunk_embedder")
+pipe.add_component(
+    LLMEntityRelationExtractor(
+        llm=llm,
+        prompt_template=prompt_template,
+        # WARN: neo4j-graphrag has no WARN mode; IGNORE skips failed chunks and logs them at ERROR level.
+        on_error=OnError.IGNORE,
+    ),
+    "extractor",
+)
+pipe.add_component(Neo4jWriter(driver, neo4j_database="neo4j"), "writer")
+pipe.connect("splitter", "chunk_embedder", input_config={"text_chunks": "splitter"})
+pipe.connect("chunk_embedder", "extractor", input_config={"chunks": "chunk_embedder"})
+pipe.connect("extractor", "writer", input_config={"graph": "extractor"})
+
+if __name__ == "__main__":
+    with open(sys.argv[1], encoding="utf-8") as f:
+        inputs = {
+            "splitter": {"text": f.read()},
+            "extractor": {"lexical_graph_config": lexical_graph_config},
+        }
+        asyncio.run(pipe.run(inputs))
+    resolver = SpaCySemanticMatchResolver(
+        driver,
+        resolve_properties=["name"],
+        similarity_threshold=0.9,
+        spacy_model="en_core_web_lg",
+        neo4j_database="neo4j",
+    )
+    asyncio.run(resolver.run())
+    driver.close()
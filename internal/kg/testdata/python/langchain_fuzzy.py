@@ -0,0 +1,73 @@
+# KG pipeline: articles
+import asyncio
+import os
+import sys
+
+from langchain_text_splitters import RecursiveCharacterTextSplitter
+import neo4j
+from neo4j_graphrag.llm import AnthropicLLM
+from neo4j_graphrag.embeddings import OllamaEmbeddings
+from neo4j_graphrag.experimental.components.text_splitters.langchain import LangChainTextSplitterAdapter
+from neo4j_graphrag.experimental.pipeline.kg_builder import SimpleKGPipeline
+from neo4j_graphrag.experimental.components.resolver import FuzzyMatchResolver
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+llm = AnthropicLLM(
+    model_name="claude-sonnet",
+    api_key=os.environ["LLM_KEY"],
+)
+
+embedder = OllamaEmbeddings(
+    model="nomic-embed-text",
+)
+
+text_splitter = LangChainTextSplitterAdapter(
+    RecursiveCharacterTextSplitter(
+        chunk_size=1000,
+        chunk_overlap=100,
+        separators=["\n\n", "\n"],
+    )
+)
+
+schema = {
+    "node_types": [
+        {"label": "Person", "description": "A person", "properties": [
+            {"name": "name", "type": "STRING", "required": True},
+        ]},
+        {"label": "Movie", "properties": [
+            {"name": "title", "type": "STRING", "required": True},
+            {"name": "released", "type": "INTEGER"},
+        ]},
+    ],
+    "relationship_types": [
+        {"label": "DIRECTED"},
+    ],
+    "patterns": [
+        ("Person", "DIRECTED", "Movie"),
+    ],
+}
+
+kg_builder = SimpleKGPipeline(
+    llm=llm,
+    driver=driver,
+    embedder=embedder,
+    schema=schema,
+    text_splitter=text_splitter,
+    from_pdf=False,
+    perform_entity_resolution=False,
+)
+
+if __name__ == "__main__":
+    with open(sys.argv[1], encoding="utf-8") as f:
+        asyncio.run(kg_builder.run_async(text=f.read()))
+    resolver = FuzzyMatchResolver(
+        driver,
+        resolve_properties=["name"],
+        similarity_threshold=0.85,
+    )
+    asyncio.run(resolver.run())
+    driver.close()
@@ -0,0 +1,65 @@
+# KG pipeline: movies
+import asyncio
+import os
+import sys
+
+import neo4j
+from neo4j_graphrag.llm import OpenAILLM
+from neo4j_graphrag.embeddings import OpenAIEmbeddings
+from neo4j_graphrag.experimental.components.text_splitters.fixed_size_splitter import FixedSizeSplitter
+from neo4j_graphrag.experimental.pipeline.kg_builder import SimpleKGPipeline
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+llm = OpenAILLM(
+    model_name="gpt-4o",
+    model_params={"temperature": 0.2, "max_tokens": 2000},
+    api_key=os.environ["OPENAI_API_KEY"],
+)
+
+embedder = OpenAIEmbeddings(
+    model="text-embedding-3-small",
+    api_key=os.environ["OPENAI_API_KEY"],
+)
+
+text_splitter = FixedSizeSplitter(chunk_size=500, chunk_overlap=50)
+
+schema = {
+    "node_types": [
+        {"label": "Person", "description": "A person", "properties": [
+            {"name": "name", "type": "STRING", "required": True},
+        ]},
+        {"label": "Movie", "properties": [
+            {"name": "title", "type": "STRING", "required": True},
+            {"name": "released", "type": "INTEGER"},
+        ]},
+        {"label": "Show"},
+    ],
+    "relationship_types": [
+        {"label": "ACTED_IN", "properties": [
+            {"name": "role", "type": "STRING"},
+        ]},
+    ],
+    "patterns": [
+        ("Person", "ACTED_IN", "Movie"),
+        ("Person", "ACTED_IN", "Show"),
+    ],
+}
+
+kg_builder = SimpleKGPipeline(
+    llm=llm,
+    driver=driver,
+    embedder=embedder,
+    schema=schema,
+    text_splitter=text_splitter,
+    from_pdf=True,
+    on_error="IGNORE",
+    neo4j_database="neo4j",
+)
+
+if __name__ == "__main__":
+    asyncio.run(kg_builder.run_async(file_path=sys.argv[1]))
+    driver.close()
@@ -17,6 +17,7 @@ func TestInstallConfig_WritesCorrectFormat(t *testing.T) {
 
 	// Install config
 	config := NewConfig()
+	config.WorkDir = t.TempDir()
 	err := InstallConfig(config)
 	if err != nil {
 		t.Fatalf("InstallConfig failed: %v", err)
@@ -73,6 +74,7 @@ func TestInstallConfig_AgentFileExists(t *testing.T) {
 	defer func() { _ = os.Setenv("HOME", origHome) }()
 
 	config := NewConfig()
+	config.WorkDir = t.TempDir()
 	err := InstallConfig(config)
 	if err != nil {
 		t.Fatalf("InstallConfig failed: %v", err)
@@ -101,6 +103,7 @@ func TestInstallConfig_UsesFullPath(t *testing.T) {
 	}
 
 	config := NewConfig()
+	config.WorkDir = t.TempDir()
 	err := InstallConfig(config)
 	if err != nil {
 		t.Fatalf("InstallConfig failed: %v", err)
@@ -149,6 +152,7 @@ func TestInstallConfig_MCPServerFormat(t *testing.T) {
 	}
 
 	config := NewConfig()
+	config.WorkDir = t.TempDir()
 	if err := InstallConfig(config); err != nil {
 		t.Fatalf("InstallConfig failed: %v", err)
 	}
@@ -207,6 +211,7 @@ func TestInstallConfig_NoInvalidToolReferences(t *testing.T) {
 	defer func() { _ = os.Setenv("HOME", origHome) }()
 
 	config := NewConfig()
+	config.WorkDir = t.TempDir()
 	if err := InstallConfig(config); err != nil {
 		t.Fatalf("InstallConfig failed: %v", err)
 	}
@@ -235,6 +240,7 @@ func TestInstallConfig_MinimalValidStructure(t *testing.T) {
 	defer func() { _ = os.Setenv("HOME", origHome) }()
 
 	config := NewConfig()
+	config.WorkDir = t.TempDir()
 	if err := InstallConfig(config); err != nil {
 		t.Fatalf("InstallConfig failed: %v", err)
 	}
@@ -288,6 +294,7 @@ func TestInstallConfig_HasToolsArray(t *testing.T) {
 	defer func() { _ = os.Setenv("HOME", origHome) }()
 
 	config := NewConfig()
+	config.WorkDir = t.TempDir()
 	if err := InstallConfig(config); err != nil {
 		t.Fatalf("InstallConfig failed: %v", err)
 	}
@@ -3,9 +3,13 @@
 // This package implements WN4xxx lint rules for:
 // - GDS algorithm configurations (WN4001-WN4008)
 // - Style enforcement (WN4010-WN4013)
+// - Path finding algorithms (WN4014-WN4018)
+// - Centrality and community algorithms (WN4020-WN4021)
 // - ML pipeline configurations (WN4030-WN4035)
 // - GraphRAG configurations (WN4040-WN4047)
 // - Schema definitions (WN4050-WN4056)
+// - Analytics workflows (WN4070-WN4073)
+// - Version targets (WN4080-WN4081)
 //
 // Example usage:
 //
@@ -22,8 +26,13 @@ import (
 	"strings"
 
 	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/estimation"
 	"github.com/lex00/wetwire-neo4j-go/internal/kg"
 	"github.com/lex00/wetwire-neo4j-go/internal/pipelines"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+	"github.com/lex00/wetwire-neo4j-go/internal/retrievers"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
+	"github.com/lex00/wetwire-neo4j-go/internal/workflows"
 	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
 )
 
@@ -65,6 +74,9 @@ type Linter struct {
 	screamingRegex      *regexp.Regexp
 	maxInlineProperties int
 	maxNestingDepth     int
+	memoryBudget        int64
+	graphSize           estimation.GraphSize
+	target              target.Target
 }
 
 // NewLinter creates a new linter with default settings.
@@ -90,6 +102,22 @@ func (l *Linter) WithMaxNestingDepth(max int) *Linter {
 	return l
 }
 
+// WithMemoryBudget enables WN4073: workflows whose estimated peak heap against
+// the declared graph size exceeds budget bytes are reported.
+func (l *Linter) WithMemoryBudget(budget int64, size estimation.GraphSize) *Linter {
+	l.memoryBudget = budget
+	l.graphSize = size
+	return l
+}
+
+// WithTarget enables WN4080 and WN4081: features unavailable or deprecated in
+// the target's Neo4j and GDS versions are reported. Unset versions are not
+// checked.
+func (l *Linter) WithTarget(t target.Target) *Linter {
+	l.target = t
+	return l
+}
+
 // LintAlgorithm validates a GDS algorithm configuration.
 func (l *Linter) LintAlgorithm(algo algorithms.Algorithm) []LintResult {
 	var results []LintResult
@@ -107,8 +135,104 @@ func (l *Linter) LintAlgorithm(algo algorithms.Algorithm) []LintResult {
 		results = append(results, l.lintKNN(a)...)
 	case *algorithms.NodeSimilarity:
 		results = append(results, l.lintNodeSimilarity(a)...)
+	case *algorithms.Eigenvector:
+		results = append(results, lintMaxIterations("Eigenvector.MaxIterations", a.MaxIterations)...)
+	case *algorithms.HITS:
+		results = append(results, lintMaxIterations("HITS.HitsIterations", a.HitsIterations)...)
+	case *algorithms.CELF:
+		results = append(results, l.lintCELF(a)...)
+	case *algorithms.ModularityOptimization:
+		results = append(results, lintMaxIterations("ModularityOptimization.MaxIterations", a.MaxIterations)...)
+	case *algorithms.SLPA:
+		results = append(results, l.lintSLPA(a)...)
+	case *algorithms.KMeans:
+		results = append(results, l.lintKMeans(a)...)
+	case *algorithms.K1Coloring:
+		results = append(results, lintMaxIterations("K1Coloring.MaxIterations", a.MaxIterations)...)
+	case *algorithms.Conductance:
+		results = append(results, lintCommunityProperty("Conductance", a.CommunityProperty)...)
+		results = append(results, lintSupportedModes(algo, algorithms.Stream)...)
+	case *algorithms.Modularity:
+		results = append(results, lintCommunityProperty("Modularity", a.CommunityProperty)...)
+		results = append(results, lintSupportedModes(algo, algorithms.Stream, algorithms.Stats)...)
+	}
+
+	if algo.AlgorithmCategory() == algorithms.PathFinding {
+		results = append(results, l.lintPathFinding(algo)...)
 	}
 
+	results = append(results, l.lintProcedureTarget(algo.AlgorithmType(), strings.TrimPrefix(fmt.Sprintf("%T", algo), "*algorithms."))...)
+
+	return results
+}
+
+// lintProcedureTarget reports WN4080 when the target's GDS version does not
+// provide a procedure yet.
+func (l *Linter) lintProcedureTarget(procedure, location string) []LintResult {
+	if l.target.GDS.IsZero() {
+		return nil
+	}
+	if ok, since := l.target.ProcedureAvailable(procedure); !ok {
+		return []LintResult{{
+			Rule:     "WN4080",
+			Severity: Error,
+			Message:  fmt.Sprintf("%s requires GDS %s, but the target is GDS %s", procedure, since, l.target.GDS),
+			Location: location,
+		}}
+	}
+	return nil
+}
+
+// LintProjection validates a graph projection against the target: WN4081
+// reports Cypher projections deprecated by the target's GDS version, and
+// WN4080 filtering and sampling procedures it does not provide.
+func (l *Linter) LintProjection(p projections.Projection) []LintResult {
+	location := fmt.Sprintf("%s(%s)", strings.TrimPrefix(fmt.Sprintf("%T", p), "*projections."), p.ProjectionName())
+	switch v := p.(type) {
+	case *projections.CypherProjection:
+		if l.target.CypherProjectionDeprecated() {
+			return []LintResult{{
+				Rule:     "WN4081",
+				Severity: Warning,
+				Message:  fmt.Sprintf("gds.graph.project.cypher is deprecated in GDS %s; project with Cypher aggregation instead", l.target.GDS),
+				Location: location,
+			}}
+		}
+	case *projections.FilteredGraph:
+		return l.lintProcedureTarget("gds.graph.filter", location)
+	case *projections.SampledGraph:
+		return l.lintProcedureTarget("gds.graph.sample."+string(v.GetMethod()), location)
+	}
+	return nil
+}
+
+// lintSchemaTarget reports WN4080 for constraint and index types the
+// target's Neo4j version does not support.
+func (l *Linter) lintSchemaTarget(location string, constraints []schema.Constraint, indexes []schema.Index) []LintResult {
+	if l.target.Neo4j.IsZero() {
+		return nil
+	}
+	var results []LintResult
+	for _, c := range constraints {
+		if ok, since := l.target.ConstraintAvailable(c.Type); !ok {
+			results = append(results, LintResult{
+				Rule:     "WN4080",
+				Severity: Error,
+				Message:  fmt.Sprintf("%s constraints require Neo4j %s, but the target is Neo4j %s", c.Type, since, l.target.Neo4j),
+				Location: location + ".Constraints",
+			})
+		}
+	}
+	for _, idx := range indexes {
+		if ok, since := l.target.IndexAvailable(idx.Type); !ok {
+			results = append(results, LintResult{
+				Rule:     "WN4080",
+				Severity: Error,
+				Message:  fmt.Sprintf("%s indexes require Neo4j %s, but the target is Neo4j %s", idx.Type, since, l.target.Neo4j),
+				Location: location + ".Indexes",
+			})
+		}
+	}
 	return results
 }
 
@@ -222,6 +346,289 @@ func (l *Linter) lintKNN(knn *algorithms.KNN) []LintResult {
 	return results
 }
 
+// lintMaxIterations checks WN4002 for an iteration count field.
+func lintMaxIterations(location string, n int) []LintResult {
+	if n >= 0 {
+		return nil
+	}
+	return []LintResult{{
+		Rule:     "WN4002",
+		Severity: Error,
+		Message:  fmt.Sprintf("maxIterations must be positive, got %d", n),
+		Location: location,
+	}}
+}
+
+// lintCommunityProperty checks WN4020 for community quality metrics, which
+// score an existing community assignment and cannot run without one.
+func lintCommunityProperty(name, property string) []LintResult {
+	if property != "" {
+		return nil
+	}
+	return []LintResult{{
+		Rule:     "WN4020",
+		Severity: Error,
+		Message:  fmt.Sprintf("%s requires a CommunityProperty", name),
+		Location: name + ".CommunityProperty",
+	}}
+}
+
+func (l *Linter) lintCELF(celf *algorithms.CELF) []LintResult {
+	var results []LintResult
+
+	// WN4020: seed set size is required
+	if celf.SeedSetSize <= 0 {
+		results = append(results, LintResult{
+			Rule:     "WN4020",
+			Severity: Error,
+			Message:  fmt.Sprintf("seedSetSize must be positive, got %d", celf.SeedSetSize),
+			Location: "CELF.SeedSetSize",
+		})
+	}
+
+	// WN4021: parameters must be in range
+	if celf.PropagationProbability < 0 || celf.PropagationProbability > 1 {
+		results = append(results, LintResult{
+			Rule:     "WN4021",
+			Severity: Error,
+			Message:  fmt.Sprintf("propagationProbability must be in [0, 1], got %v", celf.PropagationProbability),
+			Location: "CELF.PropagationProbability",
+		})
+	}
+	if celf.MonteCarloSimulations < 0 {
+		results = append(results, LintResult{
+			Rule:     "WN4021",
+			Severity: Error,
+			Message:  fmt.Sprintf("monteCarloSimulations must be positive, got %d", celf.MonteCarloSimulations),
+			Location: "CELF.MonteCarloSimulations",
+		})
+	}
+
+	return results
+}
+
+func (l *Linter) lintSLPA(slpa *algorithms.SLPA) []LintResult {
+	results := lintMaxIterations("SLPA.MaxIterations", slpa.MaxIterations)
+
+	// WN4021: parameters must be in range
+	if slpa.MinAssociationStrength < 0 || slpa.MinAssociationStrength > 1 {
+		results = append(results, LintResult{
+			Rule:     "WN4021",
+			Severity: Error,
+			Message:  fmt.Sprintf("minAssociationStrength must be in [0, 1], got %v", slpa.MinAssociationStrength),
+			Location: "SLPA.MinAssociationStrength",
+		})
+	}
+
+	return results
+}
+
+func (l *Linter) lintKMeans(km *algorithms.KMeans) []LintResult {
+	results := lintMaxIterations("KMeans.MaxIterations", km.MaxIterations)
+
+	// WN4020: the clustered property is required
+	if km.NodeProperty == "" {
+		results = append(results, LintResult{
+			Rule:     "WN4020",
+			Severity: Error,
+			Message:  "KMeans requires a NodeProperty to cluster on",
+			Location: "KMeans.NodeProperty",
+		})
+	}
+
+	// WN4021: parameters must be in range
+	if km.K < 0 {
+		results = append(results, LintResult{
+			Rule:     "WN4021",
+			Severity: Error,
+			Message:  fmt.Sprintf("k must be positive, got %d", km.K),
+			Location: "KMeans.K",
+		})
+	}
+	if km.DeltaThreshold < 0 || km.DeltaThreshold > 1 {
+		results = append(results, LintResult{
+			Rule:     "WN4021",
+			Severity: Error,
+			Message:  fmt.Sprintf("deltaThreshold must be in [0, 1], got %v", km.DeltaThreshold),
+			Location: "KMeans.DeltaThreshold",
+		})
+	}
+	if km.InitialSampler != "" && km.InitialSampler != "uniform" && km.InitialSampler != "kmeans++" {
+		results = append(results, LintResult{
+			Rule:     "WN4021",
+			Severity: Error,
+			Message:  fmt.Sprintf("initialSampler must be 'uniform' or 'kmeans++', got '%s'", km.InitialSampler),
+			Location: "KMeans.InitialSampler",
+		})
+	}
+	if km.K > 0 && len(km.SeedCentroids) > 0 && len(km.SeedCentroids) != km.K {
+		results = append(results, LintResult{
+			Rule:     "WN4021",
+			Severity: Error,
+			Message:  fmt.Sprintf("%d seedCentroids given for k=%d", len(km.SeedCentroids), km.K),
+			Location: "KMeans.SeedCentroids",
+		})
+	}
+
+	return results
+}
+
+// lintSupportedModes checks WN4018 for algorithms that only run in some
+// execution modes.
+func lintSupportedModes(algo algorithms.Algorithm, modes ...algorithms.Mode) []LintResult {
+	for _, m := range modes {
+		if algo.GetMode() == m {
+			return nil
+		}
+	}
+	name := strings.TrimPrefix(fmt.Sprintf("%T", algo), "*algorithms.")
+	return []LintResult{{
+		Rule:     "WN4018",
+		Severity: Error,
+		Message:  fmt.Sprintf("%s does not support %s mode", name, algo.GetMode()),
+		Location: name + ".Mode",
+	}}
+}
+
+// pathFindingInputs are the inputs of a path finding algorithm checked by WN4014-WN4018.
+type pathFindingInputs struct {
+	source         any
+	needsSource    bool
+	targets        []any
+	needsTarget    bool
+	weight         string
+	weighted       bool
+	supportedModes []algorithms.Mode
+}
+
+func (l *Linter) lintPathFinding(algo algorithms.Algorithm) []LintResult {
+	var results []LintResult
+	var in pathFindingInputs
+	name := strings.TrimPrefix(fmt.Sprintf("%T", algo), "*algorithms.")
+
+	invalid := func(field, msg string) {
+		results = append(results, LintResult{
+			Rule:     "WN4017",
+			Severity: Error,
+			Message:  msg,
+			Location: name + "." + field,
+		})
+	}
+
+	switch a := algo.(type) {
+	case *algorithms.Dijkstra:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true, weight: a.RelationshipWeightProperty, weighted: true}
+	case *algorithms.AStar:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true, targets: []any{a.TargetNode}, needsTarget: true,
+			weight: a.RelationshipWeightProperty, weighted: true}
+		if a.LatitudeProperty == "" || a.LongitudeProperty == "" {
+			invalid("LatitudeProperty", "A* requires both LatitudeProperty and LongitudeProperty for its heuristic")
+		}
+	case *algorithms.BellmanFord:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true, weight: a.RelationshipWeightProperty, weighted: true}
+	case *algorithms.Yens:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true, targets: []any{a.TargetNode}, needsTarget: true,
+			weight: a.RelationshipWeightProperty, weighted: true}
+		if a.K < 0 {
+			invalid("K", fmt.Sprintf("k must be positive, got %d", a.K))
+		}
+	case *algorithms.DeltaStepping:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true, weight: a.RelationshipWeightProperty, weighted: true}
+		if a.Delta < 0 {
+			invalid("Delta", fmt.Sprintf("delta must be positive, got %v", a.Delta))
+		}
+	case *algorithms.AllShortestPaths:
+		in = pathFindingInputs{supportedModes: []algorithms.Mode{algorithms.Stream}}
+	case *algorithms.MinimumSpanningTree:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true, weight: a.RelationshipWeightProperty, weighted: true}
+		if a.Objective != "" && a.Objective != "minimum" && a.Objective != "maximum" {
+			invalid("Objective", fmt.Sprintf("objective must be 'minimum' or 'maximum', got '%s'", a.Objective))
+		}
+	case *algorithms.SteinerTree:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true, targets: a.TargetNodes, needsTarget: true,
+			weight: a.RelationshipWeightProperty, weighted: true}
+		if a.Delta < 0 {
+			invalid("Delta", fmt.Sprintf("delta must be positive, got %v", a.Delta))
+		}
+	case *algorithms.RandomWalk:
+		in = pathFindingInputs{supportedModes: []algorithms.Mode{algorithms.Stream, algorithms.Stats}}
+		if a.WalkLength < 0 {
+			invalid("WalkLength", fmt.Sprintf("walkLength must be positive, got %d", a.WalkLength))
+		}
+		if a.WalksPerNode < 0 {
+			invalid("WalksPerNode", fmt.Sprintf("walksPerNode must be positive, got %d", a.WalksPerNode))
+		}
+		if a.InOutFactor < 0 {
+			invalid("InOutFactor", fmt.Sprintf("inOutFactor must be positive, got %v", a.InOutFactor))
+		}
+		if a.ReturnFactor < 0 {
+			invalid("ReturnFactor", fmt.Sprintf("returnFactor must be positive, got %v", a.ReturnFactor))
+		}
+	case *algorithms.LongestPath:
+		in = pathFindingInputs{supportedModes: []algorithms.Mode{algorithms.Stream}}
+	case *algorithms.BFS:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true}
+	case *algorithms.DFS:
+		in = pathFindingInputs{source: a.SourceNode, needsSource: true}
+	}
+
+	// WN4014: source node is required
+	if in.needsSource && isEmptyNode(in.source) {
+		results = append(results, LintResult{
+			Rule:     "WN4014",
+			Severity: Error,
+			Message:  fmt.Sprintf("%s requires a SourceNode", name),
+			Location: name + ".SourceNode",
+		})
+	}
+
+	// WN4015: target nodes are required
+	if in.needsTarget {
+		missing := len(in.targets) == 0
+		for _, t := range in.targets {
+			if isEmptyNode(t) {
+				missing = true
+			}
+		}
+		if missing {
+			results = append(results, LintResult{
+				Rule:     "WN4015",
+				Severity: Error,
+				Message:  fmt.Sprintf("%s requires target nodes", name),
+				Location: name + ".TargetNode",
+			})
+		}
+	}
+
+	// WN4016: weighted path algorithms without a weight count hops
+	if in.weighted && in.weight == "" {
+		results = append(results, LintResult{
+			Rule:     "WN4016",
+			Severity: Warning,
+			Message:  fmt.Sprintf("%s has no RelationshipWeightProperty; every relationship costs 1", name),
+			Location: name + ".RelationshipWeightProperty",
+		})
+	}
+
+	// WN4018: execution mode must be supported
+	if len(in.supportedModes) > 0 {
+		results = append(results, lintSupportedModes(algo, in.supportedModes...)...)
+	}
+
+	return results
+}
+
+// isEmptyNode reports whether a node reference is unset.
+func isEmptyNode(node any) bool {
+	if node == nil {
+		return true
+	}
+	if s, ok := node.(string); ok {
+		return s == ""
+	}
+	return false
+}
+
 func (l *Linter) lintNodeSimilarity(ns *algorithms.NodeSimilarity) []LintResult {
 	var results []LintResult
 
@@ -283,6 +690,13 @@ func (l *Linter) lintSplitConfig(config pipelines.SplitConfig, name string) []Li
 
 // LintKGPipeline validates a KG construction pipeline.
 func (l *Linter) LintKGPipeline(pipeline kg.KGPipeline) []LintResult {
+	return l.LintKGPipelineWithSchema(pipeline, nil)
+}
+
+// LintKGPipelineWithSchema validates a KG construction pipeline and
+// reconciles the entity and relation types of a SimpleKGPipeline with the
+// node and relationship types of sch. sch may be nil.
+func (l *Linter) LintKGPipelineWithSchema(pipeline kg.KGPipeline, sch *schema.Schema) []LintResult {
 	var results []LintResult
 
 	switch p := pipeline.(type) {
@@ -320,6 +734,220 @@ func (l *Linter) LintKGPipeline(pipeline kg.KGPipeline) []LintResult {
 				}
 			}
 		}
+
+		if sch != nil && len(sch.Nodes) > 0 {
+			results = append(results, lintKGSchema(p, sch)...)
+		}
+
+	case *kg.CommunitySummaryPipeline:
+		// WN4049: community summary pipelines need Leiden, an LLM and an embedder
+		if err := p.Validate(); err != nil {
+			results = append(results, LintResult{
+				Rule:     "WN4049",
+				Severity: Error,
+				Message:  err.Error(),
+				Location: p.Name,
+			})
+		} else if !p.Leiden.IncludeIntermediateCommunities {
+			results = append(results, LintResult{
+				Rule:     "WN4049",
+				Severity: Warning,
+				Message:  "Leiden without IncludeIntermediateCommunities yields a single community level for global search",
+				Location: fmt.Sprintf("%s.Leiden.IncludeIntermediateCommunities", p.Name),
+			})
+		}
+	}
+
+	return results
+}
+
+// lintKGSchema reports where extraction types disagree with the schema.
+func lintKGSchema(p *kg.SimpleKGPipeline, sch *schema.Schema) []LintResult {
+	var results []LintResult
+	for _, m := range kg.Reconcile(p.EntityTypes, p.RelationTypes, sch) {
+		result := LintResult{Message: m.Message}
+		switch m.Kind {
+		case kg.MismatchMissing:
+			// WN4041: extraction types should be declared in the schema
+			result.Rule, result.Severity = "WN4041", Warning
+		case kg.MismatchPropertyType:
+			// WN4042: extracted property types must match the schema
+			result.Rule, result.Severity = "WN4042", Error
+		case kg.MismatchEndpoint:
+			// WN4048: relation endpoints must be allowed by the schema
+			result.Rule, result.Severity = "WN4048", Error
+		}
+		if m.Relation {
+			result.Location = fmt.Sprintf("%s.RelationTypes", p.Name)
+		} else {
+			result.Location = fmt.Sprintf("%s.EntityTypes", p.Name)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// LintRetriever validates the filters and reranker of a retriever, and the
+// examples and schema prompt of a Text2Cypher retriever. Filters are checked
+// against the node type matching the retriever's NodeLabel, and examples
+// against the retriever's Schema, or else against sch. sch may be nil.
+func (l *Linter) LintRetriever(r retrievers.Retriever, sch *schema.Schema) []LintResult {
+	if t2c, ok := r.(*retrievers.Text2CypherRetriever); ok {
+		return l.lintText2Cypher(t2c, sch)
+	}
+
+	var label string
+	var filters *retrievers.Filter
+	var reranker *retrievers.RerankerConfig
+	topK := 0
+
+	switch v := r.(type) {
+	case *retrievers.VectorRetriever:
+		label, filters, reranker, topK = v.NodeLabel, v.Filters, v.Reranker, v.TopK
+	case *retrievers.VectorCypherRetriever:
+		label, filters, reranker, topK = v.NodeLabel, v.Filters, v.Reranker, v.TopK
+	case *retrievers.HybridRetriever:
+		label, filters, reranker, topK = v.NodeLabel, v.Filters, v.Reranker, v.TopK
+	case *retrievers.HybridCypherRetriever:
+		label, filters, reranker, topK = v.NodeLabel, v.Filters, v.Reranker, v.TopK
+	default:
+		return nil
+	}
+
+	var results []LintResult
+	name := r.RetrieverName()
+
+	// WN4044: filters must reference existing properties with matching types
+	if filters != nil {
+		var node *schema.NodeType
+		if sch != nil {
+			for _, n := range sch.Nodes {
+				if n.Label == label {
+					node = n
+					break
+				}
+			}
+		}
+		for _, err := range filters.Validate(node) {
+			results = append(results, LintResult{
+				Rule:     "WN4044",
+				Severity: Error,
+				Message:  err.Error(),
+				Location: fmt.Sprintf("%s.Filters", name),
+			})
+		}
+	}
+
+	// WN4045: the reranker can only keep results the search returned
+	if reranker != nil && topK > 0 && reranker.TopN > topK {
+		results = append(results, LintResult{
+			Rule:     "WN4045",
+			Severity: Warning,
+			Message:  fmt.Sprintf("reranker TopN %d exceeds TopK %d", reranker.TopN, topK),
+			Location: fmt.Sprintf("%s.Reranker.TopN", name),
+		})
+	}
+
+	return results
+}
+
+// lintText2Cypher checks the examples and compiled schema prompt of a
+// Text2Cypher retriever.
+func (l *Linter) lintText2Cypher(r *retrievers.Text2CypherRetriever, sch *schema.Schema) []LintResult {
+	var results []LintResult
+	if r.Schema != nil {
+		sch = r.Schema
+	}
+
+	// WN4046: examples must only use schema elements
+	if sch != nil && len(sch.Nodes) > 0 {
+		for _, err := range retrievers.ValidateExamples(sch, r.Examples) {
+			results = append(results, LintResult{
+				Rule:     "WN4046",
+				Severity: Error,
+				Message:  err.Error(),
+				Location: fmt.Sprintf("%s.Examples", r.Name),
+			})
+		}
+	}
+
+	// WN4047: the compiled schema prompt should fit its token budget
+	if r.SchemaDescription == "" && r.Schema != nil {
+		prompt, err := r.SchemaPrompt()
+		switch {
+		case err != nil:
+			results = append(results, LintResult{
+				Rule:     "WN4047",
+				Severity: Error,
+				Message:  err.Error(),
+				Location: fmt.Sprintf("%s.SchemaTokenBudget", r.Name),
+			})
+		case len(prompt.Dropped) > 0:
+			results = append(results, LintResult{
+				Rule:     "WN4047",
+				Severity: Warning,
+				Message:  fmt.Sprintf("schema prompt exceeds %d tokens; dropped %s", r.SchemaTokenBudget, strings.Join(prompt.Dropped, ", ")),
+				Location: fmt.Sprintf("%s.SchemaTokenBudget", r.Name),
+			})
+		}
+	}
+
+	return results
+}
+
+// LintWorkflow validates an analytics workflow.
+func (l *Linter) LintWorkflow(w *workflows.Workflow) []LintResult {
+	var results []LintResult
+
+	// WN4071: workflow must have a projection and steps bound to its graph
+	if err := w.Validate(); err != nil {
+		results = append(results, LintResult{
+			Rule:     "WN4071",
+			Severity: Error,
+			Message:  err.Error(),
+			Location: fmt.Sprintf("Workflow(%s)", w.Name),
+		})
+	}
+
+	missing, unused := w.DataFlow()
+
+	// WN4070: step inputs must be projected or produced by an earlier mutate step
+	for _, m := range missing {
+		results = append(results, LintResult{
+			Rule:     "WN4070",
+			Severity: Error,
+			Message: fmt.Sprintf("step %d (%s) reads %s '%s', which is not projected or mutated by an earlier step",
+				m.Step, m.StepName, m.Kind, m.Name),
+			Location: fmt.Sprintf("Workflow(%s).Steps[%d]", w.Name, m.Step-1),
+		})
+	}
+
+	// WN4072: mutated outputs should be used by a later step
+	for _, u := range unused {
+		results = append(results, LintResult{
+			Rule:     "WN4072",
+			Severity: Warning,
+			Message:  fmt.Sprintf("step %d (%s) mutates %s '%s', but no later step reads it", u.Step, u.StepName, u.Kind, u.Name),
+			Location: fmt.Sprintf("Workflow(%s).Steps[%d]", w.Name, u.Step-1),
+		})
+	}
+
+	// WN4073: estimated peak heap must fit the configured memory budget
+	if l.memoryBudget > 0 {
+		report := w.EstimateMemory(l.graphSize)
+		if report.Peak.BytesMax > l.memoryBudget {
+			location := fmt.Sprintf("Workflow(%s).Projection", w.Name)
+			if report.PeakStep > 0 {
+				location = fmt.Sprintf("Workflow(%s).Steps[%d]", w.Name, report.PeakStep-1)
+			}
+			results = append(results, LintResult{
+				Rule:     "WN4073",
+				Severity: Error,
+				Message: fmt.Sprintf("estimated peak heap %s exceeds the memory budget of %s",
+					report.Peak, estimation.FormatBytes(l.memoryBudget)),
+				Location: location,
+			})
+		}
 	}
 
 	return results
@@ -373,6 +1001,8 @@ func (l *Linter) lintNodeTypeWithDepth(node *schema.NodeType, depth int) []LintR
 		})
 	}
 
+	results = append(results, l.lintSchemaTarget(fmt.Sprintf("NodeType(%s)", node.Label), node.Constraints, node.Indexes)...)
+
 	return results
 }
 
@@ -442,6 +1072,8 @@ func (l *Linter) lintRelationshipTypeWithDepth(rel *schema.RelationshipType, dep
 		})
 	}
 
+	results = append(results, l.lintSchemaTarget(fmt.Sprintf("RelationshipType(%s)", rel.Label), rel.Constraints, nil)...)
+
 	return results
 }
 
@@ -454,6 +1086,28 @@ func (l *Linter) LintAll(resources []any) []LintResult {
 func (l *Linter) LintAllWithOptions(resources []any, opts LintOptions) []LintResult {
 	var results []LintResult
 
+	// KG pipelines are checked against the node and relationship types
+	// linted with them, and retrievers also against the lexical graphs of
+	// the KG pipelines.
+	sch := &schema.Schema{}
+	for _, r := range resources {
+		switch v := r.(type) {
+		case *schema.NodeType:
+			sch.Nodes = append(sch.Nodes, v)
+		case *schema.RelationshipType:
+			sch.Relationships = append(sch.Relationships, v)
+		}
+	}
+	retrieverSchema := &schema.Schema{Nodes: sch.Nodes, Relationships: sch.Relationships}
+	for _, r := range resources {
+		if p, ok := r.(kg.KGPipeline); ok {
+			if lexical, err := kg.LexicalGraphSchema(p); err == nil {
+				retrieverSchema.Nodes = append(retrieverSchema.Nodes, lexical.Nodes...)
+				retrieverSchema.Relationships = append(retrieverSchema.Relationships, lexical.Relationships...)
+			}
+		}
+	}
+
 	for _, r := range resources {
 		switch v := r.(type) {
 		case algorithms.Algorithm:
@@ -461,11 +1115,17 @@ func (l *Linter) LintAllWithOptions(resources []any, opts LintOptions) []LintRes
 		case pipelines.Pipeline:
 			results = append(results, l.LintPipeline(v)...)
 		case kg.KGPipeline:
-			results = append(results, l.LintKGPipeline(v)...)
+			results = append(results, l.LintKGPipelineWithSchema(v, sch)...)
+		case retrievers.Retriever:
+			results = append(results, l.LintRetriever(v, retrieverSchema)...)
+		case *workflows.Workflow:
+			results = append(results, l.LintWorkflow(v)...)
 		case *schema.NodeType:
 			results = append(results, l.LintNodeType(v)...)
 		case *schema.RelationshipType:
 			results = append(results, l.LintRelationshipType(v)...)
+		case projections.Projection:
+			results = append(results, l.LintProjection(v)...)
 		}
 	}
 
@@ -189,6 +189,102 @@ func TestLinter_WN4040_EntityTypeRequired(t *testing.T) {
 	})
 }
 
+// WN4041, WN4042, WN4048: extraction types must agree with the schema
+func TestLinter_KGSchemaReconciliation(t *testing.T) {
+	l := NewLinter()
+	sch := &schema.Schema{
+		Nodes: []*schema.NodeType{
+			{Label: "Person", Properties: []schema.Property{{Name: "born", Type: schema.INTEGER}}},
+			{Label: "Company"},
+		},
+		Relationships: []*schema.RelationshipType{
+			{Label: "WORKS_AT", Source: "Person", Target: "Company"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		pipeline *kg.SimpleKGPipeline
+		rule     string
+	}{
+		{"missing entity type", &kg.SimpleKGPipeline{EntityTypes: []kg.EntityType{{Name: "City"}}}, "WN4041"},
+		{"missing relation type", &kg.SimpleKGPipeline{
+			EntityTypes:   []kg.EntityType{{Name: "Person"}},
+			RelationTypes: []kg.RelationType{{Name: "KNOWS", SourceTypes: []string{"Person"}, TargetTypes: []string{"Person"}}},
+		}, "WN4041"},
+		{"property type", &kg.SimpleKGPipeline{
+			EntityTypes: []kg.EntityType{{Name: "Person", Properties: []kg.EntityProperty{{Name: "born", Type: "DATE"}}}},
+		}, "WN4042"},
+		{"reversed endpoints", &kg.SimpleKGPipeline{
+			EntityTypes:   []kg.EntityType{{Name: "Person"}, {Name: "Company"}},
+			RelationTypes: []kg.RelationType{{Name: "WORKS_AT", SourceTypes: []string{"Company"}, TargetTypes: []string{"Person"}}},
+		}, "WN4048"},
+		{"consistent", &kg.SimpleKGPipeline{
+			EntityTypes:   []kg.EntityType{{Name: "Person", Properties: []kg.EntityProperty{{Name: "born", Type: "INTEGER"}, {Name: "name"}}}},
+			RelationTypes: []kg.RelationType{{Name: "WORKS_AT", SourceTypes: []string{"Person"}, TargetTypes: []string{"Company"}}},
+		}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintKGPipelineWithSchema(tt.pipeline, sch)
+			if tt.rule == "" {
+				if len(results) > 0 {
+					t.Errorf("unexpected results: %v", results)
+				}
+				return
+			}
+			if len(results) != 1 || results[0].Rule != tt.rule {
+				t.Errorf("expected one %s result, got %v", tt.rule, results)
+			}
+		})
+	}
+
+	if results := l.LintKGPipeline(tests[0].pipeline); containsRule(results, "WN4041") {
+		t.Error("unexpected WN4041 without a schema")
+	}
+}
+
+// WN4049: community summary pipelines must be runnable
+func TestLinter_WN4049_CommunitySummary(t *testing.T) {
+	l := NewLinter()
+	pipeline := func(leiden *algorithms.Leiden) *kg.CommunitySummaryPipeline {
+		return &kg.CommunitySummaryPipeline{
+			BasePipeline: kg.BasePipeline{
+				Name:           "communities",
+				LLMConfig:      &kg.LLMConfig{Model: "gpt-4o"},
+				EmbedderConfig: &kg.EmbedderConfig{Model: "text-embedding-3-small", Dimensions: 1536},
+			},
+			Leiden: leiden,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pipeline *kg.CommunitySummaryPipeline
+		severity Severity
+	}{
+		{"no leiden", pipeline(nil), Error},
+		{"single level", pipeline(&algorithms.Leiden{}), Warning},
+		{"hierarchical", pipeline(&algorithms.Leiden{IncludeIntermediateCommunities: true}), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintKGPipeline(tt.pipeline)
+			if tt.severity == "" {
+				if len(results) > 0 {
+					t.Errorf("unexpected results: %v", results)
+				}
+				return
+			}
+			if len(results) != 1 || results[0].Rule != "WN4049" || results[0].Severity != tt.severity {
+				t.Errorf("expected one WN4049 %s, got %v", tt.severity, results)
+			}
+		})
+	}
+}
+
 // WN4052: Node labels should be PascalCase
 func TestLinter_WN4052_NodeLabelCase(t *testing.T) {
 	l := NewLinter()
@@ -426,3 +522,153 @@ func TestLinter_WN4031_SplitConfig(t *testing.T) {
 		})
 	}
 }
+
+// WN4014-WN4018: path finding inputs and modes
+func TestLinter_PathFinding(t *testing.T) {
+	l := NewLinter()
+
+	tests := []struct {
+		name  string
+		algo  algorithms.Algorithm
+		rules []string
+	}{
+		{
+			name: "valid dijkstra",
+			algo: &algorithms.Dijkstra{SourceNode: 1, RelationshipWeightProperty: "distance"},
+		},
+		{
+			name:  "dijkstra without source",
+			algo:  &algorithms.Dijkstra{RelationshipWeightProperty: "distance"},
+			rules: []string{"WN4014"},
+		},
+		{
+			name:  "yens without target or weight",
+			algo:  &algorithms.Yens{SourceNode: 1, K: 3},
+			rules: []string{"WN4015", "WN4016"},
+		},
+		{
+			name:  "steiner tree without terminals",
+			algo:  &algorithms.SteinerTree{SourceNode: 1, RelationshipWeightProperty: "cost"},
+			rules: []string{"WN4015"},
+		},
+		{
+			name:  "negative delta",
+			algo:  &algorithms.DeltaStepping{SourceNode: 1, Delta: -1, RelationshipWeightProperty: "cost"},
+			rules: []string{"WN4017"},
+		},
+		{
+			name:  "astar without coordinates",
+			algo:  &algorithms.AStar{SourceNode: 1, TargetNode: 2, RelationshipWeightProperty: "distance"},
+			rules: []string{"WN4017"},
+		},
+		{
+			name:  "spanning tree objective",
+			algo:  &algorithms.MinimumSpanningTree{SourceNode: 1, RelationshipWeightProperty: "cost", Objective: "shortest"},
+			rules: []string{"WN4017"},
+		},
+		{
+			name:  "random walk in write mode",
+			algo:  &algorithms.RandomWalk{BaseAlgorithm: algorithms.BaseAlgorithm{Mode: algorithms.Write}},
+			rules: []string{"WN4018"},
+		},
+		{
+			name: "longest path in stream mode",
+			algo: &algorithms.LongestPath{},
+		},
+	}
+
+	pathRules := []string{"WN4014", "WN4015", "WN4016", "WN4017", "WN4018"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintAlgorithm(tt.algo)
+			for _, rule := range pathRules {
+				want := false
+				for _, r := range tt.rules {
+					if r == rule {
+						want = true
+					}
+				}
+				if got := containsRule(results, rule); got != want {
+					t.Errorf("%s reported = %v, want %v (results: %v)", rule, got, want, results)
+				}
+			}
+		})
+	}
+}
+
+func TestLinter_CentralityAndCommunity(t *testing.T) {
+	l := NewLinter()
+
+	tests := []struct {
+		name  string
+		algo  algorithms.Algorithm
+		rules []string
+	}{
+		{
+			name: "valid kmeans",
+			algo: &algorithms.KMeans{NodeProperty: "embedding", K: 5},
+		},
+		{
+			name:  "kmeans without property",
+			algo:  &algorithms.KMeans{K: 5},
+			rules: []string{"WN4020"},
+		},
+		{
+			name:  "kmeans centroids do not match k",
+			algo:  &algorithms.KMeans{NodeProperty: "embedding", K: 3, SeedCentroids: [][]float64{{0, 1}}},
+			rules: []string{"WN4021"},
+		},
+		{
+			name:  "hits negative iterations",
+			algo:  &algorithms.HITS{HitsIterations: -1},
+			rules: []string{"WN4002"},
+		},
+		{
+			name:  "celf probability out of range",
+			algo:  &algorithms.CELF{SeedSetSize: 5, PropagationProbability: 1.5},
+			rules: []string{"WN4021"},
+		},
+		{
+			name:  "celf without seed set size",
+			algo:  &algorithms.CELF{},
+			rules: []string{"WN4020"},
+		},
+		{
+			name:  "slpa association strength",
+			algo:  &algorithms.SLPA{MinAssociationStrength: 2},
+			rules: []string{"WN4021"},
+		},
+		{
+			name:  "conductance without community property",
+			algo:  &algorithms.Conductance{},
+			rules: []string{"WN4020"},
+		},
+		{
+			name:  "modularity in write mode",
+			algo:  &algorithms.Modularity{BaseAlgorithm: algorithms.BaseAlgorithm{Mode: algorithms.Write}, CommunityProperty: "community"},
+			rules: []string{"WN4018"},
+		},
+		{
+			name: "modularity in stats mode",
+			algo: &algorithms.Modularity{BaseAlgorithm: algorithms.BaseAlgorithm{Mode: algorithms.Stats}, CommunityProperty: "community"},
+		},
+	}
+
+	checked := []string{"WN4002", "WN4018", "WN4020", "WN4021"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintAlgorithm(tt.algo)
+			for _, rule := range checked {
+				want := false
+				for _, r := range tt.rules {
+					if r == rule {
+						want = true
+					}
+				}
+				if got := containsRule(results, rule); got != want {
+					t.Errorf("%s reported = %v, want %v (results: %v)", rule, got, want, results)
+				}
+			}
+		})
+	}
+}
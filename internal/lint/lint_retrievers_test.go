@@ -0,0 +1,145 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/kg"
+	"github.com/lex00/wetwire-neo4j-go/internal/retrievers"
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+func TestLinter_Retrievers(t *testing.T) {
+	chunk := &schema.NodeType{
+		Label: "Chunk",
+		Properties: []schema.Property{
+			{Name: "lang", Type: schema.STRING},
+			{Name: "year", Type: schema.INTEGER},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		retriever retrievers.Retriever
+		wantRule  string
+	}{
+		{
+			name: "valid filters",
+			retriever: &retrievers.VectorRetriever{
+				NodeLabel: "Chunk",
+				Filters:   &retrievers.Filter{Property: "year", Gte: 2020},
+				TopK:      10,
+				Reranker:  &retrievers.RerankerConfig{TopN: 3},
+			},
+		},
+		{
+			name: "unknown property",
+			retriever: &retrievers.VectorRetriever{
+				NodeLabel: "Chunk",
+				Filters:   &retrievers.Filter{Property: "author", Eq: "Ada"},
+			},
+			wantRule: "WN4044",
+		},
+		{
+			name: "type mismatch",
+			retriever: &retrievers.HybridRetriever{
+				NodeLabel: "Chunk",
+				Filters:   &retrievers.Filter{Property: "lang", Gt: 3},
+			},
+			wantRule: "WN4044",
+		},
+		{
+			name: "reranker keeps more than retrieved",
+			retriever: &retrievers.HybridCypherRetriever{
+				TopK:     5,
+				Reranker: &retrievers.RerankerConfig{TopN: 10},
+			},
+			wantRule: "WN4045",
+		},
+	}
+
+	l := NewLinter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintAll([]any{chunk, tt.retriever})
+			if tt.wantRule == "" {
+				if len(results) > 0 {
+					t.Errorf("expected no results, got %v", results)
+				}
+				return
+			}
+			if !containsRule(results, tt.wantRule) {
+				t.Errorf("expected %s, got %v", tt.wantRule, results)
+			}
+		})
+	}
+}
+
+func TestLinter_Retrievers_LexicalGraph(t *testing.T) {
+	pipeline := &kg.SimpleKGPipeline{
+		BasePipeline: kg.BasePipeline{
+			EmbedderConfig: &kg.EmbedderConfig{Model: "text-embedding-3-small", Dimensions: 1536},
+			LexicalGraph:   &kg.LexicalGraphConfig{ChunkTextProperty: "content"},
+		},
+		EntityTypes: []kg.EntityType{{Name: "Person"}},
+	}
+
+	l := NewLinter()
+	valid := &retrievers.VectorRetriever{NodeLabel: "Chunk", Filters: &retrievers.Filter{Property: "content", Eq: "x"}}
+	if results := l.LintAll([]any{pipeline, valid}); len(results) > 0 {
+		t.Errorf("expected no results, got %v", results)
+	}
+	invalid := &retrievers.VectorRetriever{NodeLabel: "Chunk", Filters: &retrievers.Filter{Property: "text", Eq: "x"}}
+	if results := l.LintAll([]any{pipeline, invalid}); !containsRule(results, "WN4044") {
+		t.Errorf("expected WN4044, got %v", results)
+	}
+}
+
+func TestLinter_Text2Cypher(t *testing.T) {
+	person := &schema.NodeType{Label: "Person", Properties: []schema.Property{{Name: "name", Type: schema.STRING}}}
+	movie := &schema.NodeType{Label: "Movie", Properties: []schema.Property{{Name: "title", Type: schema.STRING}}}
+	actedIn := &schema.RelationshipType{Label: "ACTED_IN", Source: "Person", Target: "Movie"}
+
+	tests := []struct {
+		name      string
+		retriever *retrievers.Text2CypherRetriever
+		wantRule  string
+	}{
+		{
+			name: "valid examples",
+			retriever: &retrievers.Text2CypherRetriever{Examples: []retrievers.CypherExample{
+				{Question: "Who acted in Heat?", Cypher: "MATCH (p:Person)-[:ACTED_IN]->(m:Movie {title: 'Heat'}) RETURN p.name"},
+			}},
+		},
+		{
+			name: "unknown relationship",
+			retriever: &retrievers.Text2CypherRetriever{Examples: []retrievers.CypherExample{
+				{Question: "Who directed Heat?", Cypher: "MATCH (p:Person)-[:DIRECTED]->(m:Movie) RETURN p.name"},
+			}},
+			wantRule: "WN4046",
+		},
+		{
+			name: "pruned schema prompt",
+			retriever: &retrievers.Text2CypherRetriever{
+				Schema:            &schema.Schema{Nodes: []*schema.NodeType{person, movie}, Relationships: []*schema.RelationshipType{actedIn}},
+				SchemaTokenBudget: 10,
+			},
+			wantRule: "WN4047",
+		},
+	}
+
+	l := NewLinter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintAll([]any{person, movie, actedIn, tt.retriever})
+			if tt.wantRule == "" {
+				if len(results) > 0 {
+					t.Errorf("expected no results, got %v", results)
+				}
+				return
+			}
+			if !containsRule(results, tt.wantRule) {
+				t.Errorf("expected %s, got %v", tt.wantRule, results)
+			}
+		})
+	}
+}
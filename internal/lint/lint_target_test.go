@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+// WN4080: Feature unavailable in the target, WN4081: deprecated in the target
+func TestLinter_WN4080_WN4081_Target(t *testing.T) {
+	native := &projections.NativeProjection{BaseProjection: projections.BaseProjection{Name: "base", GraphName: "g"}}
+
+	tests := []struct {
+		name     string
+		target   target.Target
+		resource any
+		rule     string
+		message  string
+	}{
+		{"procedure not yet provided", target.Target{GDS: target.V(2, 2)}, &algorithms.HashGNN{}, "WN4080", "gds.hashgnn requires GDS 2.3, but the target is GDS 2.2"},
+		{"procedure provided", target.Target{GDS: target.V(2, 3)}, &algorithms.HashGNN{}, "", ""},
+		{"procedure without GDS target", target.Target{Neo4j: target.V(4, 4)}, &algorithms.HashGNN{}, "", ""},
+		{"relationship key", target.Target{Neo4j: target.V(5, 6)}, &schema.RelationshipType{
+			Label: "ACTED_IN", Source: "Person", Target: "Movie",
+			Constraints: []schema.Constraint{{Name: "acted_in_key", Type: schema.REL_KEY, Properties: []string{"role"}}},
+		}, "WN4080", "REL_KEY constraints require Neo4j 5.7, but the target is Neo4j 5.6"},
+		{"vector index", target.Target{Neo4j: target.V(4, 4)}, &schema.NodeType{
+			Label:   "Chunk",
+			Indexes: []schema.Index{{Name: "chunk_embedding", Type: schema.VECTOR, Properties: []string{"embedding"}}},
+		}, "WN4080", "VECTOR indexes require Neo4j 5.13, but the target is Neo4j 4.4"},
+		{"point index", target.Target{Neo4j: target.V(4, 4)}, &schema.NodeType{
+			Label:   "Place",
+			Indexes: []schema.Index{{Name: "place_location", Type: schema.POINT_INDEX, Properties: []string{"location"}}},
+		}, "", ""},
+		{"cypher projection", target.Target{GDS: target.V(2, 4)}, &projections.CypherProjection{
+			BaseProjection: projections.BaseProjection{Name: "people", GraphName: "g"},
+		}, "WN4081", "gds.graph.project.cypher is deprecated in GDS 2.4"},
+		{"cypher projection before deprecation", target.Target{GDS: target.V(2, 3)}, &projections.CypherProjection{}, "", ""},
+		{"sampling", target.Target{GDS: target.V(2, 3)}, &projections.SampledGraph{
+			BaseProjection: projections.BaseProjection{Name: "sample"}, Parent: native, Method: projections.CommonNeighbourAwareRandomWalk,
+		}, "WN4080", "gds.graph.sample.cnarw requires GDS 2.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := NewLinter().WithTarget(tt.target).LintAll([]any{tt.resource})
+			var found *LintResult
+			for i, r := range results {
+				if r.Rule == "WN4080" || r.Rule == "WN4081" {
+					found = &results[i]
+				}
+			}
+			if tt.rule == "" {
+				if found != nil {
+					t.Errorf("unexpected %s: %s", found.Rule, found.Message)
+				}
+				return
+			}
+			if found == nil || found.Rule != tt.rule || !strings.Contains(found.Message, tt.message) {
+				t.Errorf("expected %s containing %q, got %+v", tt.rule, tt.message, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/estimation"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+	"github.com/lex00/wetwire-neo4j-go/internal/workflows"
+)
+
+func newCustomerWorkflow(steps ...workflows.Step) *workflows.Workflow {
+	return &workflows.Workflow{
+		Name: "segments",
+		Projection: &projections.NativeProjection{
+			BaseProjection: projections.BaseProjection{Name: "customers"},
+			NodeProjections: []projections.NodeProjection{
+				{Label: "Customer", Properties: []string{"age"}},
+			},
+			RelationshipTypes: []string{"BOUGHT"},
+		},
+		Steps: steps,
+	}
+}
+
+// WN4070: step inputs must be projected or mutated by an earlier step
+func TestLinter_WN4070_MissingInput(t *testing.T) {
+	l := NewLinter()
+
+	knn := workflows.Step{Algorithm: &algorithms.KNN{
+		BaseAlgorithm:  algorithms.BaseAlgorithm{Name: "similar", GraphName: "customers", Mode: algorithms.Stream},
+		NodeProperties: []string{"embedding"},
+	}}
+	fastRP := workflows.Step{Algorithm: &algorithms.FastRP{
+		BaseAlgorithm:      algorithms.BaseAlgorithm{Name: "embeddings", GraphName: "customers", Mode: algorithms.Mutate},
+		EmbeddingDimension: 64,
+		MutateProperty:     "embedding",
+	}}
+
+	tests := []struct {
+		name        string
+		workflow    *workflows.Workflow
+		expectError bool
+	}{
+		{"input produced by earlier step", newCustomerWorkflow(fastRP, knn), false},
+		{"input never produced", newCustomerWorkflow(knn), true},
+		{"input produced too late", newCustomerWorkflow(knn, fastRP), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintWorkflow(tt.workflow)
+			hasError := containsRule(results, "WN4070")
+			if hasError != tt.expectError {
+				t.Errorf("WN4070 error = %v, want %v", hasError, tt.expectError)
+			}
+		})
+	}
+}
+
+// WN4071: workflow structure must be valid
+func TestLinter_WN4071_InvalidWorkflow(t *testing.T) {
+	l := NewLinter()
+
+	pageRank := workflows.Step{Algorithm: &algorithms.PageRank{
+		BaseAlgorithm: algorithms.BaseAlgorithm{Name: "pr", GraphName: "other", Mode: algorithms.Stream},
+	}}
+
+	tests := []struct {
+		name        string
+		workflow    *workflows.Workflow
+		expectError bool
+	}{
+		{"no steps", newCustomerWorkflow(), true},
+		{"step on another graph", newCustomerWorkflow(pageRank), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintWorkflow(tt.workflow)
+			hasError := containsRule(results, "WN4071")
+			if hasError != tt.expectError {
+				t.Errorf("WN4071 error = %v, want %v", hasError, tt.expectError)
+			}
+		})
+	}
+}
+
+// WN4072: mutated outputs should be read by a later step
+func TestLinter_WN4072_UnusedOutput(t *testing.T) {
+	l := NewLinter()
+
+	wcc := workflows.Step{Algorithm: &algorithms.WCC{
+		BaseAlgorithm:  algorithms.BaseAlgorithm{Name: "components", GraphName: "customers", Mode: algorithms.Mutate},
+		MutateProperty: "component",
+	}}
+	write := workflows.Step{Operation: &projections.NodePropertiesWrite{
+		BaseOperation:  projections.BaseOperation{Name: "write_components", Graph: newCustomerWorkflow().Projection},
+		NodeProperties: []string{"component"},
+	}}
+
+	tests := []struct {
+		name          string
+		workflow      *workflows.Workflow
+		expectWarning bool
+	}{
+		{"output written back", newCustomerWorkflow(wcc, write), false},
+		{"output discarded", newCustomerWorkflow(wcc), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := l.LintWorkflow(tt.workflow)
+			hasWarning := containsRule(results, "WN4072")
+			if hasWarning != tt.expectWarning {
+				t.Errorf("WN4072 warning = %v, want %v", hasWarning, tt.expectWarning)
+			}
+		})
+	}
+}
+
+// WN4073: estimated peak heap must fit the memory budget
+func TestLinter_WN4073_MemoryBudget(t *testing.T) {
+	size := estimation.GraphSize{
+		NodeCounts:         map[string]int64{"Customer": 1000000},
+		RelationshipCounts: map[string]int64{"BOUGHT": 10000000},
+	}
+	workflow := newCustomerWorkflow(workflows.Step{Algorithm: &algorithms.PageRank{
+		BaseAlgorithm: algorithms.BaseAlgorithm{Name: "pr", GraphName: "customers", Mode: algorithms.Write},
+		WriteProperty: "pagerank",
+	}})
+
+	tests := []struct {
+		name        string
+		linter      *Linter
+		expectError bool
+	}{
+		{"no budget configured", NewLinter(), false},
+		{"within budget", NewLinter().WithMemoryBudget(8<<30, size), false},
+		{"over budget", NewLinter().WithMemoryBudget(64<<20, size), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := tt.linter.LintWorkflow(workflow)
+			hasError := containsRule(results, "WN4073")
+			if hasError != tt.expectError {
+				t.Errorf("WN4073 error = %v, want %v (results: %v)", hasError, tt.expectError, results)
+			}
+		})
+	}
+}
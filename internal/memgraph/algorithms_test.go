@@ -0,0 +1,340 @@
+package memgraph
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+func scores(rows []ScoreRow) map[string]float64 {
+	m := make(map[string]float64, len(rows))
+	for _, r := range rows {
+		m[r.NodeID] = r.Score
+	}
+	return m
+}
+
+// communities renders rows as "node=community" pairs in node order.
+func communities(rows []CommunityRow) string {
+	parts := make([]string, len(rows))
+	for i, r := range rows {
+		parts[i] = fmt.Sprintf("%s=%d", r.NodeID, r.CommunityID)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestGraph_PageRank(t *testing.T) {
+	g := projectKnows(t, projections.Natural, projections.None)
+
+	one, err := g.PageRank(&algorithms.PageRank{MaxIterations: 1})
+	if err != nil {
+		t.Fatalf("PageRank failed: %v", err)
+	}
+	// erin's only incoming relationship is from dave, who has one outgoing.
+	if got := scores(one)["erin"]; math.Abs(got-(0.15+0.85*0.15)) > 1e-9 {
+		t.Errorf("erin after one iteration = %v", got)
+	}
+
+	rows, err := g.PageRank(&algorithms.PageRank{DampingFactor: 0.85})
+	if err != nil {
+		t.Fatalf("PageRank failed: %v", err)
+	}
+	s := scores(rows)
+	for id, score := range s {
+		if id != "dave" && score >= s["dave"] {
+			t.Errorf("expected dave to rank first, %s has %v >= %v", id, score, s["dave"])
+		}
+	}
+
+	weighted, err := g.PageRank(&algorithms.PageRank{RelationshipWeightProperty: "weight"})
+	if err != nil {
+		t.Fatalf("weighted PageRank failed: %v", err)
+	}
+	if w := scores(weighted); w["dave"] <= s["dave"] || w["alice"] >= s["alice"] {
+		t.Errorf("expected carol's heavy relationship to favor dave: weighted %v, unweighted %v", w, s)
+	}
+
+	if _, err := g.PageRank(&algorithms.PageRank{RelationshipWeightProperty: "cost"}); err == nil || !strings.Contains(err.Error(), "not projected") {
+		t.Errorf("expected unprojected weight error, got %v", err)
+	}
+	if _, err := g.PageRank(&algorithms.PageRank{DampingFactor: 1}); err == nil {
+		t.Error("expected damping factor error")
+	}
+}
+
+func TestGraph_Degree(t *testing.T) {
+	g := projectKnows(t, projections.Natural, projections.None)
+
+	tests := []struct {
+		cfg      *algorithms.Degree
+		expected map[string]float64
+	}{
+		{&algorithms.Degree{}, map[string]float64{"alice": 2, "bob": 1, "carol": 2, "dave": 1, "erin": 1, "frank": 1}},
+		{&algorithms.Degree{Orientation: "REVERSE"}, map[string]float64{"alice": 1, "bob": 2, "carol": 1, "dave": 2, "erin": 1, "frank": 1}},
+		{&algorithms.Degree{Orientation: "UNDIRECTED"}, map[string]float64{"alice": 3, "bob": 3, "carol": 3, "dave": 3, "erin": 2, "frank": 2}},
+		{&algorithms.Degree{RelationshipWeightProperty: "weight"}, map[string]float64{"alice": 3, "bob": 1, "carol": 6, "dave": 1, "erin": 1, "frank": 1}},
+		{&algorithms.Degree{BaseAlgorithm: algorithms.BaseAlgorithm{NodeLabels: []string{"Manager"}}}, map[string]float64{"frank": 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+v", *tt.cfg), func(t *testing.T) {
+			rows, err := g.Degree(tt.cfg)
+			if err != nil {
+				t.Fatalf("Degree failed: %v", err)
+			}
+			got := scores(rows)
+			if len(got) != len(tt.expected) {
+				t.Errorf("expected %d rows, got %v", len(tt.expected), got)
+			}
+			for id, want := range tt.expected {
+				if got[id] != want {
+					t.Errorf("%s = %v, want %v", id, got[id], want)
+				}
+			}
+		})
+	}
+
+	if _, err := g.Degree(&algorithms.Degree{Orientation: "SIDEWAYS"}); err == nil {
+		t.Error("expected orientation error")
+	}
+}
+
+func TestGraph_Communities(t *testing.T) {
+	g := projectKnows(t, projections.Undirected, projections.None)
+
+	// Two triangles with no relationship between them.
+	twoTriangles := &Fixture{}
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		twoTriangles.Nodes = append(twoTriangles.Nodes, FixtureNode{ID: id, Labels: []string{"N"}})
+	}
+	for _, pair := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"d", "e"}, {"e", "f"}, {"f", "d"}} {
+		twoTriangles.Relationships = append(twoTriangles.Relationships, FixtureRelationship{Source: pair[0], Target: pair[1], Type: "R"})
+	}
+	split, err := Project(twoTriangles, &projections.NativeProjection{
+		NodeLabels:              []string{"N"},
+		RelationshipProjections: []projections.RelationshipProjection{{Type: "R", Orientation: projections.Undirected}},
+	})
+	if err != nil {
+		t.Fatalf("Project failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		graph    *Graph
+		run      func(*Graph) ([]CommunityRow, error)
+		expected string
+	}{
+		{"wcc", g, func(g *Graph) ([]CommunityRow, error) { return g.WCC(&algorithms.WCC{}) },
+			"alice=0 bob=0 carol=0 dave=0 erin=0 frank=0"},
+		{"wcc threshold", g, func(g *Graph) ([]CommunityRow, error) {
+			return g.WCC(&algorithms.WCC{RelationshipWeightProperty: "weight", Threshold: 1.5})
+		}, "alice=0 bob=0 carol=2 dave=2 erin=4 frank=5"},
+		{"wcc seeded", g, func(g *Graph) ([]CommunityRow, error) {
+			return g.WCC(&algorithms.WCC{SeedProperty: "team", RelationshipWeightProperty: "weight", Threshold: 1.5})
+		}, "alice=1 bob=1 carol=2 dave=2 erin=4 frank=5"},
+		{"label propagation", split, func(g *Graph) ([]CommunityRow, error) {
+			return g.LabelPropagation(&algorithms.LabelPropagation{})
+		}, "a=1 b=1 c=1 d=4 e=4 f=4"},
+		{"louvain", g, func(g *Graph) ([]CommunityRow, error) { return g.Louvain(&algorithms.Louvain{}) },
+			"alice=0 bob=0 carol=0 dave=3 erin=3 frank=3"},
+		{"louvain weighted", g, func(g *Graph) ([]CommunityRow, error) {
+			return g.Louvain(&algorithms.Louvain{RelationshipWeightProperty: "weight"})
+		}, "alice=0 bob=0 carol=2 dave=2 erin=4 frank=4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := tt.run(tt.graph)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			if got := communities(rows); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	rows, err := g.Louvain(&algorithms.Louvain{IncludeIntermediateCommunities: true})
+	if err != nil {
+		t.Fatalf("Louvain failed: %v", err)
+	}
+	for _, r := range rows {
+		levels := r.IntermediateCommunityIDs
+		if len(levels) == 0 || levels[len(levels)-1] != r.CommunityID {
+			t.Errorf("%s: intermediate communities %v should end with %d", r.NodeID, levels, r.CommunityID)
+		}
+	}
+
+	if _, err := g.WCC(&algorithms.WCC{SeedProperty: "rank"}); err == nil || !strings.Contains(err.Error(), "seed property rank") {
+		t.Errorf("expected seed property error, got %v", err)
+	}
+}
+
+func TestGraph_TriangleCount(t *testing.T) {
+	g := projectKnows(t, projections.Undirected, projections.None)
+
+	rows, err := g.TriangleCount(&algorithms.TriangleCount{})
+	if err != nil {
+		t.Fatalf("TriangleCount failed: %v", err)
+	}
+	for _, r := range rows {
+		if r.TriangleCount != 1 {
+			t.Errorf("%s: %d triangles, want 1", r.NodeID, r.TriangleCount)
+		}
+	}
+
+	rows, err = g.TriangleCount(&algorithms.TriangleCount{MaxDegree: 2})
+	if err != nil {
+		t.Fatalf("TriangleCount failed: %v", err)
+	}
+	want := map[string]int64{"alice": 0, "bob": 0, "carol": -1, "dave": -1, "erin": 0, "frank": 0}
+	for _, r := range rows {
+		if r.TriangleCount != want[r.NodeID] {
+			t.Errorf("%s: %d triangles, want %d", r.NodeID, r.TriangleCount, want[r.NodeID])
+		}
+	}
+
+	directed := projectKnows(t, projections.Natural, projections.None)
+	if _, err := directed.TriangleCount(&algorithms.TriangleCount{}); err == nil || !strings.Contains(err.Error(), "UNDIRECTED") {
+		t.Errorf("expected orientation error, got %v", err)
+	}
+}
+
+func TestGraph_Dijkstra(t *testing.T) {
+	g := projectKnows(t, projections.Natural, projections.None)
+
+	rows, err := g.Dijkstra(&algorithms.Dijkstra{SourceNode: "alice", TargetNode: "frank", RelationshipWeightProperty: "weight"})
+	if err != nil {
+		t.Fatalf("Dijkstra failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected one path, got %+v", rows)
+	}
+	if got := strings.Join(rows[0].NodeIDs, ","); got != "alice,bob,carol,dave,erin,frank" {
+		t.Errorf("path = %s", got)
+	}
+	if got := fmt.Sprint(rows[0].Costs); rows[0].TotalCost != 9 || got != "[0 1 2 7 8 9]" {
+		t.Errorf("total cost %v, costs %s", rows[0].TotalCost, got)
+	}
+
+	all, err := g.Dijkstra(&algorithms.Dijkstra{SourceNode: "dave"})
+	if err != nil {
+		t.Fatalf("Dijkstra failed: %v", err)
+	}
+	var targets []string
+	for _, r := range all {
+		targets = append(targets, fmt.Sprintf("%s:%g", r.TargetNode, r.TotalCost))
+	}
+	if got := strings.Join(targets, " "); got != "dave:0 erin:1 frank:2" {
+		t.Errorf("all paths from dave = %s", got)
+	}
+
+	if rows, err := g.Dijkstra(&algorithms.Dijkstra{SourceNode: "frank", TargetNode: "alice"}); err != nil || len(rows) != 0 {
+		t.Errorf("expected no path from frank to alice, got %+v, %v", rows, err)
+	}
+	if _, err := g.Dijkstra(&algorithms.Dijkstra{SourceNode: "zoe"}); err == nil || !strings.Contains(err.Error(), "sourceNode zoe") {
+		t.Errorf("expected unknown source error, got %v", err)
+	}
+}
+
+func TestGraph_Traversal(t *testing.T) {
+	g := projectKnows(t, projections.Undirected, projections.None)
+
+	tests := []struct {
+		name     string
+		run      func() ([]TraversalRow, error)
+		expected string
+	}{
+		{"bfs", func() ([]TraversalRow, error) { return g.BFS(&algorithms.BFS{SourceNode: "dave"}) }, "dave,carol,erin,frank,bob,alice"},
+		{"dfs", func() ([]TraversalRow, error) { return g.DFS(&algorithms.DFS{SourceNode: "dave"}) }, "dave,carol,bob,alice,erin,frank"},
+		{"bfs max depth", func() ([]TraversalRow, error) { return g.BFS(&algorithms.BFS{SourceNode: "dave", MaxDepth: 1}) }, "dave,carol,erin,frank"},
+		{"bfs target", func() ([]TraversalRow, error) {
+			return g.BFS(&algorithms.BFS{SourceNode: "dave", TargetNodes: []any{"erin", "alice"}})
+		}, "dave,carol,erin"},
+		{"dfs target", func() ([]TraversalRow, error) {
+			return g.DFS(&algorithms.DFS{SourceNode: "dave", TargetNodes: []any{"alice"}})
+		}, "dave,carol,bob,alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := tt.run()
+			if err != nil {
+				t.Fatalf("traversal failed: %v", err)
+			}
+			if len(rows) != 1 || rows[0].SourceNode != "dave" {
+				t.Fatalf("unexpected rows: %+v", rows)
+			}
+			if got := strings.Join(rows[0].NodeIDs, ","); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGraph_NodeSimilarity(t *testing.T) {
+	g, err := Project(loadSocial(t), &projections.NativeProjection{
+		NodeLabels:        []string{"Person", "Topic"},
+		RelationshipTypes: []string{"LIKES"},
+	})
+	if err != nil {
+		t.Fatalf("Project failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *algorithms.NodeSimilarity
+		expected string
+	}{
+		{"jaccard", &algorithms.NodeSimilarity{}, "alice-bob:0.67 bob-alice:0.67 bob-carol:0.33 carol-bob:0.33"},
+		{"top k", &algorithms.NodeSimilarity{TopK: 1}, "alice-bob:0.67 bob-alice:0.67 carol-bob:0.33"},
+		{"top n", &algorithms.NodeSimilarity{TopN: 1}, "alice-bob:0.67"},
+		{"cutoff", &algorithms.NodeSimilarity{SimilarityCutoff: 0.5}, "alice-bob:0.67 bob-alice:0.67"},
+		{"degree cutoff", &algorithms.NodeSimilarity{DegreeCutoff: 2}, "alice-bob:0.67 bob-alice:0.67"},
+		{"overlap", &algorithms.NodeSimilarity{SimilarityMetric: "OVERLAP"}, "alice-bob:1.00 bob-alice:1.00 bob-carol:1.00 carol-bob:1.00"},
+		{"cosine", &algorithms.NodeSimilarity{SimilarityMetric: "COSINE", TopN: 2}, "alice-bob:0.82 bob-alice:0.82"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := g.NodeSimilarity(tt.cfg)
+			if err != nil {
+				t.Fatalf("NodeSimilarity failed: %v", err)
+			}
+			var pairs []string
+			for _, r := range rows {
+				pairs = append(pairs, fmt.Sprintf("%s-%s:%.2f", r.Node1, r.Node2, r.Similarity))
+			}
+			if got := strings.Join(pairs, " "); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := g.NodeSimilarity(&algorithms.NodeSimilarity{SimilarityMetric: "EUCLIDEAN"}); err == nil {
+		t.Error("expected metric error")
+	}
+}
+
+func TestGraph_Run(t *testing.T) {
+	g := projectKnows(t, projections.Natural, projections.None)
+
+	rows, err := g.Run(&algorithms.PageRank{BaseAlgorithm: algorithms.BaseAlgorithm{Mode: algorithms.Write}, WriteProperty: "rank"})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if scores, ok := rows.([]ScoreRow); !ok || len(scores) != 6 {
+		t.Errorf("expected six score rows, got %T %v", rows, rows)
+	}
+
+	if _, err := g.Run(&algorithms.Betweenness{}); err == nil || !strings.Contains(err.Error(), "gds.betweenness is not supported") {
+		t.Errorf("expected unsupported error, got %v", err)
+	}
+	if _, err := g.Run(&algorithms.WCC{BaseAlgorithm: algorithms.BaseAlgorithm{RelationshipTypes: []string{"LIKES"}}}); err == nil || !strings.Contains(err.Error(), "LIKES is not projected") {
+		t.Errorf("expected unprojected type error, got %v", err)
+	}
+}
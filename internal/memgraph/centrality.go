@@ -0,0 +1,127 @@
+package memgraph
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+)
+
+// ScoreRow is a node score, as streamed by gds.pageRank and gds.degree.
+type ScoreRow struct {
+	NodeID string
+	Score  float64
+}
+
+// PageRank runs gds.pageRank. Scores start at 1 - DampingFactor and each
+// node passes its score to its relationship targets in proportion to their
+// weight. It stops after MaxIterations or once no score changes by more than
+// Tolerance.
+func (g *Graph) PageRank(cfg *algorithms.PageRank) ([]ScoreRow, error) {
+	g, err := g.filter(&cfg.BaseAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := g.weights(cfg.RelationshipWeightProperty)
+	if err != nil {
+		return nil, err
+	}
+	damping := orDefault(cfg.DampingFactor, 0.85)
+	if damping < 0 || damping >= 1 {
+		return nil, fmt.Errorf("dampingFactor must be in [0, 1), got %v", damping)
+	}
+	iterations := orDefaultInt(cfg.MaxIterations, 20)
+	tolerance := orDefault(cfg.Tolerance, 1e-7)
+
+	outWeight := make([]float64, len(g.ids))
+	for n := range g.ids {
+		for _, r := range g.out[n] {
+			outWeight[n] += weight(r)
+		}
+	}
+
+	scores := make([]float64, len(g.ids))
+	for i := range scores {
+		scores[i] = 1 - damping
+	}
+	for it := 0; it < iterations; it++ {
+		next := make([]float64, len(g.ids))
+		converged := true
+		for n := range g.ids {
+			sum := 0.0
+			for _, r := range g.in[n] {
+				source := g.rels[r].source
+				if outWeight[source] > 0 {
+					sum += scores[source] * weight(r) / outWeight[source]
+				}
+			}
+			next[n] = 1 - damping + damping*sum
+			if math.Abs(next[n]-scores[n]) > tolerance {
+				converged = false
+			}
+		}
+		scores = next
+		if converged {
+			break
+		}
+	}
+	return g.scoreRows(scores), nil
+}
+
+// Degree runs gds.degree: the number, or total weight, of each node's
+// outgoing relationships for NATURAL orientation, incoming for REVERSE, and
+// both for UNDIRECTED.
+func (g *Graph) Degree(cfg *algorithms.Degree) ([]ScoreRow, error) {
+	g, err := g.filter(&cfg.BaseAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := g.weights(cfg.RelationshipWeightProperty)
+	if err != nil {
+		return nil, err
+	}
+	orientation := cfg.Orientation
+	if orientation == "" {
+		orientation = "NATURAL"
+	}
+	if orientation != "NATURAL" && orientation != "REVERSE" && orientation != "UNDIRECTED" {
+		return nil, fmt.Errorf("unsupported orientation %q", cfg.Orientation)
+	}
+
+	scores := make([]float64, len(g.ids))
+	for n := range g.ids {
+		if orientation != "REVERSE" {
+			for _, r := range g.out[n] {
+				scores[n] += weight(r)
+			}
+		}
+		if orientation != "NATURAL" {
+			for _, r := range g.in[n] {
+				scores[n] += weight(r)
+			}
+		}
+	}
+	return g.scoreRows(scores), nil
+}
+
+func (g *Graph) scoreRows(scores []float64) []ScoreRow {
+	rows := make([]ScoreRow, len(scores))
+	for i, s := range scores {
+		rows[i] = ScoreRow{NodeID: g.ids[i], Score: s}
+	}
+	return rows
+}
+
+func orDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+func orDefaultInt(v, def int) int {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,390 @@
+package memgraph
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+// CommunityRow is a node's community, as streamed by gds.wcc (componentId),
+// gds.labelPropagation and gds.louvain (communityId).
+type CommunityRow struct {
+	NodeID      string
+	CommunityID int64
+	// IntermediateCommunityIDs are the Louvain communities after each level,
+	// when IncludeIntermediateCommunities is set.
+	IntermediateCommunityIDs []int64
+}
+
+// TriangleCountRow is the number of triangles a node is part of, or -1 for
+// nodes excluded by MaxDegree.
+type TriangleCountRow struct {
+	NodeID        string
+	TriangleCount int64
+}
+
+// WCC runs gds.wcc, ignoring relationship direction. With a
+// RelationshipWeightProperty, only relationships weighing more than
+// Threshold connect nodes. A component's ID is the smallest initial ID of
+// its nodes: the SeedProperty value, or the node's position.
+func (g *Graph) WCC(cfg *algorithms.WCC) ([]CommunityRow, error) {
+	g, err := g.filter(&cfg.BaseAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := g.weights(cfg.RelationshipWeightProperty)
+	if err != nil {
+		return nil, err
+	}
+	seeds, err := g.seeds(cfg.SeedProperty)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := make([]int, len(g.ids))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(n int) int {
+		if parent[n] != n {
+			parent[n] = find(parent[n])
+		}
+		return parent[n]
+	}
+	for i, r := range g.rels {
+		if cfg.RelationshipWeightProperty != "" && !(weight(i) > cfg.Threshold) {
+			continue
+		}
+		a, b := find(r.source), find(r.target)
+		if a == b {
+			continue
+		}
+		// Keep the root with the smaller initial ID.
+		if seeds[b] < seeds[a] {
+			a, b = b, a
+		}
+		parent[b] = a
+	}
+
+	rows := make([]CommunityRow, len(g.ids))
+	for n := range g.ids {
+		rows[n] = CommunityRow{NodeID: g.ids[n], CommunityID: seeds[find(n)]}
+	}
+	return rows, nil
+}
+
+// LabelPropagation runs gds.labelPropagation. Nodes start with their
+// SeedProperty value or position as label and, in order, adopt the label
+// with the largest total weight among the targets of their relationships,
+// keeping their own label on a tie and otherwise preferring the smallest. It
+// stops after MaxIterations or once no label changes.
+func (g *Graph) LabelPropagation(cfg *algorithms.LabelPropagation) ([]CommunityRow, error) {
+	g, err := g.filter(&cfg.BaseAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := g.weights(cfg.RelationshipWeightProperty)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := g.seeds(cfg.SeedProperty)
+	if err != nil {
+		return nil, err
+	}
+
+	iterations := orDefaultInt(cfg.MaxIterations, 10)
+	for it := 0; it < iterations; it++ {
+		changed := false
+		for n := range g.ids {
+			votes := make(map[int64]float64)
+			for _, r := range g.out[n] {
+				votes[labels[g.rels[r].target]] += weight(r)
+			}
+			if len(votes) == 0 {
+				continue
+			}
+			most := 0.0
+			for _, v := range votes {
+				most = math.Max(most, v)
+			}
+			best := labels[n]
+			if votes[best] < most {
+				best = math.MaxInt64
+				for label, v := range votes {
+					if v == most && label < best {
+						best = label
+					}
+				}
+			}
+			if best != labels[n] {
+				labels[n] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	rows := make([]CommunityRow, len(g.ids))
+	for n := range g.ids {
+		rows[n] = CommunityRow{NodeID: g.ids[n], CommunityID: labels[n]}
+	}
+	return rows, nil
+}
+
+// Louvain runs gds.louvain on the graph with relationship direction
+// ignored. Each level moves nodes, in order, to the neighboring community
+// with the largest modularity gain until no node moves or MaxIterations is
+// reached, then merges communities into nodes for the next level. It stops
+// after MaxLevels or when a level improves modularity by less than
+// Tolerance. Community IDs are the smallest initial ID of their nodes.
+func (g *Graph) Louvain(cfg *algorithms.Louvain) ([]CommunityRow, error) {
+	g, err := g.filter(&cfg.BaseAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := g.weights(cfg.RelationshipWeightProperty)
+	if err != nil {
+		return nil, err
+	}
+	seeds, err := g.seeds(cfg.SeedProperty)
+	if err != nil {
+		return nil, err
+	}
+	maxLevels := orDefaultInt(cfg.MaxLevels, 10)
+	maxIterations := orDefaultInt(cfg.MaxIterations, 10)
+	tolerance := orDefault(cfg.Tolerance, 0.0001)
+
+	// adj is the symmetric weighted adjacency of the current level.
+	adj := make([]map[int]float64, len(g.ids))
+	for i := range adj {
+		adj[i] = make(map[int]float64)
+	}
+	for i, r := range g.rels {
+		w := weight(i)
+		adj[r.source][r.target] += w
+		adj[r.target][r.source] += w
+	}
+
+	// community maps each original node to its node at the current level.
+	community := make([]int, len(g.ids))
+	for i := range community {
+		community[i] = i
+	}
+	// Seeded nodes start in one community per seed value.
+	initial := make([]int, len(g.ids))
+	if cfg.SeedProperty != "" {
+		first := make(map[int64]int)
+		for n, s := range seeds {
+			if _, ok := first[s]; !ok {
+				first[s] = n
+			}
+			initial[n] = first[s]
+		}
+	} else {
+		for n := range initial {
+			initial[n] = n
+		}
+	}
+
+	var intermediate [][]int
+	modularity := math.Inf(-1)
+	for level := 0; level < maxLevels; level++ {
+		assignment := localMoves(adj, initial, maxIterations)
+		q := modularityOf(adj, assignment)
+		moved := false
+		for n, c := range assignment {
+			if c != n {
+				moved = true
+				break
+			}
+		}
+		if level > 0 && (!moved || q-modularity < tolerance) {
+			break
+		}
+		modularity = q
+
+		// Renumber communities and merge them into the next level's nodes.
+		ids := make(map[int]int)
+		for _, c := range assignment {
+			if _, ok := ids[c]; !ok {
+				ids[c] = len(ids)
+			}
+		}
+		for n := range community {
+			community[n] = ids[assignment[community[n]]]
+		}
+		next := make([]map[int]float64, len(ids))
+		for i := range next {
+			next[i] = make(map[int]float64)
+		}
+		for a, edges := range adj {
+			for b, w := range edges {
+				next[ids[assignment[a]]][ids[assignment[b]]] += w
+			}
+		}
+		adj = next
+		initial = make([]int, len(adj))
+		for i := range initial {
+			initial[i] = i
+		}
+		intermediate = append(intermediate, append([]int(nil), community...))
+		if !moved {
+			break
+		}
+	}
+
+	label := func(level []int) []int64 {
+		ids := make(map[int]int64)
+		for n, c := range level {
+			if id, ok := ids[c]; !ok || seeds[n] < id {
+				ids[c] = seeds[n]
+			}
+		}
+		result := make([]int64, len(level))
+		for n, c := range level {
+			result[n] = ids[c]
+		}
+		return result
+	}
+	final := label(community)
+	levels := make([][]int64, len(intermediate))
+	for i, level := range intermediate {
+		levels[i] = label(level)
+	}
+
+	rows := make([]CommunityRow, len(g.ids))
+	for n := range g.ids {
+		rows[n] = CommunityRow{NodeID: g.ids[n], CommunityID: final[n]}
+		if cfg.IncludeIntermediateCommunities {
+			for _, level := range levels {
+				rows[n].IntermediateCommunityIDs = append(rows[n].IntermediateCommunityIDs, level[n])
+			}
+		}
+	}
+	return rows, nil
+}
+
+// localMoves runs the Louvain local moving phase and returns each node's
+// community, identified by one of its nodes.
+func localMoves(adj []map[int]float64, initial []int, maxIterations int) []int {
+	assignment := append([]int(nil), initial...)
+	degree := make([]float64, len(adj))
+	total := make(map[int]float64)
+	m2 := 0.0
+	for n, edges := range adj {
+		for _, w := range edges {
+			degree[n] += w
+		}
+		total[assignment[n]] += degree[n]
+		m2 += degree[n]
+	}
+	if m2 == 0 {
+		return assignment
+	}
+
+	for it := 0; it < maxIterations; it++ {
+		moved := false
+		for n := range adj {
+			current := assignment[n]
+			links := make(map[int]float64)
+			for neighbor, w := range adj[n] {
+				if neighbor != n {
+					links[assignment[neighbor]] += w
+				}
+			}
+			total[current] -= degree[n]
+			best := current
+			bestGain := links[current] - total[current]*degree[n]/m2
+			for c, w := range links {
+				gain := w - total[c]*degree[n]/m2
+				if gain > bestGain+1e-12 || (math.Abs(gain-bestGain) <= 1e-12 && c < best && best != current) {
+					best, bestGain = c, gain
+				}
+			}
+			total[best] += degree[n]
+			if best != current {
+				assignment[n] = best
+				moved = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+	return assignment
+}
+
+// modularityOf returns the modularity of a community assignment.
+func modularityOf(adj []map[int]float64, assignment []int) float64 {
+	m2 := 0.0
+	internal := make(map[int]float64)
+	total := make(map[int]float64)
+	for n, edges := range adj {
+		for neighbor, w := range edges {
+			m2 += w
+			total[assignment[n]] += w
+			if assignment[neighbor] == assignment[n] {
+				internal[assignment[n]] += w
+			}
+		}
+	}
+	if m2 == 0 {
+		return 0
+	}
+	q := 0.0
+	for c, t := range total {
+		q += internal[c]/m2 - (t/m2)*(t/m2)
+	}
+	return q
+}
+
+// TriangleCount runs gds.triangleCount, which requires UNDIRECTED
+// relationships. Nodes with more than MaxDegree neighbors are not counted
+// and get -1.
+func (g *Graph) TriangleCount(cfg *algorithms.TriangleCount) ([]TriangleCountRow, error) {
+	g, err := g.filter(&cfg.BaseAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	for typ, o := range g.orientations {
+		if o != projections.Undirected {
+			return nil, fmt.Errorf("triangle count requires UNDIRECTED relationships, but %s is %s", typ, o)
+		}
+	}
+
+	neighbors := make([]map[int]bool, len(g.ids))
+	excluded := make([]bool, len(g.ids))
+	for n := range g.ids {
+		neighbors[n] = make(map[int]bool)
+		for _, nb := range g.neighbors(n) {
+			neighbors[n][nb] = true
+		}
+		excluded[n] = cfg.MaxDegree > 0 && len(neighbors[n]) > cfg.MaxDegree
+	}
+
+	rows := make([]TriangleCountRow, len(g.ids))
+	for n := range g.ids {
+		rows[n] = TriangleCountRow{NodeID: g.ids[n]}
+		if excluded[n] {
+			rows[n].TriangleCount = -1
+			continue
+		}
+		nbs := g.neighbors(n)
+		for i, a := range nbs {
+			if excluded[a] {
+				continue
+			}
+			for _, b := range nbs[i+1:] {
+				if !excluded[b] && neighbors[a][b] {
+					rows[n].TriangleCount++
+				}
+			}
+		}
+	}
+	return rows, nil
+}
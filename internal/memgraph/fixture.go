@@ -0,0 +1,149 @@
+package memgraph
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FixtureNode is a fixture node. ID identifies it in relationships and
+// results.
+type FixtureNode struct {
+	ID         string         `json:"id"`
+	Labels     []string       `json:"labels"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// FixtureRelationship is a fixture relationship between two node IDs.
+type FixtureRelationship struct {
+	Source     string         `json:"source"`
+	Target     string         `json:"target"`
+	Type       string         `json:"type"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Fixture is the stored graph a projection is loaded from, standing in for
+// a Neo4j database.
+type Fixture struct {
+	Nodes         []FixtureNode         `json:"nodes"`
+	Relationships []FixtureRelationship `json:"relationships"`
+}
+
+// LoadFixture reads a fixture from a JSON file, or from a directory holding
+// nodes.csv and relationships.csv.
+//
+// nodes.csv has an id column, a labels column with labels separated by ";",
+// and one column per property. relationships.csv has source, target and type
+// columns and one column per property. Numeric CSV values are loaded as
+// numbers and empty values are left unset.
+func LoadFixture(path string) (*Fixture, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	if info.IsDir() {
+		return loadCSVFixture(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+func loadCSVFixture(dir string) (*Fixture, error) {
+	f := &Fixture{}
+	err := readCSV(filepath.Join(dir, "nodes.csv"), []string{"id", "labels"}, func(fixed []string, props map[string]any) {
+		var labels []string
+		for _, l := range strings.Split(fixed[1], ";") {
+			if l = strings.TrimSpace(l); l != "" {
+				labels = append(labels, l)
+			}
+		}
+		f.Nodes = append(f.Nodes, FixtureNode{ID: fixed[0], Labels: labels, Properties: props})
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = readCSV(filepath.Join(dir, "relationships.csv"), []string{"source", "target", "type"}, func(fixed []string, props map[string]any) {
+		f.Relationships = append(f.Relationships, FixtureRelationship{Source: fixed[0], Target: fixed[1], Type: fixed[2], Properties: props})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// readCSV calls row for each record of a CSV file with the values of the
+// required columns and the other columns as properties.
+func readCSV(path string, required []string, row func(fixed []string, props map[string]any)) error {
+	fh, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read fixture: %w", err)
+	}
+	defer fh.Close()
+
+	r := csv.NewReader(fh)
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read header of %s: %w", path, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+	fixedIndex := make([]int, len(required))
+	for i, c := range required {
+		n, ok := index[c]
+		if !ok {
+			return fmt.Errorf("%s: missing column %s", path, c)
+		}
+		fixedIndex[i] = n
+	}
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fixed := make([]string, len(required))
+		for i, n := range fixedIndex {
+			fixed[i] = strings.TrimSpace(record[n])
+		}
+		props := make(map[string]any)
+		for name, n := range index {
+			v := strings.TrimSpace(record[n])
+			if isFixed(name, required) || v == "" {
+				continue
+			}
+			if num, err := strconv.ParseFloat(v, 64); err == nil {
+				props[name] = num
+			} else {
+				props[name] = v
+			}
+		}
+		row(fixed, props)
+	}
+}
+
+func isFixed(name string, required []string) bool {
+	for _, c := range required {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,415 @@
+// Package memgraph is a small in-memory graph engine for testing algorithm
+// configurations without GDS.
+//
+// A Graph is projected from a Fixture the way GDS projects a native
+// projection from a database: node labels, relationship types, orientation,
+// aggregation and properties are honored. A core subset of the algorithms
+// package runs on it with the same configuration semantics, returning typed
+// rows:
+//
+//	fixture, _ := memgraph.LoadFixture("testdata/social.json")
+//	g, _ := memgraph.Project(fixture, &projections.NativeProjection{
+//		NodeLabels:        []string{"Person"},
+//		RelationshipTypes: []string{"KNOWS"},
+//	})
+//	rows, _ := g.PageRank(&algorithms.PageRank{DampingFactor: 0.85})
+//
+// Results are deterministic: where GDS breaks ties arbitrarily, the engine
+// prefers the node that comes first in the fixture.
+package memgraph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+// relationship is a projected relationship between node indexes.
+type relationship struct {
+	source, target int
+	typ            string
+	props          map[string]float64
+}
+
+// Graph is a projected in-memory graph. Nodes keep their fixture order.
+type Graph struct {
+	ids    []string
+	index  map[string]int
+	labels [][]string
+	props  []map[string]float64
+
+	rels []relationship
+	// out and in hold the relationship indexes of each node.
+	out, in [][]int
+
+	// orientations are the orientations of the projected relationship types.
+	orientations map[string]projections.Orientation
+	nodeProps    map[string]bool
+	relProps     map[string]bool
+}
+
+// Project loads the part of fixture selected by p into a Graph. Nodes are
+// projected when they have one of the projected labels, relationships when
+// they have a projected type and both endpoints are projected; "*" projects
+// every label or type. Missing properties take the projection's
+// DefaultValue, or NaN.
+func Project(fixture *Fixture, p *projections.NativeProjection) (*Graph, error) {
+	g := &Graph{
+		index:        make(map[string]int),
+		orientations: make(map[string]projections.Orientation),
+		nodeProps:    make(map[string]bool),
+		relProps:     make(map[string]bool),
+	}
+
+	nodeProjections := p.GetNodeProjections()
+	for _, n := range fixture.Nodes {
+		var matched []projections.NodeProjection
+		for _, np := range nodeProjections {
+			if np.Label == "*" || hasLabel(n.Labels, np.Label) {
+				matched = append(matched, np)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		if _, dup := g.index[n.ID]; dup {
+			return nil, fmt.Errorf("duplicate node id %q", n.ID)
+		}
+
+		props := make(map[string]float64)
+		for _, np := range matched {
+			for _, key := range np.Properties {
+				v, err := propertyValue(n.Properties, key, np.DefaultValue)
+				if err != nil {
+					return nil, fmt.Errorf("node %s: %w", n.ID, err)
+				}
+				props[key] = v
+				g.nodeProps[key] = true
+			}
+		}
+		g.index[n.ID] = len(g.ids)
+		g.ids = append(g.ids, n.ID)
+		g.labels = append(g.labels, n.Labels)
+		g.props = append(g.props, props)
+	}
+	g.out = make([][]int, len(g.ids))
+	g.in = make([][]int, len(g.ids))
+
+	for _, rp := range p.GetRelationshipProjections() {
+		orientation := rp.Orientation
+		if orientation == "" {
+			orientation = projections.Natural
+		}
+		if err := g.projectRelationships(fixture, rp, orientation); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// projectRelationships adds the fixture relationships matching rp,
+// aggregating parallel relationships and adding both directions of
+// undirected ones.
+func (g *Graph) projectRelationships(fixture *Fixture, rp projections.RelationshipProjection, orientation projections.Orientation) error {
+	aggregation := rp.Aggregation
+	if aggregation == "" {
+		aggregation = projections.None
+	}
+	switch orientation {
+	case projections.Natural, projections.Reverse, projections.Undirected:
+	default:
+		return fmt.Errorf("relationship type %s: unsupported orientation %q", rp.Type, orientation)
+	}
+	if rp.Type != "*" {
+		g.orientations[rp.Type] = orientation
+	}
+
+	var rels []relationship
+	// byPair indexes aggregated relationships by endpoints and type.
+	byPair := make(map[[2]int]map[string]int)
+	// counts are the number of relationships aggregated into each one.
+	var counts []int
+	for _, r := range fixture.Relationships {
+		if rp.Type != "*" && r.Type != rp.Type {
+			continue
+		}
+		source, ok1 := g.index[r.Source]
+		target, ok2 := g.index[r.Target]
+		if !ok1 || !ok2 {
+			continue
+		}
+		if orientation == projections.Reverse {
+			source, target = target, source
+		}
+		g.orientations[r.Type] = orientation
+
+		props := make(map[string]float64, len(rp.Properties))
+		for _, key := range rp.Properties {
+			v, err := propertyValue(r.Properties, key, rp.DefaultValue)
+			if err != nil {
+				return fmt.Errorf("relationship %s-[%s]->%s: %w", r.Source, r.Type, r.Target, err)
+			}
+			props[key] = v
+			g.relProps[key] = true
+		}
+
+		if aggregation == projections.None {
+			rels = append(rels, relationship{source: source, target: target, typ: r.Type, props: props})
+			counts = append(counts, 1)
+			continue
+		}
+		pair := [2]int{source, target}
+		if orientation == projections.Undirected && target < source {
+			pair = [2]int{target, source}
+		}
+		if byPair[pair] == nil {
+			byPair[pair] = make(map[string]int)
+		}
+		i, seen := byPair[pair][r.Type]
+		if !seen {
+			if aggregation == projections.Count {
+				for key := range props {
+					props[key] = 1
+				}
+			}
+			byPair[pair][r.Type] = len(rels)
+			rels = append(rels, relationship{source: pair[0], target: pair[1], typ: r.Type, props: props})
+			counts = append(counts, 1)
+			continue
+		}
+		counts[i]++
+		for key, v := range props {
+			rels[i].props[key] = aggregate(aggregation, rels[i].props[key], v, counts[i])
+		}
+	}
+
+	for _, r := range rels {
+		g.addRelationship(r)
+		if orientation == projections.Undirected && r.source != r.target {
+			g.addRelationship(relationship{source: r.target, target: r.source, typ: r.typ, props: r.props})
+		}
+	}
+	return nil
+}
+
+// aggregate combines the property values of parallel relationships. COUNT
+// replaces the value with the number of relationships.
+func aggregate(aggregation projections.Aggregation, current, next float64, count int) float64 {
+	switch aggregation {
+	case projections.Sum:
+		return current + next
+	case projections.Min:
+		return math.Min(current, next)
+	case projections.Max:
+		return math.Max(current, next)
+	case projections.Count:
+		return float64(count)
+	}
+	// SINGLE keeps the first relationship.
+	return current
+}
+
+func (g *Graph) addRelationship(r relationship) {
+	g.out[r.source] = append(g.out[r.source], len(g.rels))
+	g.in[r.target] = append(g.in[r.target], len(g.rels))
+	g.rels = append(g.rels, r)
+}
+
+// NodeCount returns the number of projected nodes.
+func (g *Graph) NodeCount() int {
+	return len(g.ids)
+}
+
+// RelationshipCount returns the number of projected relationships. An
+// undirected relationship counts twice, as in GDS.
+func (g *Graph) RelationshipCount() int {
+	return len(g.rels)
+}
+
+// NodeProperty returns a projected property of a node.
+func (g *Graph) NodeProperty(id, key string) (float64, bool) {
+	i, ok := g.index[id]
+	if !ok {
+		return 0, false
+	}
+	v, ok := g.props[i][key]
+	return v, ok
+}
+
+// filter returns the subgraph an algorithm runs on: the nodes with one of
+// its NodeLabels and the relationships with one of its RelationshipTypes.
+// Empty filters and "*" select everything.
+func (g *Graph) filter(base *algorithms.BaseAlgorithm) (*Graph, error) {
+	labels := selectAll(base.NodeLabels)
+	types := selectAll(base.RelationshipTypes)
+	if labels && types {
+		return g, nil
+	}
+	for _, t := range base.RelationshipTypes {
+		if _, ok := g.orientations[t]; !ok && t != "*" {
+			return nil, fmt.Errorf("relationship type %s is not projected", t)
+		}
+	}
+
+	sub := &Graph{
+		index:        make(map[string]int),
+		orientations: make(map[string]projections.Orientation),
+		nodeProps:    g.nodeProps,
+		relProps:     g.relProps,
+	}
+	mapping := make([]int, len(g.ids))
+	for i, id := range g.ids {
+		mapping[i] = -1
+		if !labels && !hasAnyLabel(g.labels[i], base.NodeLabels) {
+			continue
+		}
+		mapping[i] = len(sub.ids)
+		sub.index[id] = len(sub.ids)
+		sub.ids = append(sub.ids, id)
+		sub.labels = append(sub.labels, g.labels[i])
+		sub.props = append(sub.props, g.props[i])
+	}
+	sub.out = make([][]int, len(sub.ids))
+	sub.in = make([][]int, len(sub.ids))
+	for _, r := range g.rels {
+		if mapping[r.source] < 0 || mapping[r.target] < 0 {
+			continue
+		}
+		if !types && !contains(base.RelationshipTypes, r.typ) {
+			continue
+		}
+		sub.orientations[r.typ] = g.orientations[r.typ]
+		sub.addRelationship(relationship{source: mapping[r.source], target: mapping[r.target], typ: r.typ, props: r.props})
+	}
+	return sub, nil
+}
+
+// weights returns a function giving the weight of a relationship: the
+// property's value, or 1 when property is empty.
+func (g *Graph) weights(property string) (func(rel int) float64, error) {
+	if property == "" {
+		return func(int) float64 { return 1 }, nil
+	}
+	if !g.relProps[property] {
+		return nil, fmt.Errorf("relationship weight property %s is not projected", property)
+	}
+	return func(rel int) float64 { return g.rels[rel].props[property] }, nil
+}
+
+// node returns the index of a source or target node given as a fixture ID.
+func (g *Graph) node(id any, field string) (int, error) {
+	if id == nil {
+		return 0, fmt.Errorf("%s is required", field)
+	}
+	key, ok := id.(string)
+	if !ok {
+		key = fmt.Sprint(id)
+	}
+	i, ok := g.index[key]
+	if !ok {
+		return 0, fmt.Errorf("%s %s is not in the graph", field, key)
+	}
+	return i, nil
+}
+
+// seeds returns each node's initial community: the seed property's value,
+// or, for nodes without one, ids counting up from the largest seed. Without
+// a seed property a node's initial community is its index.
+func (g *Graph) seeds(property string) ([]int64, error) {
+	labels := make([]int64, len(g.ids))
+	if property == "" {
+		for i := range labels {
+			labels[i] = int64(i)
+		}
+		return labels, nil
+	}
+	if !g.nodeProps[property] {
+		return nil, fmt.Errorf("seed property %s is not projected", property)
+	}
+	next := int64(0)
+	for _, p := range g.props {
+		if v := p[property]; !math.IsNaN(v) && int64(v) >= next {
+			next = int64(v) + 1
+		}
+	}
+	for i, p := range g.props {
+		if v := p[property]; !math.IsNaN(v) {
+			labels[i] = int64(v)
+		} else {
+			labels[i] = next
+			next++
+		}
+	}
+	return labels, nil
+}
+
+// neighbors returns the distinct nodes connected to node in either
+// direction, excluding node itself, in index order.
+func (g *Graph) neighbors(node int) []int {
+	seen := make(map[int]bool)
+	for _, r := range g.out[node] {
+		seen[g.rels[r].target] = true
+	}
+	for _, r := range g.in[node] {
+		seen[g.rels[r].source] = true
+	}
+	delete(seen, node)
+	result := make([]int, 0, len(seen))
+	for n := range seen {
+		result = append(result, n)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// propertyValue returns a numeric property, the default when it is
+// missing, or NaN when there is no default.
+func propertyValue(props map[string]any, key string, def any) (float64, error) {
+	v, ok := props[key]
+	if !ok || v == nil {
+		if def == nil {
+			return math.NaN(), nil
+		}
+		v = def
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	}
+	return 0, fmt.Errorf("property %s is not numeric: %v", key, v)
+}
+
+func hasLabel(labels []string, label string) bool {
+	return contains(labels, label)
+}
+
+func hasAnyLabel(labels, wanted []string) bool {
+	for _, l := range wanted {
+		if hasLabel(labels, l) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectAll(filter []string) bool {
+	return len(filter) == 0 || contains(filter, "*")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package memgraph
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+func loadSocial(t *testing.T) *Fixture {
+	t.Helper()
+	f, err := LoadFixture("testdata/social.json")
+	if err != nil {
+		t.Fatalf("LoadFixture failed: %v", err)
+	}
+	return f
+}
+
+// projectKnows projects the people and their KNOWS relationships with a
+// weight property.
+func projectKnows(t *testing.T, orientation projections.Orientation, aggregation projections.Aggregation) *Graph {
+	t.Helper()
+	g, err := Project(loadSocial(t), &projections.NativeProjection{
+		NodeProjections: []projections.NodeProjection{{Label: "Person", Properties: []string{"age", "team"}}},
+		RelationshipProjections: []projections.RelationshipProjection{{
+			Type: "KNOWS", Orientation: orientation, Aggregation: aggregation,
+			Properties: []string{"weight"}, DefaultValue: 1.0,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Project failed: %v", err)
+	}
+	return g
+}
+
+func TestLoadFixture_CSV(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"nodes.csv":         "id,labels,age\nalice,Person;Manager,34\nbob,Person,\n",
+		"relationships.csv": "source,target,type,weight,since\nalice,bob,KNOWS,2.5,yesterday\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := LoadFixture(dir)
+	if err != nil {
+		t.Fatalf("LoadFixture failed: %v", err)
+	}
+	if len(f.Nodes) != 2 || len(f.Nodes[0].Labels) != 2 || f.Nodes[0].Properties["age"] != 34.0 {
+		t.Errorf("unexpected nodes: %+v", f.Nodes)
+	}
+	if _, ok := f.Nodes[1].Properties["age"]; ok {
+		t.Errorf("expected empty value to be unset, got %+v", f.Nodes[1])
+	}
+	if r := f.Relationships; len(r) != 1 || r[0].Type != "KNOWS" || r[0].Properties["weight"] != 2.5 || r[0].Properties["since"] != "yesterday" {
+		t.Errorf("unexpected relationships: %+v", r)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "relationships.csv"), []byte("from,to\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFixture(dir); err == nil || !strings.Contains(err.Error(), "missing column source") {
+		t.Errorf("expected missing column error, got %v", err)
+	}
+	if _, err := LoadFixture(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing fixture")
+	}
+}
+
+func TestProject(t *testing.T) {
+	fixture := loadSocial(t)
+
+	tests := []struct {
+		name          string
+		projection    *projections.NativeProjection
+		nodes, rels   int
+		wantWeight    float64
+		wantWeightSet bool
+	}{
+		{"labels and types", &projections.NativeProjection{NodeLabels: []string{"Person"}, RelationshipTypes: []string{"KNOWS"}}, 6, 8, 0, false},
+		{"all", &projections.NativeProjection{NodeLabels: []string{"*"}, RelationshipTypes: []string{"*"}}, 9, 14, 0, false},
+		{"relationships need both endpoints", &projections.NativeProjection{NodeLabels: []string{"Person"}, RelationshipTypes: []string{"LIKES"}}, 6, 0, 0, false},
+		{"undirected", &projections.NativeProjection{
+			NodeLabels:              []string{"Person"},
+			RelationshipProjections: []projections.RelationshipProjection{{Type: "KNOWS", Orientation: projections.Undirected}},
+		}, 6, 16, 0, false},
+		{"sum", &projections.NativeProjection{
+			NodeLabels:              []string{"Person"},
+			RelationshipProjections: []projections.RelationshipProjection{{Type: "KNOWS", Aggregation: projections.Sum, Properties: []string{"weight"}}},
+		}, 6, 7, 3, true},
+		{"max", &projections.NativeProjection{
+			NodeLabels:              []string{"Person"},
+			RelationshipProjections: []projections.RelationshipProjection{{Type: "KNOWS", Aggregation: projections.Max, Properties: []string{"weight"}}},
+		}, 6, 7, 2, true},
+		{"single", &projections.NativeProjection{
+			NodeLabels:              []string{"Person"},
+			RelationshipProjections: []projections.RelationshipProjection{{Type: "KNOWS", Aggregation: projections.Single, Properties: []string{"weight"}}},
+		}, 6, 7, 1, true},
+		{"count", &projections.NativeProjection{
+			NodeLabels:              []string{"Person"},
+			RelationshipProjections: []projections.RelationshipProjection{{Type: "KNOWS", Aggregation: projections.Count, Properties: []string{"weight"}}},
+		}, 6, 7, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Project(fixture, tt.projection)
+			if err != nil {
+				t.Fatalf("Project failed: %v", err)
+			}
+			if g.NodeCount() != tt.nodes || g.RelationshipCount() != tt.rels {
+				t.Errorf("got %d nodes, %d relationships; want %d, %d", g.NodeCount(), g.RelationshipCount(), tt.nodes, tt.rels)
+			}
+			if tt.wantWeightSet {
+				// alice-[KNOWS]->bob is the first, aggregated, relationship.
+				if w := g.rels[0].props["weight"]; w != tt.wantWeight {
+					t.Errorf("aggregated weight = %v, want %v", w, tt.wantWeight)
+				}
+			}
+		})
+	}
+}
+
+func TestProject_Properties(t *testing.T) {
+	g := projectKnows(t, projections.Reverse, projections.None)
+
+	if age, ok := g.NodeProperty("frank", "age"); !ok || age != 52 {
+		t.Errorf("age = %v, %v", age, ok)
+	}
+	if team, ok := g.NodeProperty("carol", "team"); !ok || !math.IsNaN(team) {
+		t.Errorf("expected missing team without default to be NaN, got %v", team)
+	}
+	// REVERSE flips relationships: frank-[KNOWS]->dave becomes dave->frank,
+	// with the default weight.
+	last := g.rels[len(g.rels)-1]
+	if g.ids[last.source] != "dave" || g.ids[last.target] != "frank" || last.props["weight"] != 1 {
+		t.Errorf("unexpected reversed relationship: %s->%s %v", g.ids[last.source], g.ids[last.target], last.props)
+	}
+
+	bad := &Fixture{Nodes: []FixtureNode{{ID: "a", Labels: []string{"A"}, Properties: map[string]any{"name": "x"}}}}
+	if _, err := Project(bad, &projections.NativeProjection{NodeProjections: []projections.NodeProjection{{Label: "A", Properties: []string{"name"}}}}); err == nil || !strings.Contains(err.Error(), "not numeric") {
+		t.Errorf("expected non-numeric property error, got %v", err)
+	}
+	dup := &Fixture{Nodes: []FixtureNode{{ID: "a", Labels: []string{"A"}}, {ID: "a", Labels: []string{"A"}}}}
+	if _, err := Project(dup, &projections.NativeProjection{NodeLabels: []string{"A"}}); err == nil || !strings.Contains(err.Error(), "duplicate node id") {
+		t.Errorf("expected duplicate id error, got %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package memgraph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+)
+
+// PathRow is a shortest path, as streamed by gds.shortestPath.dijkstra.
+type PathRow struct {
+	Index      int
+	SourceNode string
+	TargetNode string
+	TotalCost  float64
+	// NodeIDs are the nodes on the path, from source to target.
+	NodeIDs []string
+	// Costs are the accumulated costs at each node of the path.
+	Costs []float64
+}
+
+// TraversalRow is the visiting order of a traversal, as streamed by gds.bfs
+// and gds.dfs.
+type TraversalRow struct {
+	SourceNode string
+	NodeIDs    []string
+}
+
+// Dijkstra runs gds.shortestPath.dijkstra from SourceNode. With a
+// TargetNode it returns the shortest path to it, if any; otherwise the
+// shortest path to every reachable node, the source included, cheapest
+// first. Relationships weigh 1 without a RelationshipWeightProperty.
+func (g *Graph) Dijkstra(cfg *algorithms.Dijkstra) ([]PathRow, error) {
+	g, err := g.filter(&cfg.BaseAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := g.weights(cfg.RelationshipWeightProperty)
+	if err != nil {
+		return nil, err
+	}
+	source, err := g.node(cfg.SourceNode, "sourceNode")
+	if err != nil {
+		return nil, err
+	}
+	target := -1
+	if cfg.TargetNode != nil {
+		if target, err = g.node(cfg.TargetNode, "targetNode"); err != nil {
+			return nil, err
+		}
+	}
+	for i := range g.rels {
+		if w := weight(i); w < 0 || math.IsNaN(w) {
+			return nil, fmt.Errorf("dijkstra requires non-negative relationship weights, got %v", w)
+		}
+	}
+
+	dist := make([]float64, len(g.ids))
+	prev := make([]int, len(g.ids))
+	done := make([]bool, len(g.ids))
+	for i := range dist {
+		dist[i] = -1
+		prev[i] = -1
+	}
+	dist[source] = 0
+	queue := &nodeQueue{{node: source}}
+	var order []int
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(queueItem)
+		if done[item.node] {
+			continue
+		}
+		done[item.node] = true
+		order = append(order, item.node)
+		if item.node == target {
+			break
+		}
+		for _, r := range g.out[item.node] {
+			next := g.rels[r].target
+			cost := item.cost + weight(r)
+			if !done[next] && (dist[next] < 0 || cost < dist[next]) {
+				dist[next] = cost
+				prev[next] = item.node
+				heap.Push(queue, queueItem{node: next, cost: cost})
+			}
+		}
+	}
+
+	path := func(n int) PathRow {
+		row := PathRow{SourceNode: g.ids[source], TargetNode: g.ids[n], TotalCost: dist[n]}
+		var nodes []int
+		for ; n >= 0; n = prev[n] {
+			nodes = append(nodes, n)
+		}
+		for i := len(nodes) - 1; i >= 0; i-- {
+			row.NodeIDs = append(row.NodeIDs, g.ids[nodes[i]])
+			row.Costs = append(row.Costs, dist[nodes[i]])
+		}
+		return row
+	}
+
+	if target >= 0 {
+		if !done[target] {
+			return nil, nil
+		}
+		return []PathRow{path(target)}, nil
+	}
+	rows := make([]PathRow, len(order))
+	for i, n := range order {
+		rows[i] = path(n)
+		rows[i].Index = i
+	}
+	return rows, nil
+}
+
+// BFS runs gds.bfs from SourceNode, visiting relationship targets level by
+// level. It stops at the first of TargetNodes it reaches, and does not go
+// deeper than MaxDepth relationships when MaxDepth is set.
+func (g *Graph) BFS(cfg *algorithms.BFS) ([]TraversalRow, error) {
+	return g.traverse(&cfg.BaseAlgorithm, cfg.SourceNode, cfg.TargetNodes, cfg.MaxDepth, false)
+}
+
+// DFS runs gds.dfs from SourceNode, following relationship targets as deep
+// as possible before backtracking. TargetNodes and MaxDepth stop it as for
+// BFS.
+func (g *Graph) DFS(cfg *algorithms.DFS) ([]TraversalRow, error) {
+	return g.traverse(&cfg.BaseAlgorithm, cfg.SourceNode, cfg.TargetNodes, cfg.MaxDepth, true)
+}
+
+func (g *Graph) traverse(base *algorithms.BaseAlgorithm, sourceNode any, targetNodes []any, maxDepth int, depthFirst bool) ([]TraversalRow, error) {
+	g, err := g.filter(base)
+	if err != nil {
+		return nil, err
+	}
+	source, err := g.node(sourceNode, "sourceNode")
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[int]bool)
+	for _, t := range targetNodes {
+		n, err := g.node(t, "targetNode")
+		if err != nil {
+			return nil, err
+		}
+		targets[n] = true
+	}
+
+	type entry struct{ node, depth int }
+	visited := make([]bool, len(g.ids))
+	row := TraversalRow{SourceNode: g.ids[source]}
+	pending := []entry{{source, 0}}
+	for len(pending) > 0 {
+		var e entry
+		if depthFirst {
+			e, pending = pending[len(pending)-1], pending[:len(pending)-1]
+		} else {
+			e, pending = pending[0], pending[1:]
+		}
+		if visited[e.node] {
+			continue
+		}
+		visited[e.node] = true
+		row.NodeIDs = append(row.NodeIDs, g.ids[e.node])
+		if targets[e.node] {
+			break
+		}
+		if maxDepth > 0 && e.depth >= maxDepth {
+			continue
+		}
+
+		next := g.out[e.node]
+		if depthFirst {
+			// Push in reverse so the first relationship is followed first.
+			for i := len(next) - 1; i >= 0; i-- {
+				pending = append(pending, entry{g.rels[next[i]].target, e.depth + 1})
+			}
+			continue
+		}
+		for _, r := range next {
+			pending = append(pending, entry{g.rels[r].target, e.depth + 1})
+		}
+	}
+	return []TraversalRow{row}, nil
+}
+
+type queueItem struct {
+	node int
+	cost float64
+}
+
+// nodeQueue is a min-heap of nodes by cost, then position.
+type nodeQueue []queueItem
+
+func (q nodeQueue) Len() int { return len(q) }
+func (q nodeQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return q[i].node < q[j].node
+}
+func (q nodeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x any)   { *q = append(*q, x.(queueItem)) }
+func (q *nodeQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,37 @@
+package memgraph
+
+import (
+	"fmt"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+)
+
+// Run executes an algorithm and returns its rows: []ScoreRow,
+// []CommunityRow, []TriangleCountRow, []PathRow, []TraversalRow or
+// []SimilarityRow. Results are returned as streamed whatever the
+// algorithm's Mode; nothing is mutated or written.
+func (g *Graph) Run(algo algorithms.Algorithm) (any, error) {
+	switch a := algo.(type) {
+	case *algorithms.PageRank:
+		return g.PageRank(a)
+	case *algorithms.Degree:
+		return g.Degree(a)
+	case *algorithms.WCC:
+		return g.WCC(a)
+	case *algorithms.LabelPropagation:
+		return g.LabelPropagation(a)
+	case *algorithms.Louvain:
+		return g.Louvain(a)
+	case *algorithms.TriangleCount:
+		return g.TriangleCount(a)
+	case *algorithms.Dijkstra:
+		return g.Dijkstra(a)
+	case *algorithms.BFS:
+		return g.BFS(a)
+	case *algorithms.DFS:
+		return g.DFS(a)
+	case *algorithms.NodeSimilarity:
+		return g.NodeSimilarity(a)
+	}
+	return nil, fmt.Errorf("%s is not supported by the in-memory engine", algo.AlgorithmType())
+}
@@ -0,0 +1,98 @@
+package memgraph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+)
+
+// SimilarityRow is the similarity of two nodes, as streamed by
+// gds.nodeSimilarity.
+type SimilarityRow struct {
+	Node1      string
+	Node2      string
+	Similarity float64
+}
+
+// NodeSimilarity runs gds.nodeSimilarity, comparing nodes with at least
+// DegreeCutoff relationship targets by their sets of targets. Each node
+// keeps its TopK most similar nodes scoring above zero and at least
+// SimilarityCutoff; TopN then keeps the most similar pairs overall. Rows are
+// ordered by Node1, most similar first, unless TopN is set, which orders
+// them by similarity alone.
+func (g *Graph) NodeSimilarity(cfg *algorithms.NodeSimilarity) ([]SimilarityRow, error) {
+	g, err := g.filter(&cfg.BaseAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	metric := strings.ToUpper(cfg.SimilarityMetric)
+	if metric == "" {
+		metric = "JACCARD"
+	}
+	if metric != "JACCARD" && metric != "OVERLAP" && metric != "COSINE" {
+		return nil, fmt.Errorf("unsupported similarity metric %q", cfg.SimilarityMetric)
+	}
+	degreeCutoff := orDefaultInt(cfg.DegreeCutoff, 1)
+	topK := orDefaultInt(cfg.TopK, 10)
+
+	// targets are each node's distinct relationship targets.
+	targets := make([]map[int]bool, len(g.ids))
+	var candidates []int
+	for n := range g.ids {
+		targets[n] = make(map[int]bool)
+		for _, r := range g.out[n] {
+			targets[n][g.rels[r].target] = true
+		}
+		if len(targets[n]) >= degreeCutoff {
+			candidates = append(candidates, n)
+		}
+	}
+
+	var rows []SimilarityRow
+	for _, a := range candidates {
+		var top []SimilarityRow
+		for _, b := range candidates {
+			if a == b {
+				continue
+			}
+			s := similarity(metric, targets[a], targets[b])
+			if s <= 0 || s < cfg.SimilarityCutoff {
+				continue
+			}
+			top = append(top, SimilarityRow{Node1: g.ids[a], Node2: g.ids[b], Similarity: s})
+		}
+		// Candidates are in node order, so a stable sort keeps ties in it.
+		sort.SliceStable(top, func(i, j int) bool { return top[i].Similarity > top[j].Similarity })
+		if len(top) > topK {
+			top = top[:topK]
+		}
+		rows = append(rows, top...)
+	}
+
+	if cfg.TopN > 0 {
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].Similarity > rows[j].Similarity })
+		if len(rows) > cfg.TopN {
+			rows = rows[:cfg.TopN]
+		}
+	}
+	return rows, nil
+}
+
+func similarity(metric string, a, b map[int]bool) float64 {
+	shared := 0
+	for n := range a {
+		if b[n] {
+			shared++
+		}
+	}
+	switch metric {
+	case "OVERLAP":
+		return float64(shared) / math.Min(float64(len(a)), float64(len(b)))
+	case "COSINE":
+		return float64(shared) / math.Sqrt(float64(len(a))*float64(len(b)))
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
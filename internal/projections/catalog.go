@@ -0,0 +1,180 @@
+package projections
+
+// SamplingMethod selects the GDS graph sampling algorithm.
+type SamplingMethod string
+
+const (
+	// RandomWalkWithRestarts samples with gds.graph.sample.rwr.
+	RandomWalkWithRestarts SamplingMethod = "rwr"
+	// CommonNeighbourAwareRandomWalk samples with gds.graph.sample.cnarw.
+	CommonNeighbourAwareRandomWalk SamplingMethod = "cnarw"
+)
+
+// FilteredGraph is a subgraph derived from a parent projection using gds.graph.filter.
+type FilteredGraph struct {
+	BaseProjection
+	// Parent is the projection to filter.
+	Parent Projection
+	// NodeFilter is a Cypher predicate over nodes, e.g. "n:Person AND n.age > 18".
+	// Defaults to "*" (all nodes).
+	NodeFilter string
+	// RelationshipFilter is a Cypher predicate over relationships, e.g. "r.weight > 0.5".
+	// Defaults to "*" (all relationships).
+	RelationshipFilter string
+	// Parameters are query parameters referenced by the filters.
+	Parameters map[string]any
+	// Concurrency is the number of concurrent threads.
+	Concurrency int
+}
+
+func (g *FilteredGraph) ProjectionType() ProjectionType { return Filtered }
+
+// GetNodeProjections returns the parent's node projections.
+func (g *FilteredGraph) GetNodeProjections() []NodeProjection {
+	if g.Parent == nil {
+		return nil
+	}
+	return g.Parent.GetNodeProjections()
+}
+
+// GetRelationshipProjections returns the parent's relationship projections.
+func (g *FilteredGraph) GetRelationshipProjections() []RelationshipProjection {
+	if g.Parent == nil {
+		return nil
+	}
+	return g.Parent.GetRelationshipProjections()
+}
+
+// SampledGraph is a subgraph sampled from a parent projection using random walks.
+type SampledGraph struct {
+	BaseProjection
+	// Parent is the projection to sample from.
+	Parent Projection
+	// Method is the sampling algorithm (default: rwr).
+	Method SamplingMethod
+	// SamplingRatio is the fraction of nodes to sample (default: 0.15).
+	SamplingRatio float64
+	// RestartProbability is the probability of restarting a walk (default: 0.1).
+	RestartProbability float64
+	// StartNodes are node IDs to start walks from (default: random).
+	StartNodes []any
+	// NodeLabelStratification preserves the node label distribution of the parent.
+	NodeLabelStratification bool
+	// RelationshipWeightProperty biases walks by relationship weight.
+	RelationshipWeightProperty string
+	// RandomSeed makes sampling deterministic.
+	RandomSeed int64
+	// Concurrency is the number of concurrent threads.
+	Concurrency int
+}
+
+func (g *SampledGraph) ProjectionType() ProjectionType { return Sampled }
+
+// GetNodeProjections returns the parent's node projections.
+func (g *SampledGraph) GetNodeProjections() []NodeProjection {
+	if g.Parent == nil {
+		return nil
+	}
+	return g.Parent.GetNodeProjections()
+}
+
+// GetRelationshipProjections returns the parent's relationship projections.
+func (g *SampledGraph) GetRelationshipProjections() []RelationshipProjection {
+	if g.Parent == nil {
+		return nil
+	}
+	return g.Parent.GetRelationshipProjections()
+}
+
+// GetMethod returns the sampling method, defaulting to rwr.
+func (g *SampledGraph) GetMethod() SamplingMethod {
+	if g.Method == "" {
+		return RandomWalkWithRestarts
+	}
+	return g.Method
+}
+
+// CatalogOperation is the interface for graph catalog steps that act on an
+// existing projection, such as writing properties back to the database.
+type CatalogOperation interface {
+	// OperationName returns the name of this operation.
+	OperationName() string
+	// Procedure returns the GDS procedure called (e.g., "gds.graph.nodeProperties.write").
+	Procedure() string
+	// GetGraph returns the projection the operation acts on.
+	GetGraph() Projection
+}
+
+// BaseOperation contains common catalog operation fields.
+type BaseOperation struct {
+	// Name is the operation name used for identification.
+	Name string
+	// Graph is the projection the operation acts on.
+	Graph Projection
+}
+
+// OperationName returns the operation name.
+func (b *BaseOperation) OperationName() string {
+	return b.Name
+}
+
+// GetGraph returns the projection the operation acts on.
+func (b *BaseOperation) GetGraph() Projection {
+	return b.Graph
+}
+
+// NodePropertiesWrite writes in-memory node properties back to the database.
+type NodePropertiesWrite struct {
+	BaseOperation
+	// NodeProperties are the projected properties to write.
+	NodeProperties []string
+	// NodeLabels restricts which nodes are written (default: all).
+	NodeLabels []string
+	// WriteConcurrency is the number of concurrent write threads.
+	WriteConcurrency int
+}
+
+func (o *NodePropertiesWrite) Procedure() string { return "gds.graph.nodeProperties.write" }
+
+// RelationshipWrite writes in-memory relationships back to the database.
+// With more than one property, gds.graph.relationshipProperties.write is used.
+type RelationshipWrite struct {
+	BaseOperation
+	// RelationshipType is the projected relationship type to write.
+	RelationshipType string
+	// RelationshipProperties are the relationship properties to write.
+	RelationshipProperties []string
+	// WriteConcurrency is the number of concurrent write threads.
+	WriteConcurrency int
+}
+
+func (o *RelationshipWrite) Procedure() string {
+	if len(o.RelationshipProperties) > 1 {
+		return "gds.graph.relationshipProperties.write"
+	}
+	return "gds.graph.relationship.write"
+}
+
+// NodeLabelMutate adds a node label to nodes of the in-memory graph.
+type NodeLabelMutate struct {
+	BaseOperation
+	// NodeLabel is the label to add.
+	NodeLabel string
+	// NodeFilter is a Cypher predicate selecting the nodes to label.
+	NodeFilter string
+}
+
+func (o *NodeLabelMutate) Procedure() string { return "gds.graph.nodeLabel.mutate" }
+
+// GraphExport exports the in-memory graph to a new Neo4j database.
+type GraphExport struct {
+	BaseOperation
+	// DBName is the name of the database to create.
+	DBName string
+	// WriteConcurrency is the number of concurrent write threads.
+	WriteConcurrency int
+	// BatchSize is the number of entities written per transaction.
+	BatchSize int
+}
+
+func (o *GraphExport) Procedure() string { return "gds.graph.export" }
@@ -0,0 +1,380 @@
+package projections
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
+)
+
+func newSocialGraph() *NativeProjection {
+	return &NativeProjection{
+		BaseProjection: BaseProjection{
+			Name:      "social",
+			GraphName: "social_graph",
+		},
+		NodeLabels:        []string{"Person"},
+		RelationshipTypes: []string{"KNOWS"},
+	}
+}
+
+func TestBaseProjection_GetGraphName(t *testing.T) {
+	p := &NativeProjection{BaseProjection: BaseProjection{Name: "social"}}
+	if p.GetGraphName() != "social" {
+		t.Errorf("GetGraphName() = %v, want fallback to Name", p.GetGraphName())
+	}
+
+	p.GraphName = "social_graph"
+	if p.GetGraphName() != "social_graph" {
+		t.Errorf("GetGraphName() = %v, want social_graph", p.GetGraphName())
+	}
+}
+
+func TestFilteredGraph_Interface(t *testing.T) {
+	parent := newSocialGraph()
+	g := &FilteredGraph{
+		BaseProjection: BaseProjection{Name: "adults"},
+		Parent:         parent,
+		NodeFilter:     "n.age > 18",
+	}
+
+	if g.ProjectionType() != Filtered {
+		t.Errorf("ProjectionType() = %v, want Filtered", g.ProjectionType())
+	}
+	if len(g.GetNodeProjections()) != 1 {
+		t.Errorf("GetNodeProjections() should inherit from parent, got %d", len(g.GetNodeProjections()))
+	}
+	if len((&FilteredGraph{}).GetRelationshipProjections()) != 0 {
+		t.Error("GetRelationshipProjections() without parent should be empty")
+	}
+}
+
+func TestProjectionSerializer_ToCypher_Filtered(t *testing.T) {
+	s := NewProjectionSerializer()
+	g := &FilteredGraph{
+		BaseProjection:     BaseProjection{Name: "adults"},
+		Parent:             newSocialGraph(),
+		NodeFilter:         "n:Person AND n.age > $minAge",
+		RelationshipFilter: "r.since > 2020",
+		Parameters:         map[string]any{"minAge": 18},
+	}
+
+	result, err := s.ToCypher(g)
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+
+	expected := []string{
+		"CALL gds.graph.filter(",
+		"'adults'",
+		"'social_graph'",
+		"'n:Person AND n.age > $minAge'",
+		"'r.since > 2020'",
+		"parameters: {minAge: 18}",
+		"YIELD graphName, fromGraphName",
+	}
+	for _, e := range expected {
+		if !strings.Contains(result, e) {
+			t.Errorf("expected %q in output, got: %s", e, result)
+		}
+	}
+}
+
+func TestProjectionSerializer_ToCypher_FilteredDefaults(t *testing.T) {
+	s := NewProjectionSerializer()
+	g := &FilteredGraph{
+		BaseProjection: BaseProjection{Name: "copy"},
+		Parent:         newSocialGraph(),
+	}
+
+	result, err := s.ToCypher(g)
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+	if strings.Count(result, "'*'") != 2 {
+		t.Errorf("expected wildcard node and relationship filters, got: %s", result)
+	}
+}
+
+func TestProjectionSerializer_ParentNameOnly(t *testing.T) {
+	s := NewProjectionSerializer()
+	parents := []Projection{
+		&NativeProjection{BaseProjection: BaseProjection{Name: "social"}, NodeLabels: []string{"Person"}, RelationshipTypes: []string{"KNOWS"}},
+		&NativeProjection{BaseProjection: BaseProjection{Name: "social"}, NodeProjections: []NodeProjection{{Label: "Person"}}},
+		&CypherProjection{BaseProjection: BaseProjection{Name: "social"}, NodeQuery: "MATCH (n) RETURN id(n) AS id", RelationshipQuery: "MATCH (a)-->(b) RETURN id(a) AS source, id(b) AS target"},
+	}
+
+	for _, parent := range parents {
+		projected, err := s.ToCypher(parent)
+		if err != nil {
+			t.Fatalf("ToCypher failed: %v", err)
+		}
+		if !strings.Contains(projected, "'social'") {
+			t.Errorf("expected the parent to be projected as its name, got: %s", projected)
+		}
+		if got := s.ToMap(parent)["graphName"]; got != "social" {
+			t.Errorf("graphName = %v, want social", got)
+		}
+
+		filtered, err := s.ToCypher(&FilteredGraph{BaseProjection: BaseProjection{Name: "adults"}, Parent: parent})
+		if err != nil {
+			t.Fatalf("ToCypher failed: %v", err)
+		}
+		if !strings.Contains(filtered, "'adults',\n  'social'") {
+			t.Errorf("expected the filtered graph to reference social, got: %s", filtered)
+		}
+	}
+}
+
+func TestProjectionSerializer_WithTarget(t *testing.T) {
+	s := NewProjectionSerializer().WithTarget(target.Target{GDS: target.V(2, 3)})
+	parent := newSocialGraph()
+
+	filtered, err := s.ToCypher(&FilteredGraph{BaseProjection: BaseProjection{Name: "adults"}, Parent: parent})
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+	if !strings.Contains(filtered, "CALL gds.beta.graph.project.subgraph(") {
+		t.Errorf("expected beta subgraph procedure, got: %s", filtered)
+	}
+	sampled, err := s.ToCypher(&SampledGraph{BaseProjection: BaseProjection{Name: "sample"}, Parent: parent})
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+	if !strings.Contains(sampled, "CALL gds.alpha.graph.sample.rwr(") {
+		t.Errorf("expected alpha sampling procedure, got: %s", sampled)
+	}
+}
+
+func TestProjectionSerializer_ToCypher_FilteredNoParent(t *testing.T) {
+	s := NewProjectionSerializer()
+	_, err := s.ToCypher(&FilteredGraph{BaseProjection: BaseProjection{Name: "orphan"}})
+	if err == nil {
+		t.Error("expected error for filtered graph without parent")
+	}
+}
+
+func TestProjectionSerializer_ToCypher_Sampled(t *testing.T) {
+	s := NewProjectionSerializer()
+
+	tests := []struct {
+		name   string
+		method SamplingMethod
+		want   string
+	}{
+		{"default", "", "gds.graph.sample.rwr("},
+		{"rwr", RandomWalkWithRestarts, "gds.graph.sample.rwr("},
+		{"cnarw", CommonNeighbourAwareRandomWalk, "gds.graph.sample.cnarw("},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &SampledGraph{
+				BaseProjection: BaseProjection{Name: "sample"},
+				Parent:         newSocialGraph(),
+				Method:         tt.method,
+				SamplingRatio:  0.25,
+				StartNodes:     []any{1, 2},
+				RandomSeed:     42,
+			}
+
+			result, err := s.ToCypher(g)
+			if err != nil {
+				t.Fatalf("ToCypher failed: %v", err)
+			}
+
+			expected := []string{tt.want, "'sample'", "'social_graph'", "samplingRatio: 0.25", "startNodes: [1, 2]", "randomSeed: 42"}
+			for _, e := range expected {
+				if !strings.Contains(result, e) {
+					t.Errorf("expected %q in output, got: %s", e, result)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectionSerializer_ToCypher_SampledUnknownMethod(t *testing.T) {
+	s := NewProjectionSerializer()
+	g := &SampledGraph{
+		BaseProjection: BaseProjection{Name: "sample"},
+		Parent:         newSocialGraph(),
+		Method:         "bogus",
+	}
+	if _, err := s.ToCypher(g); err == nil {
+		t.Error("expected error for unknown sampling method")
+	}
+}
+
+func TestProjectionSerializer_ToMap_Derived(t *testing.T) {
+	s := NewProjectionSerializer()
+	g := &SampledGraph{
+		BaseProjection: BaseProjection{Name: "sample"},
+		Parent:         newSocialGraph(),
+		Method:         CommonNeighbourAwareRandomWalk,
+	}
+
+	m := s.ToMap(g)
+	if m["projectionType"] != "Sampled" {
+		t.Errorf("projectionType = %v, want Sampled", m["projectionType"])
+	}
+	if m["fromGraphName"] != "social_graph" {
+		t.Errorf("fromGraphName = %v, want social_graph", m["fromGraphName"])
+	}
+	if m["method"] != "cnarw" {
+		t.Errorf("method = %v, want cnarw", m["method"])
+	}
+}
+
+func TestProjectionSerializer_OperationToCypher(t *testing.T) {
+	s := NewProjectionSerializer()
+	graph := newSocialGraph()
+
+	tests := []struct {
+		name     string
+		op       CatalogOperation
+		expected []string
+	}{
+		{
+			name: "node properties write",
+			op: &NodePropertiesWrite{
+				BaseOperation:  BaseOperation{Name: "write_scores", Graph: graph},
+				NodeProperties: []string{"pagerank", "community"},
+				NodeLabels:     []string{"Person"},
+			},
+			expected: []string{
+				"CALL gds.graph.nodeProperties.write(",
+				"'social_graph'",
+				"['pagerank', 'community']",
+				"'Person'",
+				"YIELD propertiesWritten",
+			},
+		},
+		{
+			name: "relationship write single property",
+			op: &RelationshipWrite{
+				BaseOperation:          BaseOperation{Name: "write_similar", Graph: graph},
+				RelationshipType:       "SIMILAR",
+				RelationshipProperties: []string{"score"},
+			},
+			expected: []string{
+				"CALL gds.graph.relationship.write(",
+				"'SIMILAR'",
+				"'score'",
+				"YIELD relationshipsWritten",
+			},
+		},
+		{
+			name: "relationship write multiple properties",
+			op: &RelationshipWrite{
+				BaseOperation:          BaseOperation{Name: "write_similar", Graph: graph},
+				RelationshipType:       "SIMILAR",
+				RelationshipProperties: []string{"score", "rank"},
+				WriteConcurrency:       8,
+			},
+			expected: []string{
+				"CALL gds.graph.relationshipProperties.write(",
+				"['score', 'rank']",
+				"{writeConcurrency: 8}",
+			},
+		},
+		{
+			name: "node label mutate",
+			op: &NodeLabelMutate{
+				BaseOperation: BaseOperation{Name: "label_vips", Graph: graph},
+				NodeLabel:     "VIP",
+				NodeFilter:    "n.pagerank > 0.5",
+			},
+			expected: []string{
+				"CALL gds.graph.nodeLabel.mutate(",
+				"'VIP'",
+				"{nodeFilter: 'n.pagerank > 0.5'}",
+				"YIELD nodeLabelsWritten",
+			},
+		},
+		{
+			name: "graph export",
+			op: &GraphExport{
+				BaseOperation: BaseOperation{Name: "export", Graph: graph},
+				DBName:        "social_copy",
+				BatchSize:     10000,
+			},
+			expected: []string{
+				"CALL gds.graph.export(",
+				"{dbName: 'social_copy', batchSize: 10000}",
+				"YIELD dbName",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.OperationToCypher(tt.op)
+			if err != nil {
+				t.Fatalf("OperationToCypher failed: %v", err)
+			}
+			for _, e := range tt.expected {
+				if !strings.Contains(result, e) {
+					t.Errorf("expected %q in output, got: %s", e, result)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectionSerializer_OperationToCypher_Invalid(t *testing.T) {
+	s := NewProjectionSerializer()
+	graph := newSocialGraph()
+
+	tests := []struct {
+		name string
+		op   CatalogOperation
+	}{
+		{"no graph", &GraphExport{BaseOperation: BaseOperation{Name: "export"}, DBName: "db"}},
+		{"no properties", &NodePropertiesWrite{BaseOperation: BaseOperation{Name: "w", Graph: graph}}},
+		{"no relationship type", &RelationshipWrite{BaseOperation: BaseOperation{Name: "w", Graph: graph}}},
+		{"no label", &NodeLabelMutate{BaseOperation: BaseOperation{Name: "m", Graph: graph}}},
+		{"no database", &GraphExport{BaseOperation: BaseOperation{Name: "e", Graph: graph}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.OperationToCypher(tt.op); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestProjectionSerializer_OperationToJSON(t *testing.T) {
+	s := NewProjectionSerializer()
+	op := &NodePropertiesWrite{
+		BaseOperation:  BaseOperation{Name: "write_scores", Graph: newSocialGraph()},
+		NodeProperties: []string{"pagerank"},
+	}
+
+	data, err := s.OperationToJSON(op)
+	if err != nil {
+		t.Fatalf("OperationToJSON failed: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if result["procedure"] != "gds.graph.nodeProperties.write" {
+		t.Errorf("procedure = %v, want gds.graph.nodeProperties.write", result["procedure"])
+	}
+	if result["graphName"] != "social_graph" {
+		t.Errorf("graphName = %v, want social_graph", result["graphName"])
+	}
+}
+
+func TestCatalogOperation_ImplementsInterface(t *testing.T) {
+	var _ CatalogOperation = &NodePropertiesWrite{}
+	var _ CatalogOperation = &RelationshipWrite{}
+	var _ CatalogOperation = &NodeLabelMutate{}
+	var _ CatalogOperation = &GraphExport{}
+	var _ Projection = &FilteredGraph{}
+	var _ Projection = &SampledGraph{}
+}
@@ -4,6 +4,10 @@
 // - NativeProjection: Project node labels and relationship types directly
 // - CypherProjection: Use Cypher queries to define projections
 // - DataFrameProjection: Used with Aura Analytics
+// - FilteredGraph and SampledGraph: Subgraphs derived from another projection
+//
+// Graph catalog write-back and export steps (NodePropertiesWrite, RelationshipWrite,
+// NodeLabelMutate, GraphExport) implement CatalogOperation.
 //
 // Example usage:
 //
@@ -25,6 +29,10 @@ const (
 	Cypher ProjectionType = "Cypher"
 	// DataFrame projection for Aura Analytics.
 	DataFrame ProjectionType = "DataFrame"
+	// Filtered subgraph derived with gds.graph.filter.
+	Filtered ProjectionType = "Filtered"
+	// Sampled subgraph derived with gds.graph.sample.*.
+	Sampled ProjectionType = "Sampled"
 )
 
 // Orientation defines the direction of relationships.
@@ -63,6 +71,8 @@ type Projection interface {
 	ProjectionName() string
 	// ProjectionType returns the type of projection.
 	ProjectionType() ProjectionType
+	// GetGraphName returns the name of the graph in the GDS catalog.
+	GetGraphName() string
 	// GetNodeProjections returns node projection configurations.
 	GetNodeProjections() []NodeProjection
 	// GetRelationshipProjections returns relationship projection configurations.
@@ -84,6 +94,14 @@ func (b *BaseProjection) ProjectionName() string {
 	return b.Name
 }
 
+// GetGraphName returns the catalog graph name, falling back to Name if unset.
+func (b *BaseProjection) GetGraphName() string {
+	if b.GraphName == "" {
+		return b.Name
+	}
+	return b.GraphName
+}
+
 // NodeProjection defines how to project nodes.
 type NodeProjection struct {
 	// Label is the node label to project.
@@ -207,6 +207,67 @@ func TestProjectionSerializer_ToCypher_Cypher(t *testing.T) {
 	}
 }
 
+func TestProjectionSerializer_ToEstimateCypher(t *testing.T) {
+	s := NewProjectionSerializer()
+
+	tests := []struct {
+		name       string
+		projection Projection
+		expected   []string
+	}{
+		{
+			name: "native simple",
+			projection: &NativeProjection{
+				BaseProjection:    BaseProjection{Name: "social", GraphName: "social"},
+				NodeLabels:        []string{"Person"},
+				RelationshipTypes: []string{"KNOWS"},
+			},
+			expected: []string{"CALL gds.graph.project.estimate(", "'Person'", "'KNOWS'"},
+		},
+		{
+			name: "native extended",
+			projection: &NativeProjection{
+				BaseProjection: BaseProjection{Name: "social", GraphName: "social"},
+				NodeProjections: []NodeProjection{
+					{Label: "Person", Properties: []string{"age"}},
+				},
+			},
+			expected: []string{"CALL gds.graph.project.estimate(", "properties: 'age'"},
+		},
+		{
+			name: "cypher",
+			projection: &CypherProjection{
+				BaseProjection:    BaseProjection{Name: "custom", GraphName: "custom"},
+				NodeQuery:         "MATCH (n:Person) RETURN id(n) AS id",
+				RelationshipQuery: "MATCH (a)-[:KNOWS]->(b) RETURN id(a) AS source, id(b) AS target",
+			},
+			expected: []string{"CALL gds.graph.project.cypher.estimate(", "'MATCH (n:Person) RETURN id(n) AS id'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.ToEstimateCypher(tt.projection)
+			if err != nil {
+				t.Fatalf("ToEstimateCypher failed: %v", err)
+			}
+			for _, e := range append(tt.expected, "YIELD requiredMemory, bytesMin, bytesMax") {
+				if !strings.Contains(result, e) {
+					t.Errorf("expected %q in output, got: %s", e, result)
+				}
+			}
+			if strings.Contains(result, "'social'") || strings.Contains(result, "'custom'") {
+				t.Errorf("estimate should not take a graph name, got: %s", result)
+			}
+		})
+	}
+
+	filtered := &FilteredGraph{BaseProjection: BaseProjection{Name: "adults"}}
+	if _, err := s.ToEstimateCypher(filtered); err == nil {
+		t.Error("expected error for filtered graph estimate")
+	}
+}
+
 func TestProjectionSerializer_ToJSON_Native(t *testing.T) {
 	s := NewProjectionSerializer()
 	p := &NativeProjection{
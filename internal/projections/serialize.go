@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"text/template"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
 )
 
 // ProjectionSerializer serializes projection configurations to Cypher and JSON.
 type ProjectionSerializer struct {
 	templates *template.Template
+	// target selects the procedure tier of filtering, sampling and catalog
+	// operations.
+	target target.Target
 }
 
 // NewProjectionSerializer creates a new projection serializer.
@@ -20,6 +26,13 @@ func NewProjectionSerializer() *ProjectionSerializer {
 	return s
 }
 
+// WithTarget sets the GDS version the calls are generated for, which selects
+// the alpha, beta or GA name of each procedure.
+func (s *ProjectionSerializer) WithTarget(t target.Target) *ProjectionSerializer {
+	s.target = t
+	return s
+}
+
 func (s *ProjectionSerializer) initTemplates() *template.Template {
 	tmpl := template.New("projections")
 
@@ -53,6 +66,42 @@ YIELD graphName, nodeCount, relationshipCount`))
 )
 YIELD graphName, nodeCount, relationshipCount`))
 
+	// Projection memory estimate template (native and Cypher)
+	template.Must(tmpl.New("estimate").Parse(
+		`CALL {{.Procedure}}(
+  {{.NodeProjection}},
+  {{.RelationshipProjection}}{{if .Config}},
+  {{.Config}}{{end}}
+)
+YIELD requiredMemory, bytesMin, bytesMax, nodeCount, relationshipCount`))
+
+	// Filtered subgraph template
+	template.Must(tmpl.New("filter").Parse(
+		`CALL {{.Procedure}}(
+  '{{.GraphName}}',
+  '{{.FromGraphName}}',
+  '{{.NodeFilter}}',
+  '{{.RelationshipFilter}}'{{if .Config}},
+  {{.Config}}{{end}}
+)
+YIELD graphName, fromGraphName, nodeCount, relationshipCount`))
+
+	// Sampled subgraph template
+	template.Must(tmpl.New("sample").Parse(
+		`CALL {{.Procedure}}(
+  '{{.GraphName}}',
+  '{{.FromGraphName}}'{{if .Config}},
+  {{.Config}}{{end}}
+)
+YIELD graphName, fromGraphName, nodeCount, relationshipCount, startNodeCount`))
+
+	// Catalog operation template (write-back, mutate, export)
+	template.Must(tmpl.New("operation").Parse(
+		`CALL {{.Procedure}}(
+  {{.Args}}
+)
+YIELD {{.YieldFields}}`))
+
 	// Drop graph template
 	template.Must(tmpl.New("drop").Parse(
 		`CALL gds.graph.drop('{{.GraphName}}') YIELD graphName`))
@@ -77,11 +126,53 @@ func (s *ProjectionSerializer) ToCypher(projection Projection) (string, error) {
 		return s.cypherToCypher(p)
 	case *DataFrameProjection:
 		return s.dataframeToCypher(p)
+	case *FilteredGraph:
+		return s.filteredToCypher(p)
+	case *SampledGraph:
+		return s.sampledToCypher(p)
 	default:
 		return "", fmt.Errorf("unknown projection type: %T", projection)
 	}
 }
 
+// ToEstimateCypher converts a projection to a Cypher CALL of the matching
+// .estimate procedure. Only native and Cypher projections can be estimated;
+// derived graphs are bounded by the estimate of their parent.
+func (s *ProjectionSerializer) ToEstimateCypher(projection Projection) (string, error) {
+	var data map[string]string
+
+	switch p := projection.(type) {
+	case *NativeProjection:
+		nodes := formatLabels(p.NodeLabels)
+		rels := formatLabels(p.RelationshipTypes)
+		if len(p.NodeProjections) > 0 || len(p.RelationshipProjections) > 0 {
+			nodes = s.formatNodeProjections(p.GetNodeProjections())
+			rels = s.formatRelationshipProjections(p.GetRelationshipProjections())
+		}
+		data = map[string]string{
+			"Procedure":              "gds.graph.project.estimate",
+			"NodeProjection":         nodes,
+			"RelationshipProjection": rels,
+			"Config":                 s.buildNativeConfig(p),
+		}
+	case *CypherProjection:
+		data = map[string]string{
+			"Procedure":              "gds.graph.project.cypher.estimate",
+			"NodeProjection":         "'" + escapeString(p.NodeQuery) + "'",
+			"RelationshipProjection": "'" + escapeString(p.RelationshipQuery) + "'",
+			"Config":                 s.buildCypherConfig(p),
+		}
+	default:
+		return "", fmt.Errorf("projection %s: no estimate procedure for %s projections", projection.ProjectionName(), projection.ProjectionType())
+	}
+
+	var buf bytes.Buffer
+	if err := s.templates.ExecuteTemplate(&buf, "estimate", data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 func (s *ProjectionSerializer) nativeToCypher(p *NativeProjection) (string, error) {
 	// Use simple form if only labels/types are specified
 	if len(p.NodeProjections) == 0 && len(p.RelationshipProjections) == 0 {
@@ -97,7 +188,7 @@ func (s *ProjectionSerializer) nativeSimpleToCypher(p *NativeProjection) (string
 	config := s.buildNativeConfig(p)
 
 	data := map[string]string{
-		"GraphName":         p.GraphName,
+		"GraphName":         p.GetGraphName(),
 		"NodeLabels":        nodeLabels,
 		"RelationshipTypes": relTypes,
 		"Config":            config,
@@ -117,7 +208,7 @@ func (s *ProjectionSerializer) nativeExtendedToCypher(p *NativeProjection) (stri
 	config := s.buildNativeConfig(p)
 
 	data := map[string]string{
-		"GraphName":               p.GraphName,
+		"GraphName":               p.GetGraphName(),
 		"NodeProjections":         nodeProjections,
 		"RelationshipProjections": relProjections,
 		"Config":                  config,
@@ -134,7 +225,7 @@ func (s *ProjectionSerializer) cypherToCypher(p *CypherProjection) (string, erro
 	config := s.buildCypherConfig(p)
 
 	data := map[string]string{
-		"GraphName":         p.GraphName,
+		"GraphName":         p.GetGraphName(),
 		"NodeQuery":         escapeString(p.NodeQuery),
 		"RelationshipQuery": escapeString(p.RelationshipQuery),
 		"Config":            config,
@@ -155,7 +246,82 @@ func (s *ProjectionSerializer) dataframeToCypher(p *DataFrameProjection) (string
 		"//   '%s',\n"+
 		"//   nodes_df,\n"+
 		"//   relationships_df\n"+
-		"// )", p.Name, p.GraphName), nil
+		"// )", p.Name, p.GetGraphName()), nil
+}
+
+func (s *ProjectionSerializer) filteredToCypher(p *FilteredGraph) (string, error) {
+	if p.Parent == nil {
+		return "", fmt.Errorf("filtered graph %s has no parent projection", p.Name)
+	}
+
+	var parts []string
+	if p.Concurrency > 0 {
+		parts = append(parts, fmt.Sprintf("concurrency: %d", p.Concurrency))
+	}
+	if len(p.Parameters) > 0 {
+		parts = append(parts, "parameters: "+formatParameters(p.Parameters))
+	}
+
+	data := map[string]string{
+		"Procedure":          s.target.Procedure("gds.graph.filter"),
+		"GraphName":          p.GetGraphName(),
+		"FromGraphName":      p.Parent.GetGraphName(),
+		"NodeFilter":         escapeString(filterOrWildcard(p.NodeFilter)),
+		"RelationshipFilter": escapeString(filterOrWildcard(p.RelationshipFilter)),
+		"Config":             formatConfig(parts),
+	}
+
+	var buf bytes.Buffer
+	if err := s.templates.ExecuteTemplate(&buf, "filter", data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (s *ProjectionSerializer) sampledToCypher(p *SampledGraph) (string, error) {
+	if p.Parent == nil {
+		return "", fmt.Errorf("sampled graph %s has no parent projection", p.Name)
+	}
+	method := p.GetMethod()
+	if method != RandomWalkWithRestarts && method != CommonNeighbourAwareRandomWalk {
+		return "", fmt.Errorf("unknown sampling method: %s", method)
+	}
+
+	var parts []string
+	if p.SamplingRatio > 0 {
+		parts = append(parts, fmt.Sprintf("samplingRatio: %v", p.SamplingRatio))
+	}
+	if p.RestartProbability > 0 {
+		parts = append(parts, fmt.Sprintf("restartProbability: %v", p.RestartProbability))
+	}
+	if len(p.StartNodes) > 0 {
+		parts = append(parts, "startNodes: "+formatList(p.StartNodes))
+	}
+	if p.NodeLabelStratification {
+		parts = append(parts, "nodeLabelStratification: true")
+	}
+	if p.RelationshipWeightProperty != "" {
+		parts = append(parts, fmt.Sprintf("relationshipWeightProperty: '%s'", p.RelationshipWeightProperty))
+	}
+	if p.RandomSeed != 0 {
+		parts = append(parts, fmt.Sprintf("randomSeed: %d", p.RandomSeed))
+	}
+	if p.Concurrency > 0 {
+		parts = append(parts, fmt.Sprintf("concurrency: %d", p.Concurrency))
+	}
+
+	data := map[string]string{
+		"Procedure":     s.target.Procedure("gds.graph.sample." + string(method)),
+		"GraphName":     p.GetGraphName(),
+		"FromGraphName": p.Parent.GetGraphName(),
+		"Config":        formatConfig(parts),
+	}
+
+	var buf bytes.Buffer
+	if err := s.templates.ExecuteTemplate(&buf, "sample", data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
 }
 
 func (s *ProjectionSerializer) buildNativeConfig(p *NativeProjection) string {
@@ -262,7 +428,7 @@ func (s *ProjectionSerializer) ToMap(projection Projection) map[string]any {
 
 	switch p := projection.(type) {
 	case *NativeProjection:
-		result["graphName"] = p.GraphName
+		result["graphName"] = p.GetGraphName()
 		if len(p.NodeLabels) > 0 {
 			result["nodeLabels"] = p.NodeLabels
 		}
@@ -280,7 +446,7 @@ func (s *ProjectionSerializer) ToMap(projection Projection) map[string]any {
 		}
 
 	case *CypherProjection:
-		result["graphName"] = p.GraphName
+		result["graphName"] = p.GetGraphName()
 		if p.NodeQuery != "" {
 			result["nodeQuery"] = p.NodeQuery
 		}
@@ -298,13 +464,55 @@ func (s *ProjectionSerializer) ToMap(projection Projection) map[string]any {
 		}
 
 	case *DataFrameProjection:
-		result["graphName"] = p.GraphName
+		result["graphName"] = p.GetGraphName()
 		if len(p.NodeDataFrames) > 0 {
 			result["nodeDataFrames"] = s.nodeDataFramesToMaps(p.NodeDataFrames)
 		}
 		if len(p.RelationshipDataFrames) > 0 {
 			result["relationshipDataFrames"] = s.relDataFramesToMaps(p.RelationshipDataFrames)
 		}
+
+	case *FilteredGraph:
+		result["graphName"] = p.GetGraphName()
+		if p.Parent != nil {
+			result["fromGraphName"] = p.Parent.GetGraphName()
+		}
+		result["nodeFilter"] = filterOrWildcard(p.NodeFilter)
+		result["relationshipFilter"] = filterOrWildcard(p.RelationshipFilter)
+		if len(p.Parameters) > 0 {
+			result["parameters"] = p.Parameters
+		}
+		if p.Concurrency > 0 {
+			result["concurrency"] = p.Concurrency
+		}
+
+	case *SampledGraph:
+		result["graphName"] = p.GetGraphName()
+		if p.Parent != nil {
+			result["fromGraphName"] = p.Parent.GetGraphName()
+		}
+		result["method"] = string(p.GetMethod())
+		if p.SamplingRatio > 0 {
+			result["samplingRatio"] = p.SamplingRatio
+		}
+		if p.RestartProbability > 0 {
+			result["restartProbability"] = p.RestartProbability
+		}
+		if len(p.StartNodes) > 0 {
+			result["startNodes"] = p.StartNodes
+		}
+		if p.NodeLabelStratification {
+			result["nodeLabelStratification"] = true
+		}
+		if p.RelationshipWeightProperty != "" {
+			result["relationshipWeightProperty"] = p.RelationshipWeightProperty
+		}
+		if p.RandomSeed != 0 {
+			result["randomSeed"] = p.RandomSeed
+		}
+		if p.Concurrency > 0 {
+			result["concurrency"] = p.Concurrency
+		}
 	}
 
 	return result
@@ -379,6 +587,131 @@ func (s *ProjectionSerializer) relDataFramesToMaps(dataframes []RelationshipData
 	return result
 }
 
+// OperationToCypher converts a catalog operation to a Cypher CALL statement.
+func (s *ProjectionSerializer) OperationToCypher(op CatalogOperation) (string, error) {
+	graph := op.GetGraph()
+	if graph == nil {
+		return "", fmt.Errorf("catalog operation %s has no graph", op.OperationName())
+	}
+	args := []string{fmt.Sprintf("'%s'", graph.GetGraphName())}
+
+	var yieldFields string
+	switch o := op.(type) {
+	case *NodePropertiesWrite:
+		if len(o.NodeProperties) == 0 {
+			return "", fmt.Errorf("node properties write %s has no properties", o.Name)
+		}
+		args = append(args, formatLabels(o.NodeProperties), formatLabels(o.NodeLabels))
+		if o.WriteConcurrency > 0 {
+			args = append(args, fmt.Sprintf("{writeConcurrency: %d}", o.WriteConcurrency))
+		}
+		yieldFields = "propertiesWritten, writeMillis"
+
+	case *RelationshipWrite:
+		if o.RelationshipType == "" {
+			return "", fmt.Errorf("relationship write %s has no relationship type", o.Name)
+		}
+		args = append(args, fmt.Sprintf("'%s'", o.RelationshipType))
+		switch len(o.RelationshipProperties) {
+		case 0:
+		case 1:
+			args = append(args, fmt.Sprintf("'%s'", o.RelationshipProperties[0]))
+		default:
+			args = append(args, formatLabels(o.RelationshipProperties))
+		}
+		if o.WriteConcurrency > 0 {
+			args = append(args, fmt.Sprintf("{writeConcurrency: %d}", o.WriteConcurrency))
+		}
+		yieldFields = "relationshipsWritten, propertiesWritten, writeMillis"
+
+	case *NodeLabelMutate:
+		if o.NodeLabel == "" {
+			return "", fmt.Errorf("node label mutate %s has no node label", o.Name)
+		}
+		args = append(args,
+			fmt.Sprintf("'%s'", o.NodeLabel),
+			fmt.Sprintf("{nodeFilter: '%s'}", escapeString(filterOrWildcard(o.NodeFilter))))
+		yieldFields = "nodeLabelsWritten, mutateMillis"
+
+	case *GraphExport:
+		if o.DBName == "" {
+			return "", fmt.Errorf("graph export %s has no database name", o.Name)
+		}
+		parts := []string{fmt.Sprintf("dbName: '%s'", o.DBName)}
+		if o.WriteConcurrency > 0 {
+			parts = append(parts, fmt.Sprintf("writeConcurrency: %d", o.WriteConcurrency))
+		}
+		if o.BatchSize > 0 {
+			parts = append(parts, fmt.Sprintf("batchSize: %d", o.BatchSize))
+		}
+		args = append(args, "{"+strings.Join(parts, ", ")+"}")
+		yieldFields = "dbName, nodeCount, relationshipCount, writeMillis"
+
+	default:
+		return "", fmt.Errorf("unknown catalog operation type: %T", op)
+	}
+
+	data := map[string]string{
+		"Procedure":   s.target.Procedure(op.Procedure()),
+		"Args":        strings.Join(args, ",\n  "),
+		"YieldFields": yieldFields,
+	}
+
+	var buf bytes.Buffer
+	if err := s.templates.ExecuteTemplate(&buf, "operation", data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// OperationToJSON converts a catalog operation to JSON.
+func (s *ProjectionSerializer) OperationToJSON(op CatalogOperation) ([]byte, error) {
+	return json.MarshalIndent(s.OperationToMap(op), "", "  ")
+}
+
+// OperationToMap converts a catalog operation to a map.
+func (s *ProjectionSerializer) OperationToMap(op CatalogOperation) map[string]any {
+	result := map[string]any{
+		"name":      op.OperationName(),
+		"procedure": op.Procedure(),
+	}
+	if graph := op.GetGraph(); graph != nil {
+		result["graphName"] = graph.GetGraphName()
+	}
+
+	switch o := op.(type) {
+	case *NodePropertiesWrite:
+		result["nodeProperties"] = o.NodeProperties
+		if len(o.NodeLabels) > 0 {
+			result["nodeLabels"] = o.NodeLabels
+		}
+		if o.WriteConcurrency > 0 {
+			result["writeConcurrency"] = o.WriteConcurrency
+		}
+	case *RelationshipWrite:
+		result["relationshipType"] = o.RelationshipType
+		if len(o.RelationshipProperties) > 0 {
+			result["relationshipProperties"] = o.RelationshipProperties
+		}
+		if o.WriteConcurrency > 0 {
+			result["writeConcurrency"] = o.WriteConcurrency
+		}
+	case *NodeLabelMutate:
+		result["nodeLabel"] = o.NodeLabel
+		result["nodeFilter"] = filterOrWildcard(o.NodeFilter)
+	case *GraphExport:
+		result["dbName"] = o.DBName
+		if o.WriteConcurrency > 0 {
+			result["writeConcurrency"] = o.WriteConcurrency
+		}
+		if o.BatchSize > 0 {
+			result["batchSize"] = o.BatchSize
+		}
+	}
+
+	return result
+}
+
 // DropGraph generates a Cypher statement to drop a projected graph.
 func (s *ProjectionSerializer) DropGraph(graphName string) string {
 	var buf bytes.Buffer
@@ -432,6 +765,46 @@ func formatValue(v any) string {
 	}
 }
 
+// formatList formats a slice of values as a Cypher list.
+func formatList(values []any) string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = formatValue(v)
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// formatParameters formats a parameter map as a Cypher map with sorted keys.
+func formatParameters(params map[string]any) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, len(keys))
+	for i, k := range keys {
+		entries[i] = fmt.Sprintf("%s: %s", k, formatValue(params[k]))
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+// formatConfig formats configuration entries as an indented Cypher map.
+func formatConfig(parts []string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+	return "{\n    " + strings.Join(parts, ",\n    ") + "\n  }"
+}
+
+// filterOrWildcard returns the filter predicate, or "*" if empty.
+func filterOrWildcard(filter string) string {
+	if filter == "" {
+		return "*"
+	}
+	return filter
+}
+
 // escapeString escapes single quotes in a string for Cypher.
 func escapeString(s string) string {
 	return strings.ReplaceAll(s, "'", "\\'")
@@ -0,0 +1,219 @@
+package retrievers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+)
+
+// Searcher runs a retriever for a query. Runtime searches a Neo4j database;
+// Corpus searches an in-memory fixture.
+type Searcher interface {
+	Search(ctx context.Context, retriever Retriever, queryText string) ([]Result, error)
+}
+
+// Document is a fixture node with a precomputed embedding.
+type Document struct {
+	ID         string         `json:"id"`
+	Text       string         `json:"text"`
+	Embedding  []float64      `json:"embedding"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Corpus is an in-memory stand-in for the vector and fulltext indexes of a
+// Neo4j database, used to evaluate retrievers without a database.
+//
+// Vector scores follow the Neo4j cosine index, (1 + cosine) / 2. Fulltext
+// scores count the query terms found in a document's text. Filters match
+// document Properties as they would node properties. A RetrievalQuery
+// cannot run without Neo4j, so Cypher retrievers are evaluated on the nodes
+// their index search returns.
+type Corpus struct {
+	Documents []Document `json:"documents"`
+	// Queries holds precomputed query embeddings keyed by query text.
+	Queries map[string][]float64 `json:"queries,omitempty"`
+	// Embedder embeds query text missing from Queries. Optional.
+	Embedder Embedder `json:"-"`
+}
+
+// LoadCorpus reads a Corpus from a JSON file.
+func LoadCorpus(path string) (*Corpus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %w", err)
+	}
+	var c Corpus
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse corpus %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Search runs retriever against the corpus and returns at most TopK results
+// scoring at least ScoreThreshold, best first.
+func (c *Corpus) Search(ctx context.Context, retriever Retriever, queryText string) ([]Result, error) {
+	var hits map[string]float64
+	var topK int
+	var threshold float64
+	var filters *Filter
+
+	switch r := retriever.(type) {
+	case *VectorRetriever:
+		topK, threshold, filters = r.TopK, r.ScoreThreshold, r.Filters
+	case *VectorCypherRetriever:
+		topK, threshold, filters = r.TopK, r.ScoreThreshold, r.Filters
+	case *HybridRetriever:
+		topK, filters = r.TopK, r.Filters
+	case *HybridCypherRetriever:
+		topK, filters = r.TopK, r.Filters
+	default:
+		return nil, fmt.Errorf("retriever type %s is not supported by the corpus", retriever.RetrieverType())
+	}
+	topK = effectiveTopK(topK)
+
+	embedding, err := c.queryEmbedding(ctx, queryText)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := retriever.(type) {
+	case *HybridRetriever:
+		hits = c.hybridScores(embedding, queryText, topK, r.VectorWeight, r.FulltextWeight, filters)
+	case *HybridCypherRetriever:
+		hits = c.hybridScores(embedding, queryText, topK, r.VectorWeight, r.FulltextWeight, filters)
+	default:
+		hits = c.vectorScores(embedding, topK, filters)
+	}
+
+	results := make([]Result, 0, len(hits))
+	for _, doc := range c.Documents {
+		score, ok := hits[doc.ID]
+		if !ok || (threshold > 0 && score < threshold) || (filters != nil && !filters.Matches(doc.Properties)) {
+			continue
+		}
+		record := map[string]any{"text": doc.Text}
+		for k, v := range doc.Properties {
+			record[k] = v
+		}
+		results = append(results, Result{ID: doc.ID, Score: score, Record: record})
+	}
+	sortResults(results)
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results, nil
+}
+
+func (c *Corpus) queryEmbedding(ctx context.Context, queryText string) ([]float64, error) {
+	if embedding, ok := c.Queries[queryText]; ok {
+		return embedding, nil
+	}
+	if c.Embedder == nil {
+		return nil, fmt.Errorf("no precomputed embedding for query %q and no embedder", queryText)
+	}
+	embedding, err := c.Embedder.EmbedQuery(ctx, queryText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	return embedding, nil
+}
+
+// vectorScores returns the topK documents most similar to embedding among
+// those passing filters, which may be nil. Documents without an embedding
+// are skipped, as they are absent from the vector index.
+func (c *Corpus) vectorScores(embedding []float64, topK int, filters *Filter) map[string]float64 {
+	results := make([]Result, 0, len(c.Documents))
+	for _, doc := range c.Documents {
+		if len(doc.Embedding) == 0 || (filters != nil && !filters.Matches(doc.Properties)) {
+			continue
+		}
+		results = append(results, Result{ID: doc.ID, Score: (1 + graphrag.CosineSimilarity(embedding, doc.Embedding)) / 2})
+	}
+	return topScores(results, topK)
+}
+
+// fulltextScores returns the topK documents containing the most query terms
+// among those passing filters, which may be nil.
+func (c *Corpus) fulltextScores(queryText string, topK int, filters *Filter) map[string]float64 {
+	terms := tokenize(queryText)
+	results := make([]Result, 0, len(c.Documents))
+	for _, doc := range c.Documents {
+		if filters != nil && !filters.Matches(doc.Properties) {
+			continue
+		}
+		var score float64
+		for _, word := range tokenize(doc.Text) {
+			for _, term := range terms {
+				if word == term {
+					score++
+				}
+			}
+		}
+		if score > 0 {
+			results = append(results, Result{ID: doc.ID, Score: score})
+		}
+	}
+	return topScores(results, topK)
+}
+
+// hybridScores mirrors the hybrid Cypher of ToCypher: each index's scores
+// among the documents passing filters are divided by its best score,
+// weighted and summed per document.
+func (c *Corpus) hybridScores(embedding []float64, queryText string, topK int, vectorWeight, fulltextWeight float64, filters *Filter) map[string]float64 {
+	if vectorWeight == 0 && fulltextWeight == 0 {
+		vectorWeight, fulltextWeight = 0.5, 0.5
+	}
+	combined := make(map[string]float64)
+	addNormalized(combined, c.vectorScores(embedding, topK, filters), vectorWeight)
+	addNormalized(combined, c.fulltextScores(queryText, topK, filters), fulltextWeight)
+	return combined
+}
+
+func addNormalized(combined, scores map[string]float64, weight float64) {
+	var maxScore float64
+	for _, s := range scores {
+		maxScore = math.Max(maxScore, s)
+	}
+	if maxScore == 0 {
+		return
+	}
+	for id, s := range scores {
+		combined[id] += weight * s / maxScore
+	}
+}
+
+func topScores(results []Result, topK int) map[string]float64 {
+	sortResults(results)
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	scores := make(map[string]float64, len(results))
+	for _, r := range results {
+		scores[r.ID] = r.Score
+	}
+	return scores
+}
+
+// sortResults orders results by descending score, then by ID.
+func sortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+}
+
+// tokenize splits text into lower-cased words, as FakeEmbedder does.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
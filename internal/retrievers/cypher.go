@@ -0,0 +1,309 @@
+package retrievers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+)
+
+const vectorSearchCall = "CALL db.index.vector.queryNodes($indexName, $topK, $embedding) YIELD node, score\n"
+
+// filteredVectorSearch is an exact similarity search over the nodes that
+// pass a filter, since the vector index cannot filter before ranking. Nodes
+// without an embedding would score null, which sorts first, so they are
+// skipped like the index skips them.
+const filteredVectorSearch = `MATCH (node:%[1]s)
+WHERE %[2]s AND node.%[3]s IS NOT NULL
+WITH node, vector.similarity.%[4]s(node.%[3]s, $embedding) AS score
+ORDER BY score DESC
+LIMIT $topK
+`
+
+// hybridSearchCall normalizes each index's scores by its best score, weights
+// them and sums them per node. Hits from an index whose best score is 0
+// score 0, as in neo4j-graphrag.
+const hybridSearchCall = `CALL {
+  CALL db.index.vector.queryNodes($vectorIndexName, $topK, $embedding) YIELD node, score
+  WITH collect({node: node, score: score}) AS hits, max(score) AS maxScore
+  UNWIND hits AS hit
+  RETURN hit.node AS node, $vectorWeight * CASE WHEN maxScore = 0 THEN 0.0 ELSE hit.score / maxScore END AS score
+  UNION ALL
+  CALL db.index.fulltext.queryNodes($fulltextIndexName, $queryText, {limit: $topK}) YIELD node, score
+  WITH collect({node: node, score: score}) AS hits, max(score) AS maxScore
+  UNWIND hits AS hit
+  RETURN hit.node AS node, $fulltextWeight * CASE WHEN maxScore = 0 THEN 0.0 ELSE hit.score / maxScore END AS score
+}
+WITH node, sum(score) AS score
+`
+
+// filteredHybridSearchCall is the hybrid search over the nodes of a label
+// that pass a filter. The vector part is an exact search like
+// filteredVectorSearch, and fulltext hits are filtered before they are
+// ranked, so both parts return up to $topK matching nodes.
+const filteredHybridSearchCall = `CALL {
+  MATCH (node:%[1]s)
+  WHERE %[2]s AND node.%[3]s IS NOT NULL
+  WITH node, vector.similarity.%[4]s(node.%[3]s, $embedding) AS score
+  ORDER BY score DESC
+  LIMIT $topK
+  WITH collect({node: node, score: score}) AS hits, max(score) AS maxScore
+  UNWIND hits AS hit
+  RETURN hit.node AS node, $vectorWeight * CASE WHEN maxScore = 0 THEN 0.0 ELSE hit.score / maxScore END AS score
+  UNION ALL
+  CALL db.index.fulltext.queryNodes($fulltextIndexName, $queryText) YIELD node, score
+  WHERE node:%[1]s AND %[2]s
+  WITH node, score
+  ORDER BY score DESC
+  LIMIT $topK
+  WITH collect({node: node, score: score}) AS hits, max(score) AS maxScore
+  UNWIND hits AS hit
+  RETURN hit.node AS node, $fulltextWeight * CASE WHEN maxScore = 0 THEN 0.0 ELSE hit.score / maxScore END AS score
+}
+WITH node, sum(score) AS score
+`
+
+const hybridRankClause = "ORDER BY score DESC\nLIMIT $topK\n"
+
+// externalMatchCall looks up the Neo4j nodes for IDs returned by an external
+// vector store.
+const externalMatchCall = `UNWIND $matches AS match
+MATCH (node) WHERE node[$idProperty] = match.id
+WITH node, match.score AS score
+`
+
+// ToCypher returns the parameterized Cypher a retriever runs against Neo4j.
+//
+// The query reads $queryText and $embedding, which are supplied per search,
+// and the parameters returned by Parameters. A RetrievalQuery is appended
+// with node and score in scope; without one, node properties are returned.
+// Text2Cypher retrievers have no fixed query and return an error.
+func (s *RetrieverSerializer) ToCypher(retriever Retriever) (string, error) {
+	switch r := retriever.(type) {
+	case *VectorRetriever:
+		search, err := vectorSearch(r.NodeLabel, r.EmbeddingProperty, r.SimilarityFunction, r.Filters)
+		if err != nil {
+			return "", fmt.Errorf("retriever %s: %w", r.Name, err)
+		}
+		return search + thresholdClause(r.ScoreThreshold) + returnClause("", r.ReturnProperties), nil
+	case *VectorCypherRetriever:
+		if r.RetrievalQuery == "" {
+			return "", fmt.Errorf("retriever %s: retrieval query is required", r.Name)
+		}
+		search, err := vectorSearch(r.NodeLabel, r.EmbeddingProperty, r.SimilarityFunction, r.Filters)
+		if err != nil {
+			return "", fmt.Errorf("retriever %s: %w", r.Name, err)
+		}
+		return search + thresholdClause(r.ScoreThreshold) + returnClause(r.RetrievalQuery, nil), nil
+	case *HybridRetriever:
+		search, err := hybridSearch(r.NodeLabel, r.EmbeddingProperty, r.SimilarityFunction, r.Filters)
+		if err != nil {
+			return "", fmt.Errorf("retriever %s: %w", r.Name, err)
+		}
+		return search + returnClause("", r.ReturnProperties), nil
+	case *HybridCypherRetriever:
+		if r.RetrievalQuery == "" {
+			return "", fmt.Errorf("retriever %s: retrieval query is required", r.Name)
+		}
+		search, err := hybridSearch(r.NodeLabel, r.EmbeddingProperty, r.SimilarityFunction, r.Filters)
+		if err != nil {
+			return "", fmt.Errorf("retriever %s: %w", r.Name, err)
+		}
+		return search + returnClause(r.RetrievalQuery, nil), nil
+	case *WeaviateRetriever:
+		return externalMatchCall + returnClause(r.RetrievalQuery, nil), nil
+	case *PineconeRetriever:
+		return externalMatchCall + returnClause(r.RetrievalQuery, nil), nil
+	case *QdrantRetriever:
+		return externalMatchCall + returnClause(r.RetrievalQuery, nil), nil
+	case *Text2CypherRetriever:
+		return "", fmt.Errorf("retriever %s: Text2Cypher queries are generated by the LLM at search time", r.Name)
+	default:
+		return "", fmt.Errorf("unsupported retriever type %T", retriever)
+	}
+}
+
+// vectorSearch returns the vector index call, or an exact search over the
+// filtered nodes of label scored with the index's similarity function.
+func vectorSearch(label, embeddingProperty, similarityFunction string, filters *Filter) (string, error) {
+	if filters == nil {
+		return vectorSearchCall, nil
+	}
+	if label == "" {
+		return "", fmt.Errorf("filters require NodeLabel")
+	}
+	where, _, err := filterCypher(filters)
+	if err != nil {
+		return "", err
+	}
+	similarity, err := vectorSimilarity(similarityFunction)
+	if err != nil {
+		return "", err
+	}
+	if embeddingProperty == "" {
+		embeddingProperty = "embedding"
+	}
+	return fmt.Sprintf(filteredVectorSearch, graphrag.CypherName(label), where, graphrag.CypherName(embeddingProperty), similarity), nil
+}
+
+// vectorSimilarity returns the vector.similarity function matching a vector
+// index similarity function (default: cosine). Both return the normalized
+// scores db.index.vector.queryNodes returns.
+func vectorSimilarity(similarityFunction string) (string, error) {
+	switch strings.ToLower(similarityFunction) {
+	case "", "cosine":
+		return "cosine", nil
+	case "euclidean":
+		return "euclidean", nil
+	default:
+		return "", fmt.Errorf("unsupported similarity function %q, want cosine or euclidean", similarityFunction)
+	}
+}
+
+// hybridSearch returns the hybrid search, or the hybrid search over the
+// filtered nodes of label.
+func hybridSearch(label, embeddingProperty, similarityFunction string, filters *Filter) (string, error) {
+	if filters == nil {
+		return hybridSearchCall + hybridRankClause, nil
+	}
+	if label == "" {
+		return "", fmt.Errorf("filters require NodeLabel")
+	}
+	where, _, err := filterCypher(filters)
+	if err != nil {
+		return "", err
+	}
+	similarity, err := vectorSimilarity(similarityFunction)
+	if err != nil {
+		return "", err
+	}
+	if embeddingProperty == "" {
+		embeddingProperty = "embedding"
+	}
+	return fmt.Sprintf(filteredHybridSearchCall, graphrag.CypherName(label), where, graphrag.CypherName(embeddingProperty), similarity) + hybridRankClause, nil
+}
+
+// Parameters returns the query parameters fixed by the retriever
+// configuration. $queryText, $embedding and, for external retrievers,
+// $matches are added per search.
+func (s *RetrieverSerializer) Parameters(retriever Retriever) map[string]any {
+	params := make(map[string]any)
+
+	switch r := retriever.(type) {
+	case *VectorRetriever:
+		params["indexName"] = r.IndexName
+		params["topK"] = effectiveTopK(r.TopK)
+		if r.ScoreThreshold > 0 {
+			params["scoreThreshold"] = r.ScoreThreshold
+		}
+		addFilterParams(params, r.Filters)
+	case *VectorCypherRetriever:
+		params["indexName"] = r.IndexName
+		params["topK"] = effectiveTopK(r.TopK)
+		if r.ScoreThreshold > 0 {
+			params["scoreThreshold"] = r.ScoreThreshold
+		}
+		addFilterParams(params, r.Filters)
+	case *HybridRetriever:
+		addHybridParams(params, r.VectorIndexName, r.FulltextIndexName, r.TopK, r.VectorWeight, r.FulltextWeight)
+		addFilterParams(params, r.Filters)
+	case *HybridCypherRetriever:
+		addHybridParams(params, r.VectorIndexName, r.FulltextIndexName, r.TopK, r.VectorWeight, r.FulltextWeight)
+		addFilterParams(params, r.Filters)
+	case *WeaviateRetriever:
+		params["idProperty"] = externalIDProperty(r.IDProperty)
+	case *PineconeRetriever:
+		params["idProperty"] = externalIDProperty(r.IDProperty)
+	case *QdrantRetriever:
+		params["idProperty"] = externalIDProperty(r.IDProperty)
+	}
+
+	return params
+}
+
+// addHybridParams sets the hybrid search parameters. Equal weights are used
+// when neither weight is set.
+func addHybridParams(params map[string]any, vectorIndex, fulltextIndex string, topK int, vectorWeight, fulltextWeight float64) {
+	if vectorWeight == 0 && fulltextWeight == 0 {
+		vectorWeight, fulltextWeight = 0.5, 0.5
+	}
+	params["vectorIndexName"] = vectorIndex
+	params["fulltextIndexName"] = fulltextIndex
+	params["topK"] = effectiveTopK(topK)
+	params["vectorWeight"] = vectorWeight
+	params["fulltextWeight"] = fulltextWeight
+}
+
+// addFilterParams sets the $filter_N parameters of filters. Invalid filters
+// add none; ToCypher reports them.
+func addFilterParams(params map[string]any, filters *Filter) {
+	if filters == nil {
+		return
+	}
+	_, values, err := filterCypher(filters)
+	if err != nil {
+		return
+	}
+	for k, v := range values {
+		params[k] = v
+	}
+}
+
+func externalIDProperty(idProperty string) string {
+	if idProperty == "" {
+		return "id"
+	}
+	return idProperty
+}
+
+func thresholdClause(threshold float64) string {
+	if threshold <= 0 {
+		return "WITH node, score\n"
+	}
+	return "WITH node, score WHERE score >= $scoreThreshold\n"
+}
+
+// returnClause appends the retrieval query, or returns the node's element
+// ID, properties and score.
+func returnClause(retrievalQuery string, props []string) string {
+	if retrievalQuery != "" {
+		return strings.TrimSpace(retrievalQuery)
+	}
+	projection := "node {.*}"
+	if len(props) > 0 {
+		fields := make([]string, len(props))
+		for i, p := range props {
+			fields[i] = "." + p
+		}
+		projection = "node {" + strings.Join(fields, ", ") + "}"
+	}
+	return fmt.Sprintf("RETURN elementId(node) AS id, %s AS properties, score", projection)
+}
+
+// ParamsHeader renders params as a Neo4j Browser :params command so that
+// ToCypher output can be pasted and run directly.
+func ParamsHeader(params map[string]any) string {
+	keys := sortedKeys(params)
+	entries := make([]string, len(keys))
+	for i, k := range keys {
+		entries[i] = fmt.Sprintf("%s: %s", k, cypherLiteral(params[k]))
+	}
+	return ":params {" + strings.Join(entries, ", ") + "}"
+}
+
+func cypherLiteral(v any) string {
+	switch val := v.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(val, "'", "\\'") + "'"
+	case float64:
+		return graphrag.PyFloat(val)
+	case []any:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = cypherLiteral(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,159 @@
+package retrievers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRetrieverSerializer_ToCypher(t *testing.T) {
+	tests := []struct {
+		name      string
+		retriever Retriever
+		expected  []string
+	}{
+		{
+			name:      "vector",
+			retriever: &VectorRetriever{IndexName: "chunks", ScoreThreshold: 0.7, ReturnProperties: []string{"title", "text"}},
+			expected: []string{
+				"CALL db.index.vector.queryNodes($indexName, $topK, $embedding) YIELD node, score",
+				"WITH node, score WHERE score >= $scoreThreshold",
+				"RETURN elementId(node) AS id, node {.title, .text} AS properties, score",
+			},
+		},
+		{
+			name: "vector cypher",
+			retriever: &VectorCypherRetriever{
+				IndexName:      "chunks",
+				RetrievalQuery: "\n  MATCH (node)<-[:HAS_CHUNK]-(d:Document)\n  RETURN d.title AS title, score\n",
+			},
+			expected: []string{
+				"YIELD node, score\nWITH node, score\nMATCH (node)<-[:HAS_CHUNK]-(d:Document)",
+				"RETURN d.title AS title, score",
+			},
+		},
+		{
+			name:      "hybrid",
+			retriever: &HybridRetriever{VectorIndexName: "chunks", FulltextIndexName: "chunk_text"},
+			expected: []string{
+				"CALL db.index.vector.queryNodes($vectorIndexName, $topK, $embedding)",
+				"$vectorWeight * CASE WHEN maxScore = 0 THEN 0.0 ELSE hit.score / maxScore END AS score",
+				"UNION ALL",
+				"CALL db.index.fulltext.queryNodes($fulltextIndexName, $queryText, {limit: $topK})",
+				"$fulltextWeight * CASE WHEN maxScore = 0 THEN 0.0 ELSE hit.score / maxScore END AS score",
+				"WITH node, sum(score) AS score\nORDER BY score DESC\nLIMIT $topK",
+				"RETURN elementId(node) AS id, node {.*} AS properties, score",
+			},
+		},
+		{
+			name: "filtered vector",
+			retriever: &VectorRetriever{
+				IndexName:         "chunks",
+				NodeLabel:         "Chunk",
+				EmbeddingProperty: "vec",
+				Filters:           &Filter{Property: "lang", Eq: "en"},
+			},
+			expected: []string{
+				"MATCH (node:`Chunk`)\nWHERE node.`lang` = $filter_0 AND node.`vec` IS NOT NULL\n",
+				"WITH node, vector.similarity.cosine(node.`vec`, $embedding) AS score\nORDER BY score DESC\nLIMIT $topK",
+			},
+		},
+		{
+			name: "filtered vector euclidean",
+			retriever: &VectorCypherRetriever{
+				IndexName:          "chunks",
+				NodeLabel:          "Chunk",
+				SimilarityFunction: "EUCLIDEAN",
+				RetrievalQuery:     "RETURN node.text AS text, score",
+				Filters:            &Filter{Property: "lang", Eq: "en"},
+			},
+			expected: []string{
+				"WHERE node.`lang` = $filter_0 AND node.`embedding` IS NOT NULL\n",
+				"WITH node, vector.similarity.euclidean(node.`embedding`, $embedding) AS score",
+			},
+		},
+		{
+			name: "filtered hybrid",
+			retriever: &HybridRetriever{
+				VectorIndexName:   "chunks",
+				FulltextIndexName: "chunk_text",
+				NodeLabel:         "Chunk",
+				Filters:           &Filter{Property: "year", Gte: 2020},
+			},
+			expected: []string{
+				"  MATCH (node:`Chunk`)\n  WHERE node.`year` >= $filter_0 AND node.`embedding` IS NOT NULL\n",
+				"vector.similarity.cosine(node.`embedding`, $embedding) AS score\n  ORDER BY score DESC\n  LIMIT $topK\n",
+				"CALL db.index.fulltext.queryNodes($fulltextIndexName, $queryText) YIELD node, score\n  WHERE node:`Chunk` AND node.`year` >= $filter_0\n",
+				"WITH node, sum(score) AS score\nORDER BY score DESC\nLIMIT $topK",
+			},
+		},
+		{
+			name:      "external",
+			retriever: &QdrantRetriever{CollectionName: "docs", RetrievalQuery: "RETURN node.text AS text"},
+			expected: []string{
+				"UNWIND $matches AS match",
+				"MATCH (node) WHERE node[$idProperty] = match.id",
+				"RETURN node.text AS text",
+			},
+		},
+	}
+
+	s := NewRetrieverSerializer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cypher, err := s.ToCypher(tt.retriever)
+			if err != nil {
+				t.Fatalf("ToCypher failed: %v", err)
+			}
+			for _, e := range tt.expected {
+				if !strings.Contains(cypher, e) {
+					t.Errorf("expected %q in output, got:\n%s", e, cypher)
+				}
+			}
+		})
+	}
+}
+
+func TestRetrieverSerializer_ToCypher_Errors(t *testing.T) {
+	s := NewRetrieverSerializer()
+	if _, err := s.ToCypher(&Text2CypherRetriever{}); err == nil {
+		t.Error("expected error for Text2Cypher retriever")
+	}
+	if _, err := s.ToCypher(&HybridCypherRetriever{}); err == nil || !strings.Contains(err.Error(), "retrieval query is required") {
+		t.Errorf("expected missing retrieval query error, got %v", err)
+	}
+	if _, err := s.ToCypher(&VectorRetriever{Filters: &Filter{Property: "lang", Eq: "en"}}); err == nil || !strings.Contains(err.Error(), "filters require NodeLabel") {
+		t.Errorf("expected missing node label error, got %v", err)
+	}
+	if _, err := s.ToCypher(&VectorRetriever{NodeLabel: "Chunk", SimilarityFunction: "dot", Filters: &Filter{Property: "lang", Eq: "en"}}); err == nil || !strings.Contains(err.Error(), `unsupported similarity function "dot"`) {
+		t.Errorf("expected similarity function error, got %v", err)
+	}
+	if _, err := s.ToCypher(&HybridRetriever{Filters: &Filter{Property: "lang", Eq: "en"}}); err == nil || !strings.Contains(err.Error(), "filters require NodeLabel") {
+		t.Errorf("expected missing node label error, got %v", err)
+	}
+	if _, err := s.ToCypher(&HybridRetriever{NodeLabel: "Chunk", Filters: &Filter{Property: "lang"}}); err == nil || !strings.Contains(err.Error(), "has no operator") {
+		t.Errorf("expected invalid filter error, got %v", err)
+	}
+}
+
+func TestRetrieverSerializer_Parameters(t *testing.T) {
+	s := NewRetrieverSerializer()
+
+	params := s.Parameters(&HybridRetriever{VectorIndexName: "chunks", FulltextIndexName: "chunk_text", TopK: 7})
+	if params["topK"] != 7 || params["vectorWeight"] != 0.5 || params["fulltextWeight"] != 0.5 {
+		t.Errorf("unexpected hybrid params: %v", params)
+	}
+
+	filtered := ParamsHeader(s.Parameters(&VectorRetriever{
+		IndexName: "chunks",
+		NodeLabel: "Chunk",
+		Filters:   &Filter{Property: "lang", In: []any{"en", "de"}},
+	}))
+	if !strings.Contains(filtered, "filter_0: ['en', 'de']") {
+		t.Errorf("expected filter params in header, got %s", filtered)
+	}
+
+	header := ParamsHeader(s.Parameters(&VectorRetriever{IndexName: "chunks", ScoreThreshold: 0.7}))
+	if header != ":params {indexName: 'chunks', scoreThreshold: 0.7, topK: 5}" {
+		t.Errorf("unexpected params header: %s", header)
+	}
+}
@@ -0,0 +1,55 @@
+package retrievers
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+)
+
+// Embedder turns query text into a vector for similarity search.
+type Embedder interface {
+	// EmbedQuery returns the embedding of text.
+	EmbedQuery(ctx context.Context, text string) ([]float64, error)
+}
+
+// FakeEmbedder is a deterministic Embedder for tests and offline runs.
+//
+// Each lower-cased word is hashed into one of Dimensions buckets and the
+// resulting bag-of-words vector is L2-normalized, so texts that share words
+// have a positive cosine similarity and identical texts have similarity 1.
+type FakeEmbedder struct {
+	// Dimensions is the vector length (default: 16).
+	Dimensions int
+}
+
+// NewFakeEmbedder creates a FakeEmbedder producing vectors of the given length.
+func NewFakeEmbedder(dimensions int) *FakeEmbedder {
+	return &FakeEmbedder{Dimensions: dimensions}
+}
+
+// EmbedQuery returns the hashed bag-of-words vector of text.
+func (e *FakeEmbedder) EmbedQuery(_ context.Context, text string) ([]float64, error) {
+	dims := e.Dimensions
+	if dims <= 0 {
+		dims = 16
+	}
+
+	vec := make([]float64, dims)
+	for _, word := range tokenize(text) {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(word))
+		vec[h.Sum32()%uint32(dims)]++
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += v * v
+	}
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range vec {
+			vec[i] /= norm
+		}
+	}
+	return vec, nil
+}
@@ -0,0 +1,202 @@
+package retrievers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// GoldenQuestion is a query with the results a retriever should find.
+// A result is relevant if its ID is in ExpectedIDs or one of its record
+// values contains an ExpectedSnippet (case-insensitive).
+type GoldenQuestion struct {
+	Question         string   `json:"question"`
+	ExpectedIDs      []string `json:"expectedIds,omitempty"`
+	ExpectedSnippets []string `json:"expectedSnippets,omitempty"`
+}
+
+// GoldenSet is a named list of golden questions.
+type GoldenSet struct {
+	Name      string           `json:"name"`
+	Questions []GoldenQuestion `json:"questions"`
+}
+
+// LoadGoldenSet reads a GoldenSet from a JSON file.
+func LoadGoldenSet(path string) (*GoldenSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read golden set: %w", err)
+	}
+	var g GoldenSet
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("failed to parse golden set %s: %w", path, err)
+	}
+	if len(g.Questions) == 0 {
+		return nil, fmt.Errorf("golden set %s has no questions", path)
+	}
+	return &g, nil
+}
+
+// QuestionScore holds the metrics of one golden question.
+type QuestionScore struct {
+	Question string
+	Recall   float64
+	MRR      float64
+	NDCG     float64
+}
+
+// EvalReport holds the metrics of a retriever over a golden set. Recall,
+// MRR and NDCG are means over all questions, computed at K.
+type EvalReport struct {
+	Retriever string
+	K         int
+	Recall    float64
+	MRR       float64
+	NDCG      float64
+	Questions []QuestionScore
+}
+
+// Evaluate runs retriever through searcher for every question in golden and
+// scores the first k results (default: the retriever's TopK).
+func Evaluate(ctx context.Context, searcher Searcher, retriever Retriever, golden *GoldenSet, k int) (*EvalReport, error) {
+	if k <= 0 {
+		k = effectiveTopK(retrieverTopK(retriever))
+	}
+
+	report := &EvalReport{Retriever: retriever.RetrieverName(), K: k}
+	for _, q := range golden.Questions {
+		expected := len(q.ExpectedIDs) + len(q.ExpectedSnippets)
+		if expected == 0 {
+			return nil, fmt.Errorf("question %q has no expected IDs or snippets", q.Question)
+		}
+
+		results, err := searcher.Search(ctx, retriever, q.Question)
+		if err != nil {
+			return nil, fmt.Errorf("question %q: %w", q.Question, err)
+		}
+		if len(results) > k {
+			results = results[:k]
+		}
+
+		score := scoreQuestion(q, results, expected, k)
+		report.Questions = append(report.Questions, score)
+		report.Recall += score.Recall
+		report.MRR += score.MRR
+		report.NDCG += score.NDCG
+	}
+
+	n := float64(len(report.Questions))
+	if n > 0 {
+		report.Recall /= n
+		report.MRR /= n
+		report.NDCG /= n
+	}
+	return report, nil
+}
+
+// scoreQuestion computes recall@k, reciprocal rank and nDCG@k with binary
+// relevance. Each expected ID or snippet is credited to at most one result.
+func scoreQuestion(q GoldenQuestion, results []Result, expected, k int) QuestionScore {
+	found := make(map[string]bool)
+	score := QuestionScore{Question: q.Question}
+
+	var dcg float64
+	for i, r := range results {
+		match := matchExpected(q, r, found)
+		if match == "" {
+			continue
+		}
+		found[match] = true
+		if score.MRR == 0 {
+			score.MRR = 1 / float64(i+1)
+		}
+		dcg += 1 / math.Log2(float64(i+2))
+	}
+
+	var idcg float64
+	for i := 0; i < expected && i < k; i++ {
+		idcg += 1 / math.Log2(float64(i+2))
+	}
+
+	score.Recall = float64(len(found)) / float64(expected)
+	score.NDCG = dcg / idcg
+	return score
+}
+
+// matchExpected returns the key of the first expected ID or snippet that r
+// matches and that no earlier result matched, or "".
+func matchExpected(q GoldenQuestion, r Result, found map[string]bool) string {
+	for _, id := range q.ExpectedIDs {
+		key := "id:" + id
+		if r.ID == id && !found[key] {
+			return key
+		}
+	}
+	for _, snippet := range q.ExpectedSnippets {
+		key := "snippet:" + snippet
+		if !found[key] && recordContains(r.Record, snippet) {
+			return key
+		}
+	}
+	return ""
+}
+
+func recordContains(record map[string]any, snippet string) bool {
+	snippet = strings.ToLower(snippet)
+	for _, v := range record {
+		if s, ok := v.(string); ok && strings.Contains(strings.ToLower(s), snippet) {
+			return true
+		}
+	}
+	return false
+}
+
+func retrieverTopK(retriever Retriever) int {
+	switch r := retriever.(type) {
+	case *VectorRetriever:
+		return r.TopK
+	case *VectorCypherRetriever:
+		return r.TopK
+	case *HybridRetriever:
+		return r.TopK
+	case *HybridCypherRetriever:
+		return r.TopK
+	}
+	return 0
+}
+
+// WriteReport writes the per-question metrics and means of report to w.
+func WriteReport(w io.Writer, report *EvalReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "QUESTION\tRECALL@%d\tMRR\tNDCG@%d\n", report.K, report.K)
+	for _, q := range report.Questions {
+		fmt.Fprintf(tw, "%s\t%.3f\t%.3f\t%.3f\n", q.Question, q.Recall, q.MRR, q.NDCG)
+	}
+	fmt.Fprintf(tw, "MEAN (%s)\t%.3f\t%.3f\t%.3f\n", report.Retriever, report.Recall, report.MRR, report.NDCG)
+	return tw.Flush()
+}
+
+// WriteComparison writes the mean metrics of base and candidate side by side
+// with the change from base to candidate. Each report is measured at its own K.
+func WriteComparison(w io.Writer, base, candidate *EvalReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "METRIC\t%s\t%s\tDELTA\n", base.Retriever, candidate.Retriever)
+	fmt.Fprintf(tw, "k\t%d\t%d\t%+d\n", base.K, candidate.K, candidate.K-base.K)
+	rows := []struct {
+		name      string
+		base, cur float64
+	}{
+		{"recall", base.Recall, candidate.Recall},
+		{"mrr", base.MRR, candidate.MRR},
+		{"ndcg", base.NDCG, candidate.NDCG},
+	}
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%.3f\t%.3f\t%+.3f\n", r.name, r.base, r.cur, r.cur-r.base)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,164 @@
+package retrievers
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+func loadEvalFixtures(t *testing.T) (*Corpus, *GoldenSet) {
+	t.Helper()
+	corpus, err := LoadCorpus("testdata/eval/corpus.json")
+	if err != nil {
+		t.Fatalf("LoadCorpus failed: %v", err)
+	}
+	golden, err := LoadGoldenSet("testdata/eval/golden.json")
+	if err != nil {
+		t.Fatalf("LoadGoldenSet failed: %v", err)
+	}
+	return corpus, golden
+}
+
+func resultIDs(results []Result) string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestCorpus_Search(t *testing.T) {
+	corpus, _ := loadEvalFixtures(t)
+	ctx := context.Background()
+	question := "Which algorithm ranks nodes by relationships?"
+
+	tests := []struct {
+		name      string
+		retriever Retriever
+		expected  string
+	}{
+		{"vector", &VectorRetriever{TopK: 3}, "c2,c3,c1"},
+		{"vector threshold", &VectorRetriever{TopK: 3, ScoreThreshold: 0.85}, "c2,c3"},
+		{"vector filtered", &VectorRetriever{TopK: 2, Filters: &Filter{Property: "year", Gte: 2021}}, "c2,c3"},
+		{"vector excluded", &VectorRetriever{TopK: 2, Filters: &Filter{Property: "year", Nin: []any{2021}}}, "c3,c1"},
+		{"hybrid", &HybridRetriever{TopK: 2}, "c3,c2"},
+		{"hybrid filtered", &HybridRetriever{TopK: 2, Filters: &Filter{Property: "year", Lt: 2022}}, "c1,c2"},
+		{"vector weighted hybrid", &HybridRetriever{TopK: 2, VectorWeight: 1}, "c2,c3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := corpus.Search(ctx, tt.retriever, question)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if got := resultIDs(results); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCorpus_Search_NoEmbedding(t *testing.T) {
+	corpus := &Corpus{
+		Documents: []Document{
+			{ID: "embedded", Text: "graph", Embedding: []float64{1, 0}},
+			{ID: "pending", Text: "graph"},
+		},
+		Queries: map[string][]float64{"graph": {1, 0}},
+	}
+
+	tests := []struct {
+		name      string
+		retriever Retriever
+		expected  string
+	}{
+		{"vector", &VectorRetriever{TopK: 5}, "embedded"},
+		{"vector filtered", &VectorRetriever{TopK: 5, Filters: &Filter{Property: "lang", Ne: "de"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := corpus.Search(context.Background(), tt.retriever, "graph")
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if got := resultIDs(results); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCorpus_Search_Errors(t *testing.T) {
+	corpus, _ := loadEvalFixtures(t)
+	ctx := context.Background()
+
+	if _, err := corpus.Search(ctx, &Text2CypherRetriever{}, "anything"); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("expected unsupported type error, got %v", err)
+	}
+	if _, err := corpus.Search(ctx, &VectorRetriever{}, "unknown question"); err == nil || !strings.Contains(err.Error(), "no precomputed embedding") {
+		t.Errorf("expected missing embedding error, got %v", err)
+	}
+
+	corpus.Embedder = NewFakeEmbedder(3)
+	if _, err := corpus.Search(ctx, &VectorRetriever{}, "unknown question"); err != nil {
+		t.Errorf("expected embedder fallback, got %v", err)
+	}
+}
+
+func TestScoreQuestion(t *testing.T) {
+	q := GoldenQuestion{Question: "q", ExpectedIDs: []string{"a", "b"}}
+	results := []Result{{ID: "x"}, {ID: "a"}, {ID: "a"}}
+
+	score := scoreQuestion(q, results, 2, 3)
+	if score.Recall != 0.5 || score.MRR != 0.5 {
+		t.Errorf("unexpected recall/MRR: %+v", score)
+	}
+	// DCG counts the first "a" only; the ideal ranking has both hits first.
+	want := (1 / math.Log2(3)) / (1 + 1/math.Log2(3))
+	if math.Abs(score.NDCG-want) > 1e-9 {
+		t.Errorf("expected nDCG %v, got %v", want, score.NDCG)
+	}
+}
+
+func TestEvaluate_Comparison(t *testing.T) {
+	corpus, golden := loadEvalFixtures(t)
+	ctx := context.Background()
+
+	narrow, err := Evaluate(ctx, corpus, &VectorRetriever{BaseRetriever: BaseRetriever{Name: "narrow"}, TopK: 1}, golden, 0)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	wide, err := Evaluate(ctx, corpus, &VectorRetriever{BaseRetriever: BaseRetriever{Name: "wide"}, TopK: 3}, golden, 0)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+
+	if narrow.K != 1 || narrow.Recall != 0.75 || narrow.MRR != 1 {
+		t.Errorf("unexpected narrow report: %+v", narrow)
+	}
+	if wide.K != 3 || wide.Recall != 1 {
+		t.Errorf("unexpected wide report: %+v", wide)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteComparison(&buf, narrow, wide); err != nil {
+		t.Fatalf("WriteComparison failed: %v", err)
+	}
+	for _, e := range []string{"narrow", "wide", "recall  0.750", "+0.250"} {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("expected %q in comparison, got:\n%s", e, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := WriteReport(&buf, wide); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "How does Neo4j store data?") || !strings.Contains(buf.String(), "MEAN (wide)") {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+}
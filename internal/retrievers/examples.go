@@ -0,0 +1,258 @@
+package retrievers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+var (
+	stringLiteralPattern = regexp.MustCompile(`'(?:[^'\\]|\\.)*'|"(?:[^"\\]|\\.)*"`)
+	nodePatternRe        = regexp.MustCompile(`\(\s*(\w*)\s*((?::\s*\w+\s*)*)(\{[^{}]*\})?\s*\)`)
+	relPatternRe         = regexp.MustCompile(`\[\s*(\w*)\s*:\s*(\w+(?:\s*\|\s*:?\s*\w+)*)\s*(?:\*[\d.]*)?\s*(\{[^{}]*\})?\s*\]`)
+	pathStepRe           = regexp.MustCompile(`\(([^()]*)\)\s*(<?)-\s*\[([^\[\]]*)\]\s*-(>?)\s*\(([^()]*)\)`)
+	propertyAccessRe     = regexp.MustCompile(`\b([A-Za-z_]\w*)\.(\w+)`)
+	mapKeyRe             = regexp.MustCompile(`(\w+)\s*:`)
+)
+
+// nodeRef is a labeled node pattern such as (m:Movie {title: ...}).
+type nodeRef struct {
+	variable string
+	label    string
+	props    []string
+}
+
+// relRef is a typed relationship pattern such as [r:ACTED_IN|DIRECTED].
+type relRef struct {
+	variable string
+	relType  string
+	props    []string
+}
+
+// pathStep is a (source)-[rel]->(target) step with known endpoint labels.
+type pathStep struct {
+	relType string
+	source  string
+	target  string
+}
+
+// cypherRefs holds the schema elements a Cypher query refers to.
+type cypherRefs struct {
+	nodes []nodeRef
+	rels  []relRef
+	steps []pathStep
+	// props maps a variable to the properties read from it.
+	props map[string][]string
+}
+
+// parseCypherRefs extracts the node labels, relationship types, directions
+// and properties used by cypher. It is a pattern scan rather than a parser:
+// string literals are ignored, and properties are attributed only to
+// variables bound by a labeled pattern.
+func parseCypherRefs(cypher string) cypherRefs {
+	text := stringLiteralPattern.ReplaceAllString(cypher, "''")
+	text = strings.ReplaceAll(text, "`", "")
+
+	refs := cypherRefs{props: make(map[string][]string)}
+	for _, m := range nodePatternRe.FindAllStringSubmatch(text, -1) {
+		labels := splitLabels(m[2], ":")
+		for _, label := range labels {
+			refs.nodes = append(refs.nodes, nodeRef{variable: m[1], label: label, props: mapKeys(m[3])})
+		}
+	}
+	for _, m := range relPatternRe.FindAllStringSubmatch(text, -1) {
+		for _, relType := range splitLabels(m[2], "|") {
+			refs.rels = append(refs.rels, relRef{variable: m[1], relType: relType, props: mapKeys(m[3])})
+		}
+	}
+
+	bound := refs.bindings()
+	// Steps share their end nodes, so each search resumes at the target of
+	// the previous step.
+	for pos := 0; pos < len(text); {
+		loc := pathStepRe.FindStringSubmatchIndex(text[pos:])
+		if loc == nil {
+			break
+		}
+		m := make([]string, len(loc)/2)
+		for i := range m {
+			m[i] = text[pos+loc[2*i] : pos+loc[2*i+1]]
+		}
+		source, target := patternLabel(m[1], bound), patternLabel(m[5], bound)
+		if m[2] == "<" && m[4] == "" {
+			source, target = target, source
+		}
+		if rel := relPatternRe.FindStringSubmatch("[" + m[3] + "]"); rel != nil && m[2] != m[4] {
+			for _, relType := range splitLabels(rel[2], "|") {
+				refs.steps = append(refs.steps, pathStep{relType: relType, source: source, target: target})
+			}
+		}
+		pos += loc[10] - 1
+	}
+
+	for _, m := range propertyAccessRe.FindAllStringSubmatch(text, -1) {
+		if _, ok := bound[m[1]]; ok {
+			refs.props[m[1]] = append(refs.props[m[1]], m[2])
+		}
+	}
+	return refs
+}
+
+// bindings maps each variable bound by a labeled node or typed relationship
+// pattern to its first label or type.
+func (r cypherRefs) bindings() map[string]string {
+	bound := make(map[string]string)
+	for _, n := range r.nodes {
+		if _, ok := bound[n.variable]; n.variable != "" && !ok {
+			bound[n.variable] = n.label
+		}
+	}
+	for _, rel := range r.rels {
+		if _, ok := bound[rel.variable]; rel.variable != "" && !ok {
+			bound[rel.variable] = rel.relType
+		}
+	}
+	return bound
+}
+
+// patternLabel returns the label of a node pattern body such as "m:Movie",
+// or of its variable if bound elsewhere.
+func patternLabel(body string, bound map[string]string) string {
+	m := nodePatternRe.FindStringSubmatch("(" + body + ")")
+	if m == nil {
+		return ""
+	}
+	if labels := splitLabels(m[2], ":"); len(labels) > 0 {
+		return labels[0]
+	}
+	return bound[m[1]]
+}
+
+func splitLabels(text, sep string) []string {
+	var labels []string
+	for _, part := range strings.Split(text, sep) {
+		if part = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part), ":")); part != "" {
+			labels = append(labels, part)
+		}
+	}
+	return labels
+}
+
+// mapKeys returns the keys of an inline property map such as {title: $t}.
+func mapKeys(m string) []string {
+	var keys []string
+	for _, k := range mapKeyRe.FindAllStringSubmatch(m, -1) {
+		keys = append(keys, k[1])
+	}
+	return keys
+}
+
+// ValidateExamples checks that the Cypher of every example uses only the
+// node labels, relationship types, directions and properties declared in s.
+func ValidateExamples(s *schema.Schema, examples []CypherExample) []error {
+	if s == nil {
+		return nil
+	}
+	nodes := make(map[string]*schema.NodeType, len(s.Nodes))
+	for _, n := range s.Nodes {
+		nodes[n.Label] = n
+	}
+	rels := make(map[string][]*schema.RelationshipType, len(s.Relationships))
+	for _, r := range s.Relationships {
+		rels[r.Label] = append(rels[r.Label], r)
+	}
+
+	var errs []error
+	for _, ex := range examples {
+		refs := parseCypherRefs(ex.Cypher)
+		seen := make(map[string]bool)
+		report := func(format string, args ...any) {
+			msg := fmt.Sprintf(format, args...)
+			if !seen[msg] {
+				seen[msg] = true
+				errs = append(errs, fmt.Errorf("example %q: %s", ex.Question, msg))
+			}
+		}
+
+		for _, n := range refs.nodes {
+			node, ok := nodes[n.label]
+			if !ok {
+				report("node label %s is not in the schema", n.label)
+				continue
+			}
+			for _, p := range n.props {
+				if !hasProperty(node.Properties, p) {
+					report("property %s is not defined on %s", p, n.label)
+				}
+			}
+		}
+		for _, r := range refs.rels {
+			defs, ok := rels[r.relType]
+			if !ok {
+				report("relationship type %s is not in the schema", r.relType)
+				continue
+			}
+			for _, p := range r.props {
+				if !hasProperty(defs[0].Properties, p) {
+					report("property %s is not defined on %s", p, r.relType)
+				}
+			}
+		}
+		for _, step := range refs.steps {
+			defs, ok := rels[step.relType]
+			if !ok || step.source == "" || step.target == "" {
+				continue
+			}
+			if !hasDirection(defs, step.source, step.target) {
+				report("relationship %s does not go from %s to %s", step.relType, step.source, step.target)
+			}
+		}
+
+		bound := refs.bindings()
+		for _, v := range sortedVariables(refs.props) {
+			label := bound[v]
+			var props []schema.Property
+			if node, ok := nodes[label]; ok {
+				props = node.Properties
+			} else if defs, ok := rels[label]; ok {
+				props = defs[0].Properties
+			} else {
+				continue
+			}
+			for _, p := range refs.props[v] {
+				if !hasProperty(props, p) {
+					report("property %s is not defined on %s", p, label)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func hasProperty(props []schema.Property, name string) bool {
+	for _, p := range props {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDirection(defs []*schema.RelationshipType, source, target string) bool {
+	for _, d := range defs {
+		if d.Source == source && d.Target == target {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedVariables(m map[string][]string) []string {
+	keys := make(map[string]any, len(m))
+	for k := range m {
+		keys[k] = nil
+	}
+	return sortedKeys(keys)
+}
@@ -0,0 +1,397 @@
+package retrievers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+// Filter is a metadata filter on the properties of retrieved nodes, the
+// typed form of a neo4j-graphrag filter.
+//
+// The operator fields compare Property with a value; every operator set on
+// a Filter, and every filter in And, must hold. Or holds if any of its
+// filters does.
+//
+//	&retrievers.Filter{
+//		Property: "year",
+//		Gte:      2020,
+//		Or: []retrievers.Filter{
+//			{Property: "lang", In: []any{"en", "de"}},
+//			{Property: "title", ILike: "graph"},
+//		},
+//	}
+type Filter struct {
+	// Property is the node property compared by the operator fields.
+	Property string
+
+	// Eq matches values equal to Eq ($eq).
+	Eq any
+	// Ne matches values not equal to Ne ($ne).
+	Ne any
+	// Lt matches values less than Lt ($lt).
+	Lt any
+	// Lte matches values less than or equal to Lte ($lte).
+	Lte any
+	// Gt matches values greater than Gt ($gt).
+	Gt any
+	// Gte matches values greater than or equal to Gte ($gte).
+	Gte any
+	// Between matches values within [low, high] ($between).
+	Between []any
+	// In matches values in the list ($in).
+	In []any
+	// Nin matches values not in the list ($nin).
+	Nin []any
+	// Like matches strings containing Like; % wildcards are ignored ($like).
+	Like string
+	// ILike is a case-insensitive Like ($ilike).
+	ILike string
+
+	// And holds filters that must all match ($and).
+	And []Filter
+	// Or holds filters of which at least one must match ($or).
+	Or []Filter
+}
+
+// filterComparison is one operator set on a Filter.
+type filterComparison struct {
+	op    string
+	value any
+}
+
+// comparisons returns the operators set on f in a fixed order.
+func (f *Filter) comparisons() []filterComparison {
+	var cmps []filterComparison
+	add := func(op string, value any, set bool) {
+		if set {
+			cmps = append(cmps, filterComparison{op, value})
+		}
+	}
+	add("$eq", f.Eq, f.Eq != nil)
+	add("$ne", f.Ne, f.Ne != nil)
+	add("$lt", f.Lt, f.Lt != nil)
+	add("$lte", f.Lte, f.Lte != nil)
+	add("$gt", f.Gt, f.Gt != nil)
+	add("$gte", f.Gte, f.Gte != nil)
+	add("$between", f.Between, f.Between != nil)
+	add("$in", f.In, f.In != nil)
+	add("$nin", f.Nin, f.Nin != nil)
+	add("$like", f.Like, f.Like != "")
+	add("$ilike", f.ILike, f.ILike != "")
+	return cmps
+}
+
+// Validate checks the structure of f and, if node is not nil, that every
+// property exists on node and every value suits the property type.
+func (f *Filter) Validate(node *schema.NodeType) []error {
+	var errs []error
+	f.validate(node, &errs)
+	return errs
+}
+
+func (f *Filter) validate(node *schema.NodeType, errs *[]error) {
+	cmps := f.comparisons()
+	if len(cmps) == 0 && len(f.And) == 0 && len(f.Or) == 0 {
+		*errs = append(*errs, fmt.Errorf("filter on %q has no operator", f.Property))
+	}
+
+	if len(cmps) > 0 {
+		var prop *schema.Property
+		switch {
+		case f.Property == "":
+			*errs = append(*errs, fmt.Errorf("filter operator %s has no property", cmps[0].op))
+		case node != nil:
+			prop = findProperty(node, f.Property)
+			if prop == nil {
+				*errs = append(*errs, fmt.Errorf("property %q is not defined on %s", f.Property, node.Label))
+			}
+		}
+		for _, c := range cmps {
+			if err := validateComparison(f.Property, prop, c); err != nil {
+				*errs = append(*errs, err)
+			}
+		}
+	}
+
+	for i := range f.And {
+		f.And[i].validate(node, errs)
+	}
+	for i := range f.Or {
+		f.Or[i].validate(node, errs)
+	}
+}
+
+func findProperty(node *schema.NodeType, name string) *schema.Property {
+	for i := range node.Properties {
+		if node.Properties[i].Name == name {
+			return &node.Properties[i]
+		}
+	}
+	return nil
+}
+
+// validateComparison checks the operand shape of c and, when prop is known,
+// that its values suit the property type.
+func validateComparison(name string, prop *schema.Property, c filterComparison) error {
+	values := []any{c.value}
+	switch c.op {
+	case "$between":
+		values = c.value.([]any)
+		if len(values) != 2 {
+			return fmt.Errorf("$between on %q needs two values, got %d", name, len(values))
+		}
+	case "$in", "$nin":
+		values = c.value.([]any)
+	}
+	if prop == nil {
+		return nil
+	}
+
+	switch c.op {
+	case "$like", "$ilike":
+		if prop.Type != schema.STRING {
+			return fmt.Errorf("%s on %q needs a STRING property, got %s", c.op, name, prop.Type)
+		}
+		return nil
+	case "$lt", "$lte", "$gt", "$gte", "$between":
+		if prop.Type == schema.BOOLEAN || isListType(prop.Type) || prop.Type == schema.POINT {
+			return fmt.Errorf("%s on %q cannot order %s values", c.op, name, prop.Type)
+		}
+	}
+
+	for _, v := range values {
+		if !valueMatchesType(v, prop.Type) {
+			return fmt.Errorf("%s on %q: value %v does not match property type %s", c.op, name, v, prop.Type)
+		}
+	}
+	return nil
+}
+
+func isListType(t schema.PropertyType) bool {
+	return t == schema.LIST_STRING || t == schema.LIST_INTEGER || t == schema.LIST_FLOAT
+}
+
+// valueMatchesType reports whether v can be compared with a property of
+// type t. Dates are given as ISO 8601 strings; list properties are compared
+// with their element type.
+func valueMatchesType(v any, t schema.PropertyType) bool {
+	switch t {
+	case schema.STRING, schema.LIST_STRING, schema.DATE, schema.DATETIME:
+		_, ok := v.(string)
+		return ok
+	case schema.INTEGER, schema.LIST_INTEGER:
+		f, ok := toFloat(v)
+		return ok && f == math.Trunc(f)
+	case schema.FLOAT, schema.LIST_FLOAT:
+		_, ok := toFloat(v)
+		return ok
+	case schema.BOOLEAN:
+		_, ok := v.(bool)
+		return ok
+	}
+	return true
+}
+
+// ToMap returns f in neo4j-graphrag filter syntax, such as
+// {"year": {"$gte": 2020}, "$or": [...]}.
+func (f *Filter) ToMap() map[string]any {
+	m := make(map[string]any)
+	if cmps := f.comparisons(); len(cmps) > 0 {
+		ops := make(map[string]any, len(cmps))
+		for _, c := range cmps {
+			ops[c.op] = c.value
+		}
+		m[f.Property] = ops
+	}
+	if len(f.And) > 0 {
+		m["$and"] = filterMaps(f.And)
+	}
+	if len(f.Or) > 0 {
+		m["$or"] = filterMaps(f.Or)
+	}
+	return m
+}
+
+func filterMaps(filters []Filter) []map[string]any {
+	maps := make([]map[string]any, len(filters))
+	for i := range filters {
+		maps[i] = filters[i].ToMap()
+	}
+	return maps
+}
+
+// filterCypher renders f as a Cypher predicate on node. Values are passed
+// as $filter_N parameters, which are returned with the predicate.
+func filterCypher(f *Filter) (string, map[string]any, error) {
+	if errs := f.Validate(nil); len(errs) > 0 {
+		return "", nil, errs[0]
+	}
+	params := make(map[string]any)
+	return f.cypher(params), params, nil
+}
+
+func (f *Filter) cypher(params map[string]any) string {
+	var conds []string
+	prop := "node." + graphrag.CypherName(f.Property)
+	param := func(v any) string {
+		name := fmt.Sprintf("filter_%d", len(params))
+		params[name] = v
+		return "$" + name
+	}
+
+	for _, c := range f.comparisons() {
+		switch c.op {
+		case "$eq":
+			conds = append(conds, prop+" = "+param(c.value))
+		case "$ne":
+			conds = append(conds, prop+" <> "+param(c.value))
+		case "$lt":
+			conds = append(conds, prop+" < "+param(c.value))
+		case "$lte":
+			conds = append(conds, prop+" <= "+param(c.value))
+		case "$gt":
+			conds = append(conds, prop+" > "+param(c.value))
+		case "$gte":
+			conds = append(conds, prop+" >= "+param(c.value))
+		case "$between":
+			bounds := c.value.([]any)
+			conds = append(conds, param(bounds[0])+" <= "+prop+" <= "+param(bounds[1]))
+		case "$in":
+			conds = append(conds, prop+" IN "+param(c.value))
+		case "$nin":
+			conds = append(conds, "NOT "+prop+" IN "+param(c.value))
+		case "$like":
+			conds = append(conds, prop+" CONTAINS "+param(strings.ReplaceAll(f.Like, "%", "")))
+		case "$ilike":
+			conds = append(conds, "toLower("+prop+") CONTAINS "+param(strings.ToLower(strings.ReplaceAll(f.ILike, "%", ""))))
+		}
+	}
+	for i := range f.And {
+		conds = append(conds, "("+f.And[i].cypher(params)+")")
+	}
+	if len(f.Or) > 0 {
+		alts := make([]string, len(f.Or))
+		for i := range f.Or {
+			alts[i] = "(" + f.Or[i].cypher(params) + ")"
+		}
+		conds = append(conds, "("+strings.Join(alts, " OR ")+")")
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// Matches reports whether a node with the given properties passes f.
+func (f *Filter) Matches(props map[string]any) bool {
+	for _, c := range f.comparisons() {
+		if !matchComparison(c, props[f.Property]) {
+			return false
+		}
+	}
+	for i := range f.And {
+		if !f.And[i].Matches(props) {
+			return false
+		}
+	}
+	if len(f.Or) > 0 {
+		for i := range f.Or {
+			if f.Or[i].Matches(props) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// matchComparison reports whether actual passes c. A missing property fails
+// every operator, like the Cypher predicate, which evaluates to null and
+// drops the row. Values of different kinds are unequal, as in Cypher.
+func matchComparison(c filterComparison, actual any) bool {
+	if actual == nil {
+		return false
+	}
+	switch c.op {
+	case "$between":
+		bounds := c.value.([]any)
+		low, ok1 := compareValues(actual, bounds[0])
+		high, ok2 := compareValues(actual, bounds[1])
+		return ok1 && ok2 && low >= 0 && high <= 0
+	case "$in", "$nin":
+		found := false
+		for _, v := range c.value.([]any) {
+			if cmp, ok := compareValues(actual, v); ok && cmp == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (c.op == "$in")
+	case "$like", "$ilike":
+		s, ok := actual.(string)
+		sub := strings.ReplaceAll(c.value.(string), "%", "")
+		if c.op == "$ilike" {
+			s, sub = strings.ToLower(s), strings.ToLower(sub)
+		}
+		return ok && strings.Contains(s, sub)
+	}
+
+	cmp, ok := compareValues(actual, c.value)
+	if !ok {
+		return c.op == "$ne"
+	}
+	switch c.op {
+	case "$eq":
+		return cmp == 0
+	case "$ne":
+		return cmp != 0
+	case "$lt":
+		return cmp < 0
+	case "$lte":
+		return cmp <= 0
+	case "$gt":
+		return cmp > 0
+	case "$gte":
+		return cmp >= 0
+	}
+	return false
+}
+
+// compareValues orders numbers numerically, and strings and booleans by
+// their string form. It reports false for missing values and values of
+// different kinds, which Cypher does not order either.
+func compareValues(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	fa, aNum := toFloat(a)
+	fb, bNum := toFloat(b)
+	switch {
+	case aNum && bNum:
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		}
+		return 0, true
+	case aNum || bNum:
+		return 0, false
+	}
+	if fmt.Sprintf("%T", a) != fmt.Sprintf("%T", b) {
+		return 0, false
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b)), true
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
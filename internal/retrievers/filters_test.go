@@ -0,0 +1,156 @@
+package retrievers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+var documentType = &schema.NodeType{
+	Label: "Document",
+	Properties: []schema.Property{
+		{Name: "title", Type: schema.STRING},
+		{Name: "year", Type: schema.INTEGER},
+		{Name: "draft", Type: schema.BOOLEAN},
+		{Name: "published", Type: schema.DATE},
+	},
+}
+
+func TestFilter_Cypher(t *testing.T) {
+	f := &Filter{
+		Property: "year",
+		Between:  []any{2020, 2024},
+		Or: []Filter{
+			{Property: "lang", In: []any{"en", "de"}},
+			{Property: "title", ILike: "%Graph%"},
+		},
+		And: []Filter{{Property: "draft", Ne: true}},
+	}
+
+	where, params, err := filterCypher(f)
+	if err != nil {
+		t.Fatalf("filterCypher failed: %v", err)
+	}
+
+	expected := "$filter_0 <= node.`year` <= $filter_1 AND (node.`draft` <> $filter_2) AND " +
+		"((node.`lang` IN $filter_3) OR (toLower(node.`title`) CONTAINS $filter_4))"
+	if where != expected {
+		t.Errorf("unexpected predicate:\n got: %s\nwant: %s", where, expected)
+	}
+	if params["filter_1"] != 2024 || params["filter_4"] != "graph" {
+		t.Errorf("unexpected params: %v", params)
+	}
+}
+
+func TestFilter_ToMap(t *testing.T) {
+	f := &Filter{Property: "year", Gte: 2020, Lt: 2024, Or: []Filter{{Property: "draft", Eq: false}}}
+
+	m := f.ToMap()
+	ops, _ := m["year"].(map[string]any)
+	if ops["$gte"] != 2020 || ops["$lt"] != 2024 {
+		t.Errorf("unexpected operators: %v", m["year"])
+	}
+	if or, _ := m["$or"].([]map[string]any); len(or) != 1 {
+		t.Errorf("unexpected $or: %v", m["$or"])
+	}
+}
+
+func TestFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *Filter
+		wantErr string
+	}{
+		{"valid", &Filter{Property: "year", Gte: 2020, And: []Filter{{Property: "published", Lt: "2024-01-01"}}}, ""},
+		{"unknown property", &Filter{Property: "author", Eq: "Ada"}, `property "author" is not defined on Document`},
+		{"wrong type", &Filter{Property: "year", Eq: "2020"}, "does not match property type INTEGER"},
+		{"fractional integer", &Filter{Property: "year", In: []any{2020, 2020.5}}, "does not match property type INTEGER"},
+		{"like on integer", &Filter{Property: "year", Like: "20"}, "needs a STRING property"},
+		{"ordered boolean", &Filter{Property: "draft", Gt: false}, "cannot order BOOLEAN"},
+		{"between arity", &Filter{Property: "year", Between: []any{2020}}, "needs two values"},
+		{"no operator", &Filter{Property: "year"}, "has no operator"},
+		{"no property", &Filter{Eq: 1}, "has no property"},
+		{"nested", &Filter{Or: []Filter{{Property: "title", Eq: 1}}}, "does not match property type STRING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.filter.Validate(documentType)
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) == 0 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilter_Matches(t *testing.T) {
+	props := map[string]any{"year": 2021, "lang": "en", "title": "Graph Databases"}
+
+	tests := []struct {
+		filter *Filter
+		want   bool
+	}{
+		{&Filter{Property: "year", Gte: 2020.0, Lt: 2022}, true},
+		{&Filter{Property: "year", Between: []any{2022, 2024}}, false},
+		{&Filter{Property: "lang", Nin: []any{"de"}}, true},
+		{&Filter{Property: "title", Like: "Graph%"}, true},
+		{&Filter{Property: "title", Like: "graph"}, false},
+		{&Filter{Property: "title", ILike: "graph"}, true},
+		{&Filter{Property: "missing", Eq: "x"}, false},
+		{&Filter{Property: "year", Ne: "2021"}, true},
+		{&Filter{Property: "year", Eq: "2021"}, false},
+		{&Filter{Or: []Filter{{Property: "lang", Eq: "de"}, {Property: "year", Eq: 2021}}}, true},
+		{&Filter{Property: "year", Gt: 2000, And: []Filter{{Property: "lang", Eq: "de"}}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.Matches(props); got != tt.want {
+			t.Errorf("Matches(%+v) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+// TestFilter_MissingProperty checks that the Go matcher agrees with the
+// Cypher predicate on a node without the property: the comparison is null,
+// so the row is dropped for every operator, including $ne and $nin.
+func TestFilter_MissingProperty(t *testing.T) {
+	props := map[string]any{"year": 2021}
+
+	tests := []struct {
+		filter *Filter
+		cypher string
+	}{
+		{&Filter{Property: "lang", Eq: "en"}, "node.`lang` = $filter_0"},
+		{&Filter{Property: "lang", Ne: "en"}, "node.`lang` <> $filter_0"},
+		{&Filter{Property: "lang", Lt: "en"}, "node.`lang` < $filter_0"},
+		{&Filter{Property: "lang", Gte: "en"}, "node.`lang` >= $filter_0"},
+		{&Filter{Property: "lang", Between: []any{"a", "z"}}, "$filter_0 <= node.`lang` <= $filter_1"},
+		{&Filter{Property: "lang", In: []any{"en"}}, "node.`lang` IN $filter_0"},
+		{&Filter{Property: "lang", Nin: []any{"en"}}, "NOT node.`lang` IN $filter_0"},
+		{&Filter{Property: "lang", Like: "en"}, "node.`lang` CONTAINS $filter_0"},
+		{&Filter{Property: "lang", ILike: "en"}, "toLower(node.`lang`) CONTAINS $filter_0"},
+	}
+
+	for _, tt := range tests {
+		predicate, _, err := filterCypher(tt.filter)
+		if err != nil {
+			t.Fatalf("filterCypher(%+v) failed: %v", tt.filter, err)
+		}
+		if predicate != tt.cypher {
+			t.Errorf("filterCypher(%+v) = %q, want %q", tt.filter, predicate, tt.cypher)
+		}
+		if strings.Contains(predicate, "IS NULL") {
+			t.Errorf("predicate %q should not match missing properties", predicate)
+		}
+		if tt.filter.Matches(props) {
+			t.Errorf("Matches(%+v) = true for a node without %q", tt.filter, tt.filter.Property)
+		}
+	}
+}
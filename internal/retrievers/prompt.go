@@ -0,0 +1,309 @@
+package retrievers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+// PromptOptions configures CompileSchemaPrompt.
+type PromptOptions struct {
+	// MaxTokens is the token budget of the compiled text (0: unlimited).
+	// Tokens are estimated with EstimateTokens.
+	MaxTokens int
+	// Question ranks schema elements by relevance when pruning. Optional.
+	Question string
+	// Examples ranks the elements they reference, and the words of their
+	// questions, above the rest when pruning.
+	Examples []CypherExample
+}
+
+// SchemaPrompt is the compiled schema section of a Text2Cypher prompt.
+type SchemaPrompt struct {
+	// Text is the schema description given to the LLM.
+	Text string
+	// Tokens is the estimated token count of Text.
+	Tokens int
+	// Dropped lists the node labels and relationship types pruned to fit
+	// the token budget, least relevant first.
+	Dropped []string
+}
+
+// EstimateTokens approximates the LLM token count of text at four
+// characters per token.
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// promptElement is a node or relationship type that can be pruned.
+type promptElement struct {
+	order int
+	node  *schema.NodeType
+	rel   *schema.RelationshipType
+	score int
+}
+
+func (e *promptElement) name() string {
+	if e.node != nil {
+		return e.node.Label
+	}
+	return e.rel.Label
+}
+
+// exampleWeight ranks elements referenced by an example above any
+// word-overlap score.
+const exampleWeight = 1000
+
+// CompileSchemaPrompt renders the node types, relationship types and agent
+// context of s as the schema section of a Text2Cypher prompt: properties
+// with their types, relationship directions, descriptions and agent hints.
+//
+// When the text exceeds opts.MaxTokens, the least relevant types are
+// dropped until it fits. Relevance counts the words of the question and
+// example questions found in a type's label, properties and description;
+// types referenced by example Cypher rank first, and ties keep declaration
+// order, so the result is deterministic. A relationship is kept only with
+// both of its endpoint node types, and relationships whose endpoints are
+// not declared are left out. The agent context is never dropped.
+func CompileSchemaPrompt(s *schema.Schema, opts PromptOptions) (*SchemaPrompt, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema is required")
+	}
+
+	elements := rankElements(s, opts)
+	included := make(map[*promptElement]bool, len(elements))
+	for _, e := range elements {
+		included[e] = true
+	}
+
+	text := renderSchemaPrompt(s, elements, included)
+	if opts.MaxTokens <= 0 || EstimateTokens(text) <= opts.MaxTokens {
+		return &SchemaPrompt{Text: text, Tokens: EstimateTokens(text)}, nil
+	}
+
+	// Rebuild from the most relevant element down, keeping each one that
+	// still fits. A relationship is added together with its endpoints.
+	ranked := make([]*promptElement, len(elements))
+	copy(ranked, elements)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].order < ranked[j].order
+	})
+	nodes := make(map[string]*promptElement)
+	for _, e := range elements {
+		if e.node != nil {
+			nodes[e.node.Label] = e
+		}
+	}
+
+	included = make(map[*promptElement]bool, len(elements))
+	text = renderSchemaPrompt(s, elements, included)
+	if EstimateTokens(text) > opts.MaxTokens {
+		return nil, fmt.Errorf("token budget %d is too small for the agent context (%d tokens)", opts.MaxTokens, EstimateTokens(text))
+	}
+
+	for _, e := range ranked {
+		if included[e] {
+			continue
+		}
+		unit := []*promptElement{e}
+		if e.rel != nil {
+			source, target := nodes[e.rel.Source], nodes[e.rel.Target]
+			if source == nil || target == nil {
+				continue
+			}
+			unit = append(unit, source, target)
+		}
+		var added []*promptElement
+		for _, u := range unit {
+			if !included[u] {
+				included[u] = true
+				added = append(added, u)
+			}
+		}
+		candidate := renderSchemaPrompt(s, elements, included)
+		if EstimateTokens(candidate) > opts.MaxTokens {
+			for _, u := range added {
+				delete(included, u)
+			}
+			continue
+		}
+		text = candidate
+	}
+
+	prompt := &SchemaPrompt{Text: text, Tokens: EstimateTokens(text)}
+	for i := len(ranked) - 1; i >= 0; i-- {
+		if !included[ranked[i]] {
+			prompt.Dropped = append(prompt.Dropped, ranked[i].name())
+		}
+	}
+	return prompt, nil
+}
+
+// rankElements lists the node types, then relationship types, of s with
+// their relevance scores.
+func rankElements(s *schema.Schema, opts PromptOptions) []*promptElement {
+	words := make(map[string]bool)
+	for _, w := range splitWords(opts.Question) {
+		words[w] = true
+	}
+	referenced := make(map[string]bool)
+	for _, ex := range opts.Examples {
+		for _, w := range splitWords(ex.Question) {
+			words[w] = true
+		}
+		refs := parseCypherRefs(ex.Cypher)
+		for _, p := range refs.nodes {
+			referenced[p.label] = true
+		}
+		for _, p := range refs.rels {
+			referenced[p.relType] = true
+		}
+	}
+
+	var elements []*promptElement
+	score := func(label, description string, props []schema.Property) int {
+		n := 0
+		if referenced[label] {
+			n += exampleWeight
+		}
+		text := []string{label, description}
+		for _, p := range props {
+			text = append(text, p.Name, p.Description)
+		}
+		for _, w := range splitWords(strings.Join(text, " ")) {
+			if words[w] {
+				n++
+			}
+		}
+		return n
+	}
+	for _, n := range s.Nodes {
+		elements = append(elements, &promptElement{order: len(elements), node: n, score: score(n.Label, n.Description, n.Properties)})
+	}
+	for _, r := range s.Relationships {
+		elements = append(elements, &promptElement{order: len(elements), rel: r, score: score(r.Label, r.Description, r.Properties)})
+	}
+	return elements
+}
+
+// renderSchemaPrompt writes the included elements in declaration order.
+func renderSchemaPrompt(s *schema.Schema, elements []*promptElement, included map[*promptElement]bool) string {
+	var sb strings.Builder
+	if s.AgentContext != "" {
+		sb.WriteString("Instructions:\n")
+		sb.WriteString(strings.TrimSpace(s.AgentContext))
+		sb.WriteString("\n\n")
+	}
+
+	var nodes, relProps, patterns strings.Builder
+	for _, e := range elements {
+		if !included[e] {
+			continue
+		}
+		if n := e.node; n != nil {
+			fmt.Fprintf(&nodes, "%s %s\n", n.Label, formatPromptProperties(n.Properties))
+			writePromptNotes(&nodes, n.Description, n.AgentHint, n.Properties)
+			continue
+		}
+		r := e.rel
+		if len(r.Properties) > 0 {
+			fmt.Fprintf(&relProps, "%s %s\n", r.Label, formatPromptProperties(r.Properties))
+		}
+		fmt.Fprintf(&patterns, "(:%s)-[:%s]->(:%s)\n", r.Source, r.Label, r.Target)
+		writePromptNotes(&patterns, r.Description, r.AgentHint, r.Properties)
+	}
+
+	sections := []struct {
+		title string
+		body  string
+	}{
+		{"Node properties:", nodes.String()},
+		{"Relationship properties:", relProps.String()},
+		{"The relationships:", patterns.String()},
+	}
+	var parts []string
+	for _, sec := range sections {
+		if sec.body != "" {
+			parts = append(parts, sec.title+"\n"+sec.body)
+		}
+	}
+	sb.WriteString(strings.Join(parts, "\n"))
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// formatPromptProperties renders properties as {name: TYPE, ...}, marking
+// required and unique ones.
+func formatPromptProperties(props []schema.Property) string {
+	fields := make([]string, len(props))
+	for i, p := range props {
+		field := fmt.Sprintf("%s: %s", p.Name, promptType(p.Type))
+		switch {
+		case p.Required && p.Unique:
+			field += " (required, unique)"
+		case p.Required:
+			field += " (required)"
+		case p.Unique:
+			field += " (unique)"
+		}
+		fields[i] = field
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// promptType renders list types in Cypher notation, such as LIST<STRING>.
+func promptType(t schema.PropertyType) string {
+	if elem, ok := strings.CutPrefix(string(t), "LIST_"); ok {
+		return "LIST<" + elem + ">"
+	}
+	return string(t)
+}
+
+// writePromptNotes writes the description, agent hint and property
+// descriptions of a type as indented lines.
+func writePromptNotes(sb *strings.Builder, description, hint string, props []schema.Property) {
+	if description != "" {
+		fmt.Fprintf(sb, "  Description: %s\n", description)
+	}
+	if hint != "" {
+		fmt.Fprintf(sb, "  Hint: %s\n", hint)
+	}
+	for _, p := range props {
+		if p.Description != "" {
+			fmt.Fprintf(sb, "  %s: %s\n", p.Name, p.Description)
+		}
+	}
+}
+
+// splitWords returns the lower-cased words of text, splitting camelCase
+// and snake_case identifiers, so that "ACTED_IN" and "actedIn" both yield
+// "acted" and "in".
+func splitWords(text string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	runes := []rune(text)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(word) > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,180 @@
+package retrievers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+func movieSchema() *schema.Schema {
+	return &schema.Schema{
+		AgentContext: "Titles are case-sensitive.",
+		Nodes: []*schema.NodeType{
+			{
+				Label:       "Person",
+				Description: "An actor or director",
+				Properties: []schema.Property{
+					{Name: "name", Type: schema.STRING, Required: true, Unique: true},
+					{Name: "born", Type: schema.INTEGER, Description: "Year of birth"},
+				},
+			},
+			{
+				Label:     "Movie",
+				AgentHint: "Match movies by title",
+				Properties: []schema.Property{
+					{Name: "title", Type: schema.STRING, Required: true},
+					{Name: "released", Type: schema.INTEGER},
+				},
+			},
+			{
+				Label:      "Studio",
+				Properties: []schema.Property{{Name: "name", Type: schema.STRING}},
+			},
+		},
+		Relationships: []*schema.RelationshipType{
+			{
+				Label:      "ACTED_IN",
+				Source:     "Person",
+				Target:     "Movie",
+				Properties: []schema.Property{{Name: "roles", Type: schema.LIST_STRING}},
+			},
+			{Label: "PRODUCED", Source: "Studio", Target: "Movie", Description: "Studio that financed the movie"},
+		},
+	}
+}
+
+func TestCompileSchemaPrompt(t *testing.T) {
+	prompt, err := CompileSchemaPrompt(movieSchema(), PromptOptions{})
+	if err != nil {
+		t.Fatalf("CompileSchemaPrompt failed: %v", err)
+	}
+
+	expected := []string{
+		"Instructions:\nTitles are case-sensitive.",
+		"Node properties:\nPerson {name: STRING (required, unique), born: INTEGER}",
+		"  Description: An actor or director",
+		"  born: Year of birth",
+		"  Hint: Match movies by title",
+		"Relationship properties:\nACTED_IN {roles: LIST<STRING>}",
+		"The relationships:\n(:Person)-[:ACTED_IN]->(:Movie)",
+		"(:Studio)-[:PRODUCED]->(:Movie)\n  Description: Studio that financed the movie",
+	}
+	for _, want := range expected {
+		if !strings.Contains(prompt.Text, want) {
+			t.Errorf("expected prompt to contain %q, got:\n%s", want, prompt.Text)
+		}
+	}
+	if len(prompt.Dropped) != 0 {
+		t.Errorf("expected nothing dropped, got %v", prompt.Dropped)
+	}
+	if prompt.Tokens != EstimateTokens(prompt.Text) {
+		t.Errorf("expected %d tokens, got %d", EstimateTokens(prompt.Text), prompt.Tokens)
+	}
+}
+
+func TestCompileSchemaPrompt_Budget(t *testing.T) {
+	full, err := CompileSchemaPrompt(movieSchema(), PromptOptions{})
+	if err != nil {
+		t.Fatalf("CompileSchemaPrompt failed: %v", err)
+	}
+
+	opts := PromptOptions{
+		MaxTokens: full.Tokens - 15,
+		Question:  "Which movies did a studio produce?",
+		Examples: []CypherExample{
+			{Question: "Who acted in The Matrix?", Cypher: "MATCH (p:Person)-[:ACTED_IN]->(m:Movie {title: $title}) RETURN p.name"},
+		},
+	}
+	prompt, err := CompileSchemaPrompt(movieSchema(), opts)
+	if err != nil {
+		t.Fatalf("CompileSchemaPrompt failed: %v", err)
+	}
+	if prompt.Tokens > opts.MaxTokens {
+		t.Errorf("prompt has %d tokens, budget %d", prompt.Tokens, opts.MaxTokens)
+	}
+	if !strings.Contains(prompt.Text, "(:Person)-[:ACTED_IN]->(:Movie)") {
+		t.Errorf("expected the example's pattern to be kept, got:\n%s", prompt.Text)
+	}
+	if len(prompt.Dropped) == 0 {
+		t.Fatal("expected types to be dropped")
+	}
+	for _, d := range prompt.Dropped {
+		if d == "Person" || d == "Movie" || d == "ACTED_IN" {
+			t.Errorf("dropped %s, which the example uses", d)
+		}
+	}
+
+	again, _ := CompileSchemaPrompt(movieSchema(), opts)
+	if again.Text != prompt.Text {
+		t.Error("expected pruning to be deterministic")
+	}
+
+	if _, err := CompileSchemaPrompt(movieSchema(), PromptOptions{MaxTokens: 2}); err == nil {
+		t.Error("expected an error when the agent context exceeds the budget")
+	}
+}
+
+func TestCompileSchemaPrompt_RelationshipNeedsEndpoints(t *testing.T) {
+	s := movieSchema()
+	s.AgentContext = ""
+	prompt, err := CompileSchemaPrompt(s, PromptOptions{MaxTokens: 20, Question: "studio produced"})
+	if err != nil {
+		t.Fatalf("CompileSchemaPrompt failed: %v", err)
+	}
+	if strings.Contains(prompt.Text, "PRODUCED") && !strings.Contains(prompt.Text, "Studio {") {
+		t.Errorf("relationship kept without its endpoints:\n%s", prompt.Text)
+	}
+}
+
+func TestValidateExamples(t *testing.T) {
+	tests := []struct {
+		name   string
+		cypher string
+		errMsg string
+	}{
+		{"valid", "MATCH (p:Person)-[r:ACTED_IN]->(m:Movie) WHERE m.released > 2000 RETURN p.name, r.roles", ""},
+		{"reversed pattern", "MATCH (m:Movie)<-[:ACTED_IN]-(p:Person) RETURN p.name", ""},
+		{"string literal", "MATCH (m:Movie {title: 'p:Ghost'}) RETURN m.title", ""},
+		{"unknown label", "MATCH (d:Director) RETURN d", "node label Director"},
+		{"unknown type", "MATCH (p:Person)-[:DIRECTED]->(m:Movie) RETURN m", "relationship type DIRECTED"},
+		{"unknown property", "MATCH (m:Movie) RETURN m.rating", "property rating is not defined on Movie"},
+		{"unknown map key", "MATCH (p:Person {age: 40}) RETURN p", "property age is not defined on Person"},
+		{"wrong direction", "MATCH (m:Movie)-[:ACTED_IN]->(p:Person) RETURN p", "does not go from Movie to Person"},
+		{"bound variable", "MATCH (m:Movie) MATCH (s:Studio)-[:PRODUCED]->(m) RETURN s.name", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateExamples(movieSchema(), []CypherExample{{Question: tt.name, Cypher: tt.cypher}})
+			if tt.errMsg == "" {
+				if len(errs) > 0 {
+					t.Errorf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) == 0 {
+				t.Fatalf("expected error containing %q", tt.errMsg)
+			}
+			if !strings.Contains(errs[0].Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, errs[0])
+			}
+		})
+	}
+}
+
+func TestText2CypherRetriever_SchemaPrompt(t *testing.T) {
+	r := &Text2CypherRetriever{BaseRetriever: BaseRetriever{Name: "t2c"}, Schema: movieSchema()}
+
+	m := NewRetrieverSerializer().ToMap(r)
+	desc, _ := m["schemaDescription"].(string)
+	if !strings.Contains(desc, "(:Person)-[:ACTED_IN]->(:Movie)") {
+		t.Errorf("expected compiled schema description, got %q", desc)
+	}
+
+	r.SchemaDescription = "hand-written"
+	prompt, err := r.SchemaPrompt()
+	if err != nil || prompt.Text != "hand-written" {
+		t.Errorf("expected SchemaDescription to take precedence, got %v, %v", prompt, err)
+	}
+}
@@ -0,0 +1,461 @@
+package retrievers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+)
+
+// ToPython generates a Python script that constructs the retriever with the
+// neo4j-graphrag package.
+//
+// Secrets are never inlined. The Neo4j password and provider API keys are
+// read from environment variables: a field value of the form $NAME or
+// ${NAME} names the variable to read, otherwise the provider default
+// (NEO4J_PASSWORD, OPENAI_API_KEY, ...) is used.
+func (s *RetrieverSerializer) ToPython(retriever Retriever) (string, error) {
+	w := &pythonWriter{}
+
+	var body strings.Builder
+	var err error
+	switch r := retriever.(type) {
+	case *VectorRetriever:
+		err = w.writeVector(&body, r)
+	case *VectorCypherRetriever:
+		err = w.writeVectorCypher(&body, r)
+	case *HybridRetriever:
+		err = w.writeHybrid(&body, r)
+	case *HybridCypherRetriever:
+		err = w.writeHybridCypher(&body, r)
+	case *Text2CypherRetriever:
+		err = w.writeText2Cypher(&body, r)
+	case *WeaviateRetriever:
+		w.writeWeaviate(&body, r)
+	case *PineconeRetriever:
+		w.writePinecone(&body, r)
+	case *QdrantRetriever:
+		w.writeQdrant(&body, r)
+	default:
+		return "", fmt.Errorf("unsupported retriever type %T", retriever)
+	}
+	if err != nil {
+		return "", fmt.Errorf("retriever %s: %w", retriever.RetrieverName(), err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Retriever: %s\n", retriever.RetrieverName())
+	sb.WriteString("import os\n\n")
+	w.Write(&sb)
+	base := baseOf(retriever)
+	graphrag.WriteDriver(&sb, base.Neo4jURI, base.Neo4jUser, base.Neo4jPassword)
+	sb.WriteString(body.String())
+
+	return sb.String(), nil
+}
+
+// pythonWriter collects the imports needed by a generated script.
+type pythonWriter struct {
+	graphrag.Imports
+}
+
+// baseOf returns the BaseRetriever embedded in a retriever.
+func baseOf(retriever Retriever) *BaseRetriever {
+	switch r := retriever.(type) {
+	case *VectorRetriever:
+		return &r.BaseRetriever
+	case *VectorCypherRetriever:
+		return &r.BaseRetriever
+	case *HybridRetriever:
+		return &r.BaseRetriever
+	case *HybridCypherRetriever:
+		return &r.BaseRetriever
+	case *Text2CypherRetriever:
+		return &r.BaseRetriever
+	case *WeaviateRetriever:
+		return &r.BaseRetriever
+	case *PineconeRetriever:
+		return &r.BaseRetriever
+	case *QdrantRetriever:
+		return &r.BaseRetriever
+	}
+	return &BaseRetriever{}
+}
+
+// writeEmbedder writes the construction of the embedder variable from a
+// model name and optional config; the config takes precedence.
+func (w *pythonWriter) writeEmbedder(sb *strings.Builder, model string, config *EmbedderConfig) error {
+	provider := graphrag.DefaultProvider
+	apiKey := ""
+	if config != nil {
+		if config.Provider != "" {
+			provider = strings.ToLower(config.Provider)
+		}
+		if config.Model != "" {
+			model = config.Model
+		}
+		apiKey = config.APIKey
+	}
+	if model == "" {
+		return fmt.Errorf("no embedder model configured")
+	}
+
+	p, ok := graphrag.Providers[provider]
+	if !ok {
+		return fmt.Errorf("unsupported embedder provider %q", provider)
+	}
+	if p.EmbedderClass == "" {
+		return fmt.Errorf("provider %q has no embedding models", provider)
+	}
+	w.AddGraphRAG("embeddings", p.EmbedderClass)
+
+	fmt.Fprintf(sb, "embedder = %s(\n", p.EmbedderClass)
+	fmt.Fprintf(sb, "    model=%s,\n", graphrag.PyString(model))
+	graphrag.WriteCredentials(sb, p, apiKey)
+	sb.WriteString(")\n\n")
+	return nil
+}
+
+// writeLLM writes the LLM construction used by Text2Cypher.
+func (w *pythonWriter) writeLLM(sb *strings.Builder, provider, model, apiKey string) error {
+	if provider == "" {
+		provider = graphrag.DefaultProvider
+	}
+	provider = strings.ToLower(provider)
+	if model == "" {
+		return fmt.Errorf("no LLM model configured")
+	}
+
+	p, ok := graphrag.Providers[provider]
+	if !ok || p.LLMClass == "" {
+		return fmt.Errorf("unsupported LLM provider %q", provider)
+	}
+	w.AddGraphRAG("llm", p.LLMClass)
+
+	fmt.Fprintf(sb, "llm = %s(\n", p.LLMClass)
+	fmt.Fprintf(sb, "    model_name=%s,\n", graphrag.PyString(model))
+	graphrag.WriteCredentials(sb, p, apiKey)
+	sb.WriteString(")\n\n")
+	return nil
+}
+
+func (w *pythonWriter) writeVector(sb *strings.Builder, r *VectorRetriever) error {
+	if err := w.writeEmbedder(sb, r.EmbedderModel, r.EmbedderConfig); err != nil {
+		return err
+	}
+	w.AddGraphRAG("retrievers", "VectorRetriever")
+
+	sb.WriteString("retriever = VectorRetriever(\n")
+	sb.WriteString("    driver,\n")
+	fmt.Fprintf(sb, "    index_name=%s,\n", graphrag.PyString(r.IndexName))
+	sb.WriteString("    embedder=embedder,\n")
+	writeReturnProperties(sb, r.ReturnProperties)
+	writeDatabase(sb, &r.BaseRetriever)
+	sb.WriteString(")\n")
+
+	writeSearchKwargs(sb, r.TopK, r.ScoreThreshold, filterKwargs(r.Filters))
+	return w.writeReranker(sb, r.Reranker)
+}
+
+func (w *pythonWriter) writeVectorCypher(sb *strings.Builder, r *VectorCypherRetriever) error {
+	if err := w.writeEmbedder(sb, r.EmbedderModel, r.EmbedderConfig); err != nil {
+		return err
+	}
+	w.AddGraphRAG("retrievers", "VectorCypherRetriever")
+
+	sb.WriteString("retriever = VectorCypherRetriever(\n")
+	sb.WriteString("    driver,\n")
+	fmt.Fprintf(sb, "    index_name=%s,\n", graphrag.PyString(r.IndexName))
+	fmt.Fprintf(sb, "    retrieval_query=%s,\n", graphrag.PyString(r.RetrievalQuery))
+	sb.WriteString("    embedder=embedder,\n")
+	writeDatabase(sb, &r.BaseRetriever)
+	sb.WriteString(")\n")
+
+	writeSearchKwargs(sb, r.TopK, r.ScoreThreshold, filterKwargs(r.Filters))
+	return w.writeReranker(sb, r.Reranker)
+}
+
+func (w *pythonWriter) writeHybrid(sb *strings.Builder, r *HybridRetriever) error {
+	if err := checkHybridFilters(r.Filters); err != nil {
+		return err
+	}
+	if err := w.writeEmbedder(sb, r.EmbedderModel, r.EmbedderConfig); err != nil {
+		return err
+	}
+	w.AddGraphRAG("retrievers", "HybridRetriever")
+
+	sb.WriteString("retriever = HybridRetriever(\n")
+	sb.WriteString("    driver,\n")
+	fmt.Fprintf(sb, "    vector_index_name=%s,\n", graphrag.PyString(r.VectorIndexName))
+	fmt.Fprintf(sb, "    fulltext_index_name=%s,\n", graphrag.PyString(r.FulltextIndexName))
+	sb.WriteString("    embedder=embedder,\n")
+	writeReturnProperties(sb, r.ReturnProperties)
+	writeDatabase(sb, &r.BaseRetriever)
+	sb.WriteString(")\n")
+
+	writeSearchKwargs(sb, r.TopK, 0, hybridRanker(r.VectorWeight, r.FulltextWeight))
+	return w.writeReranker(sb, r.Reranker)
+}
+
+func (w *pythonWriter) writeHybridCypher(sb *strings.Builder, r *HybridCypherRetriever) error {
+	if err := checkHybridFilters(r.Filters); err != nil {
+		return err
+	}
+	if err := w.writeEmbedder(sb, r.EmbedderModel, r.EmbedderConfig); err != nil {
+		return err
+	}
+	w.AddGraphRAG("retrievers", "HybridCypherRetriever")
+
+	sb.WriteString("retriever = HybridCypherRetriever(\n")
+	sb.WriteString("    driver,\n")
+	fmt.Fprintf(sb, "    vector_index_name=%s,\n", graphrag.PyString(r.VectorIndexName))
+	fmt.Fprintf(sb, "    fulltext_index_name=%s,\n", graphrag.PyString(r.FulltextIndexName))
+	fmt.Fprintf(sb, "    retrieval_query=%s,\n", graphrag.PyString(r.RetrievalQuery))
+	sb.WriteString("    embedder=embedder,\n")
+	writeDatabase(sb, &r.BaseRetriever)
+	sb.WriteString(")\n")
+
+	writeSearchKwargs(sb, r.TopK, 0, hybridRanker(r.VectorWeight, r.FulltextWeight))
+	return w.writeReranker(sb, r.Reranker)
+}
+
+func (w *pythonWriter) writeText2Cypher(sb *strings.Builder, r *Text2CypherRetriever) error {
+	if err := w.writeLLM(sb, r.LLMProvider, r.LLMModel, r.LLMAPIKey); err != nil {
+		return err
+	}
+	w.AddGraphRAG("retrievers", "Text2CypherRetriever")
+
+	sb.WriteString("retriever = Text2CypherRetriever(\n")
+	sb.WriteString("    driver,\n")
+	sb.WriteString("    llm=llm,\n")
+	prompt, err := r.SchemaPrompt()
+	if err != nil {
+		return err
+	}
+	if prompt.Text != "" {
+		fmt.Fprintf(sb, "    neo4j_schema=%s,\n", graphrag.PyString(prompt.Text))
+	}
+	if len(r.Examples) > 0 {
+		sb.WriteString("    examples=[\n")
+		for _, ex := range r.Examples {
+			fmt.Fprintf(sb, "        %s,\n", graphrag.PyString(fmt.Sprintf("USER INPUT: '%s' QUERY: %s", ex.Question, ex.Cypher)))
+		}
+		sb.WriteString("    ],\n")
+	}
+	writeDatabase(sb, &r.BaseRetriever)
+	sb.WriteString(")\n")
+	return nil
+}
+
+func (w *pythonWriter) writeWeaviate(sb *strings.Builder, r *WeaviateRetriever) {
+	w.AddThirdParty("import weaviate")
+	w.AddThirdParty("from weaviate.classes.init import Auth")
+	w.AddGraphRAG("retrievers", "WeaviateNeo4jRetriever")
+
+	sb.WriteString("client = weaviate.connect_to_weaviate_cloud(\n")
+	fmt.Fprintf(sb, "    cluster_url=%s,\n", graphrag.PyString(r.WeaviateURL))
+	fmt.Fprintf(sb, "    auth_credentials=Auth.api_key(%s),\n", graphrag.EnvLookup(graphrag.SecretEnv(r.WeaviateAPIKey, "WEAVIATE_API_KEY")))
+	sb.WriteString(")\n\n")
+
+	sb.WriteString("retriever = WeaviateNeo4jRetriever(\n")
+	sb.WriteString("    driver=driver,\n")
+	sb.WriteString("    client=client,\n")
+	fmt.Fprintf(sb, "    collection=%s,\n", graphrag.PyString(r.Collection))
+	sb.WriteString("    id_property_external=\"neo4j_id\",\n")
+	writeExternalCommon(sb, r.IDProperty, r.RetrievalQuery, &r.BaseRetriever)
+	sb.WriteString(")\n")
+
+	writeSearchKwargs(sb, r.TopK, 0, nil)
+}
+
+func (w *pythonWriter) writePinecone(sb *strings.Builder, r *PineconeRetriever) {
+	w.AddThirdParty("from pinecone import Pinecone")
+	w.AddGraphRAG("retrievers", "PineconeNeo4jRetriever")
+
+	fmt.Fprintf(sb, "client = Pinecone(api_key=%s)\n\n", graphrag.EnvLookup(graphrag.SecretEnv(r.PineconeAPIKey, "PINECONE_API_KEY")))
+
+	sb.WriteString("retriever = PineconeNeo4jRetriever(\n")
+	sb.WriteString("    driver=driver,\n")
+	sb.WriteString("    client=client,\n")
+	fmt.Fprintf(sb, "    index_name=%s,\n", graphrag.PyString(r.IndexName))
+	writeExternalCommon(sb, r.IDProperty, r.RetrievalQuery, &r.BaseRetriever)
+	sb.WriteString(")\n")
+
+	writeSearchKwargs(sb, r.TopK, 0, nil)
+}
+
+func (w *pythonWriter) writeQdrant(sb *strings.Builder, r *QdrantRetriever) {
+	w.AddThirdParty("from qdrant_client import QdrantClient")
+	w.AddGraphRAG("retrievers", "QdrantNeo4jRetriever")
+
+	sb.WriteString("client = QdrantClient(\n")
+	fmt.Fprintf(sb, "    url=%s,\n", graphrag.PyString(r.QdrantURL))
+	if r.QdrantAPIKey != "" {
+		fmt.Fprintf(sb, "    api_key=%s,\n", graphrag.EnvLookup(graphrag.SecretEnv(r.QdrantAPIKey, "QDRANT_API_KEY")))
+	}
+	sb.WriteString(")\n\n")
+
+	sb.WriteString("retriever = QdrantNeo4jRetriever(\n")
+	sb.WriteString("    driver=driver,\n")
+	sb.WriteString("    client=client,\n")
+	fmt.Fprintf(sb, "    collection_name=%s,\n", graphrag.PyString(r.CollectionName))
+	writeExternalCommon(sb, r.IDProperty, r.RetrievalQuery, &r.BaseRetriever)
+	sb.WriteString(")\n")
+
+	writeSearchKwargs(sb, r.TopK, 0, nil)
+}
+
+func writeExternalCommon(sb *strings.Builder, idProperty, retrievalQuery string, base *BaseRetriever) {
+	if idProperty != "" {
+		fmt.Fprintf(sb, "    id_property_neo4j=%s,\n", graphrag.PyString(idProperty))
+	}
+	if retrievalQuery != "" {
+		fmt.Fprintf(sb, "    retrieval_query=%s,\n", graphrag.PyString(retrievalQuery))
+	}
+	writeDatabase(sb, base)
+}
+
+func writeReturnProperties(sb *strings.Builder, props []string) {
+	if len(props) > 0 {
+		fmt.Fprintf(sb, "    return_properties=%s,\n", graphrag.PyStringList(props))
+	}
+}
+
+func writeDatabase(sb *strings.Builder, base *BaseRetriever) {
+	if base.Neo4jDatabase != "" {
+		fmt.Fprintf(sb, "    neo4j_database=%s,\n", graphrag.PyString(base.Neo4jDatabase))
+	}
+}
+
+// hybridRanker returns the search arguments for a weighted hybrid search.
+// neo4j-graphrag weights the vector score by alpha and the fulltext score
+// by 1 - alpha.
+func hybridRanker(vectorWeight, fulltextWeight float64) []string {
+	total := vectorWeight + fulltextWeight
+	if total <= 0 {
+		return nil
+	}
+	alpha := math.Round(vectorWeight/total*1e4) / 1e4
+	return []string{`"ranker": "linear"`, `"alpha": ` + graphrag.PyFloat(alpha)}
+}
+
+// writeSearchKwargs writes the arguments to pass to retriever.search, since
+// neo4j-graphrag takes the result limit per search rather than per retriever.
+func writeSearchKwargs(sb *strings.Builder, topK int, scoreThreshold float64, extra []string) {
+	var kwargs []string
+	if topK > 0 {
+		kwargs = append(kwargs, fmt.Sprintf(`"top_k": %d`, topK))
+	}
+	kwargs = append(kwargs, extra...)
+
+	sb.WriteString("\n# Usage: retriever.search(query_text=..., **search_kwargs)\n")
+	fmt.Fprintf(sb, "search_kwargs = {%s}\n", strings.Join(kwargs, ", "))
+	if scoreThreshold > 0 {
+		fmt.Fprintf(sb, "# Keep results with score >= %s; neo4j-graphrag does not filter by score.\n", graphrag.PyFloat(scoreThreshold))
+	}
+}
+
+// filterKwargs returns the filters search argument in neo4j-graphrag syntax.
+func filterKwargs(filters *Filter) []string {
+	if filters == nil {
+		return nil
+	}
+	return []string{`"filters": ` + pyValue(filters.ToMap())}
+}
+
+// checkHybridFilters rejects filters on a hybrid retriever: neo4j-graphrag's
+// hybrid search cannot apply them, and dropping them would widen the search.
+func checkHybridFilters(filters *Filter) error {
+	if filters != nil {
+		return fmt.Errorf("neo4j-graphrag hybrid search does not support filters; run the filtered Cypher from `wetwire-neo4j explain` instead")
+	}
+	return nil
+}
+
+// rerankerModels holds the default cross-encoder of each reranker provider.
+var rerankerModels = map[string]string{
+	"sentence-transformers": "cross-encoder/ms-marco-MiniLM-L-6-v2",
+	"cohere":                "rerank-v3.5",
+}
+
+// writeReranker writes a rerank function that reorders the items returned
+// by retriever.search with a cross-encoder.
+func (w *pythonWriter) writeReranker(sb *strings.Builder, config *RerankerConfig) error {
+	if config == nil {
+		return nil
+	}
+	provider := strings.ToLower(config.Provider)
+	if provider == "" {
+		provider = "sentence-transformers"
+	}
+	model, ok := rerankerModels[provider]
+	if !ok {
+		return fmt.Errorf("unsupported reranker provider %q", config.Provider)
+	}
+	if config.Model != "" {
+		model = config.Model
+	}
+	topN := "None"
+	if config.TopN > 0 {
+		topN = strconv.Itoa(config.TopN)
+	}
+
+	sb.WriteString("\n# Usage: rerank(query_text, retriever.search(query_text=query_text, **search_kwargs).items)\n")
+	switch provider {
+	case "cohere":
+		w.AddThirdParty("import cohere")
+		fmt.Fprintf(sb, "reranker = cohere.ClientV2(api_key=%s)\n\n\n", graphrag.EnvLookup(graphrag.SecretEnv(config.APIKey, "CO_API_KEY")))
+		fmt.Fprintf(sb, "def rerank(query_text, items, top_n=%s):\n", topN)
+		sb.WriteString("    documents = [str(item.content) for item in items]\n")
+		fmt.Fprintf(sb, "    response = reranker.rerank(model=%s, query=query_text, documents=documents, top_n=top_n)\n", graphrag.PyString(model))
+		sb.WriteString("    return [items[result.index] for result in response.results]\n")
+	default:
+		w.AddThirdParty("from sentence_transformers import CrossEncoder")
+		fmt.Fprintf(sb, "reranker = CrossEncoder(%s)\n\n\n", graphrag.PyString(model))
+		fmt.Fprintf(sb, "def rerank(query_text, items, top_n=%s):\n", topN)
+		sb.WriteString("    scores = reranker.predict([(query_text, str(item.content)) for item in items])\n")
+		sb.WriteString("    ranked = sorted(zip(scores, items), key=lambda pair: pair[0], reverse=True)\n")
+		sb.WriteString("    return [item for _, item in ranked[:top_n]]\n")
+	}
+	return nil
+}
+
+// pyValue renders a filter value as a Python literal.
+func pyValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "None"
+	case string:
+		return graphrag.PyString(val)
+	case bool:
+		if val {
+			return "True"
+		}
+		return "False"
+	case float64:
+		return graphrag.PyFloat(val)
+	case []any:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = pyValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []map[string]any:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = pyValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]any:
+		keys := sortedKeys(val)
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			entries[i] = graphrag.PyString(k) + ": " + pyValue(val[k])
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,188 @@
+package retrievers
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRetrieverSerializer_ToPython_Golden(t *testing.T) {
+	base := BaseRetriever{
+		Name:          "docs",
+		Neo4jPassword: "s3cret-password",
+		Neo4jDatabase: "neo4j",
+	}
+
+	tests := []struct {
+		golden    string
+		retriever Retriever
+	}{
+		{"vector", &VectorRetriever{
+			BaseRetriever:    base,
+			IndexName:        "document_embeddings",
+			EmbedderModel:    "text-embedding-3-small",
+			TopK:             10,
+			ReturnProperties: []string{"title", "text"},
+			ScoreThreshold:   0.7,
+		}},
+		{"vector_cypher", &VectorCypherRetriever{
+			BaseRetriever: base,
+			IndexName:     "chunk_embeddings",
+			EmbedderConfig: &EmbedderConfig{
+				Provider: "Azure",
+				Model:    "text-embedding-3-large",
+				APIKey:   "${DOCS_AZURE_KEY}",
+			},
+			RetrievalQuery: "MATCH (node)<-[:HAS_CHUNK]-(d:Document)\nRETURN d.title AS title, node.text AS text",
+			TopK:           5,
+		}},
+		{"hybrid", &HybridRetriever{
+			BaseRetriever:     base,
+			VectorIndexName:   "chunk_embeddings",
+			FulltextIndexName: "chunk_text",
+			EmbedderConfig:    &EmbedderConfig{Provider: "ollama", Model: "nomic-embed-text"},
+			TopK:              8,
+			VectorWeight:      0.6,
+			FulltextWeight:    0.2,
+		}},
+		{"hybrid_cypher", &HybridCypherRetriever{
+			BaseRetriever:     BaseRetriever{Name: "docs", Neo4jURI: "neo4j+s://demo.databases.neo4j.io", Neo4jUser: "neo4j"},
+			VectorIndexName:   "chunk_embeddings",
+			FulltextIndexName: "chunk_text",
+			EmbedderModel:     "text-embedding-3-small",
+			RetrievalQuery:    "RETURN node.text AS text",
+		}},
+		{"vector_filtered", &VectorRetriever{
+			BaseRetriever: base,
+			IndexName:     "document_embeddings",
+			EmbedderModel: "text-embedding-3-small",
+			NodeLabel:     "Document",
+			Filters: &Filter{
+				Property: "year",
+				Gte:      2020,
+				Or: []Filter{
+					{Property: "lang", In: []any{"en", "de"}},
+					{Property: "draft", Eq: false},
+				},
+			},
+			Reranker: &RerankerConfig{TopN: 3},
+		}},
+		{"hybrid_reranked", &HybridRetriever{
+			BaseRetriever:     base,
+			VectorIndexName:   "chunk_embeddings",
+			FulltextIndexName: "chunk_text",
+			EmbedderModel:     "text-embedding-3-small",
+			Reranker:          &RerankerConfig{Provider: "cohere", Model: "rerank-v3.5", APIKey: "$DOCS_COHERE_KEY"},
+		}},
+		{"text2cypher", &Text2CypherRetriever{
+			BaseRetriever:     base,
+			LLMProvider:       "anthropic",
+			LLMModel:          "claude-sonnet-4-5",
+			LLMAPIKey:         "sk-ant-literal",
+			SchemaDescription: "(:Person)-[:ACTED_IN]->(:Movie)",
+			Examples: []CypherExample{
+				{Question: "Who acted in The Matrix?", Cypher: "MATCH (p:Person)-[:ACTED_IN]->(:Movie {title: 'The Matrix'}) RETURN p.name"},
+			},
+		}},
+		{"weaviate", &WeaviateRetriever{
+			BaseRetriever:  base,
+			WeaviateURL:    "https://demo.weaviate.network",
+			WeaviateAPIKey: "wv-literal",
+			Collection:     "Documents",
+			TopK:           5,
+			IDProperty:     "id",
+		}},
+		{"pinecone", &PineconeRetriever{
+			BaseRetriever:  base,
+			PineconeAPIKey: "$PINECONE_KEY",
+			IndexName:      "documents",
+			IDProperty:     "id",
+			RetrievalQuery: "RETURN node.title AS title",
+		}},
+		{"qdrant", &QdrantRetriever{
+			BaseRetriever:  base,
+			QdrantURL:      "http://localhost:6333",
+			CollectionName: "documents",
+			TopK:           3,
+			IDProperty:     "id",
+		}},
+	}
+
+	s := NewRetrieverSerializer()
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			got, err := s.ToPython(tt.retriever)
+			if err != nil {
+				t.Fatalf("ToPython failed: %v", err)
+			}
+
+			path := filepath.Join("testdata", "python", tt.golden+".py")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file (run with -update to create): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("ToPython output does not match %s:\n%s", path, got)
+			}
+		})
+	}
+}
+
+func TestRetrieverSerializer_ToPython_NoInlineSecrets(t *testing.T) {
+	r := &VectorRetriever{
+		BaseRetriever:  BaseRetriever{Name: "docs", Neo4jPassword: "neo4j-secret"},
+		IndexName:      "embeddings",
+		EmbedderConfig: &EmbedderConfig{Provider: "openai", Model: "text-embedding-3-small", APIKey: "sk-secret"},
+	}
+
+	python, err := NewRetrieverSerializer().ToPython(r)
+	if err != nil {
+		t.Fatalf("ToPython failed: %v", err)
+	}
+	for _, secret := range []string{"neo4j-secret", "sk-secret"} {
+		if strings.Contains(python, secret) {
+			t.Errorf("secret %q inlined in output:\n%s", secret, python)
+		}
+	}
+	for _, env := range []string{`os.environ["NEO4J_PASSWORD"]`, `os.environ["OPENAI_API_KEY"]`} {
+		if !strings.Contains(python, env) {
+			t.Errorf("expected %s in output:\n%s", env, python)
+		}
+	}
+}
+
+func TestRetrieverSerializer_ToPython_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		retriever Retriever
+		wantErr   string
+	}{
+		{"no embedder model", &VectorRetriever{IndexName: "idx"}, "no embedder model"},
+		{"unknown provider", &VectorRetriever{EmbedderConfig: &EmbedderConfig{Provider: "acme", Model: "m"}}, "unsupported embedder provider"},
+		{"provider without embeddings", &VectorRetriever{EmbedderConfig: &EmbedderConfig{Provider: "anthropic", Model: "m"}}, "no embedding models"},
+		{"no llm model", &Text2CypherRetriever{}, "no LLM model"},
+		{"hybrid filters", &HybridCypherRetriever{EmbedderModel: "m", Filters: &Filter{Property: "lang", Eq: "en"}}, "hybrid search does not support filters"},
+	}
+
+	s := NewRetrieverSerializer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ToPython(tt.retriever)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ToPython() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
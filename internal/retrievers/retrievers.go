@@ -17,8 +17,26 @@
 //		TopK:             10,
 //	}
 //	config, err := serializer.ToJSON(retriever)
+//	script, err := serializer.ToPython(retriever)
+//
+// Vector and hybrid retrievers can also be executed from Go with a Runtime:
+//
+//	rt := retrievers.NewRuntime(retrievers.NewDriverRunner(driver, "neo4j"), embedder)
+//	results, err := rt.Search(ctx, retriever, "What is a graph?")
+//
+// Evaluate scores a retriever against a golden question set, offline with a
+// fixture Corpus or live with a Runtime:
+//
+//	report, err := retrievers.Evaluate(ctx, corpus, retriever, golden, 10)
 package retrievers
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
 // RetrieverType represents the type of GraphRAG retriever.
 type RetrieverType string
 
@@ -57,7 +75,8 @@ type BaseRetriever struct {
 	Neo4jURI string
 	// Neo4jUser is the Neo4j username.
 	Neo4jUser string
-	// Neo4jPassword is the Neo4j password.
+	// Neo4jPassword is the Neo4j password. Generated code reads it from
+	// NEO4J_PASSWORD, or from NAME when set to $NAME.
 	Neo4jPassword string
 	// Neo4jDatabase is the database name (default: neo4j).
 	Neo4jDatabase string
@@ -74,12 +93,27 @@ type EmbedderConfig struct {
 	Provider string
 	// Model is the embedding model name.
 	Model string
-	// APIKey is the API key for the provider.
+	// APIKey is the API key for the provider. Generated code never inlines it;
+	// a value of the form $NAME names the environment variable to read.
 	APIKey string
 	// Dimensions is the embedding dimension (optional).
 	Dimensions int
 }
 
+// RerankerConfig configures a cross-encoder that reorders retrieved results
+// by their relevance to the query.
+type RerankerConfig struct {
+	// Provider is the reranker provider: sentence-transformers (default) or cohere.
+	Provider string
+	// Model is the cross-encoder model name.
+	Model string
+	// TopN is the number of results kept after reranking (default: all).
+	TopN int
+	// APIKey is the API key for hosted providers. Generated code never
+	// inlines it; a value of the form $NAME names the environment variable.
+	APIKey string
+}
+
 // CypherExample provides a question-to-Cypher example for Text2Cypher.
 type CypherExample struct {
 	// Question is a natural language question.
@@ -103,6 +137,19 @@ type VectorRetriever struct {
 	ReturnProperties []string
 	// ScoreThreshold filters results below this similarity score.
 	ScoreThreshold float64
+	// NodeLabel is the label of the indexed nodes. Filters require it and
+	// are validated against its schema properties.
+	NodeLabel string
+	// EmbeddingProperty is the node property holding embeddings
+	// (default: embedding). Filtered searches compute similarity from it.
+	EmbeddingProperty string
+	// SimilarityFunction is the similarity function of the vector index,
+	// cosine (default) or euclidean. Filtered searches score with it.
+	SimilarityFunction string
+	// Filters restricts the search to nodes whose properties match.
+	Filters *Filter
+	// Reranker reorders results with a cross-encoder.
+	Reranker *RerankerConfig
 }
 
 func (r *VectorRetriever) RetrieverType() RetrieverType { return Vector }
@@ -117,12 +164,25 @@ type VectorCypherRetriever struct {
 	// EmbedderConfig provides detailed embedder configuration.
 	EmbedderConfig *EmbedderConfig
 	// RetrievalQuery is the Cypher query for post-search traversal.
-	// Use $node to reference the matched node.
+	// The variables node and score hold the matched node and its score.
 	RetrievalQuery string
 	// TopK is the number of results to return (default: 5).
 	TopK int
 	// ScoreThreshold filters results below this similarity score.
 	ScoreThreshold float64
+	// NodeLabel is the label of the indexed nodes. Filters require it and
+	// are validated against its schema properties.
+	NodeLabel string
+	// EmbeddingProperty is the node property holding embeddings
+	// (default: embedding). Filtered searches compute similarity from it.
+	EmbeddingProperty string
+	// SimilarityFunction is the similarity function of the vector index,
+	// cosine (default) or euclidean. Filtered searches score with it.
+	SimilarityFunction string
+	// Filters restricts the search to nodes whose properties match.
+	Filters *Filter
+	// Reranker reorders results with a cross-encoder.
+	Reranker *RerankerConfig
 }
 
 func (r *VectorCypherRetriever) RetrieverType() RetrieverType { return VectorCypher }
@@ -146,6 +206,22 @@ type HybridRetriever struct {
 	VectorWeight float64
 	// FulltextWeight is the weight for fulltext search (0-1).
 	FulltextWeight float64
+	// NodeLabel is the label of the indexed nodes. Filters require it and
+	// are validated against its schema properties.
+	NodeLabel string
+	// EmbeddingProperty is the node property holding embeddings
+	// (default: embedding). Filtered searches compute similarity from it.
+	EmbeddingProperty string
+	// SimilarityFunction is the similarity function of the vector index,
+	// cosine (default) or euclidean. Filtered searches score with it.
+	SimilarityFunction string
+	// Filters restricts the search to nodes whose properties match. Both
+	// indexes are searched among the matching nodes only. The generated
+	// Python does not support them, since neo4j-graphrag's hybrid search
+	// cannot filter.
+	Filters *Filter
+	// Reranker reorders results with a cross-encoder.
+	Reranker *RerankerConfig
 }
 
 func (r *HybridRetriever) RetrieverType() RetrieverType { return Hybrid }
@@ -169,6 +245,22 @@ type HybridCypherRetriever struct {
 	VectorWeight float64
 	// FulltextWeight is the weight for fulltext search (0-1).
 	FulltextWeight float64
+	// NodeLabel is the label of the indexed nodes. Filters require it and
+	// are validated against its schema properties.
+	NodeLabel string
+	// EmbeddingProperty is the node property holding embeddings
+	// (default: embedding). Filtered searches compute similarity from it.
+	EmbeddingProperty string
+	// SimilarityFunction is the similarity function of the vector index,
+	// cosine (default) or euclidean. Filtered searches score with it.
+	SimilarityFunction string
+	// Filters restricts the search to nodes whose properties match. Both
+	// indexes are searched among the matching nodes only. The generated
+	// Python does not support them, since neo4j-graphrag's hybrid search
+	// cannot filter.
+	Filters *Filter
+	// Reranker reorders results with a cross-encoder.
+	Reranker *RerankerConfig
 }
 
 func (r *HybridCypherRetriever) RetrieverType() RetrieverType { return HybridCypher }
@@ -182,8 +274,15 @@ type Text2CypherRetriever struct {
 	LLMProvider string
 	// LLMAPIKey is the API key for the LLM provider.
 	LLMAPIKey string
-	// SchemaDescription describes the graph schema for the LLM.
+	// SchemaDescription describes the graph schema for the LLM. When empty,
+	// it is compiled from Schema.
 	SchemaDescription string
+	// Schema is the graph schema compiled into the prompt by
+	// CompileSchemaPrompt. Optional.
+	Schema *schema.Schema
+	// SchemaTokenBudget caps the compiled schema prompt, in estimated tokens
+	// (0: unlimited).
+	SchemaTokenBudget int
 	// Examples are question-to-Cypher examples for few-shot learning.
 	Examples []CypherExample
 	// MaxRetries is the maximum retries on Cypher generation failure.
@@ -192,6 +291,23 @@ type Text2CypherRetriever struct {
 
 func (r *Text2CypherRetriever) RetrieverType() RetrieverType { return Text2Cypher }
 
+// SchemaPrompt returns the schema section of the prompt: SchemaDescription
+// if set, otherwise Schema compiled within SchemaTokenBudget, with Examples
+// ranking the types to keep. It returns an empty prompt if neither is set.
+func (r *Text2CypherRetriever) SchemaPrompt() (*SchemaPrompt, error) {
+	if r.SchemaDescription != "" {
+		return &SchemaPrompt{Text: r.SchemaDescription, Tokens: EstimateTokens(r.SchemaDescription)}, nil
+	}
+	if r.Schema == nil {
+		return &SchemaPrompt{}, nil
+	}
+	prompt, err := CompileSchemaPrompt(r.Schema, PromptOptions{MaxTokens: r.SchemaTokenBudget, Examples: r.Examples})
+	if err != nil {
+		return nil, fmt.Errorf("retriever %s: %w", r.Name, err)
+	}
+	return prompt, nil
+}
+
 // WeaviateRetriever integrates with external Weaviate vector database.
 type WeaviateRetriever struct {
 	BaseRetriever
@@ -250,3 +366,46 @@ type QdrantRetriever struct {
 }
 
 func (r *QdrantRetriever) RetrieverType() RetrieverType { return Qdrant }
+
+// retrieverTypes creates an empty retriever for each Go type name.
+var retrieverTypes = map[string]func() Retriever{
+	"VectorRetriever":       func() Retriever { return &VectorRetriever{} },
+	"VectorCypherRetriever": func() Retriever { return &VectorCypherRetriever{} },
+	"HybridRetriever":       func() Retriever { return &HybridRetriever{} },
+	"HybridCypherRetriever": func() Retriever { return &HybridCypherRetriever{} },
+	"Text2CypherRetriever":  func() Retriever { return &Text2CypherRetriever{} },
+	"WeaviateRetriever":     func() Retriever { return &WeaviateRetriever{} },
+	"PineconeRetriever":     func() Retriever { return &PineconeRetriever{} },
+	"QdrantRetriever":       func() Retriever { return &QdrantRetriever{} },
+}
+
+// FromFields builds a retriever of the named Go type from field values keyed
+// by Go field name, such as those recorded by discovery. Fields of the
+// embedded BaseRetriever may be given directly or under "BaseRetriever".
+func FromFields(typeName string, fields map[string]any) (Retriever, error) {
+	newRetriever, ok := retrieverTypes[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown retriever type %q", typeName)
+	}
+
+	flat := make(map[string]any, len(fields))
+	for k, v := range fields {
+		if base, ok := v.(map[string]any); ok && k == "BaseRetriever" {
+			for bk, bv := range base {
+				flat[bk] = bv
+			}
+			continue
+		}
+		flat[k] = v
+	}
+
+	data, err := json.Marshal(flat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s fields: %w", typeName, err)
+	}
+	retriever := newRetriever()
+	if err := json.Unmarshal(data, retriever); err != nil {
+		return nil, fmt.Errorf("failed to decode %s fields: %w", typeName, err)
+	}
+	return retriever, nil
+}
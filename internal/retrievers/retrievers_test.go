@@ -2,6 +2,7 @@ package retrievers
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -398,3 +399,32 @@ func TestEmbedderConfig_Structure(t *testing.T) {
 		t.Error("Model should not be empty")
 	}
 }
+
+func TestFromFields(t *testing.T) {
+	r, err := FromFields("HybridRetriever", map[string]any{
+		"BaseRetriever":     map[string]any{"Name": "hybrid"},
+		"TopK":              int64(7),
+		"VectorIndexName":   "chunks",
+		"FulltextIndexName": "chunk_text",
+		"VectorWeight":      0.25,
+		"EmbedderConfig":    map[string]any{"Provider": "openai", "Model": "text-embedding-3-small"},
+	})
+	if err != nil {
+		t.Fatalf("FromFields failed: %v", err)
+	}
+
+	h, ok := r.(*HybridRetriever)
+	if !ok {
+		t.Fatalf("expected *HybridRetriever, got %T", r)
+	}
+	if h.Name != "hybrid" || h.TopK != 7 || h.VectorIndexName != "chunks" || h.VectorWeight != 0.25 {
+		t.Errorf("unexpected retriever: %+v", h)
+	}
+	if h.EmbedderConfig == nil || h.EmbedderConfig.Model != "text-embedding-3-small" {
+		t.Errorf("unexpected embedder config: %+v", h.EmbedderConfig)
+	}
+
+	if _, err := FromFields("MagicRetriever", nil); err == nil || !strings.Contains(err.Error(), "unknown retriever type") {
+		t.Errorf("expected unknown type error, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package retrievers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// defaultTopK is the number of results returned when TopK is unset.
+const defaultTopK = 5
+
+// QueryRunner runs a Cypher query and returns its records as maps.
+type QueryRunner interface {
+	Run(ctx context.Context, query string, params map[string]any) ([]map[string]any, error)
+}
+
+// driverRunner is a QueryRunner backed by a neo4j-go-driver session.
+type driverRunner struct {
+	driver   neo4j.DriverWithContext
+	database string
+}
+
+// NewDriverRunner creates a QueryRunner that runs read queries against
+// database (default: neo4j) through driver.
+func NewDriverRunner(driver neo4j.DriverWithContext, database string) QueryRunner {
+	if database == "" {
+		database = "neo4j"
+	}
+	return &driverRunner{driver: driver, database: database}
+}
+
+// Run executes query in a read session and collects all records.
+func (r *driverRunner) Run(ctx context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: r.database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer func() { _ = session.Close(ctx) }()
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	records, err := result.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]any, len(records))
+	for i, record := range records {
+		rows[i] = record.AsMap()
+	}
+	return rows, nil
+}
+
+// Result is a single retrieved item.
+type Result struct {
+	// ID is the element ID of the matched node. It is empty for rows returned
+	// by a RetrievalQuery.
+	ID string
+	// Score is the similarity score, or the combined score for hybrid search.
+	Score float64
+	// Record holds the node properties, or the columns returned by the
+	// RetrievalQuery.
+	Record map[string]any
+}
+
+// Runtime executes Neo4j-backed retrievers from their configurations.
+type Runtime struct {
+	runner     QueryRunner
+	embedder   Embedder
+	serializer *RetrieverSerializer
+}
+
+// NewRuntime creates a Runtime that queries through runner and embeds query
+// text with embedder.
+func NewRuntime(runner QueryRunner, embedder Embedder) *Runtime {
+	return &Runtime{runner: runner, embedder: embedder, serializer: NewRetrieverSerializer()}
+}
+
+// Search runs retriever for queryText and returns at most TopK results
+// scoring at least ScoreThreshold, best first. The query is exactly the one
+// rendered by RetrieverSerializer.ToCypher.
+func (rt *Runtime) Search(ctx context.Context, retriever Retriever, queryText string) ([]Result, error) {
+	switch retriever.(type) {
+	case *VectorRetriever, *VectorCypherRetriever, *HybridRetriever, *HybridCypherRetriever:
+	default:
+		return nil, fmt.Errorf("retriever type %s is not supported by the runtime", retriever.RetrieverType())
+	}
+
+	query, err := rt.serializer.ToCypher(retriever)
+	if err != nil {
+		return nil, err
+	}
+
+	if rt.embedder == nil {
+		return nil, fmt.Errorf("retriever %s: vector search requires an embedder", retriever.RetrieverName())
+	}
+	embedding, err := rt.embedder.EmbedQuery(ctx, queryText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	params := rt.serializer.Parameters(retriever)
+	params["queryText"] = queryText
+	params["embedding"] = embedding
+
+	rows, err := rt.runner.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("retriever %s: query failed: %w", retriever.RetrieverName(), err)
+	}
+	return toResults(rows), nil
+}
+
+func effectiveTopK(topK int) int {
+	if topK <= 0 {
+		return defaultTopK
+	}
+	return topK
+}
+
+// toResults converts query rows. Rows of the default RETURN clause carry the
+// node's id and properties; rows of a RetrievalQuery are kept as they are.
+func toResults(rows []map[string]any) []Result {
+	results := make([]Result, 0, len(rows))
+	for _, row := range rows {
+		score, _ := toFloat(row["score"])
+		result := Result{Score: score, Record: row}
+		if props, ok := row["properties"].(map[string]any); ok {
+			result.ID, _ = row["id"].(string)
+			result.Record = props
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
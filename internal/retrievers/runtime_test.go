@@ -0,0 +1,224 @@
+package retrievers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/graphrag"
+)
+
+// fakeRunner returns canned rows and records every query.
+type fakeRunner struct {
+	rows []map[string]any
+	err  error
+
+	queries []string
+	params  []map[string]any
+}
+
+func (f *fakeRunner) Run(_ context.Context, query string, params map[string]any) ([]map[string]any, error) {
+	f.queries = append(f.queries, query)
+	f.params = append(f.params, params)
+	return f.rows, f.err
+}
+
+func row(id string, score float64, props map[string]any) map[string]any {
+	return map[string]any{"id": id, "score": score, "properties": props}
+}
+
+func TestFakeEmbedder_Deterministic(t *testing.T) {
+	e := NewFakeEmbedder(32)
+	ctx := context.Background()
+
+	a, _ := e.EmbedQuery(ctx, "graph databases store relationships")
+	b, _ := e.EmbedQuery(ctx, "Graph databases store relationships!")
+	c, _ := e.EmbedQuery(ctx, "bananas")
+
+	if len(a) != 32 {
+		t.Fatalf("expected 32 dimensions, got %d", len(a))
+	}
+	if graphrag.CosineSimilarity(a, b) < 0.9999 {
+		t.Errorf("expected identical words to embed identically, similarity %v", graphrag.CosineSimilarity(a, b))
+	}
+	if graphrag.CosineSimilarity(a, c) >= graphrag.CosineSimilarity(a, b) {
+		t.Errorf("expected unrelated text to be less similar: %v >= %v", graphrag.CosineSimilarity(a, c), graphrag.CosineSimilarity(a, b))
+	}
+}
+
+func TestRuntime_Search_Vector(t *testing.T) {
+	runner := &fakeRunner{rows: []map[string]any{
+		row("n1", 0.95, map[string]any{"title": "Graphs"}),
+		row("n2", 0.80, map[string]any{"title": "Trees"}),
+	}}
+	rt := NewRuntime(runner, NewFakeEmbedder(8))
+	r := &VectorRetriever{
+		IndexName:        "chunks",
+		TopK:             3,
+		ScoreThreshold:   0.5,
+		ReturnProperties: []string{"title"},
+	}
+
+	results, err := rt.Search(context.Background(), r, "graphs")
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 2 || results[0].ID != "n1" || results[0].Score != 0.95 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if results[0].Record["title"] != "Graphs" {
+		t.Errorf("expected node properties as record, got %v", results[0].Record)
+	}
+
+	want, _ := NewRetrieverSerializer().ToCypher(r)
+	if runner.queries[0] != want {
+		t.Errorf("runtime query differs from ToCypher:\n%s", runner.queries[0])
+	}
+	params := runner.params[0]
+	if params["indexName"] != "chunks" || params["topK"] != 3 || params["scoreThreshold"] != 0.5 || params["queryText"] != "graphs" {
+		t.Errorf("unexpected params: %v", params)
+	}
+	if emb, ok := params["embedding"].([]float64); !ok || len(emb) != 8 {
+		t.Errorf("expected 8-dimensional embedding param, got %v", params["embedding"])
+	}
+}
+
+func TestRuntime_Search_Hybrid(t *testing.T) {
+	tests := []struct {
+		name           string
+		retriever      *HybridRetriever
+		vectorWeight   float64
+		fulltextWeight float64
+	}{
+		{
+			name: "explicit weights",
+			retriever: &HybridRetriever{
+				VectorIndexName:   "chunks",
+				FulltextIndexName: "chunk_text",
+				TopK:              2,
+				VectorWeight:      0.7,
+				FulltextWeight:    0.3,
+			},
+			vectorWeight:   0.7,
+			fulltextWeight: 0.3,
+		},
+		{
+			name: "default weights",
+			retriever: &HybridRetriever{
+				VectorIndexName:   "chunks",
+				FulltextIndexName: "chunk_text",
+			},
+			vectorWeight:   0.5,
+			fulltextWeight: 0.5,
+		},
+		{
+			name: "filtered",
+			retriever: &HybridRetriever{
+				VectorIndexName:   "chunks",
+				FulltextIndexName: "chunk_text",
+				NodeLabel:         "Chunk",
+				Filters:           &Filter{Property: "year", Gte: 2020},
+			},
+			vectorWeight:   0.5,
+			fulltextWeight: 0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{rows: []map[string]any{
+				row("n1", 0.9, map[string]any{"text": "graphs"}),
+			}}
+			rt := NewRuntime(runner, NewFakeEmbedder(8))
+
+			results, err := rt.Search(context.Background(), tt.retriever, "graphs")
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			if len(results) != 1 || results[0].ID != "n1" || results[0].Score != 0.9 {
+				t.Fatalf("unexpected results: %+v", results)
+			}
+
+			want, _ := NewRetrieverSerializer().ToCypher(tt.retriever)
+			if runner.queries[0] != want {
+				t.Errorf("runtime query differs from ToCypher:\n%s", runner.queries[0])
+			}
+			// A zero best score must not divide by zero
+			guarded := "CASE WHEN maxScore = 0 THEN 0.0 ELSE hit.score / maxScore END"
+			if strings.Count(runner.queries[0], guarded) != 2 || strings.Count(runner.queries[0], "/ maxScore") != 2 {
+				t.Errorf("expected both normalizations guarded against a zero maxScore:\n%s", runner.queries[0])
+			}
+			params := runner.params[0]
+			if params["vectorIndexName"] != "chunks" || params["fulltextIndexName"] != "chunk_text" || params["queryText"] != "graphs" {
+				t.Errorf("unexpected params: %v", params)
+			}
+			if params["vectorWeight"] != tt.vectorWeight || params["fulltextWeight"] != tt.fulltextWeight {
+				t.Errorf("weights = %v/%v, want %v/%v", params["vectorWeight"], params["fulltextWeight"], tt.vectorWeight, tt.fulltextWeight)
+			}
+			if _, ok := params["embedding"].([]float64); !ok {
+				t.Errorf("expected embedding param, got %v", params["embedding"])
+			}
+		})
+	}
+}
+
+func TestRuntime_Search_RetrievalQuery(t *testing.T) {
+	runner := &fakeRunner{rows: []map[string]any{
+		{"text": "about graphs", "score": 0.875},
+	}}
+	rt := NewRuntime(runner, NewFakeEmbedder(8))
+
+	results, err := rt.Search(context.Background(), &HybridCypherRetriever{
+		VectorIndexName:   "chunks",
+		FulltextIndexName: "chunk_text",
+		RetrievalQuery:    "RETURN node.text AS text, score",
+		VectorWeight:      0.25,
+		FulltextWeight:    0.75,
+	}, "graphs")
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].ID != "" || results[0].Score != 0.875 || results[0].Record["text"] != "about graphs" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if !strings.HasSuffix(runner.queries[0], "RETURN node.text AS text, score") {
+		t.Errorf("expected retrieval query at the end:\n%s", runner.queries[0])
+	}
+	params := runner.params[0]
+	if params["vectorWeight"] != 0.25 || params["fulltextWeight"] != 0.75 || params["topK"] != defaultTopK {
+		t.Errorf("unexpected params: %v", params)
+	}
+}
+
+func TestRuntime_Search_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		runtime   *Runtime
+		retriever Retriever
+		wantErr   string
+	}{
+		{"unsupported type", NewRuntime(&fakeRunner{}, NewFakeEmbedder(8)), &Text2CypherRetriever{}, "not supported"},
+		{"no embedder", NewRuntime(&fakeRunner{}, nil), &VectorRetriever{IndexName: "chunks"}, "requires an embedder"},
+		{"query failure", NewRuntime(&fakeRunner{err: errors.New("no such index")}, NewFakeEmbedder(8)), &VectorRetriever{IndexName: "chunks"}, "no such index"},
+		{
+			"missing retrieval query",
+			NewRuntime(&fakeRunner{}, NewFakeEmbedder(8)),
+			&VectorCypherRetriever{IndexName: "chunks"},
+			"retrieval query is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.runtime.Search(ctx, tt.retriever, "graphs")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Search() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
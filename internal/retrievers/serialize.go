@@ -46,6 +46,13 @@ func (s *RetrieverSerializer) ToMap(retriever Retriever) map[string]any {
 		if r.ScoreThreshold > 0 {
 			result["scoreThreshold"] = r.ScoreThreshold
 		}
+		if r.EmbeddingProperty != "" {
+			result["embeddingProperty"] = r.EmbeddingProperty
+		}
+		if r.SimilarityFunction != "" {
+			result["similarityFunction"] = r.SimilarityFunction
+		}
+		s.addSearchOptions(result, r.NodeLabel, r.Filters, r.Reranker)
 
 	case *VectorCypherRetriever:
 		s.addBaseFields(result, &r.BaseRetriever)
@@ -67,6 +74,13 @@ func (s *RetrieverSerializer) ToMap(retriever Retriever) map[string]any {
 		if r.ScoreThreshold > 0 {
 			result["scoreThreshold"] = r.ScoreThreshold
 		}
+		if r.EmbeddingProperty != "" {
+			result["embeddingProperty"] = r.EmbeddingProperty
+		}
+		if r.SimilarityFunction != "" {
+			result["similarityFunction"] = r.SimilarityFunction
+		}
+		s.addSearchOptions(result, r.NodeLabel, r.Filters, r.Reranker)
 
 	case *HybridRetriever:
 		s.addBaseFields(result, &r.BaseRetriever)
@@ -94,6 +108,13 @@ func (s *RetrieverSerializer) ToMap(retriever Retriever) map[string]any {
 		if r.FulltextWeight > 0 {
 			result["fulltextWeight"] = r.FulltextWeight
 		}
+		if r.EmbeddingProperty != "" {
+			result["embeddingProperty"] = r.EmbeddingProperty
+		}
+		if r.SimilarityFunction != "" {
+			result["similarityFunction"] = r.SimilarityFunction
+		}
+		s.addSearchOptions(result, r.NodeLabel, r.Filters, r.Reranker)
 
 	case *HybridCypherRetriever:
 		s.addBaseFields(result, &r.BaseRetriever)
@@ -121,6 +142,13 @@ func (s *RetrieverSerializer) ToMap(retriever Retriever) map[string]any {
 		if r.FulltextWeight > 0 {
 			result["fulltextWeight"] = r.FulltextWeight
 		}
+		if r.EmbeddingProperty != "" {
+			result["embeddingProperty"] = r.EmbeddingProperty
+		}
+		if r.SimilarityFunction != "" {
+			result["similarityFunction"] = r.SimilarityFunction
+		}
+		s.addSearchOptions(result, r.NodeLabel, r.Filters, r.Reranker)
 
 	case *Text2CypherRetriever:
 		s.addBaseFields(result, &r.BaseRetriever)
@@ -133,8 +161,8 @@ func (s *RetrieverSerializer) ToMap(retriever Retriever) map[string]any {
 		if r.LLMAPIKey != "" {
 			result["llmApiKey"] = r.LLMAPIKey
 		}
-		if r.SchemaDescription != "" {
-			result["schemaDescription"] = r.SchemaDescription
+		if prompt, err := r.SchemaPrompt(); err == nil && prompt.Text != "" {
+			result["schemaDescription"] = prompt.Text
 		}
 		if len(r.Examples) > 0 {
 			result["examples"] = s.examplesToMaps(r.Examples)
@@ -229,6 +257,33 @@ func (s *RetrieverSerializer) addBaseFields(result map[string]any, base *BaseRet
 	}
 }
 
+// addSearchOptions adds the node label, filters and reranker of vector and
+// hybrid retrievers to the map. Filters use neo4j-graphrag syntax.
+func (s *RetrieverSerializer) addSearchOptions(result map[string]any, label string, filters *Filter, reranker *RerankerConfig) {
+	if label != "" {
+		result["nodeLabel"] = label
+	}
+	if filters != nil {
+		result["filters"] = filters.ToMap()
+	}
+	if reranker != nil {
+		config := make(map[string]any)
+		if reranker.Provider != "" {
+			config["provider"] = reranker.Provider
+		}
+		if reranker.Model != "" {
+			config["model"] = reranker.Model
+		}
+		if reranker.TopN > 0 {
+			config["topN"] = reranker.TopN
+		}
+		if reranker.APIKey != "" {
+			config["apiKey"] = reranker.APIKey
+		}
+		result["reranker"] = config
+	}
+}
+
 // embedderConfigToMap converts an EmbedderConfig to a map.
 func (s *RetrieverSerializer) embedderConfigToMap(config *EmbedderConfig) map[string]any {
 	result := make(map[string]any)
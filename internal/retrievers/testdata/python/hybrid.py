@@ -0,0 +1,26 @@
+# Retriever: docs
+import os
+
+import neo4j
+from neo4j_graphrag.embeddings import OllamaEmbeddings
+from neo4j_graphrag.retrievers import HybridRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+embedder = OllamaEmbeddings(
+    model="nomic-embed-text",
+)
+
+retriever = HybridRetriever(
+    driver,
+    vector_index_name="chunk_embeddings",
+    fulltext_index_name="chunk_text",
+    embedder=embedder,
+    neo4j_database="neo4j",
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {"top_k": 8, "ranker": "linear", "alpha": 0.75}
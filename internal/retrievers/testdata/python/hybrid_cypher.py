@@ -0,0 +1,27 @@
+# Retriever: docs
+import os
+
+import neo4j
+from neo4j_graphrag.embeddings import OpenAIEmbeddings
+from neo4j_graphrag.retrievers import HybridCypherRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    "neo4j+s://demo.databases.neo4j.io",
+    auth=("neo4j", os.environ["NEO4J_PASSWORD"]),
+)
+
+embedder = OpenAIEmbeddings(
+    model="text-embedding-3-small",
+    api_key=os.environ["OPENAI_API_KEY"],
+)
+
+retriever = HybridCypherRetriever(
+    driver,
+    vector_index_name="chunk_embeddings",
+    fulltext_index_name="chunk_text",
+    retrieval_query="RETURN node.text AS text",
+    embedder=embedder,
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {}
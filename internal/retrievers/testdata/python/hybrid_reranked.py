@@ -0,0 +1,37 @@
+# Retriever: docs
+import os
+
+import cohere
+import neo4j
+from neo4j_graphrag.embeddings import OpenAIEmbeddings
+from neo4j_graphrag.retrievers import HybridRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+embedder = OpenAIEmbeddings(
+    model="text-embedding-3-small",
+    api_key=os.environ["OPENAI_API_KEY"],
+)
+
+retriever = HybridRetriever(
+    driver,
+    vector_index_name="chunk_embeddings",
+    fulltext_index_name="chunk_text",
+    embedder=embedder,
+    neo4j_database="neo4j",
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {}
+
+# Usage: rerank(query_text, retriever.search(query_text=query_text, **search_kwargs).items)
+reranker = cohere.ClientV2(api_key=os.environ["DOCS_COHERE_KEY"])
+
+
+def rerank(query_text, items, top_n=None):
+    documents = [str(item.content) for item in items]
+    response = reranker.rerank(model="rerank-v3.5", query=query_text, documents=documents, top_n=top_n)
+    return [items[result.index] for result in response.results]
@@ -0,0 +1,25 @@
+# Retriever: docs
+import os
+
+from pinecone import Pinecone
+import neo4j
+from neo4j_graphrag.retrievers import PineconeNeo4jRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+client = Pinecone(api_key=os.environ["PINECONE_KEY"])
+
+retriever = PineconeNeo4jRetriever(
+    driver=driver,
+    client=client,
+    index_name="documents",
+    id_property_neo4j="id",
+    retrieval_query="RETURN node.title AS title",
+    neo4j_database="neo4j",
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {}
@@ -0,0 +1,26 @@
+# Retriever: docs
+import os
+
+from qdrant_client import QdrantClient
+import neo4j
+from neo4j_graphrag.retrievers import QdrantNeo4jRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+client = QdrantClient(
+    url="http://localhost:6333",
+)
+
+retriever = QdrantNeo4jRetriever(
+    driver=driver,
+    client=client,
+    collection_name="documents",
+    id_property_neo4j="id",
+    neo4j_database="neo4j",
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {"top_k": 3}
@@ -0,0 +1,26 @@
+# Retriever: docs
+import os
+
+import neo4j
+from neo4j_graphrag.llm import AnthropicLLM
+from neo4j_graphrag.retrievers import Text2CypherRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+llm = AnthropicLLM(
+    model_name="claude-sonnet-4-5",
+    api_key=os.environ["ANTHROPIC_API_KEY"],
+)
+
+retriever = Text2CypherRetriever(
+    driver,
+    llm=llm,
+    neo4j_schema="(:Person)-[:ACTED_IN]->(:Movie)",
+    examples=[
+        "USER INPUT: 'Who acted in The Matrix?' QUERY: MATCH (p:Person)-[:ACTED_IN]->(:Movie {title: 'The Matrix'}) RETURN p.name",
+    ],
+    neo4j_database="neo4j",
+)
@@ -0,0 +1,28 @@
+# Retriever: docs
+import os
+
+import neo4j
+from neo4j_graphrag.embeddings import OpenAIEmbeddings
+from neo4j_graphrag.retrievers import VectorRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+embedder = OpenAIEmbeddings(
+    model="text-embedding-3-small",
+    api_key=os.environ["OPENAI_API_KEY"],
+)
+
+retriever = VectorRetriever(
+    driver,
+    index_name="document_embeddings",
+    embedder=embedder,
+    return_properties=["title", "text"],
+    neo4j_database="neo4j",
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {"top_k": 10}
+# Keep results with score >= 0.7; neo4j-graphrag does not filter by score.
@@ -0,0 +1,28 @@
+# Retriever: docs
+import os
+
+import neo4j
+from neo4j_graphrag.embeddings import AzureOpenAIEmbeddings
+from neo4j_graphrag.retrievers import VectorCypherRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+embedder = AzureOpenAIEmbeddings(
+    model="text-embedding-3-large",
+    api_key=os.environ["DOCS_AZURE_KEY"],
+    azure_endpoint=os.environ["AZURE_OPENAI_ENDPOINT"],
+)
+
+retriever = VectorCypherRetriever(
+    driver,
+    index_name="chunk_embeddings",
+    retrieval_query="MATCH (node)<-[:HAS_CHUNK]-(d:Document)\nRETURN d.title AS title, node.text AS text",
+    embedder=embedder,
+    neo4j_database="neo4j",
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {"top_k": 5}
@@ -0,0 +1,36 @@
+# Retriever: docs
+import os
+
+from sentence_transformers import CrossEncoder
+import neo4j
+from neo4j_graphrag.embeddings import OpenAIEmbeddings
+from neo4j_graphrag.retrievers import VectorRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+embedder = OpenAIEmbeddings(
+    model="text-embedding-3-small",
+    api_key=os.environ["OPENAI_API_KEY"],
+)
+
+retriever = VectorRetriever(
+    driver,
+    index_name="document_embeddings",
+    embedder=embedder,
+    neo4j_database="neo4j",
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {"filters": {"$or": [{"lang": {"$in": ["en", "de"]}}, {"draft": {"$eq": False}}], "year": {"$gte": 2020}}}
+
+# Usage: rerank(query_text, retriever.search(query_text=query_text, **search_kwargs).items)
+reranker = CrossEncoder("cross-encoder/ms-marco-MiniLM-L-6-v2")
+
+
+def rerank(query_text, items, top_n=3):
+    scores = reranker.predict([(query_text, str(item.content)) for item in items])
+    ranked = sorted(zip(scores, items), key=lambda pair: pair[0], reverse=True)
+    return [item for _, item in ranked[:top_n]]
@@ -0,0 +1,29 @@
+# Retriever: docs
+import os
+
+import weaviate
+from weaviate.classes.init import Auth
+import neo4j
+from neo4j_graphrag.retrievers import WeaviateNeo4jRetriever
+
+driver = neo4j.GraphDatabase.driver(
+    os.environ["NEO4J_URI"],
+    auth=(os.environ["NEO4J_USERNAME"], os.environ["NEO4J_PASSWORD"]),
+)
+
+client = weaviate.connect_to_weaviate_cloud(
+    cluster_url="https://demo.weaviate.network",
+    auth_credentials=Auth.api_key(os.environ["WEAVIATE_API_KEY"]),
+)
+
+retriever = WeaviateNeo4jRetriever(
+    driver=driver,
+    client=client,
+    collection="Documents",
+    id_property_external="neo4j_id",
+    id_property_neo4j="id",
+    neo4j_database="neo4j",
+)
+
+# Usage: retriever.search(query_text=..., **search_kwargs)
+search_kwargs = {"top_k": 5}
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
 	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
 )
 
@@ -25,6 +26,8 @@ import (
 type CypherSerializer struct {
 	// templates holds compiled Cypher templates.
 	templates *template.Template
+	// target selects the constraint and index syntax.
+	target target.Target
 }
 
 // NewCypherSerializer creates a new Cypher serializer.
@@ -34,6 +37,14 @@ func NewCypherSerializer() *CypherSerializer {
 	return s
 }
 
+// WithTarget sets the Neo4j version the statements are generated for. Before
+// Neo4j 4.4 constraints use ON ... ASSERT, and before Neo4j 5 BTREE indexes
+// are created with the BTREE keyword.
+func (s *CypherSerializer) WithTarget(t target.Target) *CypherSerializer {
+	s.target = t
+	return s
+}
+
 // initTemplates initializes the Cypher templates.
 func (s *CypherSerializer) initTemplates() *template.Template {
 	tmpl := template.New("cypher").Funcs(template.FuncMap{
@@ -43,23 +54,23 @@ func (s *CypherSerializer) initTemplates() *template.Template {
 
 	// Constraint templates
 	template.Must(tmpl.New("unique_constraint").Parse(
-		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS FOR (n:{{.Label}}) REQUIRE ({{range $i, $p := .Properties}}{{if $i}}, {{end}}n.{{$p}}{{end}}) IS UNIQUE`))
+		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS {{.For}} (n:{{.Label}}) {{.Require}} ({{range $i, $p := .Properties}}{{if $i}}, {{end}}n.{{$p}}{{end}}) IS UNIQUE`))
 
 	template.Must(tmpl.New("exists_constraint").Parse(
-		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS FOR (n:{{.Label}}) REQUIRE n.{{index .Properties 0}} IS NOT NULL`))
+		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS {{.For}} (n:{{.Label}}) {{.Require}} n.{{index .Properties 0}} IS NOT NULL`))
 
 	template.Must(tmpl.New("node_key_constraint").Parse(
-		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS FOR (n:{{.Label}}) REQUIRE ({{range $i, $p := .Properties}}{{if $i}}, {{end}}n.{{$p}}{{end}}) IS NODE KEY`))
+		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS {{.For}} (n:{{.Label}}) {{.Require}} ({{range $i, $p := .Properties}}{{if $i}}, {{end}}n.{{$p}}{{end}}) IS NODE KEY`))
 
 	template.Must(tmpl.New("rel_exists_constraint").Parse(
-		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS FOR ()-[r:{{.Label}}]-() REQUIRE r.{{index .Properties 0}} IS NOT NULL`))
+		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS {{.For}} ()-[r:{{.Label}}]-() {{.Require}} r.{{index .Properties 0}} IS NOT NULL`))
 
 	template.Must(tmpl.New("rel_key_constraint").Parse(
-		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS FOR ()-[r:{{.Label}}]-() REQUIRE ({{range $i, $p := .Properties}}{{if $i}}, {{end}}r.{{$p}}{{end}}) IS RELATIONSHIP KEY`))
+		`CREATE CONSTRAINT {{.Name}} IF NOT EXISTS {{.For}} ()-[r:{{.Label}}]-() {{.Require}} ({{range $i, $p := .Properties}}{{if $i}}, {{end}}r.{{$p}}{{end}}) IS RELATIONSHIP KEY`))
 
 	// Index templates
 	template.Must(tmpl.New("btree_index").Parse(
-		`CREATE INDEX {{.Name}} IF NOT EXISTS FOR (n:{{.Label}}) ON ({{range $i, $p := .Properties}}{{if $i}}, {{end}}n.{{$p}}{{end}})`))
+		`CREATE {{.Keyword}}INDEX {{.Name}} IF NOT EXISTS FOR (n:{{.Label}}) ON ({{range $i, $p := .Properties}}{{if $i}}, {{end}}n.{{$p}}{{end}})`))
 
 	template.Must(tmpl.New("text_index").Parse(
 		`CREATE TEXT INDEX {{.Name}} IF NOT EXISTS FOR (n:{{.Label}}) ON (n.{{index .Properties 0}})`))
@@ -84,13 +95,19 @@ type constraintData struct {
 	Name       string
 	Label      string
 	Properties []string
+	// For and Require are the constraint keywords: FOR and REQUIRE, or ON
+	// and ASSERT before Neo4j 4.4.
+	For     string
+	Require string
 }
 
 // indexData holds data for index templates.
 type indexData struct {
-	Name               string
-	Label              string
-	Properties         []string
+	Name       string
+	Label      string
+	Properties []string
+	// Keyword is "BTREE " for BTREE indexes before Neo4j 5.
+	Keyword            string
 	Dimensions         int
 	SimilarityFunction string
 }
@@ -177,11 +194,7 @@ func (s *CypherSerializer) SerializeRelationshipType(r *schema.RelationshipType)
 
 // serializeConstraint serializes a single node constraint.
 func (s *CypherSerializer) serializeConstraint(label string, c schema.Constraint) (string, error) {
-	data := constraintData{
-		Name:       c.Name,
-		Label:      label,
-		Properties: c.Properties,
-	}
+	data := s.constraintData(label, c)
 
 	var tmplName string
 	switch c.Type {
@@ -203,13 +216,25 @@ func (s *CypherSerializer) serializeConstraint(label string, c schema.Constraint
 	return buf.String(), nil
 }
 
-// serializeRelConstraint serializes a single relationship constraint.
-func (s *CypherSerializer) serializeRelConstraint(label string, c schema.Constraint) (string, error) {
+// constraintData returns the template data of a constraint in the target's
+// syntax.
+func (s *CypherSerializer) constraintData(label string, c schema.Constraint) constraintData {
 	data := constraintData{
 		Name:       c.Name,
 		Label:      label,
 		Properties: c.Properties,
+		For:        "FOR",
+		Require:    "REQUIRE",
 	}
+	if !s.target.RequireSyntax() {
+		data.For, data.Require = "ON", "ASSERT"
+	}
+	return data
+}
+
+// serializeRelConstraint serializes a single relationship constraint.
+func (s *CypherSerializer) serializeRelConstraint(label string, c schema.Constraint) (string, error) {
+	data := s.constraintData(label, c)
 
 	var tmplName string
 	switch c.Type {
@@ -255,6 +280,9 @@ func (s *CypherSerializer) serializeIndex(label string, idx schema.Index) (strin
 	switch idx.Type {
 	case schema.BTREE:
 		tmplName = "btree_index"
+		if !s.target.RangeIndexes() {
+			data.Keyword = "BTREE "
+		}
 	case schema.TEXT:
 		tmplName = "text_index"
 	case schema.FULLTEXT:
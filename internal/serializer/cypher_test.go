@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
 	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
 )
 
@@ -246,6 +247,55 @@ func TestCypherSerializer_SerializeRelationshipType_RelKey(t *testing.T) {
 	}
 }
 
+func TestCypherSerializer_WithTarget(t *testing.T) {
+	node := &schema.NodeType{
+		Label:       "Person",
+		Properties:  []schema.Property{{Name: "email", Type: schema.STRING, Unique: true}},
+		Constraints: []schema.Constraint{{Name: "person_key", Type: schema.NODE_KEY, Properties: []string{"first", "last"}}},
+		Indexes:     []schema.Index{{Name: "person_name_idx", Type: schema.BTREE, Properties: []string{"name"}}},
+	}
+	rel := &schema.RelationshipType{
+		Label: "KNOWS", Source: "Person", Target: "Person",
+		Properties: []schema.Property{{Name: "since", Type: schema.DATE, Required: true}},
+	}
+
+	tests := []struct {
+		name   string
+		target target.Target
+		want   []string
+	}{
+		{"neo4j 4.3", target.Target{Neo4j: target.V(4, 3)}, []string{
+			"CREATE CONSTRAINT person_key IF NOT EXISTS ON (n:Person) ASSERT (n.first, n.last) IS NODE KEY",
+			"CREATE CONSTRAINT person_email_unique IF NOT EXISTS ON (n:Person) ASSERT (n.email) IS UNIQUE",
+			"CREATE BTREE INDEX person_name_idx IF NOT EXISTS FOR (n:Person) ON (n.name)",
+			"CREATE CONSTRAINT knows_since_not_null IF NOT EXISTS ON ()-[r:KNOWS]-() ASSERT r.since IS NOT NULL",
+		}},
+		{"neo4j 4.4", target.Target{Neo4j: target.V(4, 4)}, []string{
+			"CREATE CONSTRAINT person_key IF NOT EXISTS FOR (n:Person) REQUIRE (n.first, n.last) IS NODE KEY",
+			"CREATE BTREE INDEX person_name_idx",
+			"CREATE CONSTRAINT knows_since_not_null IF NOT EXISTS FOR ()-[r:KNOWS]-() REQUIRE r.since IS NOT NULL",
+		}},
+		{"neo4j 5", target.Target{Neo4j: target.V(5, 0)}, []string{
+			"REQUIRE (n.email) IS UNIQUE",
+			"CREATE INDEX person_name_idx IF NOT EXISTS FOR (n:Person) ON (n.name)",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewCypherSerializer().WithTarget(tt.target).SerializeAll([]*schema.NodeType{node}, []*schema.RelationshipType{rel})
+			if err != nil {
+				t.Fatalf("SerializeAll failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(result, want) {
+					t.Errorf("expected %q, got:\n%s", want, result)
+				}
+			}
+		})
+	}
+}
+
 func TestCypherSerializer_SerializeRelationshipType_Empty(t *testing.T) {
 	s := NewCypherSerializer()
 	rel := &schema.RelationshipType{
@@ -0,0 +1,108 @@
+package target
+
+import (
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+// tier is an earlier name of a procedure, used before a GDS version.
+type tier struct {
+	name  string
+	until Version
+}
+
+// procedure is the history of a GDS procedure, keyed by its current name.
+type procedure struct {
+	// since is the first GDS version providing the procedure under any
+	// name. The zero Version means GDS 2.0.
+	since Version
+	// tiers are the alpha and beta names used before the current one,
+	// oldest first.
+	tiers []tier
+}
+
+// procedures holds the GDS 2.x history of the procedures the serializers
+// generate. Procedures without an entry have kept their name since GDS 2.0.
+var procedures = map[string]procedure{
+	"gds.closeness":                  {tiers: []tier{{"gds.beta.closeness", V(2, 5)}}},
+	"gds.closeness.harmonic":         {tiers: []tier{{"gds.alpha.closeness.harmonic", V(2, 5)}}},
+	"gds.hits":                       {tiers: []tier{{"gds.alpha.hits", V(2, 5)}}},
+	"gds.influenceMaximization.celf": {tiers: []tier{{"gds.beta.influenceMaximization.celf", V(2, 5)}}},
+	"gds.leiden":                     {tiers: []tier{{"gds.alpha.leiden", V(2, 3)}, {"gds.beta.leiden", V(2, 5)}}},
+	"gds.kcore":                      {since: V(2, 2)},
+	"gds.scc":                        {tiers: []tier{{"gds.alpha.scc", V(2, 5)}}},
+	"gds.modularityOptimization":     {tiers: []tier{{"gds.beta.modularityOptimization", V(2, 5)}}},
+	"gds.sllpa":                      {tiers: []tier{{"gds.alpha.sllpa", V(2, 5)}}},
+	"gds.kmeans":                     {tiers: []tier{{"gds.beta.kmeans", V(2, 5)}}},
+	"gds.k1coloring":                 {tiers: []tier{{"gds.beta.k1coloring", V(2, 5)}}},
+	"gds.conductance":                {tiers: []tier{{"gds.alpha.conductance", V(2, 5)}}},
+	"gds.modularity":                 {since: V(2, 1), tiers: []tier{{"gds.alpha.modularity", V(2, 5)}}},
+	"gds.knn":                        {tiers: []tier{{"gds.beta.knn", V(2, 5)}}},
+	"gds.node2vec":                   {tiers: []tier{{"gds.beta.node2vec", V(2, 5)}}},
+	"gds.hashgnn":                    {since: V(2, 3), tiers: []tier{{"gds.beta.hashgnn", V(2, 5)}}},
+	"gds.bfs":                        {tiers: []tier{{"gds.alpha.bfs", V(2, 5)}}},
+	"gds.dfs":                        {tiers: []tier{{"gds.alpha.dfs", V(2, 5)}}},
+	"gds.bellmanFord":                {since: V(2, 3), tiers: []tier{{"gds.beta.bellmanFord", V(2, 5)}}},
+	"gds.spanningTree":               {tiers: []tier{{"gds.alpha.spanningTree", V(2, 5)}}},
+	"gds.steinerTree":                {since: V(2, 1), tiers: []tier{{"gds.beta.steinerTree", V(2, 5)}}},
+	"gds.randomWalk":                 {tiers: []tier{{"gds.beta.randomWalk", V(2, 5)}}},
+	"gds.dag.longestPath":            {since: V(2, 5)},
+	"gds.graph.filter":               {tiers: []tier{{"gds.beta.graph.project.subgraph", V(2, 5)}}},
+	"gds.graph.sample.rwr":           {since: V(2, 2), tiers: []tier{{"gds.alpha.graph.sample.rwr", V(2, 5)}}},
+	"gds.graph.nodeLabel.mutate":     {since: V(2, 3), tiers: []tier{{"gds.alpha.graph.nodeLabel.mutate", V(2, 5)}}},
+	"gds.graph.sample.cnarw":         {since: V(2, 4), tiers: []tier{{"gds.alpha.graph.sample.cnarw", V(2, 5)}}},
+}
+
+// Procedure returns the name of the procedure called name in the latest GDS
+// version, such as gds.leiden, under the target's GDS version: its alpha or
+// beta name when it had not been promoted yet.
+func (t Target) Procedure(name string) string {
+	for _, tier := range procedures[name].tiers {
+		if !t.GDS.AtLeast(tier.until) {
+			return tier.name
+		}
+	}
+	return name
+}
+
+// ProcedureAvailable reports whether the target's GDS version provides the
+// procedure called name in the latest GDS version, and which version first
+// provided it.
+func (t Target) ProcedureAvailable(name string) (bool, Version) {
+	since := procedures[name].since
+	if since.IsZero() {
+		since = V(2, 0)
+	}
+	return t.GDS.AtLeast(since), since
+}
+
+// CypherProjectionDeprecated reports whether the target's GDS version
+// deprecates gds.graph.project.cypher in favor of Cypher aggregation, as
+// GDS 2.4 did.
+func (t Target) CypherProjectionDeprecated() bool {
+	return !t.GDS.IsZero() && t.GDS.AtLeast(V(2, 4))
+}
+
+// ConstraintAvailable reports whether the target's Neo4j version supports
+// the constraint type, and which version first did. Relationship key
+// constraints need Neo4j 5.7.
+func (t Target) ConstraintAvailable(c schema.ConstraintType) (bool, Version) {
+	since := V(4, 3)
+	if c == schema.REL_KEY {
+		since = V(5, 7)
+	}
+	return t.Neo4j.AtLeast(since), since
+}
+
+// IndexAvailable reports whether the target's Neo4j version supports the
+// index type, and which version first did. POINT indexes need Neo4j 4.4 and
+// VECTOR indexes Neo4j 5.13.
+func (t Target) IndexAvailable(i schema.IndexType) (bool, Version) {
+	since := V(4, 3)
+	switch i {
+	case schema.POINT_INDEX:
+		since = V(4, 4)
+	case schema.VECTOR:
+		since = V(5, 13)
+	}
+	return t.Neo4j.AtLeast(since), since
+}
@@ -0,0 +1,145 @@
+// Package target describes the Neo4j and GDS versions generated Cypher is
+// meant to run on.
+//
+// Neo4j and GDS have renamed procedures and changed DDL across versions:
+// GDS procedures move from the alpha and beta tiers to GA, constraints
+// moved from ON ... ASSERT to FOR ... REQUIRE in Neo4j 4.4, and Neo4j 5
+// replaced BTREE indexes with RANGE indexes. A Target selects the dialect
+// for the serializers and tells the linter which features are unavailable.
+//
+// The zero Target stands for the latest versions. Parse selects older ones:
+//
+//	t, err := target.Parse("4.4", "2.3")
+//	cypher, err := serializer.NewCypherSerializer().WithTarget(t).SerializeNodeType(node)
+package target
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Version is a major.minor version. The zero Version stands for the latest
+// version.
+type Version struct {
+	Major int
+	Minor int
+}
+
+// V returns the version major.minor.
+func V(major, minor int) Version {
+	return Version{Major: major, Minor: minor}
+}
+
+// ParseVersion parses a version such as "5", "4.4" or "2.6.1". The patch
+// version is ignored.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimSpace(s), ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	var numbers [2]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", s)
+		}
+		if i < 2 {
+			numbers[i] = n
+		}
+	}
+	return Version{Major: numbers[0], Minor: numbers[1]}, nil
+}
+
+// IsZero reports whether v is the zero Version.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// AtLeast reports whether v is o or later. The zero Version is later than
+// any other.
+func (v Version) AtLeast(o Version) bool {
+	if v.IsZero() {
+		return true
+	}
+	if o.IsZero() {
+		return false
+	}
+	if v.Major != o.Major {
+		return v.Major > o.Major
+	}
+	return v.Minor >= o.Minor
+}
+
+// String returns the version as major.minor, or "latest" for the zero
+// Version.
+func (v Version) String() string {
+	if v.IsZero() {
+		return "latest"
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Target is a Neo4j and GDS version pair. A zero field stands for the
+// latest version.
+type Target struct {
+	// Neo4j is the Neo4j version (4.3 to 5.x).
+	Neo4j Version
+	// GDS is the Graph Data Science library version (2.x).
+	GDS Version
+}
+
+// Parse creates a Target from Neo4j and GDS version strings. An empty
+// string leaves the version unset.
+func Parse(neo4j, gds string) (Target, error) {
+	var t Target
+	if neo4j != "" {
+		v, err := ParseVersion(neo4j)
+		if err != nil {
+			return Target{}, fmt.Errorf("neo4j: %w", err)
+		}
+		if !v.AtLeast(V(4, 3)) || v.Major > 5 {
+			return Target{}, fmt.Errorf("unsupported Neo4j version %s: supported versions are 4.3 to 5.x", v)
+		}
+		t.Neo4j = v
+	}
+	if gds != "" {
+		v, err := ParseVersion(gds)
+		if err != nil {
+			return Target{}, fmt.Errorf("gds: %w", err)
+		}
+		if v.Major != 2 {
+			return Target{}, fmt.Errorf("unsupported GDS version %s: supported versions are 2.x", v)
+		}
+		t.GDS = v
+	}
+	return t, nil
+}
+
+// FromEnv creates a Target from $NEO4J_VERSION and $GDS_VERSION.
+func FromEnv() (Target, error) {
+	return Parse(os.Getenv("NEO4J_VERSION"), os.Getenv("GDS_VERSION"))
+}
+
+// IsZero reports whether neither version is set.
+func (t Target) IsZero() bool {
+	return t.Neo4j.IsZero() && t.GDS.IsZero()
+}
+
+// String describes the target, such as "Neo4j 4.4, GDS 2.3".
+func (t Target) String() string {
+	return fmt.Sprintf("Neo4j %s, GDS %s", t.Neo4j, t.GDS)
+}
+
+// RequireSyntax reports whether constraints use FOR ... REQUIRE rather than
+// ON ... ASSERT, which Neo4j 4.4 deprecated and Neo4j 5 removed.
+func (t Target) RequireSyntax() bool {
+	return t.Neo4j.AtLeast(V(4, 4))
+}
+
+// RangeIndexes reports whether CREATE INDEX creates a RANGE index. Before
+// Neo4j 5 it creates a BTREE index, which Neo4j 5 removed.
+func (t Target) RangeIndexes() bool {
+	return t.Neo4j.AtLeast(V(5, 0))
+}
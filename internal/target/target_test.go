@@ -0,0 +1,110 @@
+package target
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		neo4j, gds string
+		want       Target
+		wantErr    string
+	}{
+		{"4.4", "2.3", Target{Neo4j: V(4, 4), GDS: V(2, 3)}, ""},
+		{"5", "", Target{Neo4j: V(5, 0)}, ""},
+		{"5.13.0", "2.6.1", Target{Neo4j: V(5, 13), GDS: V(2, 6)}, ""},
+		{"", "", Target{}, ""},
+		{"4.2", "", Target{}, "unsupported Neo4j version 4.2"},
+		{"6.0", "", Target{}, "unsupported Neo4j version 6.0"},
+		{"", "1.8", Target{}, "unsupported GDS version 1.8"},
+		{"five", "", Target{}, `neo4j: invalid version "five"`},
+		{"", "2.x", Target{}, `gds: invalid version "2.x"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.neo4j+"/"+tt.gds, func(t *testing.T) {
+			got, err := Parse(tt.neo4j, tt.gds)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Parse() = %+v, %v; want %+v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersion_AtLeast(t *testing.T) {
+	tests := []struct {
+		v, o Version
+		want bool
+	}{
+		{V(4, 4), V(4, 4), true},
+		{V(5, 0), V(4, 4), true},
+		{V(4, 3), V(4, 4), false},
+		{Version{}, V(5, 13), true},
+		{V(5, 26), Version{}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.v.AtLeast(tt.o); got != tt.want {
+			t.Errorf("%s.AtLeast(%s) = %v, want %v", tt.v, tt.o, got, tt.want)
+		}
+	}
+}
+
+func TestTarget_Procedure(t *testing.T) {
+	tests := []struct {
+		gds  Version
+		name string
+		want string
+	}{
+		{V(2, 2), "gds.leiden", "gds.alpha.leiden"},
+		{V(2, 4), "gds.leiden", "gds.beta.leiden"},
+		{V(2, 5), "gds.leiden", "gds.leiden"},
+		{Version{}, "gds.leiden", "gds.leiden"},
+		{V(2, 0), "gds.pageRank", "gds.pageRank"},
+		{V(2, 0), "gds.beta.graphSage", "gds.beta.graphSage"},
+		{V(2, 3), "gds.graph.filter", "gds.beta.graph.project.subgraph"},
+	}
+	for _, tt := range tests {
+		if got := (Target{GDS: tt.gds}).Procedure(tt.name); got != tt.want {
+			t.Errorf("GDS %s: Procedure(%s) = %s, want %s", tt.gds, tt.name, got, tt.want)
+		}
+	}
+
+	if ok, since := (Target{GDS: V(2, 2)}).ProcedureAvailable("gds.hashgnn"); ok || since != V(2, 3) {
+		t.Errorf("expected gds.hashgnn to need GDS 2.3, got %v, %s", ok, since)
+	}
+	if ok, _ := (Target{GDS: V(2, 0)}).ProcedureAvailable("gds.pageRank"); !ok {
+		t.Error("expected gds.pageRank to be available in GDS 2.0")
+	}
+}
+
+func TestTarget_Neo4jFeatures(t *testing.T) {
+	t43, t44, t5 := Target{Neo4j: V(4, 3)}, Target{Neo4j: V(4, 4)}, Target{Neo4j: V(5, 0)}
+
+	if t43.RequireSyntax() || !t44.RequireSyntax() || !(Target{}).RequireSyntax() {
+		t.Error("expected FOR ... REQUIRE from Neo4j 4.4")
+	}
+	if t44.RangeIndexes() || !t5.RangeIndexes() {
+		t.Error("expected RANGE indexes from Neo4j 5")
+	}
+	if ok, _ := t5.ConstraintAvailable(schema.REL_KEY); ok {
+		t.Error("expected relationship keys to need Neo4j 5.7")
+	}
+	if ok, _ := t43.IndexAvailable(schema.POINT_INDEX); ok {
+		t.Error("expected POINT indexes to need Neo4j 4.4")
+	}
+	if ok, _ := t43.IndexAvailable(schema.TEXT); !ok {
+		t.Error("expected TEXT indexes in Neo4j 4.3")
+	}
+	if (Target{}).CypherProjectionDeprecated() || !(Target{GDS: V(2, 4)}).CypherProjectionDeprecated() {
+		t.Error("expected Cypher projections to be deprecated from GDS 2.4 only when GDS is set")
+	}
+}
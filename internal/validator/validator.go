@@ -330,11 +330,98 @@ func (v *Validator) ValidateAlgorithm(algo algorithms.Algorithm) []ValidationRes
 			Message: fmt.Sprintf("graph '%s' %s in catalog", graphName, existsMsg(graphExists)),
 			Details: map[string]any{"exists": graphExists},
 		})
+
+		// Path finding algorithms reference a weight property and nodes by ID
+		if graphExists && algo.AlgorithmCategory() == algorithms.PathFinding {
+			results = append(results, v.validatePathFinding(algo)...)
+		}
+	}
+
+	return results
+}
+
+// validatePathFinding checks that the weight property of a path finding
+// algorithm is in the projected graph and that its source and target nodes exist.
+func (v *Validator) validatePathFinding(algo algorithms.Algorithm) []ValidationResult {
+	var results []ValidationResult
+	algoName := algo.AlgorithmType()
+	weight, nodes := pathFindingReferences(algo)
+
+	if weight != "" {
+		exists := v.relationshipPropertyExists(algo.GetGraphName(), weight)
+		results = append(results, ValidationResult{
+			Type:    "algorithm",
+			Target:  fmt.Sprintf("%s.relationshipWeightProperty", algoName),
+			Valid:   exists,
+			Message: fmt.Sprintf("relationship property '%s' %s in graph '%s'", weight, existsMsg(exists), algo.GetGraphName()),
+			Details: map[string]any{"exists": exists},
+		})
+	}
+
+	for _, node := range nodes {
+		id, ok := nodeID(node)
+		if !ok {
+			continue
+		}
+		exists := v.nodeExists(id)
+		results = append(results, ValidationResult{
+			Type:    "algorithm",
+			Target:  fmt.Sprintf("%s.node(%d)", algoName, id),
+			Valid:   exists,
+			Message: fmt.Sprintf("node %d %s in database", id, existsMsg(exists)),
+			Details: map[string]any{"exists": exists},
+		})
 	}
 
 	return results
 }
 
+// pathFindingReferences returns the relationship weight property and the
+// source and target nodes referenced by a path finding algorithm.
+func pathFindingReferences(algo algorithms.Algorithm) (string, []any) {
+	switch a := algo.(type) {
+	case *algorithms.Dijkstra:
+		return a.RelationshipWeightProperty, []any{a.SourceNode, a.TargetNode}
+	case *algorithms.AStar:
+		return a.RelationshipWeightProperty, []any{a.SourceNode, a.TargetNode}
+	case *algorithms.BellmanFord:
+		return a.RelationshipWeightProperty, []any{a.SourceNode}
+	case *algorithms.Yens:
+		return a.RelationshipWeightProperty, []any{a.SourceNode, a.TargetNode}
+	case *algorithms.DeltaStepping:
+		return a.RelationshipWeightProperty, []any{a.SourceNode}
+	case *algorithms.AllShortestPaths:
+		return a.RelationshipWeightProperty, nil
+	case *algorithms.MinimumSpanningTree:
+		return a.RelationshipWeightProperty, []any{a.SourceNode}
+	case *algorithms.SteinerTree:
+		return a.RelationshipWeightProperty, append([]any{a.SourceNode}, a.TargetNodes...)
+	case *algorithms.RandomWalk:
+		return a.RelationshipWeightProperty, a.SourceNodes
+	case *algorithms.LongestPath:
+		return a.RelationshipWeightProperty, nil
+	case *algorithms.BFS:
+		return "", append([]any{a.SourceNode}, a.TargetNodes...)
+	case *algorithms.DFS:
+		return "", append([]any{a.SourceNode}, a.TargetNodes...)
+	}
+	return "", nil
+}
+
+// nodeID converts a node reference to a node ID. Only integer references
+// are node IDs; other values cannot be checked.
+func nodeID(node any) (int64, bool) {
+	switch n := node.(type) {
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	}
+	return 0, false
+}
+
 // ValidateProjection validates a graph projection configuration.
 func (v *Validator) ValidateProjection(proj projections.Projection) []ValidationResult {
 	var results []ValidationResult
@@ -492,6 +579,53 @@ func (v *Validator) graphExists(graphName string) bool {
 	return false
 }
 
+func (v *Validator) relationshipPropertyExists(graphName, property string) bool {
+	ctx := context.Background()
+	session := v.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: v.config.Database})
+	defer func() { _ = session.Close(ctx) }()
+
+	query := `CALL gds.graph.list($name) YIELD schema
+UNWIND keys(schema.relationships) AS type
+WITH schema.relationships[type] AS properties
+WHERE $property IN keys(properties)
+RETURN count(*) > 0 AS exists`
+	result, err := session.Run(ctx, query, map[string]any{"name": graphName, "property": property})
+	if err != nil {
+		return false
+	}
+
+	if result.Next(ctx) {
+		if exists, ok := result.Record().Get("exists"); ok {
+			if b, ok := exists.(bool); ok {
+				return b
+			}
+		}
+	}
+
+	return false
+}
+
+func (v *Validator) nodeExists(id int64) bool {
+	ctx := context.Background()
+	session := v.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: v.config.Database})
+	defer func() { _ = session.Close(ctx) }()
+
+	result, err := session.Run(ctx, "MATCH (n) WHERE id(n) = $id RETURN count(n) > 0 AS exists", map[string]any{"id": id})
+	if err != nil {
+		return false
+	}
+
+	if result.Next(ctx) {
+		if exists, ok := result.Record().Get("exists"); ok {
+			if b, ok := exists.(bool); ok {
+				return b
+			}
+		}
+	}
+
+	return false
+}
+
 func (v *Validator) validateConstraint(label string, c schema.Constraint) ValidationResult {
 	// We don't actually create the constraint, just validate it could be created
 	constraintType := string(c.Type)
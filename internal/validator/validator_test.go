@@ -3,6 +3,7 @@ package validator
 import (
 	"testing"
 
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
 	"github.com/lex00/wetwire-neo4j-go/pkg/neo4j/schema"
 )
 
@@ -502,3 +503,50 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestPathFindingReferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		algo   algorithms.Algorithm
+		weight string
+		nodes  int
+	}{
+		{"dijkstra", &algorithms.Dijkstra{SourceNode: 1, TargetNode: 2, RelationshipWeightProperty: "distance"}, "distance", 2},
+		{"bellman ford", &algorithms.BellmanFord{SourceNode: 1, RelationshipWeightProperty: "cost"}, "cost", 1},
+		{"steiner tree", &algorithms.SteinerTree{SourceNode: 1, TargetNodes: []any{2, 3}}, "", 3},
+		{"random walk", &algorithms.RandomWalk{SourceNodes: []any{5}}, "", 1},
+		{"not path finding", &algorithms.PageRank{}, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weight, nodes := pathFindingReferences(tt.algo)
+			if weight != tt.weight {
+				t.Errorf("weight = %q, want %q", weight, tt.weight)
+			}
+			if len(nodes) != tt.nodes {
+				t.Errorf("nodes = %v, want %d", nodes, tt.nodes)
+			}
+		})
+	}
+}
+
+func TestNodeID(t *testing.T) {
+	tests := []struct {
+		node any
+		id   int64
+		ok   bool
+	}{
+		{42, 42, true},
+		{int64(7), 7, true},
+		{"alice", 0, false},
+		{nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		id, ok := nodeID(tt.node)
+		if id != tt.id || ok != tt.ok {
+			t.Errorf("nodeID(%v) = (%d, %v), want (%d, %v)", tt.node, id, ok, tt.id, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package workflows
+
+import (
+	"github.com/lex00/wetwire-neo4j-go/internal/estimation"
+)
+
+// StepMemory is the estimated heap while a workflow step runs.
+type StepMemory struct {
+	// Step is the 1-based position of the step in the workflow.
+	Step int
+	// StepName is the algorithm or operation name.
+	StepName string
+	// Graph is the in-memory graph, including properties mutated by earlier steps.
+	Graph estimation.Estimate
+	// Working is the heap the step needs on top of the graph.
+	Working estimation.Estimate
+	// Output is what a mutate step adds to the graph.
+	Output estimation.Estimate
+	// Total is the sum of Graph, Working and Output.
+	Total estimation.Estimate
+}
+
+// MemoryReport is the offline heap estimate of a workflow.
+type MemoryReport struct {
+	// Projection is the estimate of the projected graph.
+	Projection estimation.GraphEstimate
+	// Steps are the per-step estimates, in order.
+	Steps []StepMemory
+	// Peak is the largest step total, or the projection if there are no steps.
+	Peak estimation.Estimate
+	// PeakStep is the 1-based step that reaches the peak, or 0 for the projection.
+	PeakStep int
+}
+
+// EstimateMemory estimates the heap a workflow needs against a declared graph
+// size. The graph grows as mutate steps add properties and relationships, so
+// the peak is usually reached by a late step.
+func (w *Workflow) EstimateMemory(size estimation.GraphSize) MemoryReport {
+	var report MemoryReport
+	if w.Projection == nil {
+		return report
+	}
+
+	report.Projection = estimation.EstimateProjection(w.Projection, size)
+	report.Peak = report.Projection.Memory
+
+	graph := report.Projection.Memory
+	nodes := report.Projection.NodeCount
+	// Relationship counts of types added by mutate steps
+	mutatedRels := make(map[string]int64)
+	var mutatedTotal int64
+
+	for i, step := range w.Steps {
+		sm := StepMemory{Step: i + 1, StepName: step.StepName(), Graph: graph}
+
+		if step.Algorithm != nil {
+			v := structValue(step.Algorithm)
+			algoNodes := nodes
+			if labels := stringsField(v, "NodeLabels"); len(labels) > 0 {
+				algoNodes = min(nodes, size.Nodes(labels))
+			}
+			rels := report.Projection.RelationshipCount + mutatedTotal
+			if types := stringsField(v, "RelationshipTypes"); len(types) > 0 {
+				rels = 0
+				for _, t := range types {
+					if count, ok := mutatedRels[t]; ok {
+						rels += count
+					} else {
+						rels += size.Relationships([]string{t})
+					}
+				}
+			}
+
+			sm.Working = estimation.AlgorithmMemory(step.Algorithm, algoNodes, rels)
+
+			out := estimation.AlgorithmOutput(step.Algorithm, algoNodes)
+			sm.Output = out.Memory
+			graph = graph.Add(out.Memory)
+			if relType := stringField(v, "MutateRelationshipType"); relType != "" && out.Relationships > 0 {
+				mutatedRels[relType] += out.Relationships
+				mutatedTotal += out.Relationships
+			}
+		}
+
+		sm.Total = sm.Graph.Add(sm.Working).Add(sm.Output)
+		if sm.Total.BytesMax > report.Peak.BytesMax {
+			report.Peak = sm.Total
+			report.PeakStep = sm.Step
+		}
+		report.Steps = append(report.Steps, sm)
+	}
+
+	return report
+}
@@ -0,0 +1,114 @@
+package workflows
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
+)
+
+// WorkflowSerializer serializes workflows to a Cypher script and JSON.
+type WorkflowSerializer struct {
+	algoSerializer *algorithms.AlgorithmSerializer
+	projSerializer *projections.ProjectionSerializer
+}
+
+// NewWorkflowSerializer creates a new workflow serializer.
+func NewWorkflowSerializer() *WorkflowSerializer {
+	return &WorkflowSerializer{
+		algoSerializer: algorithms.NewAlgorithmSerializer(),
+		projSerializer: projections.NewProjectionSerializer(),
+	}
+}
+
+// WithTarget sets the Neo4j and GDS versions the projection and algorithm
+// calls are generated for.
+func (s *WorkflowSerializer) WithTarget(t target.Target) *WorkflowSerializer {
+	s.algoSerializer.WithTarget(t)
+	s.projSerializer.WithTarget(t)
+	return s
+}
+
+// ToCypher converts a workflow to a single Cypher script. Statements are
+// terminated with semicolons so the script runs with cypher-shell. When
+// DropGraph is set, the graph is dropped in a trailing finally section;
+// run the script with --fail-at-end so that section executes after a failure.
+func (s *WorkflowSerializer) ToCypher(w *Workflow) (string, error) {
+	if err := w.Validate(); err != nil {
+		return "", err
+	}
+	if _, ok := w.Projection.(*projections.DataFrameProjection); ok {
+		return "", fmt.Errorf("workflow %s: DataFrame projections run in Aura sessions, not Cypher", w.Name)
+	}
+
+	graphName := w.Projection.GetGraphName()
+	var sections []string
+
+	header := fmt.Sprintf("// Workflow: %s", w.Name)
+	if w.DropGraph {
+		header += fmt.Sprintf("\n// Run with: cypher-shell --fail-at-end -f %s.cypher", w.Name)
+	}
+	sections = append(sections, header)
+
+	projection, err := s.projSerializer.ToCypher(w.Projection)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize projection %s: %w", w.Projection.ProjectionName(), err)
+	}
+	sections = append(sections, fmt.Sprintf("// Projection: %s\n%s;", graphName, projection))
+
+	for i, step := range w.Steps {
+		var stmt, procedure string
+		if step.Algorithm != nil {
+			stmt, err = s.algoSerializer.ToCypher(step.Algorithm)
+			procedure = step.Algorithm.AlgorithmType() + "." + string(step.Algorithm.GetMode())
+		} else {
+			stmt, err = s.projSerializer.OperationToCypher(step.Operation)
+			procedure = step.Operation.Procedure()
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize step %d (%s): %w", i+1, step.StepName(), err)
+		}
+		sections = append(sections, fmt.Sprintf("// Step %d: %s - %s\n%s;", i+1, step.StepName(), procedure, stmt))
+	}
+
+	if w.DropGraph {
+		sections = append(sections, fmt.Sprintf("// finally\nCALL gds.graph.drop('%s', false) YIELD graphName;", graphName))
+	}
+
+	return strings.Join(sections, "\n\n") + "\n", nil
+}
+
+// ToJSON converts a workflow to JSON.
+func (s *WorkflowSerializer) ToJSON(w *Workflow) ([]byte, error) {
+	return json.MarshalIndent(s.ToMap(w), "", "  ")
+}
+
+// ToMap converts a workflow to a map.
+func (s *WorkflowSerializer) ToMap(w *Workflow) map[string]any {
+	result := map[string]any{
+		"name": w.Name,
+	}
+	if w.Projection != nil {
+		result["projection"] = s.projSerializer.ToMap(w.Projection)
+	}
+
+	steps := make([]map[string]any, 0, len(w.Steps))
+	for _, step := range w.Steps {
+		switch {
+		case step.Algorithm != nil:
+			steps = append(steps, map[string]any{"algorithm": s.algoSerializer.ToMap(step.Algorithm)})
+		case step.Operation != nil:
+			steps = append(steps, map[string]any{"operation": s.projSerializer.OperationToMap(step.Operation)})
+		}
+	}
+	result["steps"] = steps
+
+	if w.DropGraph {
+		result["dropGraph"] = true
+	}
+
+	return result
+}
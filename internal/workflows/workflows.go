@@ -0,0 +1,298 @@
+// Package workflows provides ordered analytics workflows over a GDS graph projection.
+//
+// A Workflow ties together the steps of a typical GDS job: project a graph, run
+// algorithms in mutate mode that feed each other, write results back, and drop
+// the graph when done.
+//
+// Example usage:
+//
+//	workflow := &workflows.Workflow{
+//		Name:       "customer_segments",
+//		Projection: CustomerGraph,
+//		Steps: []workflows.Step{
+//			{Algorithm: CustomerEmbeddings}, // FastRP, mutate "embedding"
+//			{Algorithm: SimilarCustomers},   // KNN on "embedding", mutate SIMILAR
+//			{Algorithm: Segments},           // Louvain over SIMILAR, write "segment"
+//		},
+//		DropGraph: true,
+//	}
+//	script, err := workflows.NewWorkflowSerializer().ToCypher(workflow)
+package workflows
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+)
+
+// Workflow is an ordered sequence of algorithm and catalog steps over one projection.
+type Workflow struct {
+	// Name is the workflow name.
+	Name string
+	// Projection is the graph projected before the first step.
+	Projection projections.Projection
+	// Steps run in order after the projection.
+	Steps []Step
+	// DropGraph drops the projected graph in the finally section of the script.
+	DropGraph bool
+}
+
+// Step is a single workflow step. Exactly one of Algorithm or Operation is set.
+type Step struct {
+	// Algorithm is a GDS algorithm to run against the workflow graph.
+	Algorithm algorithms.Algorithm
+	// Operation is a graph catalog operation such as a property write-back.
+	Operation projections.CatalogOperation
+}
+
+// StepName returns the name of the algorithm or operation in this step.
+func (s Step) StepName() string {
+	switch {
+	case s.Algorithm != nil:
+		return s.Algorithm.AlgorithmName()
+	case s.Operation != nil:
+		return s.Operation.OperationName()
+	default:
+		return ""
+	}
+}
+
+// Validate checks the workflow structure: a projection, at least one step, and
+// every step bound to the workflow graph.
+func (w *Workflow) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("workflow name is required")
+	}
+	if w.Projection == nil {
+		return fmt.Errorf("workflow %s has no projection", w.Name)
+	}
+	if len(w.Steps) == 0 {
+		return fmt.Errorf("workflow %s has no steps", w.Name)
+	}
+
+	graphName := w.Projection.GetGraphName()
+	for i, step := range w.Steps {
+		switch {
+		case step.Algorithm != nil && step.Operation != nil:
+			return fmt.Errorf("workflow %s step %d sets both Algorithm and Operation", w.Name, i+1)
+		case step.Algorithm != nil:
+			if step.Algorithm.GetGraphName() != graphName {
+				return fmt.Errorf("workflow %s step %d (%s) runs on graph '%s', want '%s'",
+					w.Name, i+1, step.StepName(), step.Algorithm.GetGraphName(), graphName)
+			}
+		case step.Operation != nil:
+			graph := step.Operation.GetGraph()
+			if graph == nil || graph.GetGraphName() != graphName {
+				return fmt.Errorf("workflow %s step %d (%s) does not act on graph '%s'",
+					w.Name, i+1, step.StepName(), graphName)
+			}
+		default:
+			return fmt.Errorf("workflow %s step %d is empty", w.Name, i+1)
+		}
+	}
+
+	return nil
+}
+
+// PropertyKind distinguishes the graph elements a step reads or produces.
+type PropertyKind string
+
+const (
+	// NodeProperty is a property on nodes of the projection.
+	NodeProperty PropertyKind = "node property"
+	// RelationshipProperty is a property on relationships of the projection.
+	RelationshipProperty PropertyKind = "relationship property"
+	// RelationshipType is a relationship type in the projection.
+	RelationshipType PropertyKind = "relationship type"
+)
+
+// MissingInput describes a step input that neither the projection nor an
+// earlier mutate step provides.
+type MissingInput struct {
+	// Step is the 1-based position of the step in the workflow.
+	Step int
+	// StepName is the algorithm or operation name.
+	StepName string
+	// Kind is the kind of graph element the step reads.
+	Kind PropertyKind
+	// Name is the property or relationship type name.
+	Name string
+}
+
+// UnusedOutput describes a mutated property that no later step reads.
+type UnusedOutput struct {
+	// Step is the 1-based position of the producing step.
+	Step int
+	// StepName is the algorithm or operation name.
+	StepName string
+	// Kind is the kind of graph element produced.
+	Kind PropertyKind
+	// Name is the property or relationship type name.
+	Name string
+}
+
+// graphElement identifies a property or relationship type in the in-memory graph.
+type graphElement struct {
+	kind PropertyKind
+	name string
+}
+
+// DataFlow checks that every step input is produced by the projection or an
+// earlier mutate step, and reports mutated outputs that are never consumed.
+// Inputs of Cypher projections cannot be known statically and are not checked.
+func (w *Workflow) DataFlow() ([]MissingInput, []UnusedOutput) {
+	if w.Projection == nil {
+		return nil, nil
+	}
+	_, isCypher := w.Projection.(*projections.CypherProjection)
+	checkInputs := !isCypher
+	// An empty relationship projection projects all types ('*').
+	allRelTypes := len(w.Projection.GetRelationshipProjections()) == 0
+
+	available := make(map[graphElement]bool)
+	for _, np := range w.Projection.GetNodeProjections() {
+		for _, p := range np.Properties {
+			available[graphElement{NodeProperty, p}] = true
+		}
+	}
+	for _, rp := range w.Projection.GetRelationshipProjections() {
+		available[graphElement{RelationshipType, rp.Type}] = true
+		for _, p := range rp.Properties {
+			available[graphElement{RelationshipProperty, p}] = true
+		}
+	}
+
+	var missing []MissingInput
+	var unused []UnusedOutput
+
+	for i, step := range w.Steps {
+		for _, in := range stepInputs(step) {
+			if allRelTypes && in.kind == RelationshipType {
+				continue
+			}
+			if checkInputs && !available[in] {
+				missing = append(missing, MissingInput{
+					Step:     i + 1,
+					StepName: step.StepName(),
+					Kind:     in.kind,
+					Name:     in.name,
+				})
+			}
+		}
+		for _, out := range stepOutputs(step) {
+			available[out] = true
+			if isConsumedLater(w.Steps[i+1:], out) {
+				continue
+			}
+			unused = append(unused, UnusedOutput{
+				Step:     i + 1,
+				StepName: step.StepName(),
+				Kind:     out.kind,
+				Name:     out.name,
+			})
+		}
+	}
+
+	return missing, unused
+}
+
+func isConsumedLater(steps []Step, el graphElement) bool {
+	for _, step := range steps {
+		for _, in := range stepInputs(step) {
+			if in == el {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stepInputs returns the graph elements a step reads.
+func stepInputs(step Step) []graphElement {
+	var inputs []graphElement
+
+	if step.Algorithm != nil {
+		v := structValue(step.Algorithm)
+		for _, rt := range stringsField(v, "RelationshipTypes") {
+			inputs = append(inputs, graphElement{RelationshipType, rt})
+		}
+		if p := stringField(v, "RelationshipWeightProperty"); p != "" {
+			inputs = append(inputs, graphElement{RelationshipProperty, p})
+		}
+		for _, name := range []string{"SeedProperty", "NodeWeightProperty"} {
+			if p := stringField(v, name); p != "" {
+				inputs = append(inputs, graphElement{NodeProperty, p})
+			}
+		}
+		for _, name := range []string{"FeatureProperties", "NodeProperties"} {
+			for _, p := range stringsField(v, name) {
+				inputs = append(inputs, graphElement{NodeProperty, p})
+			}
+		}
+	}
+
+	switch op := step.Operation.(type) {
+	case *projections.NodePropertiesWrite:
+		for _, p := range op.NodeProperties {
+			inputs = append(inputs, graphElement{NodeProperty, p})
+		}
+	case *projections.RelationshipWrite:
+		inputs = append(inputs, graphElement{RelationshipType, op.RelationshipType})
+		for _, p := range op.RelationshipProperties {
+			inputs = append(inputs, graphElement{RelationshipProperty, p})
+		}
+	}
+
+	return inputs
+}
+
+// stepOutputs returns the graph elements a mutate step adds to the projection.
+func stepOutputs(step Step) []graphElement {
+	if step.Algorithm == nil || step.Algorithm.GetMode() != algorithms.Mutate {
+		return nil
+	}
+
+	v := structValue(step.Algorithm)
+	prop := stringField(v, "MutateProperty")
+	if relType := stringField(v, "MutateRelationshipType"); relType != "" {
+		outputs := []graphElement{{RelationshipType, relType}}
+		if prop != "" {
+			outputs = append(outputs, graphElement{RelationshipProperty, prop})
+		}
+		return outputs
+	}
+	if prop != "" {
+		return []graphElement{{NodeProperty, prop}}
+	}
+	return nil
+}
+
+func structValue(algo algorithms.Algorithm) reflect.Value {
+	v := reflect.ValueOf(algo)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v
+}
+
+func stringField(v reflect.Value, name string) string {
+	f := v.FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+func stringsField(v reflect.Value, name string) []string {
+	f := v.FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.Slice || f.Type().Elem().Kind() != reflect.String {
+		return nil
+	}
+	result := make([]string, f.Len())
+	for i := range result {
+		result[i] = f.Index(i).String()
+	}
+	return result
+}
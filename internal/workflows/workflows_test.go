@@ -0,0 +1,306 @@
+package workflows
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lex00/wetwire-neo4j-go/internal/algorithms"
+	"github.com/lex00/wetwire-neo4j-go/internal/estimation"
+	"github.com/lex00/wetwire-neo4j-go/internal/projections"
+	"github.com/lex00/wetwire-neo4j-go/internal/target"
+)
+
+func newCustomerGraph() *projections.NativeProjection {
+	return &projections.NativeProjection{
+		BaseProjection: projections.BaseProjection{Name: "customers"},
+		NodeProjections: []projections.NodeProjection{
+			{Label: "Customer", Properties: []string{"age"}},
+		},
+		RelationshipProjections: []projections.RelationshipProjection{
+			{Type: "BOUGHT", Properties: []string{"amount"}},
+		},
+	}
+}
+
+func newSegmentsWorkflow() *Workflow {
+	return &Workflow{
+		Name:       "segments",
+		Projection: newCustomerGraph(),
+		Steps: []Step{
+			{Algorithm: &algorithms.FastRP{
+				BaseAlgorithm:      algorithms.BaseAlgorithm{Name: "embeddings", GraphName: "customers", Mode: algorithms.Mutate},
+				EmbeddingDimension: 64,
+				FeatureProperties:  []string{"age"},
+				MutateProperty:     "embedding",
+			}},
+			{Algorithm: &algorithms.KNN{
+				BaseAlgorithm:          algorithms.BaseAlgorithm{Name: "similar", GraphName: "customers", Mode: algorithms.Mutate},
+				NodeProperties:         []string{"embedding"},
+				MutateRelationshipType: "SIMILAR",
+				MutateProperty:         "score",
+			}},
+			{Algorithm: &algorithms.Louvain{
+				BaseAlgorithm:              algorithms.BaseAlgorithm{Name: "communities", GraphName: "customers", Mode: algorithms.Write, RelationshipTypes: []string{"SIMILAR"}},
+				RelationshipWeightProperty: "score",
+				WriteProperty:              "segment",
+			}},
+		},
+		DropGraph: true,
+	}
+}
+
+func TestWorkflow_Validate(t *testing.T) {
+	graph := newCustomerGraph()
+	pageRank := func(graphName string) *algorithms.PageRank {
+		return &algorithms.PageRank{BaseAlgorithm: algorithms.BaseAlgorithm{Name: "pr", GraphName: graphName, Mode: algorithms.Stream}}
+	}
+
+	tests := []struct {
+		name    string
+		w       *Workflow
+		wantErr string
+	}{
+		{"valid", newSegmentsWorkflow(), ""},
+		{"no name", &Workflow{Projection: graph, Steps: []Step{{Algorithm: pageRank("customers")}}}, "name is required"},
+		{"no projection", &Workflow{Name: "w", Steps: []Step{{Algorithm: pageRank("customers")}}}, "no projection"},
+		{"no steps", &Workflow{Name: "w", Projection: graph}, "no steps"},
+		{"empty step", &Workflow{Name: "w", Projection: graph, Steps: []Step{{}}}, "is empty"},
+		{"wrong graph", &Workflow{Name: "w", Projection: graph, Steps: []Step{{Algorithm: pageRank("other")}}}, "runs on graph 'other'"},
+		{
+			name: "both set",
+			w: &Workflow{Name: "w", Projection: graph, Steps: []Step{{
+				Algorithm: pageRank("customers"),
+				Operation: &projections.GraphExport{BaseOperation: projections.BaseOperation{Name: "e", Graph: graph}, DBName: "db"},
+			}}},
+			wantErr: "sets both",
+		},
+		{
+			name: "operation on other graph",
+			w: &Workflow{Name: "w", Projection: graph, Steps: []Step{{
+				Operation: &projections.GraphExport{BaseOperation: projections.BaseOperation{Name: "e"}, DBName: "db"},
+			}}},
+			wantErr: "does not act on graph",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.w.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflow_DataFlow(t *testing.T) {
+	missing, unused := newSegmentsWorkflow().DataFlow()
+	if len(missing) != 0 {
+		t.Errorf("expected no missing inputs, got %v", missing)
+	}
+	if len(unused) != 0 {
+		t.Errorf("expected no unused outputs, got %v", unused)
+	}
+}
+
+func TestWorkflow_DataFlow_MissingInput(t *testing.T) {
+	w := newSegmentsWorkflow()
+	// Drop the FastRP step so "embedding" is never produced
+	w.Steps = w.Steps[1:]
+
+	missing, _ := w.DataFlow()
+	if len(missing) != 1 {
+		t.Fatalf("expected 1 missing input, got %v", missing)
+	}
+	if missing[0].Step != 1 || missing[0].Kind != NodeProperty || missing[0].Name != "embedding" {
+		t.Errorf("unexpected missing input: %+v", missing[0])
+	}
+}
+
+func TestWorkflow_DataFlow_UnusedOutput(t *testing.T) {
+	w := newSegmentsWorkflow()
+	// Without Louvain nothing reads SIMILAR or score
+	w.Steps = w.Steps[:2]
+
+	_, unused := w.DataFlow()
+	if len(unused) != 2 {
+		t.Fatalf("expected 2 unused outputs, got %v", unused)
+	}
+	if unused[0].Kind != RelationshipType || unused[0].Name != "SIMILAR" {
+		t.Errorf("unexpected unused output: %+v", unused[0])
+	}
+	if unused[1].Kind != RelationshipProperty || unused[1].Name != "score" {
+		t.Errorf("unexpected unused output: %+v", unused[1])
+	}
+}
+
+func TestWorkflow_DataFlow_CypherProjectionSkipsInputs(t *testing.T) {
+	w := newSegmentsWorkflow()
+	w.Projection = &projections.CypherProjection{
+		BaseProjection:    projections.BaseProjection{Name: "customers"},
+		NodeQuery:         "MATCH (n:Customer) RETURN id(n) AS id",
+		RelationshipQuery: "MATCH (a)-[:BOUGHT]->(b) RETURN id(a) AS source, id(b) AS target",
+	}
+	w.Steps = w.Steps[1:]
+
+	missing, _ := w.DataFlow()
+	if len(missing) != 0 {
+		t.Errorf("expected Cypher projection inputs to be skipped, got %v", missing)
+	}
+}
+
+func TestWorkflowSerializer_ToCypher(t *testing.T) {
+	s := NewWorkflowSerializer()
+	script, err := s.ToCypher(newSegmentsWorkflow())
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+
+	expected := []string{
+		"// Workflow: segments",
+		"cypher-shell --fail-at-end -f segments.cypher",
+		"// Projection: customers",
+		"CALL gds.graph.project(",
+		"// Step 1: embeddings - gds.fastRP.mutate",
+		"// Step 2: similar - gds.knn.mutate",
+		"// Step 3: communities - gds.louvain.write",
+		"// finally\nCALL gds.graph.drop('customers', false) YIELD graphName;",
+	}
+	for _, e := range expected {
+		if !strings.Contains(script, e) {
+			t.Errorf("expected %q in script, got:\n%s", e, script)
+		}
+	}
+
+	// Steps must appear in order
+	last := -1
+	for _, marker := range []string{"// Projection:", "// Step 1:", "// Step 2:", "// Step 3:", "// finally"} {
+		idx := strings.Index(script, marker)
+		if idx <= last {
+			t.Errorf("section %q out of order", marker)
+		}
+		last = idx
+	}
+}
+
+func TestWorkflowSerializer_ToCypher_NoDrop(t *testing.T) {
+	w := newSegmentsWorkflow()
+	w.DropGraph = false
+
+	script, err := NewWorkflowSerializer().ToCypher(w)
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+	if strings.Contains(script, "gds.graph.drop") || strings.Contains(script, "--fail-at-end") {
+		t.Errorf("expected no drop section, got:\n%s", script)
+	}
+}
+
+func TestWorkflowSerializer_ToCypher_Invalid(t *testing.T) {
+	s := NewWorkflowSerializer()
+	if _, err := s.ToCypher(&Workflow{Name: "empty"}); err == nil {
+		t.Error("expected error for workflow without projection")
+	}
+
+	w := newSegmentsWorkflow()
+	w.Projection = &projections.DataFrameProjection{BaseProjection: projections.BaseProjection{Name: "customers"}}
+	if _, err := s.ToCypher(w); err == nil {
+		t.Error("expected error for DataFrame projection")
+	}
+}
+
+func TestWorkflowSerializer_WithTarget(t *testing.T) {
+	graph := newCustomerGraph()
+	w := &Workflow{
+		Name:       "clusters",
+		Projection: graph,
+		Steps: []Step{
+			{Algorithm: &algorithms.Leiden{
+				BaseAlgorithm:  algorithms.BaseAlgorithm{Name: "communities", GraphName: "customers", Mode: algorithms.Mutate},
+				MutateProperty: "community",
+			}},
+			{Operation: &projections.NodeLabelMutate{
+				BaseOperation: projections.BaseOperation{Name: "label", Graph: graph},
+				NodeLabel:     "Clustered",
+				NodeFilter:    "n.community IS NOT NULL",
+			}},
+		},
+	}
+
+	script, err := NewWorkflowSerializer().WithTarget(target.Target{GDS: target.V(2, 3)}).ToCypher(w)
+	if err != nil {
+		t.Fatalf("ToCypher failed: %v", err)
+	}
+	for _, e := range []string{"CALL gds.beta.leiden.mutate(", "CALL gds.alpha.graph.nodeLabel.mutate("} {
+		if !strings.Contains(script, e) {
+			t.Errorf("expected %q in script, got:\n%s", e, script)
+		}
+	}
+}
+
+func TestWorkflowSerializer_ToJSON(t *testing.T) {
+	data, err := NewWorkflowSerializer().ToJSON(newSegmentsWorkflow())
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("failed to parse JSON: %v", err)
+	}
+	if result["name"] != "segments" {
+		t.Errorf("name = %v, want segments", result["name"])
+	}
+	if result["dropGraph"] != true {
+		t.Errorf("dropGraph = %v, want true", result["dropGraph"])
+	}
+	steps, ok := result["steps"].([]any)
+	if !ok || len(steps) != 3 {
+		t.Fatalf("expected 3 steps, got %v", result["steps"])
+	}
+	if _, ok := steps[0].(map[string]any)["algorithm"]; !ok {
+		t.Errorf("expected algorithm step, got %v", steps[0])
+	}
+}
+
+func TestWorkflow_EstimateMemory(t *testing.T) {
+	size := estimation.GraphSize{
+		NodeCounts:         map[string]int64{"Customer": 10000},
+		RelationshipCounts: map[string]int64{"BOUGHT": 100000},
+	}
+
+	report := newSegmentsWorkflow().EstimateMemory(size)
+	if report.Projection.NodeCount != 10000 || report.Projection.RelationshipCount != 100000 {
+		t.Errorf("unexpected projection size: %+v", report.Projection)
+	}
+	if len(report.Steps) != 3 {
+		t.Fatalf("expected 3 step estimates, got %d", len(report.Steps))
+	}
+
+	// Each mutate step grows the graph seen by the next step
+	if report.Steps[1].Graph.BytesMax <= report.Steps[0].Graph.BytesMax {
+		t.Errorf("expected FastRP embeddings to grow the graph: %+v", report.Steps)
+	}
+	if report.Steps[2].Graph.BytesMax <= report.Steps[1].Graph.BytesMax {
+		t.Errorf("expected KNN relationships to grow the graph: %+v", report.Steps)
+	}
+	if report.Steps[2].Output != (estimation.Estimate{}) {
+		t.Errorf("write step should not add to the graph, got %+v", report.Steps[2].Output)
+	}
+
+	for _, step := range report.Steps {
+		if step.Total.BytesMax > report.Peak.BytesMax {
+			t.Errorf("step %d total %v exceeds peak %v", step.Step, step.Total, report.Peak)
+		}
+	}
+	if report.PeakStep == 0 {
+		t.Error("expected a step to reach the peak")
+	}
+}